@@ -0,0 +1,357 @@
+// Package container: wiring aplikasi (config, koneksi database, repository, service, storage, mailer, clock).
+// Dibangun sekali saat start (cli), lalu dibagi ke route setup, job background dan subcommand CLI.
+// Test memakai Build dengan repository in-memory dan Options (mailer / storage / clock / kunci JWT pengganti).
+package container
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/app/service"
+	"github.com/nerhays/prestasi_uas/config"
+	"github.com/nerhays/prestasi_uas/database"
+	"github.com/nerhays/prestasi_uas/mailer"
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+type Container struct {
+	Config *config.Config
+
+	// koneksi database; nil kalau container dibangun lewat Build
+	DB    *gorm.DB
+	Mongo *database.Mongo
+
+	Repos   *repository.Repositories
+	Mailer  mailer.Mailer
+	Storage utils.Storage
+	Clock   utils.Clock
+	// Keys: kunci JWT dari Options.Keys atau JWT_KEY_STORE; kunci baru dimuat di LoadKeys (serve),
+	// subcommand CLI lain tidak memuatnya supaya tidak membuat kunci baru
+	Keys *utils.KeyManager
+
+	LoginGuard         *service.LoginGuard
+	TwoFactor          *service.TwoFactorService
+	Auth               *service.AuthService
+	OIDC               *service.OIDCService
+	Password           *service.PasswordService
+	APITokens          *service.APITokenService
+	Audit              *service.AuditService
+	Users              *service.UserService
+	UserLifecycle      *service.UserLifecycleService
+	Roles              *service.RoleService
+	Students           *service.StudentService
+	Lecturers          *service.LecturerService
+	Profiles           *service.ProfileService
+	Imports            *service.ImportService
+	Organizations      *service.OrganizationService
+	AcademicPeriods    *service.AcademicPeriodService
+	Duplicates         *service.DuplicateService
+	AdvisorAssignments *service.AdvisorAssignmentService
+	AdvisorDashboard   *service.AdvisorDashboardService
+	Achievements       *service.AchievementService
+	Analytics          *service.AnalyticsService
+	Trash              *service.AchievementTrashService
+	Export             *service.AchievementExportService
+	Consistency        *service.ConsistencyService
+	DemoData           *service.DemoDataService
+}
+
+// Options: dependensi yang bisa diganti; field kosong diisi bawaan dari config
+type Options struct {
+	Mailer  mailer.Mailer
+	Storage utils.Storage
+	Clock   utils.Clock
+	Keys    *utils.KeyManager
+}
+
+// Open: koneksi Postgres + Mongo dari config, lalu Build di atas repository database
+func Open(cfg *config.Config, opts Options) *Container {
+	db := database.NewPostgres(cfg.PostgresDSN)
+	mongo := database.NewMongo(cfg.MongoURI, cfg.MongoDB)
+
+	c := Build(cfg, repository.NewRepositories(db, mongo.DB), opts)
+	c.DB = db
+	c.Mongo = mongo
+	return c
+}
+
+// Build: semua service dibuat sekali di atas repos (database atau in-memory)
+func Build(cfg *config.Config, repos *repository.Repositories, opts Options) *Container {
+	c := &Container{
+		Config:  cfg,
+		Repos:   repos,
+		Mailer:  opts.Mailer,
+		Storage: opts.Storage,
+		Clock:   opts.Clock,
+		Keys:    opts.Keys,
+	}
+	if c.Mailer == nil {
+		c.Mailer = newMailer(cfg)
+	}
+	if c.Storage == nil {
+		c.Storage = utils.NewLocalStorage()
+	}
+	if c.Clock == nil {
+		c.Clock = time.Now
+	}
+	if c.Keys == nil {
+		c.Keys = newKeyManager(cfg, repos)
+	}
+
+	policy := newPasswordPolicy(cfg)
+
+	// === auth & keamanan ===
+	c.LoginGuard = newLoginGuard(repos, cfg)
+	c.TwoFactor = service.NewTwoFactorService(repos.Users, repos.TwoFactor, cfg.TOTPIssuer, c.Keys)
+	c.Auth = service.NewAuthService(repos.Users, repos.Roles, c.LoginGuard, c.TwoFactor, newAuthenticators(cfg), c.Keys)
+	c.OIDC = service.NewOIDCService(
+		service.OIDCConfig{
+			Provider:      cfg.OIDCProvider,
+			IssuerURL:     cfg.OIDCIssuerURL,
+			ClientID:      cfg.OIDCClientID,
+			ClientSecret:  cfg.OIDCClientSecret,
+			RedirectURL:   cfg.OIDCRedirectURL,
+			Scopes:        strings.Fields(cfg.OIDCScopes),
+			NIMClaim:      cfg.OIDCNIMClaim,
+			AutoProvision: cfg.OIDCAutoProvision,
+			DefaultRole:   cfg.OIDCDefaultRole,
+		},
+		c.Auth,
+		repos.Users,
+		repos.Roles,
+		repos.Students,
+		repos.UserIdentities,
+		repos.OIDCStates,
+	)
+	c.APITokens = service.NewAPITokenService(repos.APITokens, repos.Users, repos.Roles)
+	c.Audit = service.NewAuditService(repos.AuditLogs)
+
+	// === user & data master ===
+	c.AdvisorAssignments = service.NewAdvisorAssignmentService(
+		repos.AdvisorAssignments,
+		repos.VerificationDelegations,
+		repos.Students,
+		repos.Lecturers,
+		repos.AchievementReferences,
+		repos.Achievements,
+		c.Mailer,
+	)
+	c.UserLifecycle = service.NewUserLifecycleService(
+		repos.Users,
+		repos.Students,
+		repos.Lecturers,
+		repos.Profiles,
+		repos.APITokens,
+		c.AdvisorAssignments,
+	)
+	c.Users = service.NewUserService(repos.Users, repos.Roles, policy, c.UserLifecycle)
+	c.Password = service.NewPasswordService(
+		repos.Users,
+		repos.PasswordResets,
+		policy,
+		c.Mailer,
+		time.Duration(cfg.PasswordResetTTLMinutes)*time.Minute,
+		cfg.PasswordResetURL,
+		c.Clock,
+		c.UserLifecycle,
+		c.Keys,
+	)
+	c.Roles = service.NewRoleService(repos.Roles)
+	c.Students = service.NewStudentService(repos.Students, repos.Lecturers)
+	c.Lecturers = service.NewLecturerService(repos.Lecturers, repos.Students)
+	c.Profiles = service.NewProfileService(
+		repos.Users,
+		repos.Roles,
+		repos.Students,
+		repos.Lecturers,
+		repos.Profiles,
+		repos.Organizations,
+		policy,
+		c.UserLifecycle,
+		c.AdvisorAssignments,
+	)
+	c.Imports = service.NewImportService(
+		repos.Users,
+		repos.Roles,
+		repos.Students,
+		repos.Lecturers,
+		repos.Profiles,
+		repos.ImportJobs,
+		repos.Organizations,
+		policy,
+		c.AdvisorAssignments,
+	)
+	c.Organizations = service.NewOrganizationService(repos.Organizations, repos.Users, c.UserLifecycle)
+	c.AcademicPeriods = service.NewAcademicPeriodService(repos.AcademicPeriods)
+
+	// === prestasi ===
+	c.Duplicates = service.NewDuplicateService(repos.Achievements, repos.DuplicateFlags)
+	c.Achievements = service.NewAchievementService(
+		repos.Achievements,
+		repos.Students,
+		repos.AchievementReferences,
+		repos.Users,
+		repos.Lecturers,
+		repos.AchievementStatusLogs,
+		repos.AcademicPeriods,
+		repos.AchievementTeams,
+		repos.AchievementVersions,
+		c.Duplicates,
+		c.AdvisorAssignments,
+		model.TeamPointSplit(cfg.TeamPointSplit),
+	)
+	c.AdvisorDashboard = service.NewAdvisorDashboardService(
+		repos.Achievements,
+		repos.AchievementReferences,
+		repos.Students,
+		repos.Lecturers,
+		c.Mailer,
+		time.Duration(cfg.VerificationSLAHours)*time.Hour,
+		c.Clock,
+		c.AdvisorAssignments,
+	)
+	c.Analytics = service.NewAnalyticsService(
+		repos.Achievements,
+		repos.AchievementReferences,
+		repos.AchievementStatusLogs,
+		repos.AcademicPeriods,
+	)
+	c.Trash = service.NewAchievementTrashService(
+		repos.Achievements,
+		repos.AchievementReferences,
+		repos.AchievementVersions,
+		c.Storage.Remove,
+		time.Duration(cfg.TrashRetentionDays)*24*time.Hour,
+	)
+	c.Export = service.NewAchievementExportService(repos.AchievementReferences, repos.Achievements, repos.Students)
+	c.Consistency = service.NewConsistencyService(
+		repos.AchievementReferences,
+		repos.Achievements,
+		repos.Students,
+		repos.Lecturers,
+	)
+	c.DemoData = service.NewDemoDataService(
+		repos.Roles,
+		repos.Organizations,
+		repos.Profiles,
+		repos.Lecturers,
+		repos.AcademicPeriods,
+		repos.Achievements,
+		repos.AchievementReferences,
+		repos.AchievementStatusLogs,
+	)
+	return c
+}
+
+// LoadKeys: muat kunci JWT; kunci pertama dibuat otomatis kalau belum ada
+func (c *Container) LoadKeys() error {
+	return c.Keys.Load()
+}
+
+// Close: tutup koneksi database (kalau ada)
+func (c *Container) Close() {
+	if c.Mongo != nil {
+		_ = c.Mongo.Client.Disconnect(context.Background())
+	}
+	if c.DB != nil {
+		if sqlDB, err := c.DB.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	}
+}
+
+// newKeyManager: kunci JWT dari JWT_KEY_STORE, belum dimuat sampai LoadKeys
+func newKeyManager(cfg *config.Config, repos *repository.Repositories) *utils.KeyManager {
+	var store utils.KeyStore
+	switch cfg.JWTKeyStore {
+	case "memory":
+		store = utils.NewMemoryKeyStore()
+	default:
+		store = repos.SigningKeys
+	}
+	return utils.NewDeferredKeyManager(store, utils.KeyManagerConfig{
+		Algorithm:        cfg.JWTAlgorithm,
+		Issuer:           cfg.JWTIssuer,
+		Audience:         cfg.JWTAudience,
+		RotationInterval: time.Duration(cfg.JWTKeyRotationHours) * time.Hour,
+		TokenTTL:         utils.AccessTokenTTL,
+	})
+}
+
+func newMailer(cfg *config.Config) mailer.Mailer {
+	return mailer.New(mailer.Config{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.MailFrom,
+	})
+}
+
+// newPasswordPolicy: daftar password bocor dibaca dari file lokal; file tidak ada → hanya cek panjang
+func newPasswordPolicy(cfg *config.Config) *service.PasswordPolicy {
+	var breached []string
+	if cfg.BreachedPasswordsFile != "" {
+		list, err := service.LoadBreachedPasswords(cfg.BreachedPasswordsFile)
+		if err != nil {
+			log.Printf("[WARN] breached password list not loaded: %v", err)
+		}
+		breached = list
+	}
+	return service.NewPasswordPolicy(cfg.PasswordMinLength, breached)
+}
+
+func newLoginGuard(repos *repository.Repositories, cfg *config.Config) *service.LoginGuard {
+	var store repository.LoginAttemptStore
+	switch cfg.LoginAttemptStore {
+	case "postgres":
+		store = repos.LoginAttempts
+	default:
+		store = repository.NewMemoryLoginAttemptStore()
+	}
+
+	policy := service.DefaultLockoutPolicy()
+	policy.MaxAccountFailures = cfg.LoginMaxFailures
+	policy.MaxIPFailures = cfg.LoginIPMaxFailures
+	policy.Window = time.Duration(cfg.LoginFailureWindowMinutes) * time.Minute
+	policy.LockoutDuration = time.Duration(cfg.LoginLockoutMinutes) * time.Minute
+	policy.DelayAfter = cfg.LoginDelayAfter
+
+	return service.NewLoginGuard(
+		store,
+		repos.LoginAudits,
+		repos.Users,
+		policy,
+	)
+}
+
+func newAuthenticators(cfg *config.Config) *service.Authenticators {
+	auths := service.NewAuthenticators(cfg.AuthBackend)
+	if cfg.LDAPURL != "" {
+		auths.Register(model.AuthBackendLDAP, service.NewLDAPAuthenticator(service.LDAPConfig{
+			URL:                cfg.LDAPURL,
+			StartTLS:           cfg.LDAPStartTLS,
+			InsecureSkipVerify: cfg.LDAPInsecureSkipVerify,
+			BindDN:             cfg.LDAPBindDN,
+			BindPassword:       cfg.LDAPBindPassword,
+			BaseDN:             cfg.LDAPBaseDN,
+			UserFilter:         cfg.LDAPUserFilter,
+			UsernameAttr:       cfg.LDAPUsernameAttr,
+			EmailAttr:          cfg.LDAPEmailAttr,
+			NameAttr:           cfg.LDAPNameAttr,
+			GroupAttr:          cfg.LDAPGroupAttr,
+			GroupRoles:         service.ParseLDAPGroupRoles(cfg.LDAPGroupRoles),
+			Timeout:            time.Duration(cfg.LDAPTimeoutSeconds) * time.Second,
+		}))
+	}
+	if !auths.Has(cfg.AuthBackend) {
+		log.Printf("[WARN] AUTH_BACKEND=%s is not available, login will fail for users without a backend override", cfg.AuthBackend)
+	}
+	return auths
+}
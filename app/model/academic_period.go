@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+type Semester string
+
+const (
+	SemesterGanjil Semester = "ganjil"
+	SemesterGenap  Semester = "genap"
+)
+
+// AcademicPeriod: satu semester akademik + jendela pengajuan prestasi
+type AcademicPeriod struct {
+	ID           string    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	AcademicYear string    `gorm:"size:9;not null" json:"academic_year"` // contoh: 2024/2025
+	Semester     Semester  `gorm:"size:10;not null" json:"semester"`
+	StartDate    time.Time `gorm:"type:date;not null" json:"start_date"`
+	EndDate      time.Time `gorm:"type:date;not null" json:"end_date"`
+	// jendela submit prestasi; kosong = tidak dibatasi (SubmissionClosesAt biasanya cut-off SKPI)
+	SubmissionOpensAt  *time.Time `json:"submission_opens_at,omitempty"`
+	SubmissionClosesAt *time.Time `json:"submission_closes_at,omitempty"`
+	IsActive           bool       `gorm:"default:false" json:"is_active"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// Label: contoh "2024/2025 Ganjil"
+func (p AcademicPeriod) Label() string {
+	if p.Semester == SemesterGenap {
+		return p.AcademicYear + " Genap"
+	}
+	return p.AcademicYear + " Ganjil"
+}
+
+// Contains: true jika tanggal t (harian) berada di antara StartDate..EndDate
+func (p AcademicPeriod) Contains(t time.Time) bool {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	start := time.Date(p.StartDate.Year(), p.StartDate.Month(), p.StartDate.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(p.EndDate.Year(), p.EndDate.Month(), p.EndDate.Day(), 0, 0, 0, 0, time.UTC)
+	return !day.Before(start) && !day.After(end)
+}
+
+// SubmissionOpen: apakah pengajuan prestasi diterima pada waktu t
+func (p AcademicPeriod) SubmissionOpen(t time.Time) bool {
+	if p.SubmissionOpensAt != nil && t.Before(*p.SubmissionOpensAt) {
+		return false
+	}
+	if p.SubmissionClosesAt != nil && t.After(*p.SubmissionClosesAt) {
+		return false
+	}
+	return true
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -15,17 +16,110 @@ type Achievement struct {
 	Details         map[string]any     `bson:"details" json:"details"`
 	Points          float64            `bson:"points" json:"points"`
 	Attachments     []any              `bson:"attachments" json:"attachments,omitempty"`
-	CreatedAt       time.Time          `bson:"createdAt" json:"createdAt"`
-	UpdatedAt       time.Time          `bson:"updatedAt" json:"updatedAt"`
+	// Team: kosong untuk prestasi individu; StudentID tetap ketua tim
+	Team       []TeamMember   `bson:"team,omitempty" json:"team,omitempty"`
+	PointSplit TeamPointSplit `bson:"pointSplit,omitempty" json:"pointSplit,omitempty"`
+	CreatedAt  time.Time      `bson:"createdAt" json:"createdAt"`
+	UpdatedAt  time.Time      `bson:"updatedAt" json:"updatedAt"`
+	// DeletedAt: diisi saat soft delete, dasar hitung retensi tempat sampah
+	DeletedAt *time.Time `bson:"deletedAt,omitempty" json:"deletedAt,omitempty"`
 }
+
+// DetailString: nilai details[key] jika berupa string (trim spasi)
+func (a *Achievement) DetailString(key string) string {
+	v, _ := a.Details[key].(string)
+	return strings.TrimSpace(v)
+}
+
+// EventDate: details.eventDate, baik dari request (time.Time) maupun hasil decode Mongo
+func (a *Achievement) EventDate() (time.Time, bool) {
+	switch v := a.Details["eventDate"].(type) {
+	case time.Time:
+		return v, true
+	case primitive.DateTime:
+		return v.Time(), true
+	}
+	return time.Time{}, false
+}
+
+// AttachmentHashes: hash isi file lampiran; lampiran lama (sebelum ada hash) dilewati
+func (a *Achievement) AttachmentHashes() []string {
+	return a.attachmentValues("hash")
+}
+
+// AttachmentURLs: lokasi file lampiran ("/uploads/achievements/...")
+func (a *Achievement) AttachmentURLs() []string {
+	return a.attachmentValues("fileUrl")
+}
+
+// attachmentValues: field string tiap lampiran, baik dari request (Attachment) maupun hasil decode Mongo
+func (a *Achievement) attachmentValues(key string) []string {
+	var values []string
+	for _, att := range a.Attachments {
+		var v any
+		switch t := att.(type) {
+		case Attachment:
+			switch key {
+			case "hash":
+				v = t.Hash
+			case "fileUrl":
+				v = t.FileURL
+			}
+		case primitive.D:
+			for _, e := range t {
+				if e.Key == key {
+					v = e.Value
+				}
+			}
+		case primitive.M:
+			v = t[key]
+		case map[string]any:
+			v = t[key]
+		}
+		if s, ok := v.(string); ok && s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
 type Attachment struct {
-	FileName   string    `bson:"fileName" json:"fileName"`
-	FileURL    string    `bson:"fileUrl" json:"fileUrl"`
-	FileType   string    `bson:"fileType" json:"fileType"`
+	FileName string `bson:"fileName" json:"fileName"`
+	FileURL  string `bson:"fileUrl" json:"fileUrl"`
+	FileType string `bson:"fileType" json:"fileType"`
+	// Hash: sha256 isi file, dipakai deteksi lampiran yang sama antar mahasiswa
+	Hash       string    `bson:"hash,omitempty" json:"hash,omitempty"`
 	UploadedAt time.Time `bson:"uploadedAt" json:"uploadedAt"`
 }
 type AchievementStatistics struct {
-	Total            int64            `json:"total"`
-	ByType           map[string]int64 `json:"by_type"`
-	ByStatus         map[string]int64 `json:"by_status"`
+	Total    int64            `json:"total"`
+	ByType   map[string]int64 `json:"by_type"`
+	ByStatus map[string]int64 `json:"by_status"`
+}
+
+// AnalyticsBucket: satu titik/kelompok data analitik
+type AnalyticsBucket struct {
+	Key    string  `json:"key"`
+	Count  int64   `json:"count"`
+	Points float64 `json:"points"`
+}
+
+type LeaderboardEntry struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Count  int64   `json:"count"`
+	Points float64 `json:"points"`
+}
+
+type AchievementAnalytics struct {
+	From               time.Time          `json:"from"`
+	To                 time.Time          `json:"to"`
+	GroupBy            string             `json:"group_by"`
+	TotalVerified      int64              `json:"total_verified"`
+	TotalPoints        float64            `json:"total_points"`
+	Series             []AnalyticsBucket  `json:"series"`
+	AvgTurnaroundHours float64            `json:"avg_turnaround_hours"`
+	TurnaroundSamples  int64              `json:"turnaround_samples"`
+	TopStudents        []LeaderboardEntry `json:"top_students"`
+	TopPrograms        []LeaderboardEntry `json:"top_programs"`
 }
@@ -17,11 +17,20 @@ type AchievementReference struct {
 	StudentID          string            `gorm:"type:uuid;not null" json:"student_id"`
 	Student            Student           `gorm:"foreignKey:StudentID" json:"student"`
 	MongoAchievementID string            `gorm:"size:24;not null" json:"mongo_achievement_id"`
+	PeriodID           *string           `gorm:"type:uuid" json:"period_id,omitempty"`
+	Period             *AcademicPeriod   `gorm:"foreignKey:PeriodID" json:"period,omitempty"`
 	Status             AchievementStatus `gorm:"type:achievement_status;not null" json:"status"`
 	SubmittedAt        *time.Time        `json:"submitted_at,omitempty"`
 	VerifiedAt         *time.Time        `json:"verified_at,omitempty"`
 	VerifiedBy         *string           `gorm:"type:uuid" json:"verified_by,omitempty"`
 	RejectionNote      *string           `json:"rejection_note,omitempty"`
-	CreatedAt          time.Time         `json:"created_at"`
-	UpdatedAt          time.Time         `json:"updated_at"`
+	LastRemindedAt     *time.Time        `json:"last_reminded_at,omitempty"`
+	// VerifiedVersion: versi isi prestasi (achievement_versions) yang diverifikasi
+	VerifiedVersion *int `json:"verified_version,omitempty"`
+	// TeamRole: nil untuk prestasi individu
+	TeamRole *TeamRole `gorm:"size:10" json:"team_role,omitempty"`
+	// PointShare: bagian poin anggota tim, dihitung saat submit
+	PointShare *float64  `gorm:"type:numeric(10,2)" json:"point_share,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+type TeamRole string
+
+const (
+	TeamRoleLeader TeamRole = "leader"
+	TeamRoleMember TeamRole = "member"
+)
+
+type TeamInvitationStatus string
+
+const (
+	TeamInvitationPending  TeamInvitationStatus = "pending"
+	TeamInvitationAccepted TeamInvitationStatus = "accepted"
+	TeamInvitationDeclined TeamInvitationStatus = "declined"
+)
+
+// TeamPointSplit: aturan pembagian poin prestasi tim ke tiap anggota
+type TeamPointSplit string
+
+const (
+	// TeamPointSplitEqual: poin dibagi rata
+	TeamPointSplitEqual TeamPointSplit = "equal"
+	// TeamPointSplitFull: setiap anggota mendapat poin penuh
+	TeamPointSplitFull TeamPointSplit = "full"
+	// TeamPointSplitLeaderWeighted: ketua dihitung 2 bagian, anggota 1 bagian
+	TeamPointSplitLeaderWeighted TeamPointSplit = "leader_weighted"
+)
+
+func (p TeamPointSplit) Valid() bool {
+	switch p {
+	case TeamPointSplitEqual, TeamPointSplitFull, TeamPointSplitLeaderWeighted:
+		return true
+	}
+	return false
+}
+
+// Shares: poin per studentID sesuai aturan, dibulatkan 2 desimal
+func (p TeamPointSplit) Shares(points float64, roles map[string]TeamRole) map[string]float64 {
+	shares := make(map[string]float64, len(roles))
+	if len(roles) == 0 {
+		return shares
+	}
+
+	weights := make(map[string]float64, len(roles))
+	var total float64
+	for studentID, role := range roles {
+		w := 1.0
+		if p == TeamPointSplitLeaderWeighted && role == TeamRoleLeader {
+			w = 2
+		}
+		weights[studentID] = w
+		total += w
+	}
+
+	for studentID, w := range weights {
+		share := points
+		if p != TeamPointSplitFull {
+			share = points * w / total
+		}
+		shares[studentID] = math.Round(share*100) / 100
+	}
+	return shares
+}
+
+// TeamMember: anggota tim yang disimpan di dokumen achievement (Mongo)
+type TeamMember struct {
+	StudentID string   `bson:"studentId" json:"studentId"`
+	Role      TeamRole `bson:"role" json:"role"`
+}
+
+// AchievementTeamMember: undangan + status keanggotaan tim (Postgres).
+// ReferenceID terisi setelah anggota menerima undangan.
+type AchievementTeamMember struct {
+	ID                 string               `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	MongoAchievementID string               `gorm:"size:24;not null" json:"mongo_achievement_id"`
+	StudentID          string               `gorm:"type:uuid;not null" json:"student_id"`
+	Student            *Student             `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+	Role               TeamRole             `gorm:"size:10;not null" json:"role"`
+	Status             TeamInvitationStatus `gorm:"size:10;not null" json:"status"`
+	ReferenceID        *string              `gorm:"type:uuid" json:"reference_id,omitempty"`
+	InvitedBy          string               `gorm:"type:uuid;not null" json:"invited_by"`
+	RespondedAt        *time.Time           `json:"responded_at,omitempty"`
+	CreatedAt          time.Time            `json:"created_at"`
+	UpdatedAt          time.Time            `json:"updated_at"`
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AchievementVersion: snapshot isi prestasi setiap kali disimpan (koleksi achievement_versions).
+// Version mulai dari 1 dan naik per dokumen achievement.
+type AchievementVersion struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	// AchievementID: _id dokumen achievements (hex)
+	AchievementID string      `bson:"achievementId" json:"achievementId"`
+	Version       int         `bson:"version" json:"version"`
+	Snapshot      Achievement `bson:"snapshot" json:"snapshot"`
+	// EditedBy: user ID yang menyimpan; kosong untuk snapshot awal prestasi lama
+	EditedBy  string    `bson:"editedBy,omitempty" json:"editedBy,omitempty"`
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+}
+
+// AchievementVersionDiff: field yang berbeda antara dua versi, detail per key ("details.eventDate")
+type AchievementVersionDiff struct {
+	AchievementID string                 `json:"achievement_id"`
+	From          int                    `json:"from"`
+	To            int                    `json:"to"`
+	Changes       map[string]AuditChange `json:"changes"`
+}
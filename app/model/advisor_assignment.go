@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// AdvisorAssignment: riwayat dosen wali mahasiswa; EffectiveTo nil = dosen wali saat ini
+type AdvisorAssignment struct {
+	ID            string     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	StudentID     string     `gorm:"type:uuid;not null" json:"student_id"`
+	LecturerID    string     `gorm:"type:uuid;not null" json:"lecturer_id"`
+	Lecturer      *Lecturer  `gorm:"foreignKey:LecturerID" json:"lecturer,omitempty"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to,omitempty"`
+	Reason        string     `gorm:"size:255" json:"reason,omitempty"`
+	// prestasi berstatus submitted yang ikut pindah ke dosen wali baru saat penugasan ini dimulai
+	PendingHandedOver int       `gorm:"default:0" json:"pending_handed_over"`
+	AssignedBy        *string   `gorm:"type:uuid" json:"assigned_by,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// VerificationDelegation: dosen wali mengalihkan hak verifikasi mahasiswa bimbingannya
+// ke dosen lain selama rentang tanggal (cuti, tugas luar)
+type VerificationDelegation struct {
+	ID             string     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	FromLecturerID string     `gorm:"type:uuid;not null" json:"from_lecturer_id"`
+	FromLecturer   *Lecturer  `gorm:"foreignKey:FromLecturerID" json:"from_lecturer,omitempty"`
+	ToLecturerID   string     `gorm:"type:uuid;not null" json:"to_lecturer_id"`
+	ToLecturer     *Lecturer  `gorm:"foreignKey:ToLecturerID" json:"to_lecturer,omitempty"`
+	StartsAt       time.Time  `json:"starts_at"`
+	EndsAt         time.Time  `json:"ends_at"`
+	Reason         string     `gorm:"size:255" json:"reason,omitempty"`
+	CreatedBy      *string    `gorm:"type:uuid" json:"created_by,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// ActiveAt: delegasi berlaku pada waktu t (belum dicabut, starts_at <= t < ends_at)
+func (d VerificationDelegation) ActiveAt(t time.Time) bool {
+	return d.RevokedAt == nil && !t.Before(d.StartsAt) && t.Before(d.EndsAt)
+}
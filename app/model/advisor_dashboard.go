@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// PendingVerification: prestasi submitted yang menunggu verifikasi dosen wali
+type PendingVerification struct {
+	ReferenceID string    `json:"reference_id"`
+	Title       string    `json:"title"`
+	SubmittedAt time.Time `json:"submitted_at"`
+	AgeHours    float64   `json:"age_hours"`
+	Overdue     bool      `json:"overdue"`
+}
+
+type AdviseeWorkload struct {
+	StudentID string                `json:"student_id"`
+	NIM       string                `json:"nim"`
+	Name      string                `json:"name"`
+	Pending   int                   `json:"pending"`
+	Overdue   int                   `json:"overdue"`
+	Items     []PendingVerification `json:"items"`
+}
+
+// AdvisorWorkload: ringkasan beban verifikasi satu dosen wali
+type AdvisorWorkload struct {
+	LecturerID         string  `json:"lecturer_id"`
+	Name               string  `json:"name"`
+	Advisees           int     `json:"advisees"`
+	Pending            int     `json:"pending"`
+	Overdue            int     `json:"overdue"`
+	OldestPendingHours float64 `json:"oldest_pending_hours"`
+	Verified           int64   `json:"verified"` // dalam periode throughput
+	Rejected           int64   `json:"rejected"`
+}
+
+type AdvisorDashboard struct {
+	AdvisorWorkload
+	SLAHours       float64           `json:"sla_hours"`
+	ThroughputDays int               `json:"throughput_days"`
+	AdviseeDetails []AdviseeWorkload `json:"advisee_details"`
+}
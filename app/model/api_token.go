@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// APITokenPrefix: bearer yang diawali ini adalah API token, bukan JWT
+const APITokenPrefix = "prs_"
+
+// APIToken: personal access token / token akun layanan, yang disimpan hanya hash SHA-256
+type APIToken struct {
+	ID     string `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID string `gorm:"type:uuid;not null" json:"user_id"`
+	Name   string `gorm:"size:100;not null" json:"name"`
+	// beberapa karakter awal token, untuk mengenali token di daftar
+	TokenPrefix string `gorm:"size:16;not null" json:"token_prefix"`
+	TokenHash   string `gorm:"size:64;unique;not null" json:"-"`
+	// scope = permission yang boleh dipakai token (subset permission pemilik)
+	Scopes     []Permission `gorm:"many2many:api_token_permissions;joinForeignKey:TokenID;joinReferences:PermissionID" json:"scopes"`
+	CreatedBy  *string      `gorm:"type:uuid" json:"created_by,omitempty"`
+	ExpiresAt  time.Time    `json:"expires_at"`
+	LastUsedAt *time.Time   `json:"last_used_at,omitempty"`
+	LastUsedIP string       `gorm:"size:45" json:"last_used_ip,omitempty"`
+	RevokedAt  *time.Time   `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// Active: belum dicabut dan belum kedaluwarsa
+func (t APIToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// APITokenPrincipal: identitas request yang diautentikasi dengan API token
+type APITokenPrincipal struct {
+	TokenID  string
+	UserID   string
+	Username string
+	Role     string
+	// scope token yang masih dimiliki pemiliknya
+	Permissions []string
+	Scope       UnitScope
+}
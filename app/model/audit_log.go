@@ -0,0 +1,60 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditLog: satu aksi yang mengubah data. Append-only; Hash = SHA-256(PrevHash + isi entri),
+// jadi entri yang diubah / dihapus di tabel membuat rantai tidak valid.
+type AuditLog struct {
+	// urutan rantai, tanpa celah
+	Seq     int64   `gorm:"primaryKey;autoIncrement:false" json:"seq"`
+	ActorID *string `gorm:"type:uuid" json:"actor_id,omitempty"`
+	// hanya tampilan, tidak masuk Hash; diganti nama anonim saat aktor dianonimkan
+	ActorUsername string  `gorm:"size:50" json:"actor_username,omitempty"`
+	ActorRole     string  `gorm:"size:50" json:"actor_role,omitempty"`
+	APITokenID    *string `gorm:"type:uuid;column:api_token_id" json:"api_token_id,omitempty"`
+	// mis. "PUT /admin/users/:id/role"
+	Action     string `gorm:"size:150;not null" json:"action"`
+	TargetType string `gorm:"size:50" json:"target_type,omitempty"`
+	TargetID   string `gorm:"size:100" json:"target_id,omitempty"`
+	// snapshot target sebelum / sesudah; tanpa snapshot, After = body request (field rahasia disensor).
+	// Data pribadi (username, email, nama, NIM / NIDN) disensor sebelum di-hash, lihat service.AuditService.Record
+	Before json.RawMessage `gorm:"column:before_state;type:jsonb;serializer:json" json:"before,omitempty"`
+	After  json.RawMessage `gorm:"column:after_state;type:jsonb;serializer:json" json:"after,omitempty"`
+	// field yang berubah: {"field": {"old": ..., "new": ...}}
+	Changes    json.RawMessage `gorm:"type:jsonb;serializer:json" json:"changes,omitempty"`
+	StatusCode int             `json:"status_code"`
+	IPAddress  string          `gorm:"size:45" json:"ip_address,omitempty"`
+	UserAgent  string          `gorm:"size:255" json:"user_agent,omitempty"`
+	RequestID  string          `gorm:"size:64" json:"request_id,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	PrevHash   string          `gorm:"size:64" json:"prev_hash"`
+	Hash       string          `gorm:"size:64;not null" json:"hash"`
+}
+
+// AuditChange: nilai satu field sebelum & sesudah
+type AuditChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+type AuditLogFilter struct {
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+}
+
+// AuditChainReport: hasil verifikasi rantai hash
+type AuditChainReport struct {
+	Checked int64 `json:"checked"`
+	Valid   bool  `json:"valid"`
+	// entri pertama yang rusak (0 = tidak ada)
+	BrokenAt int64  `json:"broken_at,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
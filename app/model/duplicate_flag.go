@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// alasan sebuah prestasi dianggap duplikat
+const (
+	DuplicateReasonSimilarTitle      = "similar_title"
+	DuplicateReasonSameEvent         = "same_event"
+	DuplicateReasonSameAttachment    = "same_attachment"
+	DuplicateReasonSameCertification = "same_certification_number"
+)
+
+type DuplicateFlagStatus string
+
+const (
+	DuplicateFlagOpen      DuplicateFlagStatus = "open"
+	DuplicateFlagDismissed DuplicateFlagStatus = "dismissed"
+	DuplicateFlagConfirmed DuplicateFlagStatus = "confirmed"
+)
+
+// DuplicateMatch: hasil deteksi terhadap satu dokumen achievement lain
+type DuplicateMatch struct {
+	MongoAchievementID string   `json:"mongo_achievement_id"`
+	StudentID          string   `json:"student_id"`
+	Title              string   `json:"title"`
+	Reasons            []string `json:"reasons"`
+	Score              float64  `json:"score"`
+}
+
+// AchievementDuplicateFlag: peringatan duplikat untuk satu reference, ditinjau dosen wali / admin
+type AchievementDuplicateFlag struct {
+	ID                        string                `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ReferenceID               string                `gorm:"type:uuid;not null" json:"reference_id"`
+	Reference                 *AchievementReference `gorm:"foreignKey:ReferenceID" json:"reference,omitempty"`
+	MongoAchievementID        string                `gorm:"size:24;not null" json:"mongo_achievement_id"`
+	MatchedMongoAchievementID string                `gorm:"size:24;not null" json:"matched_mongo_achievement_id"`
+	MatchedStudentID          string                `gorm:"type:uuid" json:"matched_student_id"`
+	MatchedTitle              string                `gorm:"size:255" json:"matched_title"`
+	Reasons                   []string              `gorm:"type:jsonb;serializer:json" json:"reasons"`
+	Score                     float64               `gorm:"type:numeric(4,2)" json:"score"`
+	Status                    DuplicateFlagStatus   `gorm:"size:10;not null" json:"status"`
+	ReviewedBy                *string               `gorm:"type:uuid" json:"reviewed_by,omitempty"`
+	ReviewedAt                *time.Time            `json:"reviewed_at,omitempty"`
+	ReviewNote                *string               `json:"review_note,omitempty"`
+	CreatedAt                 time.Time             `json:"created_at"`
+	UpdatedAt                 time.Time             `json:"updated_at"`
+}
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+type ImportJobStatus string
+
+const (
+	ImportJobPending   ImportJobStatus = "pending"
+	ImportJobRunning   ImportJobStatus = "running"
+	ImportJobCompleted ImportJobStatus = "completed"
+	ImportJobFailed    ImportJobStatus = "failed"
+)
+
+// ImportRowError: error per baris file import (row = nomor baris di file, header = baris 1)
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportReport: hasil validasi dry-run
+type ImportReport struct {
+	TotalRows  int              `json:"total_rows"`
+	ValidRows  int              `json:"valid_rows"`
+	FailedRows int              `json:"failed_rows"`
+	Errors     []ImportRowError `json:"errors"`
+}
+
+type ImportJob struct {
+	ID            string           `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	FileName      string           `gorm:"size:255;not null" json:"file_name"`
+	Status        ImportJobStatus  `gorm:"size:20;not null" json:"status"`
+	TotalRows     int              `json:"total_rows"`
+	ProcessedRows int              `json:"processed_rows"`
+	CreatedRows   int              `json:"created_rows"`
+	UpdatedRows   int              `json:"updated_rows"`
+	FailedRows    int              `json:"failed_rows"`
+	Errors        []ImportRowError `gorm:"type:jsonb;serializer:json" json:"errors"`
+	CreatedBy     string           `gorm:"type:uuid" json:"created_by"`
+	StartedAt     *time.Time       `json:"started_at,omitempty"`
+	FinishedAt    *time.Time       `json:"finished_at,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
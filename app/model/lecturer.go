@@ -3,10 +3,13 @@ package model
 import "time"
 
 type Lecturer struct {
-	ID         string    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
-	UserID     string    `gorm:"type:uuid;not null" json:"user_id"`
-	User       User      `gorm:"foreignKey:UserID" json:"user"`
-	LecturerID string    `gorm:"size:20;unique;not null" json:"lecturer_id"`
-	Department string    `gorm:"size:100" json:"department"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID         string `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID     string `gorm:"type:uuid;not null" json:"user_id"`
+	User       User   `gorm:"foreignKey:UserID" json:"user"`
+	LecturerID string `gorm:"size:20;unique;not null" json:"lecturer_id"`
+	Department string `gorm:"size:100" json:"department"`
+	// DepartmentID: FK ke departments; Department tetap diisi nama jurusan untuk kompatibilitas
+	DepartmentID   *string     `gorm:"type:uuid" json:"department_id,omitempty"`
+	DepartmentUnit *Department `gorm:"foreignKey:DepartmentID" json:"department_unit,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
 }
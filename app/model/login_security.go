@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// LoginThrottle: counter login gagal per key ("user:<username>" / "ip:<alamat>")
+type LoginThrottle struct {
+	Key           string     `gorm:"column:throttle_key;size:255;primaryKey" json:"key"`
+	Failures      int        `json:"failures"`
+	WindowStart   time.Time  `json:"window_start"`
+	LastFailureAt time.Time  `json:"last_failure_at"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`
+}
+
+const (
+	LoginThrottleUserPrefix = "user:"
+	LoginThrottleIPPrefix   = "ip:"
+)
+
+// LoginAudit: jejak setiap percobaan login (berhasil / gagal)
+type LoginAudit struct {
+	ID        string    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID    *string   `gorm:"type:uuid" json:"user_id,omitempty"`
+	Username  string    `gorm:"size:100" json:"username"`
+	IPAddress string    `gorm:"size:45" json:"ip_address"`
+	UserAgent string    `gorm:"size:255" json:"user_agent"`
+	Success   bool      `json:"success"`
+	Reason    string    `gorm:"size:50" json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type LoginAuditFilter struct {
+	Username string
+	Success  *bool
+}
@@ -0,0 +1,67 @@
+package model
+
+import "time"
+
+type Faculty struct {
+	ID        string    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Code      string    `gorm:"size:20;unique;not null" json:"code"`
+	Name      string    `gorm:"size:150;not null" json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Department struct {
+	ID        string    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	FacultyID string    `gorm:"type:uuid;not null" json:"faculty_id"`
+	Faculty   *Faculty  `gorm:"foreignKey:FacultyID" json:"faculty,omitempty"`
+	Code      string    `gorm:"size:20;unique;not null" json:"code"`
+	Name      string    `gorm:"size:150;not null" json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type StudyProgram struct {
+	ID           string      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	DepartmentID string      `gorm:"type:uuid;not null" json:"department_id"`
+	Department   *Department `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
+	Code         string      `gorm:"size:20;unique;not null" json:"code"`
+	Name         string      `gorm:"size:150;not null" json:"name"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+// UnitScope: batas unit untuk admin fakultas/jurusan; kosong = admin global
+type UnitScope struct {
+	FacultyID    string `json:"faculty_id,omitempty"`
+	DepartmentID string `json:"department_id,omitempty"`
+}
+
+func (s UnitScope) IsGlobal() bool {
+	return s.FacultyID == "" && s.DepartmentID == ""
+}
+
+// CoversStudent: butuh StudyProgram.Department sudah di-preload
+func (s UnitScope) CoversStudent(st *Student) bool {
+	if s.IsGlobal() {
+		return true
+	}
+	if st == nil || st.StudyProgram == nil || st.StudyProgram.Department == nil {
+		return false
+	}
+	if s.DepartmentID != "" {
+		return st.StudyProgram.DepartmentID == s.DepartmentID
+	}
+	return st.StudyProgram.Department.FacultyID == s.FacultyID
+}
+
+// UnitValueCount: nilai teks lama (program_study / department) + jumlah pemakainya
+type UnitValueCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// OrganizationUnmappedReport: teks prodi / jurusan lama yang belum punya FK unit
+type OrganizationUnmappedReport struct {
+	Programs    []UnitValueCount `json:"programs"`
+	Departments []UnitValueCount `json:"departments"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// PasswordResetToken: token lupa password, yang disimpan hanya hash SHA-256 dari token yang dikirim lewat email
+type PasswordResetToken struct {
+	ID        string     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID    string     `gorm:"type:uuid;not null" json:"user_id"`
+	TokenHash string     `gorm:"size:64;unique;not null" json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -3,8 +3,10 @@ package model
 import "time"
 
 type Role struct {
-	ID          string    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
-	Name        string    `gorm:"size:50;unique;not null" json:"name"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          string `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Name        string `gorm:"size:50;unique;not null" json:"name"`
+	Description string `json:"description"`
+	// true = semua user dengan role ini wajib mengaktifkan 2FA (TOTP)
+	RequireTwoFactor bool      `gorm:"default:false" json:"require_two_factor"`
+	CreatedAt        time.Time `json:"created_at"`
 }
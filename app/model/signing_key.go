@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// SigningKey: kunci tanda tangan JWT. RetiredAt != nil = tidak dipakai tanda tangan lagi,
+// tapi tetap ada di JWKS sampai ExpiresAt supaya token lama masih bisa diverifikasi.
+type SigningKey struct {
+	KID        string     `gorm:"column:kid;size:64;primaryKey" json:"kid"`
+	Algorithm  string     `gorm:"size:10;not null" json:"algorithm"`
+	PrivateKey string     `gorm:"type:text;not null" json:"-"` // PEM PKCS#8
+	CreatedAt  time.Time  `json:"created_at"`
+	RetiredAt  *time.Time `json:"retired_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
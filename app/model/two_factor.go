@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// UserTwoFactor: secret TOTP user; Enabled baru true setelah kode pertama diverifikasi
+type UserTwoFactor struct {
+	UserID      string     `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Secret      string     `gorm:"size:64;not null" json:"-"`
+	Enabled     bool       `json:"enabled"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+	// time-step TOTP terakhir yang dipakai, kode yang sama tidak bisa dipakai dua kali
+	LastUsedStep int64     `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TwoFactorRecoveryCode: kode cadangan sekali pakai, disimpan sebagai hash SHA-256
+type TwoFactorRecoveryCode struct {
+	ID        string     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID    string     `gorm:"type:uuid;not null" json:"user_id"`
+	CodeHash  string     `gorm:"size:64;not null" json:"-"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type TwoFactorStatus struct {
+	Enabled           bool  `json:"enabled"`
+	Required          bool  `json:"required"`
+	RecoveryCodesLeft int64 `json:"recovery_codes_left"`
+	PendingEnrollment bool  `json:"pending_enrollment"`
+}
@@ -3,14 +3,69 @@ package model
 import "time"
 
 type User struct {
-	ID           string    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
-	Username     string    `gorm:"size:50;unique;not null" json:"username"`
-	Email        string    `gorm:"size:100;unique;not null" json:"email"`
-	PasswordHash string    `gorm:"size:255;not null" json:"-"`
-	FullName     string    `gorm:"size:100;not null" json:"full_name"`
-	RoleID       string    `gorm:"type:uuid;not null" json:"role_id"`
-	Role         Role      `gorm:"foreignKey:RoleID" json:"role"`
-	IsActive     bool      `gorm:"default:true" json:"is_active"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           string `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Username     string `gorm:"size:50;unique;not null" json:"username"`
+	Email        string `gorm:"size:100;unique;not null" json:"email"`
+	PasswordHash string `gorm:"size:255;not null" json:"-"`
+	FullName     string `gorm:"size:100;not null" json:"full_name"`
+	RoleID       string `gorm:"type:uuid;not null" json:"role_id"`
+	Role         Role   `gorm:"foreignKey:RoleID" json:"role"`
+	IsActive     bool   `gorm:"default:true" json:"is_active"`
+	// scope admin fakultas/jurusan; keduanya kosong = admin global
+	ScopeFacultyID    *string `gorm:"type:uuid" json:"scope_faculty_id,omitempty"`
+	ScopeDepartmentID *string `gorm:"type:uuid" json:"scope_department_id,omitempty"`
+	// akun seed / dibuat admin wajib ganti password saat login pertama
+	MustChangePassword bool       `gorm:"default:false" json:"must_change_password"`
+	PasswordChangedAt  *time.Time `json:"password_changed_at,omitempty"`
+	// salinan status user_two_factors.enabled, dipakai saat membuat token
+	TwoFactorEnabled bool `gorm:"default:false" json:"two_factor_enabled"`
+	// backend verifikasi password: local | ldap; kosong = ikut AUTH_BACKEND global
+	AuthBackend string `gorm:"size:20" json:"auth_backend,omitempty"`
+	// akun non-manusia (script / portal); tidak bisa login password, hanya lewat API token
+	IsServiceAccount bool `gorm:"default:false" json:"is_service_account"`
+	// diisi saat dinonaktifkan admin; kosong lagi setelah diaktifkan kembali
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+	// access token yang terbit sebelum waktu ini ditolak walaupun belum expired
+	SessionsRevokedAt *time.Time `json:"sessions_revoked_at,omitempty"`
+	// data pribadi sudah diganti nilai anonim (permintaan penghapusan data)
+	AnonymizedAt *time.Time `json:"anonymized_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+const (
+	AuthBackendLocal = "local"
+	AuthBackendLDAP  = "ldap"
+)
+
+// Scope: unit yang boleh diakses user (admin)
+func (u User) Scope() UnitScope {
+	var s UnitScope
+	if u.ScopeFacultyID != nil {
+		s.FacultyID = *u.ScopeFacultyID
+	}
+	if u.ScopeDepartmentID != nil {
+		s.DepartmentID = *u.ScopeDepartmentID
+	}
+	return s
+}
+
+// SessionValid: access token dengan iat issuedAt masih boleh dipakai
+func (u User) SessionValid(issuedAt time.Time) bool {
+	if !u.IsActive {
+		return false
+	}
+	// iat JWT hanya presisi detik
+	return u.SessionsRevokedAt == nil || !issuedAt.Before(u.SessionsRevokedAt.Truncate(time.Second))
+}
+
+// AnonymizedAccount: nilai pengganti data pribadi user + nomor induk profilnya
+type AnonymizedAccount struct {
+	Username     string
+	Email        string
+	FullName     string
+	PasswordHash string
+	// pengganti students.student_id / lecturers.lecturer_id
+	ProfileNumber string
+	At            time.Time
 }
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// UserIdentity: akun IdP (OIDC) yang terhubung ke user lokal, dicari lewat provider + sub
+type UserIdentity struct {
+	ID          string     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID      string     `gorm:"type:uuid;not null" json:"user_id"`
+	Provider    string     `gorm:"size:50;not null" json:"provider"`
+	Subject     string     `gorm:"size:255;not null" json:"subject"`
+	Email       string     `gorm:"size:100" json:"email"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// OIDCLoginState: state + nonce + PKCE verifier satu percobaan login SSO (sekali pakai)
+type OIDCLoginState struct {
+	State        string    `gorm:"size:64;primaryKey" json:"state"`
+	Nonce        string    `gorm:"size:64;not null" json:"-"`
+	CodeVerifier string    `gorm:"size:128;not null" json:"-"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+)
+
+type AcademicPeriodRepository interface {
+	Create(p *model.AcademicPeriod) error
+	Save(p *model.AcademicPeriod) error
+	Delete(id string) error
+	FindByID(id string) (*model.AcademicPeriod, error)
+	FindAll() ([]model.AcademicPeriod, error)
+	FindActive() (*model.AcademicPeriod, error)
+	FindByDate(t time.Time) (*model.AcademicPeriod, error)
+	FindOverlapping(start, end time.Time, excludeID string) ([]model.AcademicPeriod, error)
+	SetActive(id string) error
+}
+
+type academicPeriodRepository struct {
+	db *gorm.DB
+}
+
+func NewAcademicPeriodRepository(db *gorm.DB) AcademicPeriodRepository {
+	return &academicPeriodRepository{db: db}
+}
+
+func (r *academicPeriodRepository) Create(p *model.AcademicPeriod) error {
+	return r.db.Create(p).Error
+}
+
+func (r *academicPeriodRepository) Save(p *model.AcademicPeriod) error {
+	p.UpdatedAt = time.Now()
+	return r.db.Save(p).Error
+}
+
+func (r *academicPeriodRepository) Delete(id string) error {
+	return r.db.Delete(&model.AcademicPeriod{}, "id = ?", id).Error
+}
+
+func (r *academicPeriodRepository) FindByID(id string) (*model.AcademicPeriod, error) {
+	var p model.AcademicPeriod
+	if err := r.db.Where("id = ?", id).First(&p).Error; err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *academicPeriodRepository) FindAll() ([]model.AcademicPeriod, error) {
+	var periods []model.AcademicPeriod
+	err := r.db.Order("start_date DESC").Find(&periods).Error
+	return periods, err
+}
+
+func (r *academicPeriodRepository) FindActive() (*model.AcademicPeriod, error) {
+	var p model.AcademicPeriod
+	if err := r.db.Where("is_active = ?", true).First(&p).Error; err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// FindByDate: periode yang rentang tanggalnya memuat t
+func (r *academicPeriodRepository) FindByDate(t time.Time) (*model.AcademicPeriod, error) {
+	var p model.AcademicPeriod
+	day := t.Format("2006-01-02")
+	if err := r.db.Where("start_date <= ? AND end_date >= ?", day, day).First(&p).Error; err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *academicPeriodRepository) FindOverlapping(start, end time.Time, excludeID string) ([]model.AcademicPeriod, error) {
+	var periods []model.AcademicPeriod
+	q := r.db.Where("start_date <= ? AND end_date >= ?", end.Format("2006-01-02"), start.Format("2006-01-02"))
+	if excludeID != "" {
+		q = q.Where("id <> ?", excludeID)
+	}
+	err := q.Find(&periods).Error
+	return periods, err
+}
+
+// SetActive: hanya satu periode aktif dalam satu waktu
+func (r *academicPeriodRepository) SetActive(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.AcademicPeriod{}).
+			Where("is_active = ?", true).
+			Updates(map[string]any{"is_active": false, "updated_at": time.Now()}).Error; err != nil {
+			return err
+		}
+
+		res := tx.Model(&model.AcademicPeriod{}).
+			Where("id = ?", id).
+			Updates(map[string]any{"is_active": true, "updated_at": time.Now()})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
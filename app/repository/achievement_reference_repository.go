@@ -8,14 +8,34 @@ import (
 )
 
 type AchievementReferenceRepository interface {
-	CreateDraft(studentID string, mongoAchievementID string) (*model.AchievementReference, error)
+	CreateDraft(studentID string, mongoAchievementID string, periodID *string) (*model.AchievementReference, error)
 	GetByID(id string) (*model.AchievementReference, error)
 	Save(ref *model.AchievementReference) error
 	CountByStudentIDs(studentIDs []string, status *model.AchievementStatus) (int64, error)
     FindByStudentIDs(studentIDs []string, status *model.AchievementStatus, limit, offset int) ([]model.AchievementReference, error)
-	FindAll(offset, limit int, status *string) ([]model.AchievementReference, int64, error)
+	FindAll(offset, limit int, filter AchievementReferenceFilter) ([]model.AchievementReference, int64, error)
 	CountByStatus() (map[string]int64, error)
 	FindByStudentID(studentID string) ([]model.AchievementReference, error)
+	FindVerifiedBetween(from, to time.Time) ([]model.AchievementReference, error)
+	FindVerifiedByPeriod(periodID string) ([]model.AchievementReference, error)
+	FindPendingWithStudent() ([]model.AchievementReference, error)
+	CountReviewedBy(userIDs []string, since time.Time) (map[string]map[string]int64, error)
+	// ClaimReminders: set last_reminded_at=at hanya untuk reference yang masih submitted dan belum diingatkan
+	// sejak remindedBefore; return id yang berhasil diklaim (yang sudah diklaim instance lain tidak ikut)
+	ClaimReminders(ids []string, at, remindedBefore time.Time) ([]string, error)
+	// ReleaseReminders: batalkan klaim (email gagal terkirim) supaya dicoba lagi di putaran berikutnya
+	ReleaseReminders(ids []string, at time.Time) error
+	// FindByMongoID: semua reference satu dokumen prestasi (ketua + anggota tim)
+	FindByMongoID(mongoID string) ([]model.AchievementReference, error)
+	// PurgeByMongoID: hapus permanen reference + log status, anggota tim dan flag duplikat terkait
+	PurgeByMongoID(mongoID string) error
+}
+
+// AchievementReferenceFilter: filter opsional untuk list prestasi admin
+type AchievementReferenceFilter struct {
+	Status   *string
+	PeriodID *string
+	Scope    model.UnitScope
 }
 
 type achievementReferenceRepository struct {
@@ -26,10 +46,11 @@ func NewAchievementReferenceRepository(db *gorm.DB) AchievementReferenceReposito
 	return &achievementReferenceRepository{db: db}
 }
 
-func (r *achievementReferenceRepository) CreateDraft(studentID string, mongoAchievementID string) (*model.AchievementReference, error) {
+func (r *achievementReferenceRepository) CreateDraft(studentID string, mongoAchievementID string, periodID *string) (*model.AchievementReference, error) {
 	ref := &model.AchievementReference{
 		StudentID:          studentID,
 		MongoAchievementID: mongoAchievementID,
+		PeriodID:           periodID,
 		Status:             model.AchievementStatusDraft,
 	}
 
@@ -75,16 +96,21 @@ func (r *achievementReferenceRepository) FindByStudentIDs(studentIDs []string, s
     }
     return refs, nil
 }
-func (r *achievementReferenceRepository) FindAll(offset, limit int, status *string) ([]model.AchievementReference, int64, error) {
+func (r *achievementReferenceRepository) FindAll(offset, limit int, filter AchievementReferenceFilter) ([]model.AchievementReference, int64, error) {
 	var refs []model.AchievementReference
 	var total int64
 
 	q := r.db.Model(&model.AchievementReference{}).
-		Preload("Student")
+		Preload("Student").
+		Preload("Period")
 
-	if status != nil {
-		q = q.Where("status = ?", *status)
+	if filter.Status != nil {
+		q = q.Where("status = ?", *filter.Status)
 	}
+	if filter.PeriodID != nil {
+		q = q.Where("period_id = ?", *filter.PeriodID)
+	}
+	q = applyStudentScope(q, filter.Scope, "achievement_references.student_id")
 
 	q.Count(&total)
 
@@ -136,3 +162,138 @@ func (r *achievementReferenceRepository) FindByStudentID(
 
 	return refs, nil
 }
+
+// FindVerifiedBetween: prestasi terverifikasi berdasarkan verified_at, beserta student + user
+func (r *achievementReferenceRepository) FindVerifiedBetween(from, to time.Time) ([]model.AchievementReference, error) {
+	var refs []model.AchievementReference
+	err := r.db.
+		Preload("Student.User").
+		Preload("Student.StudyProgram.Department").
+		Preload("Period").
+		Where("status = ?", model.AchievementStatusVerified).
+		Where("verified_at >= ? AND verified_at < ?", from, to).
+		Order("verified_at ASC").
+		Find(&refs).Error
+	return refs, err
+}
+
+// FindVerifiedByPeriod: prestasi terverifikasi yang tercatat di periode akademik tertentu
+func (r *achievementReferenceRepository) FindVerifiedByPeriod(periodID string) ([]model.AchievementReference, error) {
+	var refs []model.AchievementReference
+	err := r.db.
+		Preload("Student.User").
+		Preload("Student.StudyProgram.Department").
+		Preload("Period").
+		Where("status = ?", model.AchievementStatusVerified).
+		Where("period_id = ?", periodID).
+		Order("verified_at ASC").
+		Find(&refs).Error
+	return refs, err
+}
+
+// FindPendingWithStudent: semua prestasi submitted (menunggu verifikasi), yang paling lama di depan
+func (r *achievementReferenceRepository) FindPendingWithStudent() ([]model.AchievementReference, error) {
+	var refs []model.AchievementReference
+	err := r.db.
+		Preload("Student.User").
+		Where("status = ?", model.AchievementStatusSubmitted).
+		Order("submitted_at ASC").
+		Find(&refs).Error
+	return refs, err
+}
+
+// CountReviewedBy: jumlah verified/rejected per verifier sejak tanggal tertentu
+func (r *achievementReferenceRepository) CountReviewedBy(userIDs []string, since time.Time) (map[string]map[string]int64, error) {
+	result := make(map[string]map[string]int64)
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	type row struct {
+		VerifiedBy string
+		Status     string
+		Total      int64
+	}
+
+	var rows []row
+	err := r.db.
+		Model(&model.AchievementReference{}).
+		Select("verified_by, status, COUNT(*) as total").
+		Where("verified_by IN ?", userIDs).
+		Where("status IN ?", []model.AchievementStatus{model.AchievementStatusVerified, model.AchievementStatusRejected}).
+		Where("verified_at >= ?", since).
+		Group("verified_by, status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rw := range rows {
+		if result[rw.VerifiedBy] == nil {
+			result[rw.VerifiedBy] = map[string]int64{}
+		}
+		result[rw.VerifiedBy][rw.Status] = rw.Total
+	}
+	return result, nil
+}
+
+func (r *achievementReferenceRepository) ClaimReminders(ids []string, at, remindedBefore time.Time) ([]string, error) {
+	claimed := []string{}
+	if len(ids) == 0 {
+		return claimed, nil
+	}
+	// satu UPDATE bersyarat: dua instance yang jalan bersamaan tidak bisa mengklaim baris yang sama
+	err := r.db.Raw(`
+		UPDATE achievement_references
+		SET last_reminded_at = ?
+		WHERE id IN ?
+		  AND status = ?
+		  AND (last_reminded_at IS NULL OR last_reminded_at < ?)
+		RETURNING id`,
+		at, ids, model.AchievementStatusSubmitted, remindedBefore,
+	).Scan(&claimed).Error
+	return claimed, err
+}
+
+func (r *achievementReferenceRepository) ReleaseReminders(ids []string, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.
+		Model(&model.AchievementReference{}).
+		Where("id IN ? AND last_reminded_at = ?", ids, at).
+		UpdateColumn("last_reminded_at", nil).Error
+}
+
+func (r *achievementReferenceRepository) FindByMongoID(mongoID string) ([]model.AchievementReference, error) {
+	var refs []model.AchievementReference
+	err := r.db.
+		Where("mongo_achievement_id = ?", mongoID).
+		Order("created_at ASC").
+		Find(&refs).Error
+	return refs, err
+}
+
+func (r *achievementReferenceRepository) PurgeByMongoID(mongoID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		refIDs := tx.Model(&model.AchievementReference{}).
+			Select("id").
+			Where("mongo_achievement_id = ?", mongoID)
+
+		if err := tx.Where("achievement_reference_id IN (?)", refIDs).
+			Delete(&model.AchievementStatusLog{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("mongo_achievement_id = ?", mongoID).
+			Delete(&model.AchievementTeamMember{}).Error; err != nil {
+			return err
+		}
+		// flag milik reference ini ikut terhapus (ON DELETE CASCADE); flag prestasi lain yang cocok dengan dokumen ini dihapus manual
+		if err := tx.Where("matched_mongo_achievement_id = ?", mongoID).
+			Delete(&model.AchievementDuplicateFlag{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("mongo_achievement_id = ?", mongoID).
+			Delete(&model.AchievementReference{}).Error
+	})
+}
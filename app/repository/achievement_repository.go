@@ -2,23 +2,33 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/nerhays/prestasi_uas/app/model"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type AchievementRepository interface {
 	Create(ctx context.Context, ac *model.Achievement) (*model.Achievement, error)
 	FindByStudentID(ctx context.Context, studentID string) ([]model.Achievement, error)
+	SetTeam(ctx context.Context, mongoID string, team []model.TeamMember) error
 	SoftDelete(ctx context.Context, mongoID string) error
 	FindDeletedByStudentID(ctx context.Context, studentID string) ([]model.Achievement, error)
+	Restore(ctx context.Context, mongoID string) error
+	// FindDeletedBefore: dokumen soft delete dengan deletedAt < before (kandidat purge)
+	FindDeletedBefore(ctx context.Context, before time.Time, limit int) ([]model.Achievement, error)
+	HardDelete(ctx context.Context, mongoID string) error
 	FindByIDs(ctx context.Context, ids []string) ([]model.Achievement, error)
 	AddAttachment(ctx context.Context, mongoID string, att model.Attachment) error
 	FindByID(ctx context.Context, id string) (*model.Achievement, error)
 	CountByType(ctx context.Context) (map[string]int64, error)
+	GroupByField(ctx context.Context, ids []string, field string) ([]model.AnalyticsBucket, error)
+	PointsByIDs(ctx context.Context, ids []string) (map[string]float64, error)
+	FindDuplicateCandidates(ctx context.Context, ac *model.Achievement, limit int) ([]model.Achievement, error)
 	
 	FindAll(ctx context.Context) ([]model.Achievement, error)
 	Update(ctx context.Context, id string, payload *model.Achievement) (*model.Achievement, error)
@@ -52,7 +62,7 @@ func (r *achievementRepository) Create(ctx context.Context, ac *model.Achievemen
 
 func (r *achievementRepository) FindByStudentID(ctx context.Context, studentID string) ([]model.Achievement, error) {
 	filter := bson.M{
-		"studentId": studentID,
+		"$or":       ownedOrTeam(studentID),
 		"isDeleted": bson.M{"$ne": true}, //tidak tampilkan yang soft delete
 	}
 
@@ -78,6 +88,27 @@ func (r *achievementRepository) FindByStudentID(ctx context.Context, studentID s
 	return results, nil
 }
 
+// ownedOrTeam: prestasi milik mahasiswa (ketua) atau prestasi tim yang ia ikuti
+func ownedOrTeam(studentID string) bson.A {
+	return bson.A{
+		bson.M{"studentId": studentID},
+		bson.M{"team.studentId": studentID},
+	}
+}
+
+// SetTeam: ganti daftar anggota tim pada dokumen achievement
+func (r *achievementRepository) SetTeam(ctx context.Context, mongoID string, team []model.TeamMember) error {
+	objID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateByID(ctx, objID, bson.M{
+		"$set": bson.M{"team": team, "updatedAt": time.Now()},
+	})
+	return err
+}
+
 func (r *achievementRepository) SoftDelete(ctx context.Context, mongoID string) error {
 	objID, err := primitive.ObjectIDFromHex(mongoID)
 	if err != nil {
@@ -97,9 +128,50 @@ func (r *achievementRepository) SoftDelete(ctx context.Context, mongoID string)
 
 	return err
 }
+// Restore: batalkan soft delete
+func (r *achievementRepository) Restore(ctx context.Context, mongoID string) error {
+	objID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateByID(ctx, objID, bson.M{
+		"$unset": bson.M{"isDeleted": "", "deletedAt": ""},
+		"$set":   bson.M{"updatedAt": time.Now()},
+	})
+	return err
+}
+
+func (r *achievementRepository) FindDeletedBefore(ctx context.Context, before time.Time, limit int) ([]model.Achievement, error) {
+	cur, err := r.collection.Find(ctx, bson.M{
+		"isDeleted": true,
+		"deletedAt": bson.M{"$lt": before},
+	}, options.Find().SetLimit(int64(limit)).SetSort(bson.M{"deletedAt": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var out []model.Achievement
+	if err := cur.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HardDelete: hapus dokumen permanen, hanya untuk dokumen yang sudah soft delete
+func (r *achievementRepository) HardDelete(ctx context.Context, mongoID string) error {
+	objID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objID, "isDeleted": true})
+	return err
+}
 func (r *achievementRepository) FindDeletedByStudentID(ctx context.Context, studentID string) ([]model.Achievement, error) {
     filter := bson.M{
-        "studentId": studentID,
+        "$or":       ownedOrTeam(studentID),
         "isDeleted": true,
     }
 
@@ -254,3 +326,113 @@ func (r *achievementRepository) FindAll(
 	return res, nil
 }
 
+// GroupByField: jumlah + total poin achievement (ids) dikelompokkan berdasarkan field dokumen
+func (r *achievementRepository) GroupByField(
+	ctx context.Context,
+	ids []string,
+	field string,
+) ([]model.AnalyticsBucket, error) {
+	cursor, err := r.collection.Aggregate(ctx, []bson.M{
+		{"$match": bson.M{"_id": bson.M{"$in": toObjectIDs(ids)}}},
+		{"$group": bson.M{
+			"_id":    "$" + field,
+			"count":  bson.M{"$sum": 1},
+			"points": bson.M{"$sum": "$points"},
+		}},
+		{"$sort": bson.M{"points": -1}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []model.AnalyticsBucket
+	for cursor.Next(ctx) {
+		var row struct {
+			ID     any     `bson:"_id"`
+			Count  int64   `bson:"count"`
+			Points float64 `bson:"points"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+
+		key := "unknown"
+		if row.ID != nil {
+			key = fmt.Sprint(row.ID)
+		}
+		result = append(result, model.AnalyticsBucket{Key: key, Count: row.Count, Points: row.Points})
+	}
+	return result, cursor.Err()
+}
+
+func (r *achievementRepository) PointsByIDs(ctx context.Context, ids []string) (map[string]float64, error) {
+	cursor, err := r.collection.Aggregate(ctx, []bson.M{
+		{"$match": bson.M{"_id": bson.M{"$in": toObjectIDs(ids)}}},
+		{"$project": bson.M{"points": 1}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	result := make(map[string]float64)
+	for cursor.Next(ctx) {
+		var row struct {
+			ID     primitive.ObjectID `bson:"_id"`
+			Points float64            `bson:"points"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+		result[row.ID.Hex()] = row.Points
+	}
+	return result, cursor.Err()
+}
+
+func toObjectIDs(ids []string) []primitive.ObjectID {
+	objIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, h := range ids {
+		oid, err := primitive.ObjectIDFromHex(h)
+		if err != nil {
+			continue
+		}
+		objIDs = append(objIDs, oid)
+	}
+	return objIDs
+}
+
+// FindDuplicateCandidates: dokumen lain yang mungkin duplikat dari ac — milik mahasiswa yang sama,
+// tanggal event sama, nomor sertifikat sama, atau lampiran dengan hash sama
+func (r *achievementRepository) FindDuplicateCandidates(
+	ctx context.Context,
+	ac *model.Achievement,
+	limit int,
+) ([]model.Achievement, error) {
+	or := bson.A{bson.M{"studentId": ac.StudentID}}
+	if date, ok := ac.EventDate(); ok {
+		or = append(or, bson.M{"details.eventDate": date})
+	}
+	if cert := ac.DetailString("certificationNumber"); cert != "" {
+		or = append(or, bson.M{"details.certificationNumber": cert})
+	}
+	if hashes := ac.AttachmentHashes(); len(hashes) > 0 {
+		or = append(or, bson.M{"attachments.hash": bson.M{"$in": hashes}})
+	}
+
+	cur, err := r.collection.Find(ctx, bson.M{
+		"_id":       bson.M{"$ne": ac.ID},
+		"isDeleted": bson.M{"$ne": true},
+		"$or":       or,
+	}, options.Find().SetLimit(int64(limit)).SetSort(bson.M{"createdAt": -1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var res []model.Achievement
+	if err := cur.All(ctx, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
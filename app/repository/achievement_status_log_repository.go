@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/nerhays/prestasi_uas/app/model"
 	"gorm.io/gorm"
 )
@@ -8,6 +10,7 @@ import (
 type AchievementStatusLogRepository interface {
 	Create(log *model.AchievementStatusLog) error
 	FindByReferenceID(refID string) ([]model.AchievementStatusLog, error)
+	AverageTurnaround(from, to time.Time, scope model.UnitScope) (float64, int64, error)
 }
 
 type achievementStatusLogRepo struct {
@@ -30,3 +33,36 @@ func (r *achievementStatusLogRepo) FindByReferenceID(refID string) ([]model.Achi
 		Find(&logs).Error
 	return logs, err
 }
+
+// AverageTurnaround: rata-rata detik dari submit terakhir sampai verified (verified di rentang from..to),
+// hanya prestasi mahasiswa dalam scope
+func (r *achievementStatusLogRepo) AverageTurnaround(from, to time.Time, scope model.UnitScope) (float64, int64, error) {
+	var row struct {
+		AvgSeconds *float64
+		Total      int64
+	}
+
+	q := r.db.Table("achievement_status_logs v").
+		Select(`AVG(EXTRACT(EPOCH FROM (v.created_at - s.submitted_at))) AS avg_seconds,
+		       COUNT(s.submitted_at) AS total`).
+		Joins(`JOIN LATERAL (
+			SELECT MAX(l.created_at) AS submitted_at
+			FROM achievement_status_logs l
+			WHERE l.achievement_reference_id = v.achievement_reference_id
+			  AND l.new_status = 'submitted'
+			  AND l.created_at <= v.created_at
+		) s ON TRUE`).
+		Joins("JOIN achievement_references ar ON ar.id = v.achievement_reference_id").
+		Where("v.new_status = 'verified'").
+		Where("v.created_at >= ? AND v.created_at < ?", from, to)
+
+	err := applyStudentScope(q, scope, "ar.student_id").Scan(&row).Error
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if row.AvgSeconds == nil {
+		return 0, 0, nil
+	}
+	return *row.AvgSeconds, row.Total, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+)
+
+type AchievementTeamRepository interface {
+	CreateMembers(members []model.AchievementTeamMember) error
+	Save(member *model.AchievementTeamMember) error
+	FindByID(id string) (*model.AchievementTeamMember, error)
+	FindByMongoID(mongoID string) ([]model.AchievementTeamMember, error)
+	FindPendingByStudentID(studentID string) ([]model.AchievementTeamMember, error)
+}
+
+type achievementTeamRepository struct {
+	db *gorm.DB
+}
+
+func NewAchievementTeamRepository(db *gorm.DB) AchievementTeamRepository {
+	return &achievementTeamRepository{db: db}
+}
+
+func (r *achievementTeamRepository) CreateMembers(members []model.AchievementTeamMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+	return r.db.Create(&members).Error
+}
+
+func (r *achievementTeamRepository) Save(member *model.AchievementTeamMember) error {
+	member.UpdatedAt = time.Now()
+	return r.db.Save(member).Error
+}
+
+func (r *achievementTeamRepository) FindByID(id string) (*model.AchievementTeamMember, error) {
+	var member model.AchievementTeamMember
+	if err := r.db.
+		Preload("Student.User").
+		First(&member, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// FindByMongoID: semua anggota satu prestasi tim, ketua di depan
+func (r *achievementTeamRepository) FindByMongoID(mongoID string) ([]model.AchievementTeamMember, error) {
+	var members []model.AchievementTeamMember
+	err := r.db.
+		Preload("Student.User").
+		Where("mongo_achievement_id = ?", mongoID).
+		Order("role = 'leader' DESC, created_at ASC").
+		Find(&members).Error
+	return members, err
+}
+
+func (r *achievementTeamRepository) FindPendingByStudentID(studentID string) ([]model.AchievementTeamMember, error) {
+	var members []model.AchievementTeamMember
+	err := r.db.
+		Where("student_id = ? AND status = ?", studentID, model.TeamInvitationPending).
+		Order("created_at DESC").
+		Find(&members).Error
+	return members, err
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AchievementVersionRepository interface {
+	Create(ctx context.Context, v *model.AchievementVersion) error
+	// FindByAchievementID: semua versi satu prestasi, urut version naik
+	FindByAchievementID(ctx context.Context, achievementID string) ([]model.AchievementVersion, error)
+	FindOne(ctx context.Context, achievementID string, version int) (*model.AchievementVersion, error)
+	// Latest: versi terakhir; nil tanpa error jika prestasi belum punya versi
+	Latest(ctx context.Context, achievementID string) (*model.AchievementVersion, error)
+	DeleteByAchievementID(ctx context.Context, achievementID string) error
+}
+
+type achievementVersionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAchievementVersionRepository(db *mongo.Database) AchievementVersionRepository {
+	return &achievementVersionRepository{
+		collection: db.Collection("achievement_versions"),
+	}
+}
+
+func (r *achievementVersionRepository) Create(ctx context.Context, v *model.AchievementVersion) error {
+	_, err := r.collection.InsertOne(ctx, v)
+	return err
+}
+
+func (r *achievementVersionRepository) FindByAchievementID(ctx context.Context, achievementID string) ([]model.AchievementVersion, error) {
+	cur, err := r.collection.Find(ctx,
+		bson.M{"achievementId": achievementID},
+		options.Find().SetSort(bson.M{"version": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var out []model.AchievementVersion
+	if err := cur.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *achievementVersionRepository) FindOne(ctx context.Context, achievementID string, version int) (*model.AchievementVersion, error) {
+	var v model.AchievementVersion
+	err := r.collection.FindOne(ctx, bson.M{"achievementId": achievementID, "version": version}).Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *achievementVersionRepository) Latest(ctx context.Context, achievementID string) (*model.AchievementVersion, error) {
+	var v model.AchievementVersion
+	err := r.collection.FindOne(ctx,
+		bson.M{"achievementId": achievementID},
+		options.FindOne().SetSort(bson.M{"version": -1}),
+	).Decode(&v)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *achievementVersionRepository) DeleteByAchievementID(ctx context.Context, achievementID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"achievementId": achievementID})
+	return err
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+)
+
+type AdvisorAssignmentRepository interface {
+	// Reassign: satu transaksi untuk semua mahasiswa — penugasan lama ditutup, penugasan baru dicatat,
+	// students.advisor_id diperbarui. Mahasiswa tanpa riwayat dibuatkan baris awal dari students.created_at.
+	Reassign(assignments []model.AdvisorAssignment) error
+	// FindByStudentID: riwayat dosen wali, terbaru dulu
+	FindByStudentID(studentID string) ([]model.AdvisorAssignment, error)
+}
+
+type advisorAssignmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAdvisorAssignmentRepository(db *gorm.DB) AdvisorAssignmentRepository {
+	return &advisorAssignmentRepository{db: db}
+}
+
+func (r *advisorAssignmentRepository) Reassign(assignments []model.AdvisorAssignment) error {
+	if len(assignments) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range assignments {
+			a := &assignments[i]
+
+			// data sebelum ada tabel riwayat: dosen wali lama dianggap sejak mahasiswa dibuat
+			if err := tx.Exec(`
+				INSERT INTO advisor_assignments (student_id, lecturer_id, effective_from, reason)
+				SELECT s.id, s.advisor_id, s.created_at, 'penugasan awal'
+				FROM students s
+				WHERE s.id = ? AND s.advisor_id IS NOT NULL
+				  AND NOT EXISTS (SELECT 1 FROM advisor_assignments a WHERE a.student_id = s.id)`,
+				a.StudentID).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`
+				UPDATE advisor_assignments
+				SET effective_to = GREATEST(effective_from, ?)
+				WHERE student_id = ? AND effective_to IS NULL`,
+				a.EffectiveFrom, a.StudentID).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Omit("Lecturer").Create(a).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Model(&model.Student{}).
+				Where("id = ?", a.StudentID).
+				Update("advisor_id", a.LecturerID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *advisorAssignmentRepository) FindByStudentID(studentID string) ([]model.AdvisorAssignment, error) {
+	var rows []model.AdvisorAssignment
+	err := r.db.
+		Preload("Lecturer.User").
+		Where("student_id = ?", studentID).
+		Order("effective_from DESC, created_at DESC").
+		Find(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+)
+
+// last_used_at hanya ditulis ulang setelah jeda ini, supaya tiap request tidak selalu UPDATE
+const apiTokenTouchInterval = time.Minute
+
+type APITokenRepository interface {
+	// Create: simpan token beserta scope (permission sudah ada, tidak ikut di-insert)
+	Create(token *model.APIToken) error
+	FindByHash(tokenHash string) (*model.APIToken, error)
+	FindByID(id string) (*model.APIToken, error)
+	FindByUserID(userID string) ([]model.APIToken, error)
+	// Revoke: false kalau token tidak ada / sudah dicabut
+	Revoke(id string, at time.Time) (bool, error)
+	TouchLastUsed(id, ip string, at time.Time) error
+}
+
+type apiTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAPITokenRepository(db *gorm.DB) APITokenRepository {
+	return &apiTokenRepository{db: db}
+}
+
+func (r *apiTokenRepository) Create(token *model.APIToken) error {
+	return r.db.Omit("Scopes.*").Create(token).Error
+}
+
+func (r *apiTokenRepository) FindByHash(tokenHash string) (*model.APIToken, error) {
+	var token model.APIToken
+	if err := r.db.
+		Preload("Scopes").
+		Where("token_hash = ?", tokenHash).
+		First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *apiTokenRepository) FindByID(id string) (*model.APIToken, error) {
+	var token model.APIToken
+	if err := r.db.
+		Preload("Scopes").
+		Where("id = ?", id).
+		First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *apiTokenRepository) FindByUserID(userID string) ([]model.APIToken, error) {
+	var tokens []model.APIToken
+	if err := r.db.
+		Preload("Scopes").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (r *apiTokenRepository) Revoke(id string, at time.Time) (bool, error) {
+	res := r.db.Model(&model.APIToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	return res.RowsAffected == 1, res.Error
+}
+
+func (r *apiTokenRepository) TouchLastUsed(id, ip string, at time.Time) error {
+	return r.db.Model(&model.APIToken{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, at.Add(-apiTokenTouchInterval)).
+		Updates(map[string]interface{}{"last_used_at": at, "last_used_ip": ip}).Error
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+)
+
+// kunci advisory Postgres untuk penulis rantai audit (nilai bebas, asal unik di aplikasi ini)
+const auditChainLockID = 41_0001
+
+type AuditLogRepository interface {
+	// Append: seal dipanggil dengan entri terakhir (nil kalau kosong) lalu entri disimpan,
+	// dalam satu transaksi yang menahan penulis lain supaya rantai tidak bercabang
+	Append(entry *model.AuditLog, seal func(prev *model.AuditLog)) error
+	// FindAll: terbaru di depan
+	FindAll(filter model.AuditLogFilter, offset, limit int) ([]model.AuditLog, int64, error)
+	// FindChain: entri dengan seq > afterSeq, urut naik
+	FindChain(afterSeq int64, limit int) ([]model.AuditLog, error)
+}
+
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+func (r *auditLogRepository) Append(entry *model.AuditLog, seal func(prev *model.AuditLog)) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", auditChainLockID).Error; err != nil {
+			return err
+		}
+
+		var last []model.AuditLog
+		if err := tx.Order("seq DESC").Limit(1).Find(&last).Error; err != nil {
+			return err
+		}
+		var prev *model.AuditLog
+		if len(last) == 1 {
+			prev = &last[0]
+		}
+		seal(prev)
+
+		return tx.Create(entry).Error
+	})
+}
+
+func (r *auditLogRepository) FindAll(filter model.AuditLogFilter, offset, limit int) ([]model.AuditLog, int64, error) {
+	var entries []model.AuditLog
+	var total int64
+
+	q := r.db.Model(&model.AuditLog{})
+	if filter.ActorID != "" {
+		q = q.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		q = q.Where("action ILIKE ?", "%"+filter.Action+"%")
+	}
+	if filter.TargetType != "" {
+		q = q.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		q = q.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.RequestID != "" {
+		q = q.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		q = q.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		q = q.Where("created_at < ?", *filter.To)
+	}
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := q.Order("seq DESC").Offset(offset).Limit(limit).Find(&entries).Error
+	return entries, total, err
+}
+
+func (r *auditLogRepository) FindChain(afterSeq int64, limit int) ([]model.AuditLog, error) {
+	var entries []model.AuditLog
+	err := r.db.
+		Where("seq > ?", afterSeq).
+		Order("seq ASC").
+		Limit(limit).
+		Find(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,39 @@
+package repository_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/app/repository/repotest"
+	"github.com/nerhays/prestasi_uas/database"
+)
+
+// TestConformance: suite yang sama dengan package memory, terhadap Postgres + Mongo sungguhan.
+// Jalan hanya kalau TEST_POSTGRES_DSN dan TEST_MONGO_URI diisi (pakai database khusus test, migrasi dijalankan otomatis).
+func TestConformance(t *testing.T) {
+	dsn, mongoURI := os.Getenv("TEST_POSTGRES_DSN"), os.Getenv("TEST_MONGO_URI")
+	if dsn == "" || mongoURI == "" {
+		t.Skip("TEST_POSTGRES_DSN / TEST_MONGO_URI tidak diisi")
+	}
+	mongoDBName := os.Getenv("TEST_MONGO_DB")
+	if mongoDBName == "" {
+		mongoDBName = "prestasi_test"
+	}
+
+	db := database.NewPostgres(dsn)
+	mongo := database.NewMongo(mongoURI, mongoDBName)
+	t.Cleanup(func() { _ = mongo.Client.Disconnect(context.Background()) })
+
+	migrator, err := database.NewMigrator(db, mongo.DB)
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, database.SeedRolesAndPermissions(db))
+
+	repos := repository.NewRepositories(db, mongo.DB)
+	repotest.Run(t, func(t *testing.T) *repository.Repositories { return repos })
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DuplicateFlagRepository interface {
+	// Upsert: satu flag per (reference, dokumen yang cocok); flag yang sudah ditinjau tidak dibuka ulang
+	Upsert(flag *model.AchievementDuplicateFlag) error
+	Save(flag *model.AchievementDuplicateFlag) error
+	FindByID(id string) (*model.AchievementDuplicateFlag, error)
+	FindAll(status *model.DuplicateFlagStatus, offset, limit int) ([]model.AchievementDuplicateFlag, int64, error)
+	FindOpenByReferenceIDs(refIDs []string) ([]model.AchievementDuplicateFlag, error)
+}
+
+type duplicateFlagRepository struct {
+	db *gorm.DB
+}
+
+func NewDuplicateFlagRepository(db *gorm.DB) DuplicateFlagRepository {
+	return &duplicateFlagRepository{db: db}
+}
+
+func (r *duplicateFlagRepository) Upsert(flag *model.AchievementDuplicateFlag) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "reference_id"}, {Name: "matched_mongo_achievement_id"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"reasons":    gorm.Expr("EXCLUDED.reasons"),
+			"score":      gorm.Expr("EXCLUDED.score"),
+			"updated_at": time.Now(),
+		}),
+	}).Create(flag).Error
+}
+
+func (r *duplicateFlagRepository) Save(flag *model.AchievementDuplicateFlag) error {
+	flag.UpdatedAt = time.Now()
+	return r.db.Save(flag).Error
+}
+
+func (r *duplicateFlagRepository) FindByID(id string) (*model.AchievementDuplicateFlag, error) {
+	var flag model.AchievementDuplicateFlag
+	if err := r.db.
+		Preload("Reference.Student.User").
+		First(&flag, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
+
+// FindAll: antrian review admin, skor tertinggi di depan
+func (r *duplicateFlagRepository) FindAll(status *model.DuplicateFlagStatus, offset, limit int) ([]model.AchievementDuplicateFlag, int64, error) {
+	var flags []model.AchievementDuplicateFlag
+	var total int64
+
+	q := r.db.Model(&model.AchievementDuplicateFlag{})
+	if status != nil {
+		q = q.Where("status = ?", *status)
+	}
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := q.
+		Preload("Reference.Student.User").
+		Order("score DESC, created_at ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&flags).Error
+	return flags, total, err
+}
+
+func (r *duplicateFlagRepository) FindOpenByReferenceIDs(refIDs []string) ([]model.AchievementDuplicateFlag, error) {
+	var flags []model.AchievementDuplicateFlag
+	if len(refIDs) == 0 {
+		return flags, nil
+	}
+	err := r.db.
+		Where("reference_id IN ? AND status = ?", refIDs, model.DuplicateFlagOpen).
+		Order("score DESC").
+		Find(&flags).Error
+	return flags, err
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+)
+
+type ImportJobRepository interface {
+	Create(job *model.ImportJob) error
+	Save(job *model.ImportJob) error
+	// SaveActive: Save hanya kalau job masih pending/running di database;
+	// false = job sudah diakhiri di tempat lain (mis. FailStale instance lain) dan tidak ditimpa
+	SaveActive(job *model.ImportJob) (bool, error)
+	FindByID(id string) (*model.ImportJob, error)
+	FindAll() ([]model.ImportJob, error)
+	// FailStale: job pending/running tanpa progress sejak updatedBefore ditandai failed
+	FailStale(updatedBefore time.Time, message string) (int64, error)
+}
+
+type importJobRepository struct {
+	db *gorm.DB
+}
+
+func NewImportJobRepository(db *gorm.DB) ImportJobRepository {
+	return &importJobRepository{db: db}
+}
+
+func (r *importJobRepository) Create(job *model.ImportJob) error {
+	return r.db.Create(job).Error
+}
+
+func (r *importJobRepository) Save(job *model.ImportJob) error {
+	return r.db.Save(job).Error
+}
+
+func (r *importJobRepository) SaveActive(job *model.ImportJob) (bool, error) {
+	res := r.db.Model(job).
+		Where("status IN ?", []model.ImportJobStatus{model.ImportJobPending, model.ImportJobRunning}).
+		Select("*").
+		Omit("id", "created_at").
+		Updates(job)
+	return res.RowsAffected == 1, res.Error
+}
+
+func (r *importJobRepository) FindByID(id string) (*model.ImportJob, error) {
+	var job model.ImportJob
+	if err := r.db.Where("id = ?", id).First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *importJobRepository) FindAll() ([]model.ImportJob, error) {
+	var jobs []model.ImportJob
+	// list tanpa detail error per baris (bisa ribuan)
+	err := r.db.Omit("errors").Order("created_at DESC").Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *importJobRepository) FailStale(updatedBefore time.Time, message string) (int64, error) {
+	errs, err := json.Marshal([]model.ImportRowError{{Message: message}})
+	if err != nil {
+		return 0, err
+	}
+	res := r.db.Model(&model.ImportJob{}).
+		Where("status IN ? AND updated_at < ?", []model.ImportJobStatus{model.ImportJobPending, model.ImportJobRunning}, updatedBefore).
+		Updates(map[string]interface{}{
+			"status":      model.ImportJobFailed,
+			"finished_at": time.Now(),
+			"errors":      gorm.Expr("COALESCE(errors, '[]'::jsonb) || ?::jsonb", string(errs)),
+		})
+	return res.RowsAffected, res.Error
+}
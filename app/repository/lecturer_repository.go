@@ -9,6 +9,7 @@ type LecturerRepository interface {
 	FindAll() ([]model.Lecturer, error)
 	FindByID(id string) (*model.Lecturer, error)
 	FindByUserID(userID string) (*model.Lecturer, error)
+	FindByNIDN(nidn string) (*model.Lecturer, error)
 }
 
 type lecturerRepository struct {
@@ -46,3 +47,15 @@ func (r *lecturerRepository) FindAll() ([]model.Lecturer, error) {
 	err := r.db.Preload("User").Find(&lecturers).Error
 	return lecturers, err
 }
+
+func (r *lecturerRepository) FindByNIDN(nidn string) (*model.Lecturer, error) {
+	var lect model.Lecturer
+	if err := r.db.
+		Preload("User").
+		Preload("User.Role").
+		Where("lecturer_id = ?", nidn).
+		First(&lect).Error; err != nil {
+		return nil, err
+	}
+	return &lect, nil
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+)
+
+// LoginAttemptStore: penyimpanan counter login gagal.
+// Memory cukup untuk satu instance; Postgres dipakai kalau API jalan di beberapa instance.
+type LoginAttemptStore interface {
+	// Get: nil, nil kalau key belum pernah gagal
+	Get(key string) (*model.LoginThrottle, error)
+	// RegisterFailure: tambah counter; window lama (lebih tua dari window) dimulai ulang dari 1
+	RegisterFailure(key string, now time.Time, window time.Duration) (*model.LoginThrottle, error)
+	Lock(key string, until time.Time) error
+	Reset(key string) error
+	FindLocked(now time.Time) ([]model.LoginThrottle, error)
+}
+
+// ===== memory =====
+
+type memoryLoginAttemptStore struct {
+	mu      sync.Mutex
+	entries map[string]*model.LoginThrottle
+}
+
+func NewMemoryLoginAttemptStore() LoginAttemptStore {
+	return &memoryLoginAttemptStore{entries: make(map[string]*model.LoginThrottle)}
+}
+
+func (s *memoryLoginAttemptStore) Get(key string) (*model.LoginThrottle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	cp := *t
+	return &cp, nil
+}
+
+func (s *memoryLoginAttemptStore) RegisterFailure(key string, now time.Time, window time.Duration) (*model.LoginThrottle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.entries[key]
+	switch {
+	case !ok:
+		t = &model.LoginThrottle{Key: key, WindowStart: now}
+		s.entries[key] = t
+	case t.WindowStart.Before(now.Add(-window)):
+		t.Failures = 0
+		t.WindowStart = now
+	}
+	t.Failures++
+	t.LastFailureAt = now
+
+	cp := *t
+	return &cp, nil
+}
+
+func (s *memoryLoginAttemptStore) Lock(key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.entries[key]
+	if !ok {
+		t = &model.LoginThrottle{Key: key, WindowStart: until, LastFailureAt: until}
+		s.entries[key] = t
+	}
+	t.LockedUntil = &until
+	return nil
+}
+
+func (s *memoryLoginAttemptStore) Reset(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+func (s *memoryLoginAttemptStore) FindLocked(now time.Time) ([]model.LoginThrottle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	locked := []model.LoginThrottle{}
+	for _, t := range s.entries {
+		if t.LockedUntil != nil && t.LockedUntil.After(now) {
+			locked = append(locked, *t)
+		}
+	}
+	sort.Slice(locked, func(i, j int) bool {
+		return locked[i].LockedUntil.After(*locked[j].LockedUntil)
+	})
+	return locked, nil
+}
+
+// ===== postgres =====
+
+type postgresLoginAttemptStore struct {
+	db *gorm.DB
+}
+
+func NewPostgresLoginAttemptStore(db *gorm.DB) LoginAttemptStore {
+	return &postgresLoginAttemptStore{db: db}
+}
+
+func (s *postgresLoginAttemptStore) Get(key string) (*model.LoginThrottle, error) {
+	var t model.LoginThrottle
+	err := s.db.First(&t, "throttle_key = ?", key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// RegisterFailure: upsert atomik supaya aman dipanggil dari beberapa instance sekaligus
+func (s *postgresLoginAttemptStore) RegisterFailure(key string, now time.Time, window time.Duration) (*model.LoginThrottle, error) {
+	var t model.LoginThrottle
+	err := s.db.Raw(`
+		INSERT INTO login_throttles (throttle_key, failures, window_start, last_failure_at)
+		VALUES (?, 1, ?, ?)
+		ON CONFLICT (throttle_key) DO UPDATE SET
+			failures = CASE WHEN login_throttles.window_start < ? THEN 1 ELSE login_throttles.failures + 1 END,
+			window_start = CASE WHEN login_throttles.window_start < ? THEN EXCLUDED.window_start ELSE login_throttles.window_start END,
+			last_failure_at = EXCLUDED.last_failure_at
+		RETURNING throttle_key, failures, window_start, last_failure_at, locked_until`,
+		key, now, now, now.Add(-window), now.Add(-window),
+	).Scan(&t).Error
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (s *postgresLoginAttemptStore) Lock(key string, until time.Time) error {
+	return s.db.Exec(`
+		INSERT INTO login_throttles (throttle_key, failures, window_start, last_failure_at, locked_until)
+		VALUES (?, 0, ?, ?, ?)
+		ON CONFLICT (throttle_key) DO UPDATE SET locked_until = EXCLUDED.locked_until`,
+		key, until, until, until,
+	).Error
+}
+
+func (s *postgresLoginAttemptStore) Reset(key string) error {
+	return s.db.Delete(&model.LoginThrottle{}, "throttle_key = ?", key).Error
+}
+
+func (s *postgresLoginAttemptStore) FindLocked(now time.Time) ([]model.LoginThrottle, error) {
+	var locked []model.LoginThrottle
+	err := s.db.
+		Where("locked_until > ?", now).
+		Order("locked_until DESC").
+		Find(&locked).Error
+	return locked, err
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+)
+
+type LoginAuditRepository interface {
+	Create(entry *model.LoginAudit) error
+	FindAll(filter model.LoginAuditFilter, offset, limit int) ([]model.LoginAudit, int64, error)
+}
+
+type loginAuditRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAuditRepository(db *gorm.DB) LoginAuditRepository {
+	return &loginAuditRepository{db: db}
+}
+
+func (r *loginAuditRepository) Create(entry *model.LoginAudit) error {
+	return r.db.Create(entry).Error
+}
+
+// FindAll: terbaru di depan
+func (r *loginAuditRepository) FindAll(filter model.LoginAuditFilter, offset, limit int) ([]model.LoginAudit, int64, error) {
+	var entries []model.LoginAudit
+	var total int64
+
+	q := r.db.Model(&model.LoginAudit{})
+	if filter.Username != "" {
+		q = q.Where("LOWER(username) = LOWER(?)", filter.Username)
+	}
+	if filter.Success != nil {
+		q = q.Where("success = ?", *filter.Success)
+	}
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := q.
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&entries).Error
+	return entries, total, err
+}
@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+type academicPeriodRepository struct {
+	s *Store
+}
+
+func NewAcademicPeriodRepository(s *Store) repository.AcademicPeriodRepository {
+	return &academicPeriodRepository{s: s}
+}
+
+// dateOnly: kolom DATE, jam dibuang
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (r *academicPeriodRepository) Create(p *model.AcademicPeriod) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if p.ID == "" {
+		p.ID = newID()
+	}
+	stampCreate(&p.CreatedAt, &p.UpdatedAt)
+	return r.save(p)
+}
+
+func (r *academicPeriodRepository) Save(p *model.AcademicPeriod) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	p.UpdatedAt = time.Now()
+	return r.save(p)
+}
+
+// save: UNIQUE (academic_year, semester) + hanya satu periode aktif
+func (r *academicPeriodRepository) save(p *model.AcademicPeriod) error {
+	for _, e := range r.s.periods {
+		if e.ID == p.ID {
+			continue
+		}
+		if e.AcademicYear == p.AcademicYear && e.Semester == p.Semester {
+			return errDuplicate("academic_periods_academic_year_semester_key")
+		}
+		if e.IsActive && p.IsActive {
+			return errDuplicate("idx_academic_periods_active")
+		}
+	}
+	row := *p
+	r.s.periods = upsert(r.s.periods, &row, func(e *model.AcademicPeriod) bool { return e.ID == p.ID })
+	return nil
+}
+
+// Delete: period_id prestasi di-NULL-kan (ON DELETE SET NULL)
+func (r *academicPeriodRepository) Delete(id string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.periods, _ = remove(r.s.periods, func(p *model.AcademicPeriod) bool { return p.ID == id })
+	for _, ref := range r.s.references {
+		if ref.PeriodID != nil && *ref.PeriodID == id {
+			ref.PeriodID = nil
+		}
+	}
+	return nil
+}
+
+func (r *academicPeriodRepository) one(match func(*model.AcademicPeriod) bool) (*model.AcademicPeriod, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	p, ok := first(r.s.periods, match)
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	out := *p
+	return &out, nil
+}
+
+func (r *academicPeriodRepository) FindByID(id string) (*model.AcademicPeriod, error) {
+	return r.one(func(p *model.AcademicPeriod) bool { return p.ID == id })
+}
+
+func (r *academicPeriodRepository) FindAll() ([]model.AcademicPeriod, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	periods := []model.AcademicPeriod{}
+	for _, p := range r.s.periods {
+		periods = append(periods, *p)
+	}
+	sortStable(periods, func(a, b *model.AcademicPeriod) bool { return a.StartDate.After(b.StartDate) })
+	return periods, nil
+}
+
+func (r *academicPeriodRepository) FindActive() (*model.AcademicPeriod, error) {
+	return r.one(func(p *model.AcademicPeriod) bool { return p.IsActive })
+}
+
+func (r *academicPeriodRepository) FindByDate(t time.Time) (*model.AcademicPeriod, error) {
+	return r.one(func(p *model.AcademicPeriod) bool { return p.Contains(t) })
+}
+
+func (r *academicPeriodRepository) FindOverlapping(start, end time.Time, excludeID string) ([]model.AcademicPeriod, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	start, end = dateOnly(start), dateOnly(end)
+	periods := []model.AcademicPeriod{}
+	for _, p := range r.s.periods {
+		if p.ID == excludeID {
+			continue
+		}
+		if !dateOnly(p.StartDate).After(end) && !dateOnly(p.EndDate).Before(start) {
+			periods = append(periods, *p)
+		}
+	}
+	return periods, nil
+}
+
+func (r *academicPeriodRepository) SetActive(id string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	target, ok := first(r.s.periods, func(p *model.AcademicPeriod) bool { return p.ID == id })
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	now := time.Now()
+	for _, p := range r.s.periods {
+		if p.IsActive {
+			p.IsActive, p.UpdatedAt = false, now
+		}
+	}
+	target.IsActive, target.UpdatedAt = true, now
+	return nil
+}
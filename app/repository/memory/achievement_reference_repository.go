@@ -0,0 +1,585 @@
+package memory
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+type achievementReferenceRepository struct {
+	s *Store
+}
+
+func NewAchievementReferenceRepository(s *Store) repository.AchievementReferenceRepository {
+	return &achievementReferenceRepository{s: s}
+}
+
+// refs: reference yang cocok, tanpa relasi
+func (r *achievementReferenceRepository) refs(match func(*model.AchievementReference) bool) []model.AchievementReference {
+	var out []model.AchievementReference
+	for _, ref := range filter(r.s.references, match) {
+		out = append(out, *ref)
+	}
+	return out
+}
+
+// loaded: reference yang cocok + Student + Period
+func (r *achievementReferenceRepository) loaded(match func(*model.AchievementReference) bool) []model.AchievementReference {
+	var out []model.AchievementReference
+	for _, ref := range filter(r.s.references, match) {
+		out = append(out, r.s.loadReference(ref))
+	}
+	return out
+}
+
+func (r *achievementReferenceRepository) CreateDraft(studentID string, mongoAchievementID string, periodID *string) (*model.AchievementReference, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := first(r.s.students, func(st *model.Student) bool { return st.ID == studentID }); !ok {
+		return nil, errForeignKey("achievement_references_student_id_fkey")
+	}
+	ref := &model.AchievementReference{
+		ID:                 newID(),
+		StudentID:          studentID,
+		MongoAchievementID: mongoAchievementID,
+		PeriodID:           cloneString(periodID),
+		Status:             model.AchievementStatusDraft,
+	}
+	stampCreate(&ref.CreatedAt, &ref.UpdatedAt)
+	row := *ref
+	r.s.references = append(r.s.references, &row)
+	return ref, nil
+}
+
+func (r *achievementReferenceRepository) GetByID(id string) (*model.AchievementReference, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	ref, ok := first(r.s.references, func(ref *model.AchievementReference) bool { return ref.ID == id })
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	out := *ref
+	return &out, nil
+}
+
+func (r *achievementReferenceRepository) Save(ref *model.AchievementReference) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if ref.ID == "" {
+		ref.ID = newID()
+	}
+	stampCreate(&ref.CreatedAt, nil)
+	ref.UpdatedAt = time.Now()
+	row := *ref
+	row.Student, row.Period = model.Student{}, nil
+	r.s.references = upsert(r.s.references, &row, func(e *model.AchievementReference) bool { return e.ID == ref.ID })
+	return nil
+}
+
+func statusMatches(ref *model.AchievementReference, status *model.AchievementStatus) bool {
+	return status == nil || ref.Status == *status
+}
+
+func (r *achievementReferenceRepository) CountByStudentIDs(studentIDs []string, status *model.AchievementStatus) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return int64(len(filter(r.s.references, func(ref *model.AchievementReference) bool {
+		return contains(studentIDs, ref.StudentID) && statusMatches(ref, status)
+	}))), nil
+}
+
+// FindByStudentIDs: submitted_at DESC NULLS LAST, created_at DESC
+func (r *achievementReferenceRepository) FindByStudentIDs(studentIDs []string, status *model.AchievementStatus, limit, offset int) ([]model.AchievementReference, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	refs := r.refs(func(ref *model.AchievementReference) bool {
+		return contains(studentIDs, ref.StudentID) && statusMatches(ref, status)
+	})
+	sortStable(refs, func(a, b *model.AchievementReference) bool {
+		switch {
+		case a.SubmittedAt == nil && b.SubmittedAt == nil:
+			return a.CreatedAt.After(b.CreatedAt)
+		case a.SubmittedAt == nil || b.SubmittedAt == nil:
+			return b.SubmittedAt == nil
+		case !a.SubmittedAt.Equal(*b.SubmittedAt):
+			return a.SubmittedAt.After(*b.SubmittedAt)
+		}
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+	return paginate(refs, offset, limit), nil
+}
+
+func (r *achievementReferenceRepository) FindAll(offset, limit int, f repository.AchievementReferenceFilter) ([]model.AchievementReference, int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	refs := r.loaded(func(ref *model.AchievementReference) bool {
+		if f.Status != nil && string(ref.Status) != *f.Status {
+			return false
+		}
+		if f.PeriodID != nil && (ref.PeriodID == nil || *ref.PeriodID != *f.PeriodID) {
+			return false
+		}
+		return r.s.inScope(ref.StudentID, f.Scope)
+	})
+	sortStable(refs, func(a, b *model.AchievementReference) bool { return a.CreatedAt.After(b.CreatedAt) })
+	return paginate(refs, offset, limit), int64(len(refs)), nil
+}
+
+func (r *achievementReferenceRepository) CountByStatus() (map[string]int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	result := make(map[string]int64)
+	for _, ref := range r.s.references {
+		result[string(ref.Status)]++
+	}
+	return result, nil
+}
+
+func (r *achievementReferenceRepository) FindByStudentID(studentID string) ([]model.AchievementReference, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.refs(func(ref *model.AchievementReference) bool { return ref.StudentID == studentID }), nil
+}
+
+func (r *achievementReferenceRepository) verified(match func(*model.AchievementReference) bool) []model.AchievementReference {
+	refs := r.loaded(func(ref *model.AchievementReference) bool {
+		return ref.Status == model.AchievementStatusVerified && match(ref)
+	})
+	sortStable(refs, func(a, b *model.AchievementReference) bool { return timeBefore(a.VerifiedAt, b.VerifiedAt) })
+	return refs
+}
+
+// timeBefore: urutan ASC dengan NULL di akhir (default Postgres)
+func timeBefore(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a != nil && b == nil
+	}
+	return a.Before(*b)
+}
+
+func (r *achievementReferenceRepository) FindVerifiedBetween(from, to time.Time) ([]model.AchievementReference, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.verified(func(ref *model.AchievementReference) bool {
+		return ref.VerifiedAt != nil && !ref.VerifiedAt.Before(from) && ref.VerifiedAt.Before(to)
+	}), nil
+}
+
+func (r *achievementReferenceRepository) FindVerifiedByPeriod(periodID string) ([]model.AchievementReference, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.verified(func(ref *model.AchievementReference) bool {
+		return ref.PeriodID != nil && *ref.PeriodID == periodID
+	}), nil
+}
+
+func (r *achievementReferenceRepository) FindPendingWithStudent() ([]model.AchievementReference, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	refs := r.loaded(func(ref *model.AchievementReference) bool { return ref.Status == model.AchievementStatusSubmitted })
+	sortStable(refs, func(a, b *model.AchievementReference) bool { return timeBefore(a.SubmittedAt, b.SubmittedAt) })
+	return refs, nil
+}
+
+func (r *achievementReferenceRepository) CountReviewedBy(userIDs []string, since time.Time) (map[string]map[string]int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	result := make(map[string]map[string]int64)
+	for _, ref := range r.s.references {
+		if ref.VerifiedBy == nil || !contains(userIDs, *ref.VerifiedBy) {
+			continue
+		}
+		if ref.Status != model.AchievementStatusVerified && ref.Status != model.AchievementStatusRejected {
+			continue
+		}
+		if ref.VerifiedAt == nil || ref.VerifiedAt.Before(since) {
+			continue
+		}
+		if result[*ref.VerifiedBy] == nil {
+			result[*ref.VerifiedBy] = map[string]int64{}
+		}
+		result[*ref.VerifiedBy][string(ref.Status)]++
+	}
+	return result, nil
+}
+
+func (r *achievementReferenceRepository) ClaimReminders(ids []string, at, remindedBefore time.Time) ([]string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	claimed := []string{}
+	for _, ref := range r.s.references {
+		if !contains(ids, ref.ID) || ref.Status != model.AchievementStatusSubmitted {
+			continue
+		}
+		if ref.LastRemindedAt != nil && !ref.LastRemindedAt.Before(remindedBefore) {
+			continue
+		}
+		ref.LastRemindedAt = cloneTime(&at)
+		claimed = append(claimed, ref.ID)
+	}
+	return claimed, nil
+}
+
+func (r *achievementReferenceRepository) ReleaseReminders(ids []string, at time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, ref := range r.s.references {
+		if contains(ids, ref.ID) && ref.LastRemindedAt != nil && ref.LastRemindedAt.Equal(at) {
+			ref.LastRemindedAt = nil
+		}
+	}
+	return nil
+}
+
+func (r *achievementReferenceRepository) FindByMongoID(mongoID string) ([]model.AchievementReference, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	refs := r.refs(func(ref *model.AchievementReference) bool { return ref.MongoAchievementID == mongoID })
+	sortStable(refs, func(a, b *model.AchievementReference) bool { return a.CreatedAt.Before(b.CreatedAt) })
+	return refs, nil
+}
+
+// PurgeByMongoID: log status + flag duplikat reference ikut terhapus (ON DELETE CASCADE), anggota tim dihapus
+func (r *achievementReferenceRepository) PurgeByMongoID(mongoID string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var refIDs []string
+	for _, ref := range r.s.references {
+		if ref.MongoAchievementID == mongoID {
+			refIDs = append(refIDs, ref.ID)
+		}
+	}
+
+	r.s.statusLogs, _ = remove(r.s.statusLogs, func(l *model.AchievementStatusLog) bool {
+		return contains(refIDs, l.AchievementReferenceID)
+	})
+	r.s.teamMembers, _ = remove(r.s.teamMembers, func(m *model.AchievementTeamMember) bool {
+		return m.MongoAchievementID == mongoID
+	})
+	r.s.flags, _ = remove(r.s.flags, func(f *model.AchievementDuplicateFlag) bool {
+		return f.MatchedMongoAchievementID == mongoID || contains(refIDs, f.ReferenceID)
+	})
+	r.s.references, _ = remove(r.s.references, func(ref *model.AchievementReference) bool {
+		return ref.MongoAchievementID == mongoID
+	})
+	return nil
+}
+
+// ===== achievement_status_logs =====
+
+type achievementStatusLogRepository struct {
+	s *Store
+}
+
+func NewAchievementStatusLogRepository(s *Store) repository.AchievementStatusLogRepository {
+	return &achievementStatusLogRepository{s: s}
+}
+
+func (r *achievementStatusLogRepository) Create(log *model.AchievementStatusLog) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if log.ID == "" {
+		log.ID = newID()
+	}
+	stampCreate(&log.CreatedAt, nil)
+	row := *log
+	r.s.statusLogs = append(r.s.statusLogs, &row)
+	return nil
+}
+
+func (r *achievementStatusLogRepository) FindByReferenceID(refID string) ([]model.AchievementStatusLog, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var logs []model.AchievementStatusLog
+	for _, l := range r.s.statusLogs {
+		if l.AchievementReferenceID == refID {
+			logs = append(logs, *l)
+		}
+	}
+	sortStable(logs, func(a, b *model.AchievementStatusLog) bool { return a.CreatedAt.Before(b.CreatedAt) })
+	return logs, nil
+}
+
+// AverageTurnaround: tiap log verified di from..to dipasangkan dengan log submitted terakhir sebelumnya
+func (r *achievementStatusLogRepository) AverageTurnaround(from, to time.Time, scope model.UnitScope) (float64, int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var sum float64
+	var total int64
+	for _, v := range r.s.statusLogs {
+		if v.NewStatus != string(model.AchievementStatusVerified) || v.CreatedAt.Before(from) || !v.CreatedAt.Before(to) {
+			continue
+		}
+		ref, ok := first(r.s.references, func(ref *model.AchievementReference) bool { return ref.ID == v.AchievementReferenceID })
+		if !ok || !r.s.inScope(ref.StudentID, scope) {
+			continue
+		}
+		var submittedAt *time.Time
+		for _, l := range r.s.statusLogs {
+			if l.AchievementReferenceID != v.AchievementReferenceID || l.NewStatus != string(model.AchievementStatusSubmitted) || l.CreatedAt.After(v.CreatedAt) {
+				continue
+			}
+			if submittedAt == nil || l.CreatedAt.After(*submittedAt) {
+				submittedAt = cloneTime(&l.CreatedAt)
+			}
+		}
+		if submittedAt == nil {
+			continue
+		}
+		sum += v.CreatedAt.Sub(*submittedAt).Seconds()
+		total++
+	}
+
+	if total == 0 {
+		return 0, 0, nil
+	}
+	return sum / float64(total), total, nil
+}
+
+// ===== achievement_team_members =====
+
+type achievementTeamRepository struct {
+	s *Store
+}
+
+func NewAchievementTeamRepository(s *Store) repository.AchievementTeamRepository {
+	return &achievementTeamRepository{s: s}
+}
+
+func (r *achievementTeamRepository) load(m *model.AchievementTeamMember) model.AchievementTeamMember {
+	out := *m
+	if st, ok := r.s.studentByID(m.StudentID); ok {
+		out.Student = &st
+	}
+	return out
+}
+
+// CreateMembers: satu insert; UNIQUE (mongo_achievement_id, student_id) → tidak ada yang tersimpan
+func (r *achievementTeamRepository) CreateMembers(members []model.AchievementTeamMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for i, m := range members {
+		dup := func(e *model.AchievementTeamMember) bool {
+			return e.MongoAchievementID == m.MongoAchievementID && e.StudentID == m.StudentID
+		}
+		if _, ok := first(r.s.teamMembers, dup); ok {
+			return errDuplicate("achievement_team_members_mongo_achievement_id_student_id_key")
+		}
+		for _, prev := range members[:i] {
+			if dup(&prev) {
+				return errDuplicate("achievement_team_members_mongo_achievement_id_student_id_key")
+			}
+		}
+	}
+
+	for i := range members {
+		m := &members[i]
+		if m.ID == "" {
+			m.ID = newID()
+		}
+		stampCreate(&m.CreatedAt, &m.UpdatedAt)
+		row := *m
+		row.Student = nil
+		r.s.teamMembers = append(r.s.teamMembers, &row)
+	}
+	return nil
+}
+
+func (r *achievementTeamRepository) Save(member *model.AchievementTeamMember) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if member.ID == "" {
+		member.ID = newID()
+	}
+	stampCreate(&member.CreatedAt, nil)
+	member.UpdatedAt = time.Now()
+	row := *member
+	row.Student = nil
+	r.s.teamMembers = upsert(r.s.teamMembers, &row, func(e *model.AchievementTeamMember) bool { return e.ID == member.ID })
+	return nil
+}
+
+func (r *achievementTeamRepository) FindByID(id string) (*model.AchievementTeamMember, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	m, ok := first(r.s.teamMembers, func(m *model.AchievementTeamMember) bool { return m.ID == id })
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	out := r.load(m)
+	return &out, nil
+}
+
+// FindByMongoID: ketua di depan, sisanya urut dibuat
+func (r *achievementTeamRepository) FindByMongoID(mongoID string) ([]model.AchievementTeamMember, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var members []model.AchievementTeamMember
+	for _, m := range r.s.teamMembers {
+		if m.MongoAchievementID == mongoID {
+			members = append(members, r.load(m))
+		}
+	}
+	sortStable(members, func(a, b *model.AchievementTeamMember) bool {
+		aLeader, bLeader := a.Role == model.TeamRoleLeader, b.Role == model.TeamRoleLeader
+		if aLeader != bLeader {
+			return aLeader
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+	return members, nil
+}
+
+func (r *achievementTeamRepository) FindPendingByStudentID(studentID string) ([]model.AchievementTeamMember, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var members []model.AchievementTeamMember
+	for _, m := range r.s.teamMembers {
+		if m.StudentID == studentID && m.Status == model.TeamInvitationPending {
+			members = append(members, *m)
+		}
+	}
+	sortStable(members, func(a, b *model.AchievementTeamMember) bool { return a.CreatedAt.After(b.CreatedAt) })
+	return members, nil
+}
+
+// ===== achievement_duplicate_flags =====
+
+type duplicateFlagRepository struct {
+	s *Store
+}
+
+func NewDuplicateFlagRepository(s *Store) repository.DuplicateFlagRepository {
+	return &duplicateFlagRepository{s: s}
+}
+
+func (r *duplicateFlagRepository) load(f *model.AchievementDuplicateFlag) model.AchievementDuplicateFlag {
+	out := *f
+	out.Reasons = append([]string(nil), f.Reasons...)
+	if ref, ok := first(r.s.references, func(ref *model.AchievementReference) bool { return ref.ID == f.ReferenceID }); ok {
+		loaded := r.s.loadReference(ref)
+		loaded.Period = nil
+		out.Reference = &loaded
+	}
+	return out
+}
+
+// Upsert: konflik (reference_id, matched_mongo_achievement_id) hanya memperbarui reasons + score
+func (r *duplicateFlagRepository) Upsert(flag *model.AchievementDuplicateFlag) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if existing, ok := first(r.s.flags, func(f *model.AchievementDuplicateFlag) bool {
+		return f.ReferenceID == flag.ReferenceID && f.MatchedMongoAchievementID == flag.MatchedMongoAchievementID
+	}); ok {
+		existing.Reasons = append([]string(nil), flag.Reasons...)
+		existing.Score = flag.Score
+		existing.UpdatedAt = time.Now()
+		flag.ID = existing.ID
+		return nil
+	}
+
+	if flag.ID == "" {
+		flag.ID = newID()
+	}
+	stampCreate(&flag.CreatedAt, &flag.UpdatedAt)
+	row := *flag
+	row.Reference = nil
+	row.Reasons = append([]string(nil), flag.Reasons...)
+	r.s.flags = append(r.s.flags, &row)
+	return nil
+}
+
+func (r *duplicateFlagRepository) Save(flag *model.AchievementDuplicateFlag) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if flag.ID == "" {
+		flag.ID = newID()
+	}
+	stampCreate(&flag.CreatedAt, nil)
+	flag.UpdatedAt = time.Now()
+	row := *flag
+	row.Reference = nil
+	row.Reasons = append([]string(nil), flag.Reasons...)
+	r.s.flags = upsert(r.s.flags, &row, func(f *model.AchievementDuplicateFlag) bool { return f.ID == flag.ID })
+	return nil
+}
+
+func (r *duplicateFlagRepository) FindByID(id string) (*model.AchievementDuplicateFlag, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	f, ok := first(r.s.flags, func(f *model.AchievementDuplicateFlag) bool { return f.ID == id })
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	out := r.load(f)
+	return &out, nil
+}
+
+func (r *duplicateFlagRepository) FindAll(status *model.DuplicateFlagStatus, offset, limit int) ([]model.AchievementDuplicateFlag, int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	flags := []model.AchievementDuplicateFlag{}
+	for _, f := range r.s.flags {
+		if status == nil || f.Status == *status {
+			flags = append(flags, r.load(f))
+		}
+	}
+	sortStable(flags, func(a, b *model.AchievementDuplicateFlag) bool {
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+	return paginate(flags, offset, limit), int64(len(flags)), nil
+}
+
+func (r *duplicateFlagRepository) FindOpenByReferenceIDs(refIDs []string) ([]model.AchievementDuplicateFlag, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var flags []model.AchievementDuplicateFlag
+	for _, f := range r.s.flags {
+		if contains(refIDs, f.ReferenceID) && f.Status == model.DuplicateFlagOpen {
+			out := *f
+			out.Reasons = append([]string(nil), f.Reasons...)
+			flags = append(flags, out)
+		}
+	}
+	sortStable(flags, func(a, b *model.AchievementDuplicateFlag) bool { return a.Score > b.Score })
+	return flags, nil
+}
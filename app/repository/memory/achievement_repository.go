@@ -0,0 +1,449 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+// achievementDoc: dokumen disimpan sebagai BSON supaya hasil baca sama dengan Mongo
+// (details.eventDate jadi primitive.DateTime, lampiran jadi primitive.D, presisi waktu milidetik)
+type achievementDoc struct {
+	id        primitive.ObjectID
+	raw       bson.Raw
+	isDeleted bool
+}
+
+func (d *achievementDoc) decode() model.Achievement {
+	var ac model.Achievement
+	// raw selalu hasil bson.Marshal model.Achievement, decode tidak mungkin gagal
+	_ = bson.Unmarshal(d.raw, &ac)
+	return ac
+}
+
+type achievementRepository struct {
+	s *Store
+}
+
+func NewAchievementRepository(s *Store) repository.AchievementRepository {
+	return &achievementRepository{s: s}
+}
+
+func (r *achievementRepository) doc(id primitive.ObjectID) (*achievementDoc, bool) {
+	return first(r.s.achievements, func(d *achievementDoc) bool { return d.id == id })
+}
+
+// update: padanan UpdateByID; dokumen tidak ada = no-op tanpa error
+func (r *achievementRepository) update(mongoID string, apply func(set bson.M)) error {
+	objID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	d, ok := r.doc(objID)
+	if !ok {
+		return nil
+	}
+	var current bson.M
+	if err := bson.Unmarshal(d.raw, &current); err != nil {
+		return err
+	}
+	apply(current)
+	raw, err := bson.Marshal(current)
+	if err != nil {
+		return err
+	}
+	d.raw = raw
+	return nil
+}
+
+// docsWhere: dokumen yang cocok, urutan insert (natural order)
+func (r *achievementRepository) docsWhere(match func(d *achievementDoc, ac *model.Achievement) bool) []model.Achievement {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var out []model.Achievement
+	for _, d := range r.s.achievements {
+		ac := d.decode()
+		if match(d, &ac) {
+			out = append(out, ac)
+		}
+	}
+	return out
+}
+
+// ownedOrTeam: padanan filter $or studentId / team.studentId
+func ownedOrTeam(ac *model.Achievement, studentID string) bool {
+	if ac.StudentID == studentID {
+		return true
+	}
+	for _, m := range ac.Team {
+		if m.StudentID == studentID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *achievementRepository) Create(ctx context.Context, ac *model.Achievement) (*model.Achievement, error) {
+	ac.CreatedAt = time.Now()
+	ac.UpdatedAt = time.Now()
+	if ac.ID.IsZero() {
+		ac.ID = primitive.NewObjectID()
+	}
+
+	raw, err := bson.Marshal(ac)
+	if err != nil {
+		return nil, err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, dup := r.doc(ac.ID); dup {
+		return nil, mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "duplicate key error collection: achievements index: _id_"}}}
+	}
+	r.s.achievements = append(r.s.achievements, &achievementDoc{id: ac.ID, raw: raw})
+	return ac, nil
+}
+
+func (r *achievementRepository) FindByStudentID(ctx context.Context, studentID string) ([]model.Achievement, error) {
+	return r.docsWhere(func(d *achievementDoc, ac *model.Achievement) bool {
+		return !d.isDeleted && ownedOrTeam(ac, studentID)
+	}), nil
+}
+
+func (r *achievementRepository) SetTeam(ctx context.Context, mongoID string, team []model.TeamMember) error {
+	return r.update(mongoID, func(set bson.M) {
+		set["team"] = team
+		set["updatedAt"] = time.Now()
+	})
+}
+
+func (r *achievementRepository) SoftDelete(ctx context.Context, mongoID string) error {
+	objID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+	if err := r.update(mongoID, func(set bson.M) { set["deletedAt"] = time.Now() }); err != nil {
+		return err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if d, ok := r.doc(objID); ok {
+		d.isDeleted = true
+	}
+	return nil
+}
+
+func (r *achievementRepository) Restore(ctx context.Context, mongoID string) error {
+	objID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+	if err := r.update(mongoID, func(set bson.M) {
+		delete(set, "deletedAt")
+		set["updatedAt"] = time.Now()
+	}); err != nil {
+		return err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if d, ok := r.doc(objID); ok {
+		d.isDeleted = false
+	}
+	return nil
+}
+
+func (r *achievementRepository) FindDeletedBefore(ctx context.Context, before time.Time, limit int) ([]model.Achievement, error) {
+	out := r.docsWhere(func(d *achievementDoc, ac *model.Achievement) bool {
+		return d.isDeleted && ac.DeletedAt != nil && ac.DeletedAt.Before(before)
+	})
+	sortStable(out, func(a, b *model.Achievement) bool { return a.DeletedAt.Before(*b.DeletedAt) })
+	return mongoLimit(out, limit), nil
+}
+
+// mongoLimit: SetLimit(0) = tanpa batas
+func mongoLimit(docs []model.Achievement, limit int) []model.Achievement {
+	if limit > 0 && limit < len(docs) {
+		return docs[:limit]
+	}
+	return docs
+}
+
+func (r *achievementRepository) HardDelete(ctx context.Context, mongoID string) error {
+	objID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.achievements, _ = remove(r.s.achievements, func(d *achievementDoc) bool { return d.id == objID && d.isDeleted })
+	return nil
+}
+
+func (r *achievementRepository) FindDeletedByStudentID(ctx context.Context, studentID string) ([]model.Achievement, error) {
+	return r.docsWhere(func(d *achievementDoc, ac *model.Achievement) bool {
+		return d.isDeleted && ownedOrTeam(ac, studentID)
+	}), nil
+}
+
+func (r *achievementRepository) FindByIDs(ctx context.Context, ids []string) ([]model.Achievement, error) {
+	if len(ids) == 0 {
+		return []model.Achievement{}, nil
+	}
+	objIDs := toObjectIDs(ids)
+	return r.docsWhere(func(d *achievementDoc, _ *model.Achievement) bool {
+		return !d.isDeleted && contains(objIDs, d.id)
+	}), nil
+}
+
+func toObjectIDs(ids []string) []primitive.ObjectID {
+	objIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, h := range ids {
+		if oid, err := primitive.ObjectIDFromHex(h); err == nil {
+			objIDs = append(objIDs, oid)
+		}
+	}
+	return objIDs
+}
+
+func (r *achievementRepository) AddAttachment(ctx context.Context, mongoID string, att model.Attachment) error {
+	return r.update(mongoID, func(set bson.M) {
+		atts, _ := set["attachments"].(bson.A)
+		set["attachments"] = append(atts, att)
+		set["updatedAt"] = time.Now()
+	})
+}
+
+func (r *achievementRepository) FindByID(ctx context.Context, id string) (*model.Achievement, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	d, ok := r.doc(objID)
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	ac := d.decode()
+	return &ac, nil
+}
+
+// CountByType: termasuk dokumen soft delete (sama dengan aggregate tanpa $match)
+func (r *achievementRepository) CountByType(ctx context.Context) (map[string]int64, error) {
+	result := make(map[string]int64)
+	for _, ac := range r.docsWhere(func(*achievementDoc, *model.Achievement) bool { return true }) {
+		result[ac.AchievementType]++
+	}
+	return result, nil
+}
+
+// Update: $set seluruh field payload; field omitempty yang kosong (team, pointSplit, deletedAt) tidak disentuh
+func (r *achievementRepository) Update(ctx context.Context, id string, payload *model.Achievement) (*model.Achievement, error) {
+	raw, err := bson.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	var set bson.M
+	if err := bson.Unmarshal(raw, &set); err != nil {
+		return nil, err
+	}
+	delete(set, "_id")
+
+	if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+		if err := r.update(objID.Hex(), func(current bson.M) {
+			for k, v := range set {
+				current[k] = v
+			}
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return r.FindByID(ctx, id)
+}
+
+func (r *achievementRepository) FindAll(ctx context.Context) ([]model.Achievement, error) {
+	return r.docsWhere(func(d *achievementDoc, _ *model.Achievement) bool { return !d.isDeleted }), nil
+}
+
+// GroupByField: field boleh path bertitik ("details.competitionLevel"); field tidak ada = "unknown"
+func (r *achievementRepository) GroupByField(ctx context.Context, ids []string, field string) ([]model.AnalyticsBucket, error) {
+	objIDs := toObjectIDs(ids)
+	path := strings.Split(field, ".")
+
+	r.s.mu.Lock()
+	buckets := map[string]*model.AnalyticsBucket{}
+	var order []string
+	for _, d := range r.s.achievements {
+		if !contains(objIDs, d.id) {
+			continue
+		}
+		key := "unknown"
+		if v, err := d.raw.LookupErr(path...); err == nil && v.Type != bson.TypeNull {
+			var val any
+			if err := v.Unmarshal(&val); err == nil && val != nil {
+				key = fmt.Sprint(val)
+			}
+		}
+		b, ok := buckets[key]
+		if !ok {
+			b = &model.AnalyticsBucket{Key: key}
+			buckets[key] = b
+			order = append(order, key)
+		}
+		b.Count++
+		b.Points += d.decode().Points
+	}
+	r.s.mu.Unlock()
+
+	var result []model.AnalyticsBucket
+	for _, key := range order {
+		result = append(result, *buckets[key])
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Points > result[j].Points })
+	return result, nil
+}
+
+func (r *achievementRepository) PointsByIDs(ctx context.Context, ids []string) (map[string]float64, error) {
+	objIDs := toObjectIDs(ids)
+	result := make(map[string]float64)
+	for _, ac := range r.docsWhere(func(d *achievementDoc, _ *model.Achievement) bool { return contains(objIDs, d.id) }) {
+		result[ac.ID.Hex()] = ac.Points
+	}
+	return result, nil
+}
+
+// FindDuplicateCandidates: kriteria sama dengan query $or di implementasi Mongo
+func (r *achievementRepository) FindDuplicateCandidates(ctx context.Context, ac *model.Achievement, limit int) ([]model.Achievement, error) {
+	date, hasDate := ac.EventDate()
+	cert := ac.DetailString("certificationNumber")
+	hashes := ac.AttachmentHashes()
+
+	out := r.docsWhere(func(d *achievementDoc, other *model.Achievement) bool {
+		if d.id == ac.ID || d.isDeleted {
+			return false
+		}
+		if other.StudentID == ac.StudentID {
+			return true
+		}
+		if otherDate, ok := other.EventDate(); hasDate && ok && otherDate.Equal(date.Truncate(time.Millisecond)) {
+			return true
+		}
+		if cert != "" {
+			if v, ok := other.Details["certificationNumber"].(string); ok && v == cert {
+				return true
+			}
+		}
+		for _, h := range other.AttachmentHashes() {
+			if contains(hashes, h) {
+				return true
+			}
+		}
+		return false
+	})
+	sortStable(out, func(a, b *model.Achievement) bool { return a.CreatedAt.After(b.CreatedAt) })
+	return mongoLimit(out, limit), nil
+}
+
+// ===== achievement_versions =====
+
+type versionDoc struct {
+	achievementID string
+	version       int
+	raw           bson.Raw
+}
+
+func (d *versionDoc) decode() model.AchievementVersion {
+	var v model.AchievementVersion
+	_ = bson.Unmarshal(d.raw, &v)
+	return v
+}
+
+type achievementVersionRepository struct {
+	s *Store
+}
+
+func NewAchievementVersionRepository(s *Store) repository.AchievementVersionRepository {
+	return &achievementVersionRepository{s: s}
+}
+
+// Create: sama dengan InsertOne — _id dibuat untuk dokumen tersimpan, v.ID tidak diubah
+func (r *achievementVersionRepository) Create(ctx context.Context, v *model.AchievementVersion) error {
+	stored := *v
+	if stored.ID.IsZero() {
+		stored.ID = primitive.NewObjectID()
+	}
+	raw, err := bson.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.versions = append(r.s.versions, &versionDoc{achievementID: v.AchievementID, version: v.Version, raw: raw})
+	return nil
+}
+
+func (r *achievementVersionRepository) byAchievement(achievementID string) []model.AchievementVersion {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var out []model.AchievementVersion
+	for _, d := range r.s.versions {
+		if d.achievementID == achievementID {
+			out = append(out, d.decode())
+		}
+	}
+	sortStable(out, func(a, b *model.AchievementVersion) bool { return a.Version < b.Version })
+	return out
+}
+
+func (r *achievementVersionRepository) FindByAchievementID(ctx context.Context, achievementID string) ([]model.AchievementVersion, error) {
+	return r.byAchievement(achievementID), nil
+}
+
+func (r *achievementVersionRepository) FindOne(ctx context.Context, achievementID string, version int) (*model.AchievementVersion, error) {
+	for _, v := range r.byAchievement(achievementID) {
+		if v.Version == version {
+			return &v, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (r *achievementVersionRepository) Latest(ctx context.Context, achievementID string) (*model.AchievementVersion, error) {
+	versions := r.byAchievement(achievementID)
+	if len(versions) == 0 {
+		return nil, nil
+	}
+	return &versions[len(versions)-1], nil
+}
+
+func (r *achievementVersionRepository) DeleteByAchievementID(ctx context.Context, achievementID string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.versions, _ = remove(r.s.versions, func(d *versionDoc) bool { return d.achievementID == achievementID })
+	return nil
+}
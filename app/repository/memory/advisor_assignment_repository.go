@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+type advisorAssignmentRepository struct {
+	s *Store
+}
+
+func NewAdvisorAssignmentRepository(s *Store) repository.AdvisorAssignmentRepository {
+	return &advisorAssignmentRepository{s: s}
+}
+
+// Reassign: semua atau tidak sama sekali — mahasiswa yang tidak ada membatalkan seluruh batch
+func (r *advisorAssignmentRepository) Reassign(assignments []model.AdvisorAssignment) error {
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, a := range assignments {
+		if _, ok := first(r.s.students, func(st *model.Student) bool { return st.ID == a.StudentID }); !ok {
+			return errForeignKey("advisor_assignments_student_id_fkey")
+		}
+		if _, ok := first(r.s.lecturers, func(l *model.Lecturer) bool { return l.ID == a.LecturerID }); !ok {
+			return errForeignKey("advisor_assignments_lecturer_id_fkey")
+		}
+	}
+
+	for i := range assignments {
+		a := &assignments[i]
+		st, _ := first(r.s.students, func(st *model.Student) bool { return st.ID == a.StudentID })
+
+		// data sebelum ada tabel riwayat: dosen wali lama dianggap sejak mahasiswa dibuat
+		if _, hasHistory := first(r.s.assignments, func(e *model.AdvisorAssignment) bool { return e.StudentID == st.ID }); !hasHistory && st.AdvisorID != "" {
+			r.s.assignments = append(r.s.assignments, &model.AdvisorAssignment{
+				ID:            newID(),
+				StudentID:     st.ID,
+				LecturerID:    st.AdvisorID,
+				EffectiveFrom: st.CreatedAt,
+				Reason:        "penugasan awal",
+				CreatedAt:     time.Now(),
+			})
+		}
+
+		for _, e := range r.s.assignments {
+			if e.StudentID == a.StudentID && e.EffectiveTo == nil {
+				to := a.EffectiveFrom
+				if to.Before(e.EffectiveFrom) {
+					to = e.EffectiveFrom
+				}
+				e.EffectiveTo = &to
+			}
+		}
+
+		if a.ID == "" {
+			a.ID = newID()
+		}
+		stampCreate(&a.CreatedAt, nil)
+		row := *a
+		row.Lecturer = nil
+		r.s.assignments = append(r.s.assignments, &row)
+
+		st.AdvisorID = a.LecturerID
+	}
+	return nil
+}
+
+func (r *advisorAssignmentRepository) FindByStudentID(studentID string) ([]model.AdvisorAssignment, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var rows []model.AdvisorAssignment
+	for _, a := range r.s.assignments {
+		if a.StudentID == studentID {
+			out := *a
+			out.Lecturer = r.s.lecturerByID(a.LecturerID)
+			rows = append(rows, out)
+		}
+	}
+	sortStable(rows, func(a, b *model.AdvisorAssignment) bool {
+		if !a.EffectiveFrom.Equal(b.EffectiveFrom) {
+			return a.EffectiveFrom.After(b.EffectiveFrom)
+		}
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+	return rows, nil
+}
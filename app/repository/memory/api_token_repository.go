@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+// sama dengan repository.apiTokenTouchInterval
+const apiTokenTouchInterval = time.Minute
+
+type apiTokenRepository struct {
+	s *Store
+}
+
+func NewAPITokenRepository(s *Store) repository.APITokenRepository {
+	return &apiTokenRepository{s: s}
+}
+
+// load: token + Scopes dari tabel permissions (scope yang permission-nya tidak ada dilewati)
+func (r *apiTokenRepository) load(t *model.APIToken) model.APIToken {
+	out := *t
+	out.Scopes = []model.Permission{}
+	for _, scope := range t.Scopes {
+		if p, ok := first(r.s.permissions, func(p *model.Permission) bool { return p.ID == scope.ID }); ok {
+			out.Scopes = append(out.Scopes, *p)
+		}
+	}
+	return out
+}
+
+func (r *apiTokenRepository) Create(token *model.APIToken) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, dup := first(r.s.apiTokens, func(t *model.APIToken) bool { return t.TokenHash == token.TokenHash }); dup {
+		return errDuplicate("api_tokens_token_hash_key")
+	}
+	if token.ID == "" {
+		token.ID = newID()
+	}
+	stampCreate(&token.CreatedAt, nil)
+	row := *token
+	row.Scopes = append([]model.Permission(nil), token.Scopes...)
+	r.s.apiTokens = append(r.s.apiTokens, &row)
+	return nil
+}
+
+func (r *apiTokenRepository) one(match func(*model.APIToken) bool) (*model.APIToken, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	t, ok := first(r.s.apiTokens, match)
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	out := r.load(t)
+	return &out, nil
+}
+
+func (r *apiTokenRepository) FindByHash(tokenHash string) (*model.APIToken, error) {
+	return r.one(func(t *model.APIToken) bool { return t.TokenHash == tokenHash })
+}
+
+func (r *apiTokenRepository) FindByID(id string) (*model.APIToken, error) {
+	return r.one(func(t *model.APIToken) bool { return t.ID == id })
+}
+
+func (r *apiTokenRepository) FindByUserID(userID string) ([]model.APIToken, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	tokens := []model.APIToken{}
+	for _, t := range r.s.apiTokens {
+		if t.UserID == userID {
+			tokens = append(tokens, r.load(t))
+		}
+	}
+	sortStable(tokens, func(a, b *model.APIToken) bool { return a.CreatedAt.After(b.CreatedAt) })
+	return tokens, nil
+}
+
+func (r *apiTokenRepository) Revoke(id string, at time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	t, ok := first(r.s.apiTokens, func(t *model.APIToken) bool { return t.ID == id && t.RevokedAt == nil })
+	if !ok {
+		return false, nil
+	}
+	t.RevokedAt = cloneTime(&at)
+	return true, nil
+}
+
+func (r *apiTokenRepository) TouchLastUsed(id, ip string, at time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	t, ok := first(r.s.apiTokens, func(t *model.APIToken) bool { return t.ID == id })
+	if ok && (t.LastUsedAt == nil || t.LastUsedAt.Before(at.Add(-apiTokenTouchInterval))) {
+		t.LastUsedAt = cloneTime(&at)
+		t.LastUsedIP = ip
+	}
+	return nil
+}
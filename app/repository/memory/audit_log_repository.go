@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"strings"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+type auditLogRepository struct {
+	s *Store
+}
+
+func NewAuditLogRepository(s *Store) repository.AuditLogRepository {
+	return &auditLogRepository{s: s}
+}
+
+// Append: mutex Store menggantikan advisory lock, rantai tetap linear
+func (r *auditLogRepository) Append(entry *model.AuditLog, seal func(prev *model.AuditLog)) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var prev *model.AuditLog
+	for _, e := range r.s.auditLogs {
+		if prev == nil || e.Seq > prev.Seq {
+			prev = e
+		}
+	}
+	if prev != nil {
+		last := *prev
+		prev = &last
+	}
+	seal(prev)
+
+	if _, dup := first(r.s.auditLogs, func(e *model.AuditLog) bool { return e.Seq == entry.Seq }); dup {
+		return errDuplicate("audit_logs_pkey")
+	}
+	stampCreate(&entry.CreatedAt, nil)
+	row := *entry
+	r.s.auditLogs = append(r.s.auditLogs, &row)
+	return nil
+}
+
+func (r *auditLogRepository) FindAll(f model.AuditLogFilter, offset, limit int) ([]model.AuditLog, int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	entries := []model.AuditLog{}
+	for _, e := range r.s.auditLogs {
+		switch {
+		case f.ActorID != "" && (e.ActorID == nil || *e.ActorID != f.ActorID):
+		case f.Action != "" && !strings.Contains(strings.ToLower(e.Action), strings.ToLower(f.Action)):
+		case f.TargetType != "" && e.TargetType != f.TargetType:
+		case f.TargetID != "" && e.TargetID != f.TargetID:
+		case f.RequestID != "" && e.RequestID != f.RequestID:
+		case f.From != nil && e.CreatedAt.Before(*f.From):
+		case f.To != nil && !e.CreatedAt.Before(*f.To):
+		default:
+			entries = append(entries, *e)
+		}
+	}
+	sortStable(entries, func(a, b *model.AuditLog) bool { return a.Seq > b.Seq })
+	return paginate(entries, offset, limit), int64(len(entries)), nil
+}
+
+func (r *auditLogRepository) FindChain(afterSeq int64, limit int) ([]model.AuditLog, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	entries := []model.AuditLog{}
+	for _, e := range r.s.auditLogs {
+		if e.Seq > afterSeq {
+			entries = append(entries, *e)
+		}
+	}
+	sortStable(entries, func(a, b *model.AuditLog) bool { return a.Seq < b.Seq })
+	return paginate(entries, 0, limit), nil
+}
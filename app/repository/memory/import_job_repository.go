@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+type importJobRepository struct {
+	s *Store
+}
+
+func NewImportJobRepository(s *Store) repository.ImportJobRepository {
+	return &importJobRepository{s: s}
+}
+
+func (r *importJobRepository) Create(job *model.ImportJob) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if job.ID == "" {
+		job.ID = newID()
+	}
+	stampCreate(&job.CreatedAt, &job.UpdatedAt)
+	row := *job
+	row.Errors = append([]model.ImportRowError(nil), job.Errors...)
+	r.s.importJobs = append(r.s.importJobs, &row)
+	return nil
+}
+
+func (r *importJobRepository) Save(job *model.ImportJob) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if job.ID == "" {
+		job.ID = newID()
+	}
+	stampCreate(&job.CreatedAt, nil)
+	job.UpdatedAt = time.Now()
+	row := *job
+	row.Errors = append([]model.ImportRowError(nil), job.Errors...)
+	r.s.importJobs = upsert(r.s.importJobs, &row, func(j *model.ImportJob) bool { return j.ID == job.ID })
+	return nil
+}
+
+func (r *importJobRepository) SaveActive(job *model.ImportJob) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := first(r.s.importJobs, func(j *model.ImportJob) bool { return j.ID == job.ID })
+	if !ok || (row.Status != model.ImportJobPending && row.Status != model.ImportJobRunning) {
+		return false, nil
+	}
+	job.CreatedAt = row.CreatedAt
+	job.UpdatedAt = time.Now()
+	*row = *job
+	row.Errors = append([]model.ImportRowError(nil), job.Errors...)
+	return true, nil
+}
+
+func (r *importJobRepository) FindByID(id string) (*model.ImportJob, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	job, ok := first(r.s.importJobs, func(j *model.ImportJob) bool { return j.ID == id })
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	out := *job
+	out.Errors = append([]model.ImportRowError(nil), job.Errors...)
+	return &out, nil
+}
+
+// FindAll: tanpa detail error per baris, terbaru dulu
+func (r *importJobRepository) FindAll() ([]model.ImportJob, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	jobs := []model.ImportJob{}
+	for _, job := range r.s.importJobs {
+		out := *job
+		out.Errors = nil
+		jobs = append(jobs, out)
+	}
+	sortStable(jobs, func(a, b *model.ImportJob) bool { return a.CreatedAt.After(b.CreatedAt) })
+	return jobs, nil
+}
+
+func (r *importJobRepository) FailStale(updatedBefore time.Time, message string) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var n int64
+	now := time.Now()
+	for _, job := range r.s.importJobs {
+		if job.Status != model.ImportJobPending && job.Status != model.ImportJobRunning {
+			continue
+		}
+		if !job.UpdatedAt.Before(updatedBefore) {
+			continue
+		}
+		finished := now
+		job.Status = model.ImportJobFailed
+		job.FinishedAt = &finished
+		job.Errors = append(job.Errors, model.ImportRowError{Message: message})
+		job.UpdatedAt = now
+		n++
+	}
+	return n, nil
+}
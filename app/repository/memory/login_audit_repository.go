@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"strings"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+type loginAuditRepository struct {
+	s *Store
+}
+
+func NewLoginAuditRepository(s *Store) repository.LoginAuditRepository {
+	return &loginAuditRepository{s: s}
+}
+
+func (r *loginAuditRepository) Create(entry *model.LoginAudit) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if entry.ID == "" {
+		entry.ID = newID()
+	}
+	stampCreate(&entry.CreatedAt, nil)
+	row := *entry
+	r.s.loginAudits = append(r.s.loginAudits, &row)
+	return nil
+}
+
+func (r *loginAuditRepository) FindAll(f model.LoginAuditFilter, offset, limit int) ([]model.LoginAudit, int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	entries := []model.LoginAudit{}
+	for _, e := range r.s.loginAudits {
+		if f.Username != "" && !strings.EqualFold(e.Username, f.Username) {
+			continue
+		}
+		if f.Success != nil && e.Success != *f.Success {
+			continue
+		}
+		entries = append(entries, *e)
+	}
+	sortStable(entries, func(a, b *model.LoginAudit) bool { return a.CreatedAt.After(b.CreatedAt) })
+	return paginate(entries, offset, limit), int64(len(entries)), nil
+}
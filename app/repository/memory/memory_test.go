@@ -0,0 +1,16 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/app/repository/repotest"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) *repository.Repositories {
+		s := NewStore()
+		s.SeedRolesAndPermissions()
+		return s.Repositories()
+	})
+}
@@ -0,0 +1,292 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+type organizationRepository struct {
+	s *Store
+}
+
+func NewOrganizationRepository(s *Store) repository.OrganizationRepository {
+	return &organizationRepository{s: s}
+}
+
+// ===== fakultas =====
+
+func (r *organizationRepository) CreateFaculty(f *model.Faculty) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if f.ID == "" {
+		f.ID = newID()
+	}
+	stampCreate(&f.CreatedAt, &f.UpdatedAt)
+	return r.saveFaculty(f)
+}
+
+func (r *organizationRepository) SaveFaculty(f *model.Faculty) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	f.UpdatedAt = time.Now()
+	return r.saveFaculty(f)
+}
+
+func (r *organizationRepository) saveFaculty(f *model.Faculty) error {
+	if _, dup := first(r.s.faculties, func(e *model.Faculty) bool { return e.ID != f.ID && e.Code == f.Code }); dup {
+		return errDuplicate("faculties_code_key")
+	}
+	row := *f
+	r.s.faculties = upsert(r.s.faculties, &row, func(e *model.Faculty) bool { return e.ID == f.ID })
+	return nil
+}
+
+// DeleteFaculty: ditolak selama masih punya jurusan, scope admin di-NULL-kan
+func (r *organizationRepository) DeleteFaculty(id string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, used := first(r.s.departments, func(d *model.Department) bool { return d.FacultyID == id }); used {
+		return errForeignKey("departments_faculty_id_fkey")
+	}
+	r.s.faculties, _ = remove(r.s.faculties, func(f *model.Faculty) bool { return f.ID == id })
+	for _, u := range r.s.users {
+		if u.ScopeFacultyID != nil && *u.ScopeFacultyID == id {
+			u.ScopeFacultyID = nil
+		}
+	}
+	return nil
+}
+
+func (r *organizationRepository) FindFacultyByID(id string) (*model.Faculty, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if f := r.s.faculty(id); f != nil {
+		return f, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *organizationRepository) FindAllFaculties() ([]model.Faculty, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	faculties := []model.Faculty{}
+	for _, f := range r.s.faculties {
+		faculties = append(faculties, *f)
+	}
+	sortStable(faculties, func(a, b *model.Faculty) bool { return a.Name < b.Name })
+	return faculties, nil
+}
+
+// ===== jurusan =====
+
+func (r *organizationRepository) CreateDepartment(d *model.Department) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if d.ID == "" {
+		d.ID = newID()
+	}
+	stampCreate(&d.CreatedAt, &d.UpdatedAt)
+	return r.saveDepartment(d)
+}
+
+func (r *organizationRepository) SaveDepartment(d *model.Department) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	d.UpdatedAt = time.Now()
+	return r.saveDepartment(d)
+}
+
+func (r *organizationRepository) saveDepartment(d *model.Department) error {
+	if r.s.faculty(d.FacultyID) == nil {
+		return errForeignKey("departments_faculty_id_fkey")
+	}
+	if _, dup := first(r.s.departments, func(e *model.Department) bool { return e.ID != d.ID && e.Code == d.Code }); dup {
+		return errDuplicate("departments_code_key")
+	}
+	row := *d
+	row.Faculty = nil
+	r.s.departments = upsert(r.s.departments, &row, func(e *model.Department) bool { return e.ID == d.ID })
+	return nil
+}
+
+// DeleteDepartment: ditolak selama masih dipakai prodi / dosen, scope admin di-NULL-kan
+func (r *organizationRepository) DeleteDepartment(id string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, used := first(r.s.studyPrograms, func(p *model.StudyProgram) bool { return p.DepartmentID == id }); used {
+		return errForeignKey("study_programs_department_id_fkey")
+	}
+	if _, used := first(r.s.lecturers, func(l *model.Lecturer) bool { return l.DepartmentID != nil && *l.DepartmentID == id }); used {
+		return errForeignKey("lecturers_department_id_fkey")
+	}
+	r.s.departments, _ = remove(r.s.departments, func(d *model.Department) bool { return d.ID == id })
+	for _, u := range r.s.users {
+		if u.ScopeDepartmentID != nil && *u.ScopeDepartmentID == id {
+			u.ScopeDepartmentID = nil
+		}
+	}
+	return nil
+}
+
+func (r *organizationRepository) FindDepartmentByID(id string) (*model.Department, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if d := r.s.department(&id); d != nil {
+		return d, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *organizationRepository) FindDepartments(facultyID string) ([]model.Department, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	departments := []model.Department{}
+	for _, d := range r.s.departments {
+		if facultyID == "" || d.FacultyID == facultyID {
+			departments = append(departments, *r.s.department(&d.ID))
+		}
+	}
+	sortStable(departments, func(a, b *model.Department) bool { return a.Name < b.Name })
+	return departments, nil
+}
+
+// ===== program studi =====
+
+func (r *organizationRepository) CreateStudyProgram(p *model.StudyProgram) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if p.ID == "" {
+		p.ID = newID()
+	}
+	stampCreate(&p.CreatedAt, &p.UpdatedAt)
+	return r.saveStudyProgram(p)
+}
+
+func (r *organizationRepository) SaveStudyProgram(p *model.StudyProgram) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	p.UpdatedAt = time.Now()
+	return r.saveStudyProgram(p)
+}
+
+func (r *organizationRepository) saveStudyProgram(p *model.StudyProgram) error {
+	if r.s.department(&p.DepartmentID) == nil {
+		return errForeignKey("study_programs_department_id_fkey")
+	}
+	if _, dup := first(r.s.studyPrograms, func(e *model.StudyProgram) bool { return e.ID != p.ID && e.Code == p.Code }); dup {
+		return errDuplicate("study_programs_code_key")
+	}
+	row := *p
+	row.Department = nil
+	r.s.studyPrograms = upsert(r.s.studyPrograms, &row, func(e *model.StudyProgram) bool { return e.ID == p.ID })
+	return nil
+}
+
+// DeleteStudyProgram: ditolak selama masih dipakai mahasiswa
+func (r *organizationRepository) DeleteStudyProgram(id string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, used := first(r.s.students, func(st *model.Student) bool { return st.StudyProgramID != nil && *st.StudyProgramID == id }); used {
+		return errForeignKey("students_study_program_id_fkey")
+	}
+	r.s.studyPrograms, _ = remove(r.s.studyPrograms, func(p *model.StudyProgram) bool { return p.ID == id })
+	return nil
+}
+
+func (r *organizationRepository) FindStudyProgramByID(id string) (*model.StudyProgram, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if p := r.s.studyProgram(&id); p != nil {
+		return p, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *organizationRepository) FindStudyPrograms(departmentID string) ([]model.StudyProgram, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	programs := []model.StudyProgram{}
+	for _, p := range r.s.studyPrograms {
+		if departmentID == "" || p.DepartmentID == departmentID {
+			programs = append(programs, *r.s.studyProgram(&p.ID))
+		}
+	}
+	sortStable(programs, func(a, b *model.StudyProgram) bool { return a.Name < b.Name })
+	return programs, nil
+}
+
+// ===== hitungan + teks lama =====
+
+func (r *organizationRepository) CountStudentsByProgram(programID string) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return int64(len(filter(r.s.students, func(st *model.Student) bool {
+		return st.StudyProgramID != nil && *st.StudyProgramID == programID
+	}))), nil
+}
+
+func (r *organizationRepository) CountLecturersByDepartment(departmentID string) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return int64(len(filter(r.s.lecturers, func(l *model.Lecturer) bool {
+		return l.DepartmentID != nil && *l.DepartmentID == departmentID
+	}))), nil
+}
+
+func (r *organizationRepository) UnmappedStudentPrograms() ([]model.UnitValueCount, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	counts := map[string]int64{}
+	for _, st := range r.s.students {
+		if st.StudyProgramID == nil && strings.TrimSpace(st.ProgramStudy) != "" {
+			counts[st.ProgramStudy]++
+		}
+	}
+	return valueCounts(counts), nil
+}
+
+func (r *organizationRepository) UnmappedLecturerDepartments() ([]model.UnitValueCount, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	counts := map[string]int64{}
+	for _, l := range r.s.lecturers {
+		if l.DepartmentID == nil && strings.TrimSpace(l.Department) != "" {
+			counts[l.Department]++
+		}
+	}
+	return valueCounts(counts), nil
+}
+
+func valueCounts(counts map[string]int64) []model.UnitValueCount {
+	var rows []model.UnitValueCount
+	for value, count := range counts {
+		rows = append(rows, model.UnitValueCount{Value: value, Count: count})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Value < rows[j].Value })
+	return rows
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+type passwordResetRepository struct {
+	s *Store
+}
+
+func NewPasswordResetRepository(s *Store) repository.PasswordResetRepository {
+	return &passwordResetRepository{s: s}
+}
+
+func (r *passwordResetRepository) Create(token *model.PasswordResetToken) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, dup := first(r.s.passwordResets, func(t *model.PasswordResetToken) bool { return t.TokenHash == token.TokenHash }); dup {
+		return errDuplicate("password_reset_tokens_token_hash_key")
+	}
+	if token.ID == "" {
+		token.ID = newID()
+	}
+	stampCreate(&token.CreatedAt, nil)
+	row := *token
+	r.s.passwordResets = append(r.s.passwordResets, &row)
+	return nil
+}
+
+func (r *passwordResetRepository) FindValidByHash(tokenHash string, now time.Time) (*model.PasswordResetToken, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	t, ok := first(r.s.passwordResets, func(t *model.PasswordResetToken) bool {
+		return t.TokenHash == tokenHash && t.UsedAt == nil && t.ExpiresAt.After(now)
+	})
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	out := *t
+	return &out, nil
+}
+
+func (r *passwordResetRepository) Consume(id string, at time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	t, ok := first(r.s.passwordResets, func(t *model.PasswordResetToken) bool { return t.ID == id && t.UsedAt == nil })
+	if !ok {
+		return false, nil
+	}
+	t.UsedAt = cloneTime(&at)
+	return true, nil
+}
+
+func (r *passwordResetRepository) InvalidateByUserID(userID string, at time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, t := range r.s.passwordResets {
+		if t.UserID == userID && t.UsedAt == nil {
+			t.UsedAt = cloneTime(&at)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package memory
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+type studentRepository struct {
+	s *Store
+}
+
+func NewStudentRepository(s *Store) repository.StudentRepository {
+	return &studentRepository{s: s}
+}
+
+func (r *studentRepository) list(match func(*model.Student) bool) []model.Student {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var out []model.Student
+	for _, st := range filter(r.s.students, match) {
+		out = append(out, r.s.loadStudent(st))
+	}
+	return out
+}
+
+func (r *studentRepository) one(match func(*model.Student) bool) (*model.Student, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	st, ok := first(r.s.students, match)
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	out := r.s.loadStudent(st)
+	return &out, nil
+}
+
+func (r *studentRepository) FindAll() ([]model.Student, error) {
+	return r.list(func(*model.Student) bool { return true }), nil
+}
+
+func (r *studentRepository) FindAllInScope(scope model.UnitScope) ([]model.Student, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var out []model.Student
+	for _, st := range r.s.students {
+		loaded := r.s.loadStudent(st)
+		if scope.CoversStudent(&loaded) {
+			out = append(out, loaded)
+		}
+	}
+	return out, nil
+}
+
+func (r *studentRepository) FindByUserID(userID string) (*model.Student, error) {
+	return r.one(func(st *model.Student) bool { return st.UserID == userID })
+}
+
+func (r *studentRepository) FindByID(id string) (*model.Student, error) {
+	return r.one(func(st *model.Student) bool { return st.ID == id })
+}
+
+func (r *studentRepository) FindByAdvisorLecturerID(lecturerID string) ([]model.Student, error) {
+	return r.list(func(st *model.Student) bool { return st.AdvisorID == lecturerID }), nil
+}
+
+func (r *studentRepository) FindByAdvisorID(advisorID string) ([]model.Student, error) {
+	return r.list(func(st *model.Student) bool { return st.AdvisorID == advisorID }), nil
+}
+
+func (r *studentRepository) FindByNIM(nim string) (*model.Student, error) {
+	return r.one(func(st *model.Student) bool { return st.StudentID == nim })
+}
+
+func (r *studentRepository) FindByStudyProgramID(programID string) ([]model.Student, error) {
+	return r.list(func(st *model.Student) bool {
+		return st.StudyProgramID != nil && *st.StudyProgramID == programID
+	}), nil
+}
+
+type lecturerRepository struct {
+	s *Store
+}
+
+func NewLecturerRepository(s *Store) repository.LecturerRepository {
+	return &lecturerRepository{s: s}
+}
+
+func (r *lecturerRepository) one(match func(*model.Lecturer) bool) (*model.Lecturer, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	l, ok := first(r.s.lecturers, match)
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	out := r.s.loadLecturer(l)
+	return &out, nil
+}
+
+func (r *lecturerRepository) FindAll() ([]model.Lecturer, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var out []model.Lecturer
+	for _, l := range r.s.lecturers {
+		out = append(out, r.s.loadLecturer(l))
+	}
+	return out, nil
+}
+
+func (r *lecturerRepository) FindByID(id string) (*model.Lecturer, error) {
+	return r.one(func(l *model.Lecturer) bool { return l.ID == id })
+}
+
+func (r *lecturerRepository) FindByUserID(userID string) (*model.Lecturer, error) {
+	return r.one(func(l *model.Lecturer) bool { return l.UserID == userID })
+}
+
+func (r *lecturerRepository) FindByNIDN(nidn string) (*model.Lecturer, error) {
+	return r.one(func(l *model.Lecturer) bool { return l.LecturerID == nidn })
+}
+
+type profileRepository struct {
+	s *Store
+}
+
+func NewProfileRepository(s *Store) repository.ProfileRepository {
+	return &profileRepository{s: s}
+}
+
+// SaveStudentAccount: insert kalau ID kosong, selain itu update
+func (r *profileRepository) SaveStudentAccount(user *model.User, student *model.Student) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, st := range r.s.students {
+		if st.ID != student.ID && st.StudentID == student.StudentID {
+			return errDuplicate("students_student_id_key")
+		}
+	}
+
+	// constraint dicek sebelum menulis apa pun, jadi gagal = tidak ada yang tersimpan
+	if err := r.s.saveUser(user); err != nil {
+		return err
+	}
+
+	student.UserID = user.ID
+	if student.ID == "" {
+		student.ID = newID()
+	}
+	stampCreate(&student.CreatedAt, nil)
+	row := *student
+	row.User, row.StudyProgram = model.User{}, nil
+	r.s.students = upsert(r.s.students, &row, func(st *model.Student) bool { return st.ID == student.ID })
+	return nil
+}
+
+func (r *profileRepository) SaveLecturerAccount(user *model.User, lecturer *model.Lecturer) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, l := range r.s.lecturers {
+		if l.ID != lecturer.ID && l.LecturerID == lecturer.LecturerID {
+			return errDuplicate("lecturers_lecturer_id_key")
+		}
+	}
+
+	// constraint dicek sebelum menulis apa pun, jadi gagal = tidak ada yang tersimpan
+	if err := r.s.saveUser(user); err != nil {
+		return err
+	}
+
+	lecturer.UserID = user.ID
+	if lecturer.ID == "" {
+		lecturer.ID = newID()
+	}
+	stampCreate(&lecturer.CreatedAt, nil)
+	row := *lecturer
+	row.User, row.DepartmentUnit = model.User{}, nil
+	r.s.lecturers = upsert(r.s.lecturers, &row, func(l *model.Lecturer) bool { return l.ID == lecturer.ID })
+	return nil
+}
+
+func (r *profileRepository) AnonymizeAccount(userID string, anon model.AnonymizedAccount) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	// percobaan login gagal tanpa user_id dicocokkan lewat username / email lama
+	if u, ok := first(r.s.users, func(u *model.User) bool { return u.ID == userID }); ok {
+		for _, a := range r.s.loginAudits {
+			if (a.UserID != nil && *a.UserID == userID) ||
+				strings.EqualFold(a.Username, u.Username) || strings.EqualFold(a.Username, u.Email) {
+				a.Username, a.IPAddress, a.UserAgent = anon.Username, "", ""
+			}
+		}
+	}
+	for _, e := range filter(r.s.auditLogs, func(e *model.AuditLog) bool { return e.ActorID != nil && *e.ActorID == userID }) {
+		e.ActorUsername = anon.Username
+	}
+
+	r.s.updateUser(userID, func(u *model.User) {
+		u.Username = anon.Username
+		u.Email = anon.Email
+		u.FullName = anon.FullName
+		u.PasswordHash = anon.PasswordHash
+		u.IsActive = false
+		u.MustChangePassword = false
+		u.TwoFactorEnabled = false
+		u.ScopeFacultyID, u.ScopeDepartmentID = nil, nil
+		u.AnonymizedAt = cloneTime(&anon.At)
+		u.SessionsRevokedAt = cloneTime(&anon.At)
+		if u.DeactivatedAt == nil {
+			u.DeactivatedAt = cloneTime(&anon.At)
+		}
+	})
+
+	// prodi, angkatan dan dosen wali tetap
+	for _, st := range filter(r.s.students, func(st *model.Student) bool { return st.UserID == userID }) {
+		st.StudentID = anon.ProfileNumber
+	}
+	for _, l := range filter(r.s.lecturers, func(l *model.Lecturer) bool { return l.UserID == userID }) {
+		l.LecturerID = anon.ProfileNumber
+	}
+
+	r.s.identities, _ = remove(r.s.identities, func(i *model.UserIdentity) bool { return i.UserID == userID })
+	r.s.twoFactors, _ = remove(r.s.twoFactors, func(tf *model.UserTwoFactor) bool { return tf.UserID == userID })
+	r.s.recoveryCodes, _ = remove(r.s.recoveryCodes, func(c *model.TwoFactorRecoveryCode) bool { return c.UserID == userID })
+	r.s.passwordResets, _ = remove(r.s.passwordResets, func(t *model.PasswordResetToken) bool { return t.UserID == userID })
+	return nil
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+type signingKeyRepository struct {
+	s *Store
+}
+
+func NewSigningKeyRepository(s *Store) repository.SigningKeyRepository {
+	return &signingKeyRepository{s: s}
+}
+
+func (r *signingKeyRepository) LoadKeys() ([]model.SigningKey, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	keys := []model.SigningKey{}
+	for _, k := range r.s.signingKeys {
+		keys = append(keys, *k)
+	}
+	sortStable(keys, func(a, b *model.SigningKey) bool { return a.CreatedAt.After(b.CreatedAt) })
+	return keys, nil
+}
+
+// SaveKey: kid yang sudah ada hanya diperbarui retired_at/expires_at (ON CONFLICT)
+func (r *signingKeyRepository) SaveKey(key *model.SigningKey) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if existing, ok := first(r.s.signingKeys, func(k *model.SigningKey) bool { return k.KID == key.KID }); ok {
+		existing.RetiredAt = cloneTime(key.RetiredAt)
+		existing.ExpiresAt = cloneTime(key.ExpiresAt)
+		return nil
+	}
+	if key.CreatedAt.IsZero() {
+		key.CreatedAt = time.Now()
+	}
+	row := *key
+	r.s.signingKeys = append(r.s.signingKeys, &row)
+	return nil
+}
+
+// WithLock: mutex terpisah dari Store.mu karena fn memanggil LoadKeys / SaveKey
+func (r *signingKeyRepository) WithLock(fn func() error) error {
+	r.s.signingKeyLock.Lock()
+	defer r.s.signingKeyLock.Unlock()
+	return fn()
+}
+
+func (r *signingKeyRepository) DeleteKey(kid string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.signingKeys, _ = remove(r.s.signingKeys, func(k *model.SigningKey) bool { return k.KID == kid })
+	return nil
+}
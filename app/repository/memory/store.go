@@ -0,0 +1,366 @@
+// Package memory: implementasi in-memory semua repository, perilakunya mengikuti implementasi Postgres/Mongo
+// (filter, urutan, constraint unik, cascade). Dipakai test integrasi dan HTTP test tanpa database.
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/database"
+)
+
+// Store: semua "tabel" + "collection"; satu mutex, tiap method repository = satu transaksi
+type Store struct {
+	mu sync.Mutex
+	// pengganti advisory lock rotasi kunci JWT
+	signingKeyLock sync.Mutex
+
+	roles           []*model.Role
+	permissions     []*model.Permission
+	rolePermissions []model.RolePermission
+	users           []*model.User
+	students        []*model.Student
+	lecturers       []*model.Lecturer
+
+	faculties     []*model.Faculty
+	departments   []*model.Department
+	studyPrograms []*model.StudyProgram
+	periods       []*model.AcademicPeriod
+
+	achievements []*achievementDoc
+	versions     []*versionDoc
+	references   []*model.AchievementReference
+	statusLogs   []*model.AchievementStatusLog
+	teamMembers  []*model.AchievementTeamMember
+	flags        []*model.AchievementDuplicateFlag
+
+	assignments []*model.AdvisorAssignment
+	delegations []*model.VerificationDelegation
+	importJobs  []*model.ImportJob
+
+	apiTokens      []*model.APIToken
+	auditLogs      []*model.AuditLog
+	loginAudits    []*model.LoginAudit
+	passwordResets []*model.PasswordResetToken
+	twoFactors     []*model.UserTwoFactor
+	recoveryCodes  []*model.TwoFactorRecoveryCode
+	identities     []*model.UserIdentity
+	oidcStates     []*model.OIDCLoginState
+	signingKeys    []*model.SigningKey
+}
+
+func NewStore() *Store {
+	return &Store{}
+}
+
+// NewRepositories: semua repository di atas satu Store baru
+func NewRepositories() *repository.Repositories {
+	return NewStore().Repositories()
+}
+
+func (s *Store) Repositories() *repository.Repositories {
+	return &repository.Repositories{
+		Users:                   NewUserRepository(s),
+		Roles:                   NewRoleRepository(s),
+		Students:                NewStudentRepository(s),
+		Lecturers:               NewLecturerRepository(s),
+		Profiles:                NewProfileRepository(s),
+		Organizations:           NewOrganizationRepository(s),
+		AcademicPeriods:         NewAcademicPeriodRepository(s),
+		Achievements:            NewAchievementRepository(s),
+		AchievementVersions:     NewAchievementVersionRepository(s),
+		AchievementReferences:   NewAchievementReferenceRepository(s),
+		AchievementStatusLogs:   NewAchievementStatusLogRepository(s),
+		AchievementTeams:        NewAchievementTeamRepository(s),
+		DuplicateFlags:          NewDuplicateFlagRepository(s),
+		AdvisorAssignments:      NewAdvisorAssignmentRepository(s),
+		VerificationDelegations: NewVerificationDelegationRepository(s),
+		ImportJobs:              NewImportJobRepository(s),
+		APITokens:               NewAPITokenRepository(s),
+		AuditLogs:               NewAuditLogRepository(s),
+		LoginAudits:             NewLoginAuditRepository(s),
+		LoginAttempts:           repository.NewMemoryLoginAttemptStore(),
+		PasswordResets:          NewPasswordResetRepository(s),
+		TwoFactor:               NewTwoFactorRepository(s),
+		UserIdentities:          NewUserIdentityRepository(s),
+		OIDCStates:              NewOIDCStateRepository(s),
+		SigningKeys:             NewSigningKeyRepository(s),
+	}
+}
+
+// SeedRolesAndPermissions: role + permission bawaan (sama dengan database.SeedRolesAndPermissions), idempotent
+func (s *Store) SeedRolesAndPermissions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	permIDs := map[string]string{}
+	for _, p := range database.DefaultPermissions() {
+		existing, ok := first(s.permissions, func(e *model.Permission) bool { return e.Name == p.Name })
+		if !ok {
+			p.ID = newID()
+			existing = &p
+			s.permissions = append(s.permissions, existing)
+		}
+		permIDs[p.Name] = existing.ID
+	}
+
+	for _, r := range database.DefaultRoles() {
+		role, ok := first(s.roles, func(e *model.Role) bool { return e.Name == r.Name })
+		if !ok {
+			role = &model.Role{ID: newID(), Name: r.Name, Description: r.Description, CreatedAt: time.Now()}
+			s.roles = append(s.roles, role)
+		}
+		for _, name := range r.Permissions {
+			rp := model.RolePermission{RoleID: role.ID, PermissionID: permIDs[name]}
+			if !s.hasRolePermission(rp) {
+				s.rolePermissions = append(s.rolePermissions, rp)
+			}
+		}
+	}
+}
+
+func (s *Store) hasRolePermission(rp model.RolePermission) bool {
+	for _, e := range s.rolePermissions {
+		if e.RoleID == rp.RoleID && e.PermissionID == rp.PermissionID {
+			return true
+		}
+	}
+	return false
+}
+
+// ===== helper tabel =====
+
+func newID() string {
+	return uuid.NewString()
+}
+
+func errDuplicate(constraint string) error {
+	return fmt.Errorf("%w: %s", gorm.ErrDuplicatedKey, constraint)
+}
+
+func errForeignKey(constraint string) error {
+	return fmt.Errorf("%w: %s", gorm.ErrForeignKeyViolated, constraint)
+}
+
+// stampCreate: perilaku gorm Create — CreatedAt/UpdatedAt diisi kalau masih kosong
+func stampCreate(created, updated *time.Time) {
+	now := time.Now()
+	if created != nil && created.IsZero() {
+		*created = now
+	}
+	if updated != nil && updated.IsZero() {
+		*updated = now
+	}
+}
+
+func first[T any](rows []*T, match func(*T) bool) (*T, bool) {
+	for _, r := range rows {
+		if match(r) {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+func filter[T any](rows []*T, match func(*T) bool) []*T {
+	var out []*T
+	for _, r := range rows {
+		if match(r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// remove: hapus baris yang cocok, return sisa + jumlah yang dihapus
+func remove[T any](rows []*T, match func(*T) bool) ([]*T, int) {
+	kept := rows[:0]
+	removed := 0
+	for _, r := range rows {
+		if match(r) {
+			removed++
+			continue
+		}
+		kept = append(kept, r)
+	}
+	// sisa slice dikosongkan supaya baris terhapus bisa di-GC
+	for i := len(kept); i < len(rows); i++ {
+		rows[i] = nil
+	}
+	return kept, removed
+}
+
+// upsert: ganti baris dengan key sama (perilaku gorm Save), tambah di akhir kalau belum ada
+func upsert[T any](rows []*T, row *T, sameKey func(*T) bool) []*T {
+	for i, r := range rows {
+		if sameKey(r) {
+			rows[i] = row
+			return rows
+		}
+	}
+	return append(rows, row)
+}
+
+// sortStable: urutan insert jadi tie-breaker, seperti urutan fisik tabel kecil di Postgres
+func sortStable[T any](rows []T, less func(a, b *T) bool) {
+	sort.SliceStable(rows, func(i, j int) bool { return less(&rows[i], &rows[j]) })
+}
+
+// paginate: semantik Offset/Limit gorm — limit < 0 = tanpa batas
+func paginate[T any](rows []T, offset, limit int) []T {
+	if offset > 0 {
+		if offset >= len(rows) {
+			return []T{}
+		}
+		rows = rows[offset:]
+	}
+	if limit >= 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+func contains[T comparable](list []T, v T) bool {
+	for _, e := range list {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func cloneString(p *string) *string {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func cloneTime(p *time.Time) *time.Time {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// ===== preload relasi (dipanggil dengan mu terkunci) =====
+
+func (s *Store) role(id string) model.Role {
+	if r, ok := first(s.roles, func(r *model.Role) bool { return r.ID == id }); ok {
+		return *r
+	}
+	return model.Role{}
+}
+
+// user: baris user + Role
+func (s *Store) user(id string) model.User {
+	if u, ok := first(s.users, func(u *model.User) bool { return u.ID == id }); ok {
+		out := *u
+		out.Role = s.role(u.RoleID)
+		return out
+	}
+	return model.User{}
+}
+
+func (s *Store) faculty(id string) *model.Faculty {
+	if f, ok := first(s.faculties, func(f *model.Faculty) bool { return f.ID == id }); ok {
+		out := *f
+		return &out
+	}
+	return nil
+}
+
+// department: baris jurusan + Faculty
+func (s *Store) department(id *string) *model.Department {
+	if id == nil {
+		return nil
+	}
+	if d, ok := first(s.departments, func(d *model.Department) bool { return d.ID == *id }); ok {
+		out := *d
+		out.Faculty = s.faculty(d.FacultyID)
+		return &out
+	}
+	return nil
+}
+
+// studyProgram: baris prodi + Department.Faculty
+func (s *Store) studyProgram(id *string) *model.StudyProgram {
+	if id == nil {
+		return nil
+	}
+	if p, ok := first(s.studyPrograms, func(p *model.StudyProgram) bool { return p.ID == *id }); ok {
+		out := *p
+		out.Department = s.department(&p.DepartmentID)
+		return &out
+	}
+	return nil
+}
+
+func (s *Store) period(id *string) *model.AcademicPeriod {
+	if id == nil {
+		return nil
+	}
+	if p, ok := first(s.periods, func(p *model.AcademicPeriod) bool { return p.ID == *id }); ok {
+		out := *p
+		return &out
+	}
+	return nil
+}
+
+// loadStudent: mahasiswa + User.Role + StudyProgram.Department.Faculty
+// (relasi selalu dimuat penuh, superset dari Preload di implementasi Postgres)
+func (s *Store) loadStudent(st *model.Student) model.Student {
+	out := *st
+	out.User = s.user(st.UserID)
+	out.StudyProgram = s.studyProgram(st.StudyProgramID)
+	return out
+}
+
+func (s *Store) studentByID(id string) (model.Student, bool) {
+	if st, ok := first(s.students, func(st *model.Student) bool { return st.ID == id }); ok {
+		return s.loadStudent(st), true
+	}
+	return model.Student{}, false
+}
+
+// loadLecturer: dosen + User.Role + DepartmentUnit.Faculty
+func (s *Store) loadLecturer(l *model.Lecturer) model.Lecturer {
+	out := *l
+	out.User = s.user(l.UserID)
+	out.DepartmentUnit = s.department(l.DepartmentID)
+	return out
+}
+
+func (s *Store) lecturerByID(id string) *model.Lecturer {
+	if l, ok := first(s.lecturers, func(l *model.Lecturer) bool { return l.ID == id }); ok {
+		out := s.loadLecturer(l)
+		return &out
+	}
+	return nil
+}
+
+// loadReference: reference + Student (lengkap) + Period
+func (s *Store) loadReference(ref *model.AchievementReference) model.AchievementReference {
+	out := *ref
+	out.Student, _ = s.studentByID(ref.StudentID)
+	out.Period = s.period(ref.PeriodID)
+	return out
+}
+
+// inScope: padanan applyStudentScope
+func (s *Store) inScope(studentID string, scope model.UnitScope) bool {
+	if scope.IsGlobal() {
+		return true
+	}
+	st, ok := s.studentByID(studentID)
+	return ok && scope.CoversStudent(&st)
+}
@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+type twoFactorRepository struct {
+	s *Store
+}
+
+func NewTwoFactorRepository(s *Store) repository.TwoFactorRepository {
+	return &twoFactorRepository{s: s}
+}
+
+func (r *twoFactorRepository) FindByUserID(userID string) (*model.UserTwoFactor, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	tf, ok := first(r.s.twoFactors, func(tf *model.UserTwoFactor) bool { return tf.UserID == userID })
+	if !ok {
+		return nil, nil
+	}
+	out := *tf
+	return &out, nil
+}
+
+func (r *twoFactorRepository) Save(tf *model.UserTwoFactor) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stampCreate(&tf.CreatedAt, nil)
+	tf.UpdatedAt = time.Now()
+	row := *tf
+	r.s.twoFactors = upsert(r.s.twoFactors, &row, func(e *model.UserTwoFactor) bool { return e.UserID == tf.UserID })
+	return nil
+}
+
+func (r *twoFactorRepository) Enable(userID string, step int64, codeHashes []string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	if tf, ok := first(r.s.twoFactors, func(tf *model.UserTwoFactor) bool { return tf.UserID == userID }); ok {
+		tf.Enabled = true
+		tf.ConfirmedAt = cloneTime(&now)
+		tf.LastUsedStep = step
+		tf.UpdatedAt = now
+	}
+	if u, ok := first(r.s.users, func(u *model.User) bool { return u.ID == userID }); ok {
+		u.TwoFactorEnabled = true
+	}
+	r.replaceRecoveryCodes(userID, codeHashes)
+	return nil
+}
+
+func (r *twoFactorRepository) Delete(userID string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.recoveryCodes, _ = remove(r.s.recoveryCodes, func(c *model.TwoFactorRecoveryCode) bool { return c.UserID == userID })
+	r.s.twoFactors, _ = remove(r.s.twoFactors, func(tf *model.UserTwoFactor) bool { return tf.UserID == userID })
+	if u, ok := first(r.s.users, func(u *model.User) bool { return u.ID == userID }); ok {
+		u.TwoFactorEnabled = false
+	}
+	return nil
+}
+
+func (r *twoFactorRepository) ReplaceRecoveryCodes(userID string, codeHashes []string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.replaceRecoveryCodes(userID, codeHashes)
+	return nil
+}
+
+func (r *twoFactorRepository) replaceRecoveryCodes(userID string, codeHashes []string) {
+	r.s.recoveryCodes, _ = remove(r.s.recoveryCodes, func(c *model.TwoFactorRecoveryCode) bool { return c.UserID == userID })
+	now := time.Now()
+	for _, h := range codeHashes {
+		r.s.recoveryCodes = append(r.s.recoveryCodes, &model.TwoFactorRecoveryCode{
+			ID:        newID(),
+			UserID:    userID,
+			CodeHash:  h,
+			CreatedAt: now,
+		})
+	}
+}
+
+func (r *twoFactorRepository) ConsumeRecoveryCode(userID, codeHash string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	used := false
+	for _, c := range r.s.recoveryCodes {
+		if c.UserID == userID && c.CodeHash == codeHash && c.UsedAt == nil {
+			c.UsedAt = cloneTime(&now)
+			used = true
+		}
+	}
+	return used, nil
+}
+
+func (r *twoFactorRepository) CountUnusedRecoveryCodes(userID string) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	unused := filter(r.s.recoveryCodes, func(c *model.TwoFactorRecoveryCode) bool { return c.UserID == userID && c.UsedAt == nil })
+	return int64(len(unused)), nil
+}
+
+func (r *twoFactorRepository) UseStep(userID string, step int64) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	tf, ok := first(r.s.twoFactors, func(tf *model.UserTwoFactor) bool { return tf.UserID == userID && tf.LastUsedStep < step })
+	if !ok {
+		return false, nil
+	}
+	tf.LastUsedStep = step
+	return true, nil
+}
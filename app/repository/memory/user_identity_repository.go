@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+type userIdentityRepository struct {
+	s *Store
+}
+
+func NewUserIdentityRepository(s *Store) repository.UserIdentityRepository {
+	return &userIdentityRepository{s: s}
+}
+
+func (r *userIdentityRepository) FindByProviderSubject(provider, subject string) (*model.UserIdentity, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	identity, ok := first(r.s.identities, func(i *model.UserIdentity) bool { return i.Provider == provider && i.Subject == subject })
+	if !ok {
+		return nil, nil
+	}
+	out := *identity
+	return &out, nil
+}
+
+func (r *userIdentityRepository) Create(identity *model.UserIdentity) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, dup := first(r.s.identities, func(i *model.UserIdentity) bool {
+		return i.Provider == identity.Provider && i.Subject == identity.Subject
+	}); dup {
+		return errDuplicate("user_identities_provider_subject_key")
+	}
+	if identity.ID == "" {
+		identity.ID = newID()
+	}
+	stampCreate(&identity.CreatedAt, nil)
+	row := *identity
+	r.s.identities = append(r.s.identities, &row)
+	return nil
+}
+
+func (r *userIdentityRepository) TouchLastLogin(id string, at time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if identity, ok := first(r.s.identities, func(i *model.UserIdentity) bool { return i.ID == id }); ok {
+		identity.LastLoginAt = cloneTime(&at)
+	}
+	return nil
+}
+
+type oidcStateRepository struct {
+	s *Store
+}
+
+func NewOIDCStateRepository(s *Store) repository.OIDCStateRepository {
+	return &oidcStateRepository{s: s}
+}
+
+func (r *oidcStateRepository) Create(state *model.OIDCLoginState) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, dup := first(r.s.oidcStates, func(e *model.OIDCLoginState) bool { return e.State == state.State }); dup {
+		return errDuplicate("oidc_login_states_pkey")
+	}
+	stampCreate(&state.CreatedAt, nil)
+	row := *state
+	r.s.oidcStates = append(r.s.oidcStates, &row)
+	return nil
+}
+
+func (r *oidcStateRepository) Consume(state string, now time.Time) (*model.OIDCLoginState, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := first(r.s.oidcStates, func(e *model.OIDCLoginState) bool { return e.State == state })
+	if !ok {
+		return nil, nil
+	}
+	r.s.oidcStates, _ = remove(r.s.oidcStates, func(e *model.OIDCLoginState) bool { return e.State == state })
+	if !row.ExpiresAt.After(now) {
+		return nil, nil
+	}
+	return row, nil
+}
+
+func (r *oidcStateRepository) DeleteExpired(now time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.oidcStates, _ = remove(r.s.oidcStates, func(e *model.OIDCLoginState) bool { return !e.ExpiresAt.After(now) })
+	return nil
+}
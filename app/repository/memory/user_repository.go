@@ -0,0 +1,263 @@
+package memory
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+type userRepository struct {
+	s *Store
+}
+
+func NewUserRepository(s *Store) repository.UserRepository {
+	return &userRepository{s: s}
+}
+
+// insertUser: users.username + users.email unik; baris disimpan tanpa relasi
+func (s *Store) insertUser(user *model.User) error {
+	if err := s.checkUserUnique(user); err != nil {
+		return err
+	}
+	if user.ID == "" {
+		user.ID = newID()
+	}
+	stampCreate(&user.CreatedAt, &user.UpdatedAt)
+	row := *user
+	row.Role = model.Role{}
+	s.users = append(s.users, &row)
+	return nil
+}
+
+// saveUser: insert kalau ID kosong, selain itu ganti seluruh kolom (gorm Save)
+func (s *Store) saveUser(user *model.User) error {
+	if user.ID == "" {
+		return s.insertUser(user)
+	}
+	if err := s.checkUserUnique(user); err != nil {
+		return err
+	}
+	stampCreate(&user.CreatedAt, nil)
+	user.UpdatedAt = time.Now()
+	row := *user
+	row.Role = model.Role{}
+	s.users = upsert(s.users, &row, func(u *model.User) bool { return u.ID == user.ID })
+	return nil
+}
+
+func (s *Store) checkUserUnique(user *model.User) error {
+	for _, u := range s.users {
+		if u.ID == user.ID {
+			continue
+		}
+		if u.Username == user.Username {
+			return errDuplicate("users_username_key")
+		}
+		if u.Email == user.Email {
+			return errDuplicate("users_email_key")
+		}
+	}
+	return nil
+}
+
+// updateUser: padanan UPDATE users SET ... WHERE id = ? (tanpa error kalau tidak ada baris)
+func (s *Store) updateUser(userID string, apply func(u *model.User)) {
+	if u, ok := first(s.users, func(u *model.User) bool { return u.ID == userID }); ok {
+		apply(u)
+		u.UpdatedAt = time.Now()
+	}
+}
+
+func (r *userRepository) FindByUsernameOrEmail(usernameOrEmail string) (*model.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u, ok := first(r.s.users, func(u *model.User) bool {
+		return u.Username == usernameOrEmail || u.Email == usernameOrEmail
+	})
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	out := r.s.user(u.ID)
+	return &out, nil
+}
+
+func (r *userRepository) GetPermissionsByUserID(userID string) ([]model.Permission, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var perms []model.Permission
+	u, ok := first(r.s.users, func(u *model.User) bool { return u.ID == userID })
+	if !ok {
+		return perms, nil
+	}
+	for _, rp := range r.s.rolePermissions {
+		if rp.RoleID != u.RoleID {
+			continue
+		}
+		if p, ok := first(r.s.permissions, func(p *model.Permission) bool { return p.ID == rp.PermissionID }); ok {
+			perms = append(perms, *p)
+		}
+	}
+	return perms, nil
+}
+
+func (r *userRepository) FindByID(id string) (*model.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := first(r.s.users, func(u *model.User) bool { return u.ID == id }); !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	out := r.s.user(id)
+	return &out, nil
+}
+
+func (r *userRepository) FindAll() ([]model.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var users []model.User
+	for _, u := range r.s.users {
+		users = append(users, r.s.user(u.ID))
+	}
+	return users, nil
+}
+
+func (r *userRepository) FindServiceAccounts() ([]model.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var users []model.User
+	for _, u := range filter(r.s.users, func(u *model.User) bool { return u.IsServiceAccount }) {
+		users = append(users, r.s.user(u.ID))
+	}
+	sortStable(users, func(a, b *model.User) bool { return a.CreatedAt.Before(b.CreatedAt) })
+	return users, nil
+}
+
+func (r *userRepository) Create(user *model.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.insertUser(user)
+}
+
+func (r *userRepository) Update(user *model.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.saveUser(user)
+}
+
+func (r *userRepository) SetActive(userID string, active bool, at time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.updateUser(userID, func(u *model.User) {
+		u.IsActive = active
+		u.DeactivatedAt = nil
+		if !active {
+			u.DeactivatedAt = cloneTime(&at)
+			u.SessionsRevokedAt = cloneTime(&at)
+		}
+	})
+	return nil
+}
+
+func (r *userRepository) UpdateRole(userID, roleID string, at time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.updateUser(userID, func(u *model.User) {
+		u.RoleID = roleID
+		u.SessionsRevokedAt = cloneTime(&at)
+	})
+	return nil
+}
+
+func (r *userRepository) UpdateScope(userID string, facultyID, departmentID *string, at time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.updateUser(userID, func(u *model.User) {
+		u.ScopeFacultyID = cloneString(facultyID)
+		u.ScopeDepartmentID = cloneString(departmentID)
+		u.SessionsRevokedAt = cloneTime(&at)
+	})
+	return nil
+}
+
+func (r *userRepository) UpdatePassword(userID, passwordHash string, mustChange bool, at time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.updateUser(userID, func(u *model.User) {
+		u.PasswordHash = passwordHash
+		u.MustChangePassword = mustChange
+		u.PasswordChangedAt = cloneTime(&at)
+		u.SessionsRevokedAt = cloneTime(&at)
+	})
+	return nil
+}
+
+func (r *userRepository) UpdateAuthBackend(userID, backend string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.updateUser(userID, func(u *model.User) { u.AuthBackend = backend })
+	return nil
+}
+
+type roleRepository struct {
+	s *Store
+}
+
+func NewRoleRepository(s *Store) repository.RoleRepository {
+	return &roleRepository{s: s}
+}
+
+func (r *roleRepository) FindAll() ([]model.Role, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	roles := []model.Role{}
+	for _, role := range r.s.roles {
+		roles = append(roles, *role)
+	}
+	sortStable(roles, func(a, b *model.Role) bool { return a.CreatedAt.Before(b.CreatedAt) })
+	return roles, nil
+}
+
+func (r *roleRepository) FindByID(id string) (*model.Role, error) {
+	return r.find(func(role *model.Role) bool { return role.ID == id })
+}
+
+func (r *roleRepository) FindByName(name string) (*model.Role, error) {
+	return r.find(func(role *model.Role) bool { return role.Name == name })
+}
+
+func (r *roleRepository) find(match func(*model.Role) bool) (*model.Role, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	role, ok := first(r.s.roles, match)
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	out := *role
+	return &out, nil
+}
+
+func (r *roleRepository) UpdateRequireTwoFactor(id string, required bool) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if role, ok := first(r.s.roles, func(role *model.Role) bool { return role.ID == id }); ok {
+		role.RequireTwoFactor = required
+	}
+	return nil
+}
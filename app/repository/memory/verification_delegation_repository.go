@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+type verificationDelegationRepository struct {
+	s *Store
+}
+
+func NewVerificationDelegationRepository(s *Store) repository.VerificationDelegationRepository {
+	return &verificationDelegationRepository{s: s}
+}
+
+func (r *verificationDelegationRepository) load(d *model.VerificationDelegation) model.VerificationDelegation {
+	out := *d
+	out.FromLecturer = r.s.lecturerByID(d.FromLecturerID)
+	out.ToLecturer = r.s.lecturerByID(d.ToLecturerID)
+	return out
+}
+
+func (r *verificationDelegationRepository) Create(d *model.VerificationDelegation) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if d.ID == "" {
+		d.ID = newID()
+	}
+	stampCreate(&d.CreatedAt, nil)
+	row := *d
+	row.FromLecturer, row.ToLecturer = nil, nil
+	r.s.delegations = append(r.s.delegations, &row)
+	return nil
+}
+
+func (r *verificationDelegationRepository) FindByID(id string) (*model.VerificationDelegation, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	d, ok := first(r.s.delegations, func(d *model.VerificationDelegation) bool { return d.ID == id })
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	out := r.load(d)
+	return &out, nil
+}
+
+func (r *verificationDelegationRepository) FindByLecturerID(lecturerID string) ([]model.VerificationDelegation, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var rows []model.VerificationDelegation
+	for _, d := range r.s.delegations {
+		if d.FromLecturerID == lecturerID || d.ToLecturerID == lecturerID {
+			rows = append(rows, r.load(d))
+		}
+	}
+	sortStable(rows, func(a, b *model.VerificationDelegation) bool { return a.StartsAt.After(b.StartsAt) })
+	return rows, nil
+}
+
+func (r *verificationDelegationRepository) FindActive(fromLecturerID, toLecturerID string, at time.Time) ([]model.VerificationDelegation, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var rows []model.VerificationDelegation
+	for _, d := range r.s.delegations {
+		if !d.ActiveAt(at) {
+			continue
+		}
+		if (fromLecturerID != "" && d.FromLecturerID != fromLecturerID) || (toLecturerID != "" && d.ToLecturerID != toLecturerID) {
+			continue
+		}
+		rows = append(rows, *d)
+	}
+	sortStable(rows, func(a, b *model.VerificationDelegation) bool { return a.StartsAt.Before(b.StartsAt) })
+	return rows, nil
+}
+
+func (r *verificationDelegationRepository) Revoke(id string, at time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	d, ok := first(r.s.delegations, func(d *model.VerificationDelegation) bool { return d.ID == id && d.RevokedAt == nil })
+	if !ok {
+		return false, nil
+	}
+	d.RevokedAt = cloneTime(&at)
+	return true, nil
+}
@@ -0,0 +1,66 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type AcademicPeriodRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *AcademicPeriodRepositoryMock) Create(p *model.AcademicPeriod) error {
+	args := m.Called(p)
+	return args.Error(0)
+}
+
+func (m *AcademicPeriodRepositoryMock) Save(p *model.AcademicPeriod) error {
+	args := m.Called(p)
+	return args.Error(0)
+}
+
+func (m *AcademicPeriodRepositoryMock) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *AcademicPeriodRepositoryMock) FindByID(id string) (*model.AcademicPeriod, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AcademicPeriod), args.Error(1)
+}
+
+func (m *AcademicPeriodRepositoryMock) FindAll() ([]model.AcademicPeriod, error) {
+	args := m.Called()
+	return args.Get(0).([]model.AcademicPeriod), args.Error(1)
+}
+
+func (m *AcademicPeriodRepositoryMock) FindActive() (*model.AcademicPeriod, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AcademicPeriod), args.Error(1)
+}
+
+func (m *AcademicPeriodRepositoryMock) FindByDate(t time.Time) (*model.AcademicPeriod, error) {
+	args := m.Called(t)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AcademicPeriod), args.Error(1)
+}
+
+func (m *AcademicPeriodRepositoryMock) FindOverlapping(start, end time.Time, excludeID string) ([]model.AcademicPeriod, error) {
+	args := m.Called(start, end, excludeID)
+	return args.Get(0).([]model.AcademicPeriod), args.Error(1)
+}
+
+func (m *AcademicPeriodRepositoryMock) SetActive(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
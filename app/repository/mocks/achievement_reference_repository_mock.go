@@ -1,7 +1,10 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -9,8 +12,8 @@ type AchievementReferenceRepositoryMock struct {
 	mock.Mock
 }
 
-func (m *AchievementReferenceRepositoryMock) CreateDraft(studentID, mongoID string) (*model.AchievementReference, error) {
-	args := m.Called(studentID, mongoID)
+func (m *AchievementReferenceRepositoryMock) CreateDraft(studentID, mongoID string, periodID *string) (*model.AchievementReference, error) {
+	args := m.Called(studentID, mongoID, periodID)
 	return args.Get(0).(*model.AchievementReference), args.Error(1)
 }
 
@@ -34,8 +37,8 @@ func (m *AchievementReferenceRepositoryMock) FindByStudentIDs(ids []string, stat
 	return args.Get(0).([]model.AchievementReference), args.Error(1)
 }
 
-func (m *AchievementReferenceRepositoryMock) FindAll(offset, limit int, status *string) ([]model.AchievementReference, int64, error) {
-	args := m.Called(offset, limit, status)
+func (m *AchievementReferenceRepositoryMock) FindAll(offset, limit int, filter repository.AchievementReferenceFilter) ([]model.AchievementReference, int64, error) {
+	args := m.Called(offset, limit, filter)
 	return args.Get(0).([]model.AchievementReference), args.Get(1).(int64), args.Error(2)
 }
 
@@ -48,3 +51,43 @@ func (m *AchievementReferenceRepositoryMock) CountByStatus() (map[string]int64,
 	args := m.Called()
 	return args.Get(0).(map[string]int64), args.Error(1)
 }
+
+func (m *AchievementReferenceRepositoryMock) FindVerifiedBetween(from, to time.Time) ([]model.AchievementReference, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]model.AchievementReference), args.Error(1)
+}
+
+func (m *AchievementReferenceRepositoryMock) FindVerifiedByPeriod(periodID string) ([]model.AchievementReference, error) {
+	args := m.Called(periodID)
+	return args.Get(0).([]model.AchievementReference), args.Error(1)
+}
+
+func (m *AchievementReferenceRepositoryMock) FindPendingWithStudent() ([]model.AchievementReference, error) {
+	args := m.Called()
+	return args.Get(0).([]model.AchievementReference), args.Error(1)
+}
+
+func (m *AchievementReferenceRepositoryMock) CountReviewedBy(userIDs []string, since time.Time) (map[string]map[string]int64, error) {
+	args := m.Called(userIDs, since)
+	return args.Get(0).(map[string]map[string]int64), args.Error(1)
+}
+
+func (m *AchievementReferenceRepositoryMock) ClaimReminders(ids []string, at, remindedBefore time.Time) ([]string, error) {
+	args := m.Called(ids, at, remindedBefore)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *AchievementReferenceRepositoryMock) ReleaseReminders(ids []string, at time.Time) error {
+	args := m.Called(ids, at)
+	return args.Error(0)
+}
+
+func (m *AchievementReferenceRepositoryMock) FindByMongoID(mongoID string) ([]model.AchievementReference, error) {
+	args := m.Called(mongoID)
+	return args.Get(0).([]model.AchievementReference), args.Error(1)
+}
+
+func (m *AchievementReferenceRepositoryMock) PurgeByMongoID(mongoID string) error {
+	args := m.Called(mongoID)
+	return args.Error(0)
+}
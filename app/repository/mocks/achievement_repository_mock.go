@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/nerhays/prestasi_uas/app/model"
 	"github.com/stretchr/testify/mock"
@@ -21,6 +22,11 @@ func (m *AchievementRepositoryMock) FindByStudentID(ctx context.Context, student
 	return args.Get(0).([]model.Achievement), args.Error(1)
 }
 
+func (m *AchievementRepositoryMock) SetTeam(ctx context.Context, mongoID string, team []model.TeamMember) error {
+	args := m.Called(ctx, mongoID, team)
+	return args.Error(0)
+}
+
 func (m *AchievementRepositoryMock) SoftDelete(ctx context.Context, mongoID string) error {
 	args := m.Called(ctx, mongoID)
 	return args.Error(0)
@@ -60,3 +66,33 @@ func (m *AchievementRepositoryMock) Update(ctx context.Context, id string, paylo
 	args := m.Called(ctx, id, payload)
 	return args.Get(0).(*model.Achievement), args.Error(1)
 }
+
+func (m *AchievementRepositoryMock) GroupByField(ctx context.Context, ids []string, field string) ([]model.AnalyticsBucket, error) {
+	args := m.Called(ctx, ids, field)
+	return args.Get(0).([]model.AnalyticsBucket), args.Error(1)
+}
+
+func (m *AchievementRepositoryMock) PointsByIDs(ctx context.Context, ids []string) (map[string]float64, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).(map[string]float64), args.Error(1)
+}
+
+func (m *AchievementRepositoryMock) FindDuplicateCandidates(ctx context.Context, ac *model.Achievement, limit int) ([]model.Achievement, error) {
+	args := m.Called(ctx, ac, limit)
+	return args.Get(0).([]model.Achievement), args.Error(1)
+}
+
+func (m *AchievementRepositoryMock) Restore(ctx context.Context, mongoID string) error {
+	args := m.Called(ctx, mongoID)
+	return args.Error(0)
+}
+
+func (m *AchievementRepositoryMock) FindDeletedBefore(ctx context.Context, before time.Time, limit int) ([]model.Achievement, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Get(0).([]model.Achievement), args.Error(1)
+}
+
+func (m *AchievementRepositoryMock) HardDelete(ctx context.Context, mongoID string) error {
+	args := m.Called(ctx, mongoID)
+	return args.Error(0)
+}
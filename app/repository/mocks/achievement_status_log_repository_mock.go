@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/nerhays/prestasi_uas/app/model"
 	"github.com/stretchr/testify/mock"
 )
@@ -18,3 +20,8 @@ func (m *AchievementStatusLogRepositoryMock) FindByReferenceID(refID string) ([]
 	args := m.Called(refID)
 	return args.Get(0).([]model.AchievementStatusLog), args.Error(1)
 }
+
+func (m *AchievementStatusLogRepositoryMock) AverageTurnaround(from, to time.Time, scope model.UnitScope) (float64, int64, error) {
+	args := m.Called(from, to, scope)
+	return args.Get(0).(float64), args.Get(1).(int64), args.Error(2)
+}
@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type AchievementTeamRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *AchievementTeamRepositoryMock) CreateMembers(members []model.AchievementTeamMember) error {
+	args := m.Called(members)
+	return args.Error(0)
+}
+
+func (m *AchievementTeamRepositoryMock) Save(member *model.AchievementTeamMember) error {
+	args := m.Called(member)
+	return args.Error(0)
+}
+
+func (m *AchievementTeamRepositoryMock) FindByID(id string) (*model.AchievementTeamMember, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AchievementTeamMember), args.Error(1)
+}
+
+func (m *AchievementTeamRepositoryMock) FindByMongoID(mongoID string) ([]model.AchievementTeamMember, error) {
+	args := m.Called(mongoID)
+	return args.Get(0).([]model.AchievementTeamMember), args.Error(1)
+}
+
+func (m *AchievementTeamRepositoryMock) FindPendingByStudentID(studentID string) ([]model.AchievementTeamMember, error) {
+	args := m.Called(studentID)
+	return args.Get(0).([]model.AchievementTeamMember), args.Error(1)
+}
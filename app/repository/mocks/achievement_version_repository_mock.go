@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type AchievementVersionRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *AchievementVersionRepositoryMock) Create(ctx context.Context, v *model.AchievementVersion) error {
+	args := m.Called(ctx, v)
+	return args.Error(0)
+}
+
+func (m *AchievementVersionRepositoryMock) FindByAchievementID(ctx context.Context, achievementID string) ([]model.AchievementVersion, error) {
+	args := m.Called(ctx, achievementID)
+	return args.Get(0).([]model.AchievementVersion), args.Error(1)
+}
+
+func (m *AchievementVersionRepositoryMock) FindOne(ctx context.Context, achievementID string, version int) (*model.AchievementVersion, error) {
+	args := m.Called(ctx, achievementID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AchievementVersion), args.Error(1)
+}
+
+func (m *AchievementVersionRepositoryMock) Latest(ctx context.Context, achievementID string) (*model.AchievementVersion, error) {
+	args := m.Called(ctx, achievementID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AchievementVersion), args.Error(1)
+}
+
+func (m *AchievementVersionRepositoryMock) DeleteByAchievementID(ctx context.Context, achievementID string) error {
+	args := m.Called(ctx, achievementID)
+	return args.Error(0)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type AdvisorAssignmentRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *AdvisorAssignmentRepositoryMock) Reassign(assignments []model.AdvisorAssignment) error {
+	args := m.Called(assignments)
+	return args.Error(0)
+}
+
+func (m *AdvisorAssignmentRepositoryMock) FindByStudentID(studentID string) ([]model.AdvisorAssignment, error) {
+	args := m.Called(studentID)
+	return args.Get(0).([]model.AdvisorAssignment), args.Error(1)
+}
@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type APITokenRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *APITokenRepositoryMock) Create(token *model.APIToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *APITokenRepositoryMock) FindByHash(tokenHash string) (*model.APIToken, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIToken), args.Error(1)
+}
+
+func (m *APITokenRepositoryMock) FindByID(id string) (*model.APIToken, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIToken), args.Error(1)
+}
+
+func (m *APITokenRepositoryMock) FindByUserID(userID string) ([]model.APIToken, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]model.APIToken), args.Error(1)
+}
+
+func (m *APITokenRepositoryMock) Revoke(id string, at time.Time) (bool, error) {
+	args := m.Called(id, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *APITokenRepositoryMock) TouchLastUsed(id, ip string, at time.Time) error {
+	args := m.Called(id, ip, at)
+	return args.Error(0)
+}
@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type DuplicateFlagRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *DuplicateFlagRepositoryMock) Upsert(flag *model.AchievementDuplicateFlag) error {
+	args := m.Called(flag)
+	return args.Error(0)
+}
+
+func (m *DuplicateFlagRepositoryMock) Save(flag *model.AchievementDuplicateFlag) error {
+	args := m.Called(flag)
+	return args.Error(0)
+}
+
+func (m *DuplicateFlagRepositoryMock) FindByID(id string) (*model.AchievementDuplicateFlag, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AchievementDuplicateFlag), args.Error(1)
+}
+
+func (m *DuplicateFlagRepositoryMock) FindAll(status *model.DuplicateFlagStatus, offset, limit int) ([]model.AchievementDuplicateFlag, int64, error) {
+	args := m.Called(status, offset, limit)
+	return args.Get(0).([]model.AchievementDuplicateFlag), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *DuplicateFlagRepositoryMock) FindOpenByReferenceIDs(refIDs []string) ([]model.AchievementDuplicateFlag, error) {
+	args := m.Called(refIDs)
+	return args.Get(0).([]model.AchievementDuplicateFlag), args.Error(1)
+}
@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type ImportJobRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ImportJobRepositoryMock) Create(job *model.ImportJob) error {
+	args := m.Called(job)
+	return args.Error(0)
+}
+
+func (m *ImportJobRepositoryMock) Save(job *model.ImportJob) error {
+	args := m.Called(job)
+	return args.Error(0)
+}
+
+func (m *ImportJobRepositoryMock) SaveActive(job *model.ImportJob) (bool, error) {
+	args := m.Called(job)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *ImportJobRepositoryMock) FindByID(id string) (*model.ImportJob, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ImportJob), args.Error(1)
+}
+
+func (m *ImportJobRepositoryMock) FindAll() ([]model.ImportJob, error) {
+	args := m.Called()
+	return args.Get(0).([]model.ImportJob), args.Error(1)
+}
+
+func (m *ImportJobRepositoryMock) FailStale(updatedBefore time.Time, message string) (int64, error) {
+	args := m.Called(updatedBefore, message)
+	return args.Get(0).(int64), args.Error(1)
+}
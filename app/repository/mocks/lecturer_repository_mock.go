@@ -23,3 +23,11 @@ func (m *LecturerRepositoryMock) FindAll() ([]model.Lecturer, error) {
 	args := m.Called()
 	return args.Get(0).([]model.Lecturer), args.Error(1)
 }
+
+func (m *LecturerRepositoryMock) FindByNIDN(nidn string) (*model.Lecturer, error) {
+	args := m.Called(nidn)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Lecturer), args.Error(1)
+}
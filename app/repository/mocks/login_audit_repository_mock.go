@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type LoginAuditRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *LoginAuditRepositoryMock) Create(entry *model.LoginAudit) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *LoginAuditRepositoryMock) FindAll(filter model.LoginAuditFilter, offset, limit int) ([]model.LoginAudit, int64, error) {
+	args := m.Called(filter, offset, limit)
+	return args.Get(0).([]model.LoginAudit), args.Get(1).(int64), args.Error(2)
+}
@@ -0,0 +1,114 @@
+package mocks
+
+import (
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type OrganizationRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *OrganizationRepositoryMock) CreateFaculty(f *model.Faculty) error {
+	args := m.Called(f)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepositoryMock) SaveFaculty(f *model.Faculty) error {
+	args := m.Called(f)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepositoryMock) DeleteFaculty(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepositoryMock) FindFacultyByID(id string) (*model.Faculty, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Faculty), args.Error(1)
+}
+
+func (m *OrganizationRepositoryMock) FindAllFaculties() ([]model.Faculty, error) {
+	args := m.Called()
+	return args.Get(0).([]model.Faculty), args.Error(1)
+}
+
+func (m *OrganizationRepositoryMock) CreateDepartment(d *model.Department) error {
+	args := m.Called(d)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepositoryMock) SaveDepartment(d *model.Department) error {
+	args := m.Called(d)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepositoryMock) DeleteDepartment(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepositoryMock) FindDepartmentByID(id string) (*model.Department, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Department), args.Error(1)
+}
+
+func (m *OrganizationRepositoryMock) FindDepartments(facultyID string) ([]model.Department, error) {
+	args := m.Called(facultyID)
+	return args.Get(0).([]model.Department), args.Error(1)
+}
+
+func (m *OrganizationRepositoryMock) CreateStudyProgram(p *model.StudyProgram) error {
+	args := m.Called(p)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepositoryMock) SaveStudyProgram(p *model.StudyProgram) error {
+	args := m.Called(p)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepositoryMock) DeleteStudyProgram(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *OrganizationRepositoryMock) FindStudyProgramByID(id string) (*model.StudyProgram, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.StudyProgram), args.Error(1)
+}
+
+func (m *OrganizationRepositoryMock) FindStudyPrograms(departmentID string) ([]model.StudyProgram, error) {
+	args := m.Called(departmentID)
+	return args.Get(0).([]model.StudyProgram), args.Error(1)
+}
+
+func (m *OrganizationRepositoryMock) CountStudentsByProgram(programID string) (int64, error) {
+	args := m.Called(programID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *OrganizationRepositoryMock) CountLecturersByDepartment(departmentID string) (int64, error) {
+	args := m.Called(departmentID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *OrganizationRepositoryMock) UnmappedStudentPrograms() ([]model.UnitValueCount, error) {
+	args := m.Called()
+	return args.Get(0).([]model.UnitValueCount), args.Error(1)
+}
+
+func (m *OrganizationRepositoryMock) UnmappedLecturerDepartments() ([]model.UnitValueCount, error) {
+	args := m.Called()
+	return args.Get(0).([]model.UnitValueCount), args.Error(1)
+}
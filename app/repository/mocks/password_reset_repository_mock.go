@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type PasswordResetRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *PasswordResetRepositoryMock) Create(token *model.PasswordResetToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *PasswordResetRepositoryMock) FindValidByHash(tokenHash string, now time.Time) (*model.PasswordResetToken, error) {
+	args := m.Called(tokenHash, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PasswordResetToken), args.Error(1)
+}
+
+func (m *PasswordResetRepositoryMock) Consume(id string, at time.Time) (bool, error) {
+	args := m.Called(id, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *PasswordResetRepositoryMock) InvalidateByUserID(userID string, at time.Time) error {
+	args := m.Called(userID, at)
+	return args.Error(0)
+}
@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type ProfileRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ProfileRepositoryMock) SaveStudentAccount(user *model.User, student *model.Student) error {
+	args := m.Called(user, student)
+	return args.Error(0)
+}
+
+func (m *ProfileRepositoryMock) SaveLecturerAccount(user *model.User, lecturer *model.Lecturer) error {
+	args := m.Called(user, lecturer)
+	return args.Error(0)
+}
+
+func (m *ProfileRepositoryMock) AnonymizeAccount(userID string, anon model.AnonymizedAccount) error {
+	args := m.Called(userID, anon)
+	return args.Error(0)
+}
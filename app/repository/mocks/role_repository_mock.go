@@ -18,3 +18,16 @@ func (m *RoleRepositoryMock) FindByID(id string) (*model.Role, error) {
 	args := m.Called(id)
 	return args.Get(0).(*model.Role), args.Error(1)
 }
+
+func (m *RoleRepositoryMock) FindByName(name string) (*model.Role, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Role), args.Error(1)
+}
+
+func (m *RoleRepositoryMock) UpdateRequireTwoFactor(id string, required bool) error {
+	args := m.Called(id, required)
+	return args.Error(0)
+}
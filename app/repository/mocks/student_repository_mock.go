@@ -24,6 +24,11 @@ func (m *StudentRepositoryMock) FindAll() ([]model.Student, error) {
 	return args.Get(0).([]model.Student), args.Error(1)
 }
 
+func (m *StudentRepositoryMock) FindAllInScope(scope model.UnitScope) ([]model.Student, error) {
+	args := m.Called(scope)
+	return args.Get(0).([]model.Student), args.Error(1)
+}
+
 func (m *StudentRepositoryMock) FindByAdvisorID(advisorID string) ([]model.Student, error) {
 	args := m.Called(advisorID)
 	return args.Get(0).([]model.Student), args.Error(1)
@@ -38,7 +43,16 @@ func (m *StudentRepositoryMock) SetAdvisor(studentID, advisorID string) error {
 	args := m.Called(studentID, advisorID)
 	return args.Error(0)
 }
-func (m *StudentRepositoryMock) UpdateAdvisor(studentID, advisorID string) error {
-	args := m.Called(studentID, advisorID)
-	return args.Error(0)
+
+func (m *StudentRepositoryMock) FindByStudyProgramID(programID string) ([]model.Student, error) {
+	args := m.Called(programID)
+	return args.Get(0).([]model.Student), args.Error(1)
+}
+
+func (m *StudentRepositoryMock) FindByNIM(nim string) (*model.Student, error) {
+	args := m.Called(nim)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Student), args.Error(1)
 }
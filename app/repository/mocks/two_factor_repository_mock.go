@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type TwoFactorRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *TwoFactorRepositoryMock) FindByUserID(userID string) (*model.UserTwoFactor, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.UserTwoFactor), args.Error(1)
+}
+
+func (m *TwoFactorRepositoryMock) Save(tf *model.UserTwoFactor) error {
+	args := m.Called(tf)
+	return args.Error(0)
+}
+
+func (m *TwoFactorRepositoryMock) Enable(userID string, step int64, codeHashes []string) error {
+	args := m.Called(userID, step, codeHashes)
+	return args.Error(0)
+}
+
+func (m *TwoFactorRepositoryMock) Delete(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *TwoFactorRepositoryMock) ReplaceRecoveryCodes(userID string, codeHashes []string) error {
+	args := m.Called(userID, codeHashes)
+	return args.Error(0)
+}
+
+func (m *TwoFactorRepositoryMock) ConsumeRecoveryCode(userID, codeHash string) (bool, error) {
+	args := m.Called(userID, codeHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *TwoFactorRepositoryMock) CountUnusedRecoveryCodes(userID string) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *TwoFactorRepositoryMock) UseStep(userID string, step int64) (bool, error) {
+	args := m.Called(userID, step)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type UserIdentityRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *UserIdentityRepositoryMock) FindByProviderSubject(provider, subject string) (*model.UserIdentity, error) {
+	args := m.Called(provider, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.UserIdentity), args.Error(1)
+}
+
+func (m *UserIdentityRepositoryMock) Create(identity *model.UserIdentity) error {
+	args := m.Called(identity)
+	return args.Error(0)
+}
+
+func (m *UserIdentityRepositoryMock) TouchLastLogin(id string, at time.Time) error {
+	args := m.Called(id, at)
+	return args.Error(0)
+}
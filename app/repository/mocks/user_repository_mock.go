@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/nerhays/prestasi_uas/app/model"
 	"github.com/stretchr/testify/mock"
 )
@@ -41,9 +43,30 @@ func (m *UserRepositoryMock) Create(user *model.User) error {
 func (m *UserRepositoryMock) Update(user *model.User) error {
 	return nil
 }
-func (m *UserRepositoryMock) Delete(id string) error {
-	return nil
+func (m *UserRepositoryMock) SetActive(userID string, active bool, at time.Time) error {
+	args := m.Called(userID, active, at)
+	return args.Error(0)
 }
-func (m *UserRepositoryMock) UpdateRole(userID, roleID string) error {
+func (m *UserRepositoryMock) UpdateRole(userID, roleID string, at time.Time) error {
 	return nil
 }
+
+func (m *UserRepositoryMock) UpdateScope(userID string, facultyID, departmentID *string, at time.Time) error {
+	args := m.Called(userID, facultyID, departmentID, at)
+	return args.Error(0)
+}
+
+func (m *UserRepositoryMock) UpdatePassword(userID, passwordHash string, mustChange bool, at time.Time) error {
+	args := m.Called(userID, passwordHash, mustChange, at)
+	return args.Error(0)
+}
+
+func (m *UserRepositoryMock) UpdateAuthBackend(userID, backend string) error {
+	args := m.Called(userID, backend)
+	return args.Error(0)
+}
+
+func (m *UserRepositoryMock) FindServiceAccounts() ([]model.User, error) {
+	args := m.Called()
+	return args.Get(0).([]model.User), args.Error(1)
+}
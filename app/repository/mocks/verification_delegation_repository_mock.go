@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type VerificationDelegationRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *VerificationDelegationRepositoryMock) Create(d *model.VerificationDelegation) error {
+	args := m.Called(d)
+	return args.Error(0)
+}
+
+func (m *VerificationDelegationRepositoryMock) FindByID(id string) (*model.VerificationDelegation, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.VerificationDelegation), args.Error(1)
+}
+
+func (m *VerificationDelegationRepositoryMock) FindByLecturerID(lecturerID string) ([]model.VerificationDelegation, error) {
+	args := m.Called(lecturerID)
+	return args.Get(0).([]model.VerificationDelegation), args.Error(1)
+}
+
+func (m *VerificationDelegationRepositoryMock) FindActive(fromLecturerID, toLecturerID string, at time.Time) ([]model.VerificationDelegation, error) {
+	args := m.Called(fromLecturerID, toLecturerID, at)
+	return args.Get(0).([]model.VerificationDelegation), args.Error(1)
+}
+
+func (m *VerificationDelegationRepositoryMock) Revoke(id string, at time.Time) (bool, error) {
+	args := m.Called(id, at)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+)
+
+// OrganizationRepository: fakultas -> jurusan -> program studi
+type OrganizationRepository interface {
+	CreateFaculty(f *model.Faculty) error
+	SaveFaculty(f *model.Faculty) error
+	DeleteFaculty(id string) error
+	FindFacultyByID(id string) (*model.Faculty, error)
+	FindAllFaculties() ([]model.Faculty, error)
+
+	CreateDepartment(d *model.Department) error
+	SaveDepartment(d *model.Department) error
+	DeleteDepartment(id string) error
+	FindDepartmentByID(id string) (*model.Department, error)
+	FindDepartments(facultyID string) ([]model.Department, error)
+
+	CreateStudyProgram(p *model.StudyProgram) error
+	SaveStudyProgram(p *model.StudyProgram) error
+	DeleteStudyProgram(id string) error
+	FindStudyProgramByID(id string) (*model.StudyProgram, error)
+	FindStudyPrograms(departmentID string) ([]model.StudyProgram, error)
+
+	CountStudentsByProgram(programID string) (int64, error)
+	CountLecturersByDepartment(departmentID string) (int64, error)
+
+	// teks lama yang belum punya FK (tidak terpetakan migrasi 0004)
+	UnmappedStudentPrograms() ([]model.UnitValueCount, error)
+	UnmappedLecturerDepartments() ([]model.UnitValueCount, error)
+}
+
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+func (r *organizationRepository) CreateFaculty(f *model.Faculty) error {
+	return r.db.Create(f).Error
+}
+
+func (r *organizationRepository) SaveFaculty(f *model.Faculty) error {
+	f.UpdatedAt = time.Now()
+	return r.db.Save(f).Error
+}
+
+func (r *organizationRepository) DeleteFaculty(id string) error {
+	return r.db.Delete(&model.Faculty{}, "id = ?", id).Error
+}
+
+func (r *organizationRepository) FindFacultyByID(id string) (*model.Faculty, error) {
+	var f model.Faculty
+	if err := r.db.Where("id = ?", id).First(&f).Error; err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *organizationRepository) FindAllFaculties() ([]model.Faculty, error) {
+	var faculties []model.Faculty
+	err := r.db.Order("name ASC").Find(&faculties).Error
+	return faculties, err
+}
+
+func (r *organizationRepository) CreateDepartment(d *model.Department) error {
+	return r.db.Omit("Faculty").Create(d).Error
+}
+
+func (r *organizationRepository) SaveDepartment(d *model.Department) error {
+	d.UpdatedAt = time.Now()
+	return r.db.Omit("Faculty").Save(d).Error
+}
+
+func (r *organizationRepository) DeleteDepartment(id string) error {
+	return r.db.Delete(&model.Department{}, "id = ?", id).Error
+}
+
+func (r *organizationRepository) FindDepartmentByID(id string) (*model.Department, error) {
+	var d model.Department
+	if err := r.db.Preload("Faculty").Where("id = ?", id).First(&d).Error; err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// FindDepartments: facultyID kosong = semua jurusan
+func (r *organizationRepository) FindDepartments(facultyID string) ([]model.Department, error) {
+	var departments []model.Department
+	q := r.db.Preload("Faculty").Order("name ASC")
+	if facultyID != "" {
+		q = q.Where("faculty_id = ?", facultyID)
+	}
+	err := q.Find(&departments).Error
+	return departments, err
+}
+
+func (r *organizationRepository) CreateStudyProgram(p *model.StudyProgram) error {
+	return r.db.Omit("Department").Create(p).Error
+}
+
+func (r *organizationRepository) SaveStudyProgram(p *model.StudyProgram) error {
+	p.UpdatedAt = time.Now()
+	return r.db.Omit("Department").Save(p).Error
+}
+
+func (r *organizationRepository) DeleteStudyProgram(id string) error {
+	return r.db.Delete(&model.StudyProgram{}, "id = ?", id).Error
+}
+
+func (r *organizationRepository) FindStudyProgramByID(id string) (*model.StudyProgram, error) {
+	var p model.StudyProgram
+	if err := r.db.Preload("Department.Faculty").Where("id = ?", id).First(&p).Error; err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// FindStudyPrograms: departmentID kosong = semua prodi
+func (r *organizationRepository) FindStudyPrograms(departmentID string) ([]model.StudyProgram, error) {
+	var programs []model.StudyProgram
+	q := r.db.Preload("Department.Faculty").Order("name ASC")
+	if departmentID != "" {
+		q = q.Where("department_id = ?", departmentID)
+	}
+	err := q.Find(&programs).Error
+	return programs, err
+}
+
+func (r *organizationRepository) CountStudentsByProgram(programID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Student{}).Where("study_program_id = ?", programID).Count(&count).Error
+	return count, err
+}
+
+func (r *organizationRepository) CountLecturersByDepartment(departmentID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Lecturer{}).Where("department_id = ?", departmentID).Count(&count).Error
+	return count, err
+}
+
+func (r *organizationRepository) UnmappedStudentPrograms() ([]model.UnitValueCount, error) {
+	var rows []model.UnitValueCount
+	err := r.db.
+		Model(&model.Student{}).
+		Select("program_study AS value, COUNT(*) AS count").
+		Where("study_program_id IS NULL AND TRIM(COALESCE(program_study, '')) <> ''").
+		Group("program_study").
+		Order("program_study").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *organizationRepository) UnmappedLecturerDepartments() ([]model.UnitValueCount, error) {
+	var rows []model.UnitValueCount
+	err := r.db.
+		Model(&model.Lecturer{}).
+		Select("department AS value, COUNT(*) AS count").
+		Where("department_id IS NULL AND TRIM(COALESCE(department, '')) <> ''").
+		Group("department").
+		Order("department").
+		Scan(&rows).Error
+	return rows, err
+}
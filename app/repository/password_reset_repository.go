@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+)
+
+type PasswordResetRepository interface {
+	Create(token *model.PasswordResetToken) error
+	// FindValidByHash: token belum dipakai dan belum kedaluwarsa
+	FindValidByHash(tokenHash string, now time.Time) (*model.PasswordResetToken, error)
+	// Consume: tandai token terpakai; false kalau sudah dipakai duluan (single-use)
+	Consume(id string, at time.Time) (bool, error)
+	// InvalidateByUserID: matikan semua token user yang masih aktif
+	InvalidateByUserID(userID string, at time.Time) error
+}
+
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepository{db: db}
+}
+
+func (r *passwordResetRepository) Create(token *model.PasswordResetToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *passwordResetRepository) FindValidByHash(tokenHash string, now time.Time) (*model.PasswordResetToken, error) {
+	var token model.PasswordResetToken
+	if err := r.db.
+		Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+		First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *passwordResetRepository) Consume(id string, at time.Time) (bool, error) {
+	res := r.db.Model(&model.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	return res.RowsAffected == 1, res.Error
+}
+
+func (r *passwordResetRepository) InvalidateByUserID(userID string, at time.Time) error {
+	return r.db.Model(&model.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", at).Error
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProfileRepository: simpan user + profil (students / lecturers) dalam satu transaksi
+type ProfileRepository interface {
+	SaveStudentAccount(user *model.User, student *model.Student) error
+	SaveLecturerAccount(user *model.User, lecturer *model.Lecturer) error
+	// AnonymizeAccount: ganti data pribadi user + nomor induk profil, hapus identitas login terkait;
+	// username / IP / user agent di login_audits dan actor_username di audit_logs ikut dipseudonimkan
+	AnonymizeAccount(userID string, anon model.AnonymizedAccount) error
+}
+
+type profileRepository struct {
+	db *gorm.DB
+}
+
+func NewProfileRepository(db *gorm.DB) ProfileRepository {
+	return &profileRepository{db: db}
+}
+
+// SaveStudentAccount: insert kalau ID kosong, selain itu update
+func (r *profileRepository) SaveStudentAccount(user *model.User, student *model.Student) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := saveUser(tx, user); err != nil {
+			return err
+		}
+
+		student.UserID = user.ID
+
+		omit := []string{clause.Associations}
+		// advisor_id bertipe uuid, string kosong harus jadi NULL
+		if student.AdvisorID == "" {
+			omit = append(omit, "advisor_id")
+		}
+
+		q := tx.Omit(omit...)
+		if student.ID == "" {
+			return q.Create(student).Error
+		}
+		return q.Save(student).Error
+	})
+}
+
+func (r *profileRepository) SaveLecturerAccount(user *model.User, lecturer *model.Lecturer) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := saveUser(tx, user); err != nil {
+			return err
+		}
+
+		lecturer.UserID = user.ID
+
+		if lecturer.ID == "" {
+			return tx.Omit(clause.Associations).Create(lecturer).Error
+		}
+		return tx.Omit(clause.Associations).Save(lecturer).Error
+	})
+}
+
+func saveUser(tx *gorm.DB, user *model.User) error {
+	if user.ID == "" {
+		return tx.Omit(clause.Associations).Create(user).Error
+	}
+	return tx.Omit(clause.Associations).Save(user).Error
+}
+
+func (r *profileRepository) AnonymizeAccount(userID string, anon model.AnonymizedAccount) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// percobaan login gagal tanpa user_id dicocokkan lewat username / email lama, jadi sebelum users diubah
+		if err := tx.Exec(`UPDATE login_audits SET username = ?, ip_address = '', user_agent = ''
+			WHERE user_id = ? OR LOWER(username) IN (SELECT LOWER(username) FROM users WHERE id = ? UNION SELECT LOWER(email) FROM users WHERE id = ?)`,
+			anon.Username, userID, userID, userID).Error; err != nil {
+			return err
+		}
+		// trigger append-only hanya meloloskan perubahan actor_username, kolom itu tidak masuk hash
+		if err := tx.Exec("UPDATE audit_logs SET actor_username = ? WHERE actor_id = ?", anon.Username, userID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&model.User{}).
+			Where("id = ?", userID).
+			Updates(map[string]any{
+				"username":             anon.Username,
+				"email":                anon.Email,
+				"full_name":            anon.FullName,
+				"password_hash":        anon.PasswordHash,
+				"is_active":            false,
+				"must_change_password": false,
+				"two_factor_enabled":   false,
+				"scope_faculty_id":     nil,
+				"scope_department_id":  nil,
+				"anonymized_at":        anon.At,
+				"sessions_revoked_at":  anon.At,
+				"deactivated_at":       gorm.Expr("COALESCE(deactivated_at, ?)", anon.At),
+			}).Error; err != nil {
+			return err
+		}
+
+		// prodi, angkatan dan dosen wali tetap, statistik agregat tidak berubah
+		if err := tx.Model(&model.Student{}).
+			Where("user_id = ?", userID).
+			Update("student_id", anon.ProfileNumber).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Lecturer{}).
+			Where("user_id = ?", userID).
+			Update("lecturer_id", anon.ProfileNumber).Error; err != nil {
+			return err
+		}
+
+		for _, table := range []string{
+			"user_identities",
+			"user_two_factors",
+			"two_factor_recovery_codes",
+			"password_reset_tokens",
+		} {
+			if err := tx.Exec("DELETE FROM "+table+" WHERE user_id = ?", userID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+)
+
+// Repositories: semua repository aplikasi dalam satu nilai; implementasi Postgres/Mongo (NewRepositories)
+// bisa diganti implementasi in-memory (package memory) untuk test
+type Repositories struct {
+	Users                   UserRepository
+	Roles                   RoleRepository
+	Students                StudentRepository
+	Lecturers               LecturerRepository
+	Profiles                ProfileRepository
+	Organizations           OrganizationRepository
+	AcademicPeriods         AcademicPeriodRepository
+	Achievements            AchievementRepository
+	AchievementVersions     AchievementVersionRepository
+	AchievementReferences   AchievementReferenceRepository
+	AchievementStatusLogs   AchievementStatusLogRepository
+	AchievementTeams        AchievementTeamRepository
+	DuplicateFlags          DuplicateFlagRepository
+	AdvisorAssignments      AdvisorAssignmentRepository
+	VerificationDelegations VerificationDelegationRepository
+	ImportJobs              ImportJobRepository
+	APITokens               APITokenRepository
+	AuditLogs               AuditLogRepository
+	LoginAudits             LoginAuditRepository
+	// LoginAttempts: store bersama antar instance; LOGIN_ATTEMPT_STORE=memory tetap memakai counter lokal
+	LoginAttempts  LoginAttemptStore
+	PasswordResets PasswordResetRepository
+	TwoFactor      TwoFactorRepository
+	UserIdentities UserIdentityRepository
+	OIDCStates     OIDCStateRepository
+	SigningKeys    SigningKeyRepository
+}
+
+// NewRepositories: implementasi Postgres (db) + Mongo (mongoDB)
+func NewRepositories(db *gorm.DB, mongoDB *mongo.Database) *Repositories {
+	return &Repositories{
+		Users:                   NewUserRepository(db),
+		Roles:                   NewRoleRepository(db),
+		Students:                NewStudentRepository(db),
+		Lecturers:               NewLecturerRepository(db),
+		Profiles:                NewProfileRepository(db),
+		Organizations:           NewOrganizationRepository(db),
+		AcademicPeriods:         NewAcademicPeriodRepository(db),
+		Achievements:            NewAchievementRepository(mongoDB),
+		AchievementVersions:     NewAchievementVersionRepository(mongoDB),
+		AchievementReferences:   NewAchievementReferenceRepository(db),
+		AchievementStatusLogs:   NewAchievementStatusLogRepository(db),
+		AchievementTeams:        NewAchievementTeamRepository(db),
+		DuplicateFlags:          NewDuplicateFlagRepository(db),
+		AdvisorAssignments:      NewAdvisorAssignmentRepository(db),
+		VerificationDelegations: NewVerificationDelegationRepository(db),
+		ImportJobs:              NewImportJobRepository(db),
+		APITokens:               NewAPITokenRepository(db),
+		AuditLogs:               NewAuditLogRepository(db),
+		LoginAudits:             NewLoginAuditRepository(db),
+		LoginAttempts:           NewPostgresLoginAttemptStore(db),
+		PasswordResets:          NewPasswordResetRepository(db),
+		TwoFactor:               NewTwoFactorRepository(db),
+		UserIdentities:          NewUserIdentityRepository(db),
+		OIDCStates:              NewOIDCStateRepository(db),
+		SigningKeys:             NewSigningKeyRepository(db),
+	}
+}
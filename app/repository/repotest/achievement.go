@@ -0,0 +1,402 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+func mongoID() string {
+	return primitive.NewObjectID().Hex()
+}
+
+func newAchievement(t *testing.T, repos *repository.Repositories, studentID, achievementType string, points float64) *model.Achievement {
+	t.Helper()
+	ac, err := repos.Achievements.Create(context.Background(), &model.Achievement{
+		StudentID:       studentID,
+		AchievementType: achievementType,
+		Title:           "Prestasi " + unique(),
+		Description:     "deskripsi",
+		Details:         map[string]any{"level": "nasional", "certificationNumber": "CERT-" + unique()},
+		Points:          points,
+	})
+	require.NoError(t, err)
+	require.False(t, ac.ID.IsZero())
+	return ac
+}
+
+func testAchievements(t *testing.T, repos *repository.Repositories) {
+	ctx := context.Background()
+	studentID := mongoID() // studentId di Mongo tidak punya FK, cukup unik
+	a := newAchievement(t, repos, studentID, "competition", 30)
+	b := newAchievement(t, repos, studentID, "certification", 10)
+
+	got, err := repos.Achievements.FindByID(ctx, a.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, a.Title, got.Title)
+	assert.Equal(t, "nasional", got.Details["level"])
+	assert.False(t, got.CreatedAt.IsZero())
+
+	_, err = repos.Achievements.FindByID(ctx, mongoID())
+	assert.Error(t, err)
+
+	// Update: $set seluruh payload, _id tidak ikut
+	payload := *got
+	payload.ID = primitive.NilObjectID
+	payload.Title, payload.Points = "Judul Baru", 40
+	updated, err := repos.Achievements.Update(ctx, a.ID.Hex(), &payload)
+	require.NoError(t, err)
+	assert.Equal(t, "Judul Baru", updated.Title)
+	assert.Equal(t, 40.0, updated.Points)
+	assert.Equal(t, "competition", updated.AchievementType)
+
+	att := model.Attachment{FileName: "s.pdf", FileURL: "/uploads/s.pdf", FileType: "application/pdf", Hash: "h" + unique(), UploadedAt: time.Now()}
+	require.NoError(t, repos.Achievements.AddAttachment(ctx, a.ID.Hex(), att))
+	got, err = repos.Achievements.FindByID(ctx, a.ID.Hex())
+	require.NoError(t, err)
+	assert.Len(t, got.AttachmentHashes(), 1)
+
+	// anggota tim ikut melihat prestasi ketua
+	member := mongoID()
+	require.NoError(t, repos.Achievements.SetTeam(ctx, b.ID.Hex(), []model.TeamMember{
+		{StudentID: studentID, Role: model.TeamRoleLeader},
+		{StudentID: member, Role: model.TeamRoleMember},
+	}))
+	own, err := repos.Achievements.FindByStudentID(ctx, member)
+	require.NoError(t, err)
+	assert.Equal(t, []string{b.ID.Hex()}, achievementIDs(own))
+
+	points, err := repos.Achievements.PointsByIDs(ctx, []string{a.ID.Hex(), b.ID.Hex()})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{a.ID.Hex(): 40, b.ID.Hex(): 10}, points)
+
+	buckets, err := repos.Achievements.GroupByField(ctx, []string{a.ID.Hex(), b.ID.Hex()}, "achievementType")
+	require.NoError(t, err)
+	require.Len(t, buckets, 2)
+	assert.Equal(t, "competition", buckets[0].Key, "poin terbesar di depan")
+
+	candidates, err := repos.Achievements.FindDuplicateCandidates(ctx, a, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{b.ID.Hex()}, achievementIDs(candidates), "milik mahasiswa yang sama, dirinya sendiri tidak ikut")
+
+	// soft delete -> tempat sampah -> restore / hapus permanen
+	require.NoError(t, repos.Achievements.SoftDelete(ctx, a.ID.Hex()))
+	own, err = repos.Achievements.FindByStudentID(ctx, studentID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{b.ID.Hex()}, achievementIDs(own))
+
+	deleted, err := repos.Achievements.FindDeletedByStudentID(ctx, studentID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{a.ID.Hex()}, achievementIDs(deleted))
+
+	byIDs, err := repos.Achievements.FindByIDs(ctx, []string{a.ID.Hex(), b.ID.Hex()})
+	require.NoError(t, err)
+	assert.Equal(t, []string{b.ID.Hex()}, achievementIDs(byIDs))
+
+	expired, err := repos.Achievements.FindDeletedBefore(ctx, time.Now().Add(time.Minute), 1000)
+	require.NoError(t, err)
+	assert.Contains(t, achievementIDs(expired), a.ID.Hex())
+	expired, err = repos.Achievements.FindDeletedBefore(ctx, time.Now().Add(-time.Hour), 1000)
+	require.NoError(t, err)
+	assert.NotContains(t, achievementIDs(expired), a.ID.Hex())
+
+	require.NoError(t, repos.Achievements.Restore(ctx, a.ID.Hex()))
+	own, err = repos.Achievements.FindByStudentID(ctx, studentID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{a.ID.Hex(), b.ID.Hex()}, achievementIDs(own))
+
+	// HardDelete hanya untuk dokumen yang sudah di tempat sampah
+	require.NoError(t, repos.Achievements.HardDelete(ctx, a.ID.Hex()))
+	_, err = repos.Achievements.FindByID(ctx, a.ID.Hex())
+	require.NoError(t, err)
+	require.NoError(t, repos.Achievements.SoftDelete(ctx, a.ID.Hex()))
+	require.NoError(t, repos.Achievements.HardDelete(ctx, a.ID.Hex()))
+	_, err = repos.Achievements.FindByID(ctx, a.ID.Hex())
+	assert.Error(t, err)
+}
+
+func achievementIDs(rows []model.Achievement) []string {
+	return ids(rows, func(a model.Achievement) string { return a.ID.Hex() })
+}
+
+func testAchievementVersions(t *testing.T, repos *repository.Repositories) {
+	ctx := context.Background()
+	achievementID := mongoID()
+
+	latest, err := repos.AchievementVersions.Latest(ctx, achievementID)
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+
+	for v := 1; v <= 3; v++ {
+		require.NoError(t, repos.AchievementVersions.Create(ctx, &model.AchievementVersion{
+			AchievementID: achievementID,
+			Version:       v,
+			Snapshot:      model.Achievement{Title: "v" + string(rune('0'+v))},
+			CreatedAt:     time.Now(),
+		}))
+	}
+
+	versions, err := repos.AchievementVersions.FindByAchievementID(ctx, achievementID)
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, 1, versions[0].Version)
+
+	latest, err = repos.AchievementVersions.Latest(ctx, achievementID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, latest.Version)
+
+	v2, err := repos.AchievementVersions.FindOne(ctx, achievementID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", v2.Snapshot.Title)
+	_, err = repos.AchievementVersions.FindOne(ctx, achievementID, 9)
+	assert.Error(t, err)
+
+	require.NoError(t, repos.AchievementVersions.DeleteByAchievementID(ctx, achievementID))
+	versions, err = repos.AchievementVersions.FindByAchievementID(ctx, achievementID)
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+}
+
+func testAchievementReferences(t *testing.T, repos *repository.Repositories) {
+	lecturer := newLecturer(t, repos)
+	student := newStudent(t, repos, lecturer.ID)
+	verifier := newUser(t, repos, "Dosen Wali")
+
+	draft, err := repos.AchievementReferences.CreateDraft(student.ID, mongoID(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, model.AchievementStatusDraft, draft.Status)
+
+	_, err = repos.AchievementReferences.CreateDraft(mongoIDAsUUID(), mongoID(), nil)
+	assert.Error(t, err, "mahasiswa harus ada")
+
+	submitted, err := repos.AchievementReferences.CreateDraft(student.ID, mongoID(), nil)
+	require.NoError(t, err)
+	submittedAt := time.Now().Add(-48 * time.Hour)
+	submitted.Status = model.AchievementStatusSubmitted
+	submitted.SubmittedAt = &submittedAt
+	require.NoError(t, repos.AchievementReferences.Save(submitted))
+
+	verified, err := repos.AchievementReferences.CreateDraft(student.ID, mongoID(), nil)
+	require.NoError(t, err)
+	verifiedAt := time.Now().Add(-time.Hour)
+	verified.Status = model.AchievementStatusVerified
+	verified.SubmittedAt = &submittedAt
+	verified.VerifiedAt = &verifiedAt
+	verified.VerifiedBy = &verifier.ID
+	require.NoError(t, repos.AchievementReferences.Save(verified))
+
+	status := model.AchievementStatusSubmitted
+	n, err := repos.AchievementReferences.CountByStudentIDs([]string{student.ID}, &status)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	n, err = repos.AchievementReferences.CountByStudentIDs([]string{student.ID}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	page, err := repos.AchievementReferences.FindByStudentIDs([]string{student.ID}, nil, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, page, 3)
+	assert.Equal(t, draft.ID, page[2].ID, "draft tanpa submitted_at paling akhir")
+	page, err = repos.AchievementReferences.FindByStudentIDs([]string{student.ID}, nil, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{draft.ID}, refIDs(page))
+
+	pending, err := repos.AchievementReferences.FindPendingWithStudent()
+	require.NoError(t, err)
+	assert.Contains(t, refIDs(pending), submitted.ID)
+	assert.NotContains(t, refIDs(pending), verified.ID)
+
+	between, err := repos.AchievementReferences.FindVerifiedBetween(verifiedAt.Add(-time.Minute), verifiedAt.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Contains(t, refIDs(between), verified.ID)
+
+	reviewed, err := repos.AchievementReferences.CountReviewedBy([]string{verifier.ID}, submittedAt)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), reviewed[verifier.ID][string(model.AchievementStatusVerified)])
+
+	remindedAt := time.Now().Truncate(time.Second)
+	claimed, err := repos.AchievementReferences.ClaimReminders([]string{submitted.ID, verified.ID}, remindedAt, remindedAt.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []string{submitted.ID}, claimed, "hanya yang masih submitted")
+	got, err := repos.AchievementReferences.GetByID(submitted.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.LastRemindedAt)
+	timeEqual(t, remindedAt, *got.LastRemindedAt)
+
+	// klaim kedua (instance lain) dalam jeda cooldown tidak dapat apa-apa
+	claimed, err = repos.AchievementReferences.ClaimReminders([]string{submitted.ID}, remindedAt.Add(time.Second), remindedAt.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	// email gagal: klaim dilepas, putaran berikut bisa mengklaim lagi
+	require.NoError(t, repos.AchievementReferences.ReleaseReminders([]string{submitted.ID}, remindedAt))
+	got, err = repos.AchievementReferences.GetByID(submitted.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.LastRemindedAt)
+
+	// scope unit: mahasiswa di luar prodi fakultas tidak terlihat
+	f, _, program := newOrgUnits(t, repos)
+	scoped := newStudent(t, repos, "")
+	scoped.StudyProgramID = &program.ID
+	user, err := repos.Users.FindByID(scoped.UserID)
+	require.NoError(t, err)
+	require.NoError(t, repos.Profiles.SaveStudentAccount(user, scoped))
+	scopedRef, err := repos.AchievementReferences.CreateDraft(scoped.ID, mongoID(), nil)
+	require.NoError(t, err)
+
+	refs, total, err := repos.AchievementReferences.FindAll(0, 10, repository.AchievementReferenceFilter{Scope: model.UnitScope{FacultyID: f.ID}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, []string{scopedRef.ID}, refIDs(refs))
+	assert.Equal(t, scoped.StudentID, refs[0].Student.StudentID)
+
+	inScope, err := repos.Students.FindAllInScope(model.UnitScope{FacultyID: f.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{scoped.ID}, ids(inScope, func(s model.Student) string { return s.ID }))
+
+	// rata-rata waktu verifikasi ikut scope unit
+	for _, st := range []model.AchievementStatus{model.AchievementStatusSubmitted, model.AchievementStatusVerified} {
+		require.NoError(t, repos.AchievementStatusLogs.Create(&model.AchievementStatusLog{
+			AchievementReferenceID: scopedRef.ID,
+			NewStatus:              string(st),
+			ChangedBy:              verifier.ID,
+		}))
+	}
+	from, to := time.Now().Add(-time.Minute), time.Now().Add(time.Minute)
+	_, samples, err := repos.AchievementStatusLogs.AverageTurnaround(from, to, model.UnitScope{FacultyID: f.ID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), samples)
+	other, _, _ := newOrgUnits(t, repos)
+	_, samples, err = repos.AchievementStatusLogs.AverageTurnaround(from, to, model.UnitScope{FacultyID: other.ID})
+	require.NoError(t, err)
+	assert.Zero(t, samples)
+
+	// log status
+	require.NoError(t, repos.AchievementStatusLogs.Create(&model.AchievementStatusLog{
+		AchievementReferenceID: verified.ID,
+		OldStatus:              string(model.AchievementStatusSubmitted),
+		NewStatus:              string(model.AchievementStatusVerified),
+		ChangedBy:              verifier.ID,
+	}))
+	logs, err := repos.AchievementStatusLogs.FindByReferenceID(verified.ID)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, string(model.AchievementStatusVerified), logs[0].NewStatus)
+
+	// purge: reference + log status ikut terhapus
+	require.NoError(t, repos.AchievementReferences.PurgeByMongoID(verified.MongoAchievementID))
+	_, err = repos.AchievementReferences.GetByID(verified.ID)
+	assert.Error(t, err)
+	logs, err = repos.AchievementStatusLogs.FindByReferenceID(verified.ID)
+	require.NoError(t, err)
+	assert.Empty(t, logs)
+}
+
+// mongoIDAsUUID: UUID acak yang dijamin tidak ada di tabel mana pun
+func mongoIDAsUUID() string {
+	return "00000000-0000-4000-8000-" + mongoID()[:12]
+}
+
+func refIDs(rows []model.AchievementReference) []string {
+	return ids(rows, func(r model.AchievementReference) string { return r.ID })
+}
+
+func testAchievementTeams(t *testing.T, repos *repository.Repositories) {
+	leader := newStudent(t, repos, "")
+	member := newStudent(t, repos, "")
+	docID := mongoID()
+
+	members := []model.AchievementTeamMember{
+		{MongoAchievementID: docID, StudentID: member.ID, Role: model.TeamRoleMember, Status: model.TeamInvitationPending, InvitedBy: leader.ID},
+		{MongoAchievementID: docID, StudentID: leader.ID, Role: model.TeamRoleLeader, Status: model.TeamInvitationAccepted, InvitedBy: leader.ID},
+	}
+	require.NoError(t, repos.AchievementTeams.CreateMembers(members))
+
+	dup := []model.AchievementTeamMember{{MongoAchievementID: docID, StudentID: member.ID, Role: model.TeamRoleMember, Status: model.TeamInvitationPending, InvitedBy: leader.ID}}
+	assert.Error(t, repos.AchievementTeams.CreateMembers(dup), "satu mahasiswa sekali per dokumen")
+
+	team, err := repos.AchievementTeams.FindByMongoID(docID)
+	require.NoError(t, err)
+	require.Len(t, team, 2)
+	assert.Equal(t, model.TeamRoleLeader, team[0].Role, "ketua di depan")
+
+	pending, err := repos.AchievementTeams.FindPendingByStudentID(member.ID)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	invite := pending[0]
+	now := time.Now()
+	invite.Status = model.TeamInvitationAccepted
+	invite.RespondedAt = &now
+	require.NoError(t, repos.AchievementTeams.Save(&invite))
+	got, err := repos.AchievementTeams.FindByID(invite.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.TeamInvitationAccepted, got.Status)
+
+	pending, err = repos.AchievementTeams.FindPendingByStudentID(member.ID)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func testDuplicateFlags(t *testing.T, repos *repository.Repositories) {
+	student := newStudent(t, repos, "")
+	ref, err := repos.AchievementReferences.CreateDraft(student.ID, mongoID(), nil)
+	require.NoError(t, err)
+	matched := mongoID()
+
+	flag := &model.AchievementDuplicateFlag{
+		ReferenceID:               ref.ID,
+		MongoAchievementID:        ref.MongoAchievementID,
+		MatchedMongoAchievementID: matched,
+		MatchedTitle:              "Mirip",
+		Reasons:                   []string{model.DuplicateReasonSimilarTitle},
+		Score:                     0.5,
+		Status:                    model.DuplicateFlagOpen,
+	}
+	require.NoError(t, repos.DuplicateFlags.Upsert(flag))
+	firstID := flag.ID
+	require.NotEmpty(t, firstID)
+
+	// deteksi ulang memperbarui flag yang sama
+	again := *flag
+	again.ID = ""
+	again.Reasons = []string{model.DuplicateReasonSimilarTitle, model.DuplicateReasonSameEvent}
+	again.Score = 0.9
+	require.NoError(t, repos.DuplicateFlags.Upsert(&again))
+	assert.Equal(t, firstID, again.ID)
+
+	open, err := repos.DuplicateFlags.FindOpenByReferenceIDs([]string{ref.ID})
+	require.NoError(t, err)
+	require.Len(t, open, 1)
+	assert.Len(t, open[0].Reasons, 2)
+	assert.InDelta(t, 0.9, open[0].Score, 0.001)
+
+	got, err := repos.DuplicateFlags.FindByID(firstID)
+	require.NoError(t, err)
+	now := time.Now()
+	got.Status = model.DuplicateFlagDismissed
+	got.ReviewedAt = &now
+	require.NoError(t, repos.DuplicateFlags.Save(got))
+
+	open, err = repos.DuplicateFlags.FindOpenByReferenceIDs([]string{ref.ID})
+	require.NoError(t, err)
+	assert.Empty(t, open)
+
+	dismissed := model.DuplicateFlagDismissed
+	flags, total, err := repos.DuplicateFlags.FindAll(&dismissed, 0, 1000)
+	require.NoError(t, err)
+	assert.Positive(t, total)
+	assert.Contains(t, ids(flags, func(f model.AchievementDuplicateFlag) string { return f.ID }), firstID)
+
+	// purge dokumen ikut menghapus flag
+	require.NoError(t, repos.AchievementReferences.PurgeByMongoID(ref.MongoAchievementID))
+	_, err = repos.DuplicateFlags.FindByID(firstID)
+	assert.Error(t, err)
+}
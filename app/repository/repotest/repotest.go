@@ -0,0 +1,138 @@
+// Package repotest: suite conformance untuk semua implementasi repository.
+// Suite yang sama dijalankan terhadap implementasi in-memory (package memory) dan Postgres/Mongo,
+// supaya perilaku keduanya (filter, urutan, constraint unik, cascade) tidak menyimpang.
+package repotest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+// Factory: repository siap pakai dengan role + permission bawaan (database.DefaultRoles) sudah ada.
+// Database boleh berisi data lain; semua fixture memakai nilai unik dan assertion tidak bergantung jumlah total.
+type Factory func(t *testing.T) *repository.Repositories
+
+// Run: jalankan seluruh suite conformance
+func Run(t *testing.T, newRepos Factory) {
+	t.Run("Users", func(t *testing.T) { testUsers(t, newRepos(t)) })
+	t.Run("Roles", func(t *testing.T) { testRoles(t, newRepos(t)) })
+	t.Run("Profiles", func(t *testing.T) { testProfiles(t, newRepos(t)) })
+	t.Run("Organizations", func(t *testing.T) { testOrganizations(t, newRepos(t)) })
+	t.Run("AcademicPeriods", func(t *testing.T) { testAcademicPeriods(t, newRepos(t)) })
+	t.Run("Achievements", func(t *testing.T) { testAchievements(t, newRepos(t)) })
+	t.Run("AchievementVersions", func(t *testing.T) { testAchievementVersions(t, newRepos(t)) })
+	t.Run("AchievementReferences", func(t *testing.T) { testAchievementReferences(t, newRepos(t)) })
+	t.Run("AchievementTeams", func(t *testing.T) { testAchievementTeams(t, newRepos(t)) })
+	t.Run("DuplicateFlags", func(t *testing.T) { testDuplicateFlags(t, newRepos(t)) })
+	t.Run("AdvisorAssignments", func(t *testing.T) { testAdvisorAssignments(t, newRepos(t)) })
+	t.Run("VerificationDelegations", func(t *testing.T) { testVerificationDelegations(t, newRepos(t)) })
+	t.Run("ImportJobs", func(t *testing.T) { testImportJobs(t, newRepos(t)) })
+	t.Run("ImportJobsFailStale", func(t *testing.T) { testImportJobsFailStale(t, newRepos(t)) })
+	t.Run("APITokens", func(t *testing.T) { testAPITokens(t, newRepos(t)) })
+	t.Run("AuditLogs", func(t *testing.T) { testAuditLogs(t, newRepos(t)) })
+	t.Run("LoginAudits", func(t *testing.T) { testLoginAudits(t, newRepos(t)) })
+	t.Run("LoginAttempts", func(t *testing.T) { testLoginAttempts(t, newRepos(t)) })
+	t.Run("PasswordResets", func(t *testing.T) { testPasswordResets(t, newRepos(t)) })
+	t.Run("TwoFactor", func(t *testing.T) { testTwoFactor(t, newRepos(t)) })
+	t.Run("UserIdentities", func(t *testing.T) { testUserIdentities(t, newRepos(t)) })
+	t.Run("OIDCStates", func(t *testing.T) { testOIDCStates(t, newRepos(t)) })
+	t.Run("SigningKeys", func(t *testing.T) { testSigningKeys(t, newRepos(t)) })
+}
+
+// ===== fixture =====
+
+// unique: akhiran pendek supaya username / NIM / kode unit tidak bentrok dengan data lain
+func unique() string {
+	return uuid.NewString()[:8]
+}
+
+// timeEqual: Postgres menyimpan presisi mikrodetik
+func timeEqual(t *testing.T, want, got time.Time) {
+	t.Helper()
+	require.WithinDuration(t, want, got, time.Millisecond)
+}
+
+func newUser(t *testing.T, repos *repository.Repositories, roleName string) *model.User {
+	t.Helper()
+	role, err := repos.Roles.FindByName(roleName)
+	require.NoError(t, err)
+
+	s := unique()
+	user := &model.User{
+		Username:     "u" + s,
+		Email:        s + "@repotest.local",
+		PasswordHash: "hash",
+		FullName:     "User " + s,
+		RoleID:       role.ID,
+		IsActive:     true,
+	}
+	require.NoError(t, repos.Users.Create(user))
+	require.NotEmpty(t, user.ID)
+	return user
+}
+
+func newLecturer(t *testing.T, repos *repository.Repositories) *model.Lecturer {
+	t.Helper()
+	role, err := repos.Roles.FindByName("Dosen Wali")
+	require.NoError(t, err)
+
+	s := unique()
+	user := &model.User{
+		Username: "d" + s, Email: "d" + s + "@repotest.local", PasswordHash: "hash",
+		FullName: "Dosen " + s, RoleID: role.ID, IsActive: true,
+	}
+	lecturer := &model.Lecturer{LecturerID: "D" + s, Department: "Teknik"}
+	require.NoError(t, repos.Profiles.SaveLecturerAccount(user, lecturer))
+	require.NotEmpty(t, lecturer.ID)
+	return lecturer
+}
+
+func newStudent(t *testing.T, repos *repository.Repositories, advisorID string) *model.Student {
+	t.Helper()
+	role, err := repos.Roles.FindByName("Mahasiswa")
+	require.NoError(t, err)
+
+	s := unique()
+	user := &model.User{
+		Username: "m" + s, Email: "m" + s + "@repotest.local", PasswordHash: "hash",
+		FullName: "Mahasiswa " + s, RoleID: role.ID, IsActive: true,
+	}
+	student := &model.Student{StudentID: "M" + s, ProgramStudy: "Informatika", AcademicYear: "2023", AdvisorID: advisorID}
+	require.NoError(t, repos.Profiles.SaveStudentAccount(user, student))
+	require.NotEmpty(t, student.ID)
+	return student
+}
+
+// newOrgUnits: fakultas -> jurusan -> prodi baru
+func newOrgUnits(t *testing.T, repos *repository.Repositories) (*model.Faculty, *model.Department, *model.StudyProgram) {
+	t.Helper()
+	s := unique()
+	f := &model.Faculty{Code: "F" + s, Name: "Fakultas " + s}
+	require.NoError(t, repos.Organizations.CreateFaculty(f))
+	d := &model.Department{FacultyID: f.ID, Code: "J" + s, Name: "Jurusan " + s}
+	require.NoError(t, repos.Organizations.CreateDepartment(d))
+	p := &model.StudyProgram{DepartmentID: d.ID, Code: "P" + s, Name: "Prodi " + s}
+	require.NoError(t, repos.Organizations.CreateStudyProgram(p))
+	return f, d, p
+}
+
+// academicYear: tahun akademik acak jauh di depan, tidak bentrok dengan periode lain
+func academicYear() (string, int) {
+	y := 3000 + int(uuid.New().ID()%5000)
+	return fmt.Sprintf("%d/%d", y, y+1), y
+}
+
+func ids[T any](rows []T, id func(T) string) []string {
+	out := make([]string, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, id(r))
+	}
+	return out
+}
@@ -0,0 +1,541 @@
+package repotest
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+func testAdvisorAssignments(t *testing.T, repos *repository.Repositories) {
+	oldAdvisor := newLecturer(t, repos)
+	newAdvisor := newLecturer(t, repos)
+	student := newStudent(t, repos, oldAdvisor.ID)
+
+	from := time.Now()
+	require.NoError(t, repos.AdvisorAssignments.Reassign([]model.AdvisorAssignment{
+		{StudentID: student.ID, LecturerID: newAdvisor.ID, EffectiveFrom: from, Reason: "cuti"},
+	}))
+
+	got, err := repos.Students.FindByID(student.ID)
+	require.NoError(t, err)
+	assert.Equal(t, newAdvisor.ID, got.AdvisorID)
+
+	history, err := repos.AdvisorAssignments.FindByStudentID(student.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2, "penugasan awal + penugasan baru")
+	assert.Equal(t, newAdvisor.ID, history[0].LecturerID)
+	assert.Nil(t, history[0].EffectiveTo)
+	require.NotNil(t, history[0].Lecturer)
+	assert.Equal(t, newAdvisor.LecturerID, history[0].Lecturer.LecturerID)
+	assert.Equal(t, oldAdvisor.ID, history[1].LecturerID)
+	require.NotNil(t, history[1].EffectiveTo)
+	timeEqual(t, from, *history[1].EffectiveTo)
+
+	// satu mahasiswa tidak valid membatalkan seluruh batch
+	other := newStudent(t, repos, oldAdvisor.ID)
+	err = repos.AdvisorAssignments.Reassign([]model.AdvisorAssignment{
+		{StudentID: other.ID, LecturerID: newAdvisor.ID, EffectiveFrom: from},
+		{StudentID: mongoIDAsUUID(), LecturerID: newAdvisor.ID, EffectiveFrom: from},
+	})
+	assert.Error(t, err)
+	got, err = repos.Students.FindByID(other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, oldAdvisor.ID, got.AdvisorID)
+}
+
+func testVerificationDelegations(t *testing.T, repos *repository.Repositories) {
+	from := newLecturer(t, repos)
+	to := newLecturer(t, repos)
+	now := time.Now()
+
+	d := &model.VerificationDelegation{
+		FromLecturerID: from.ID,
+		ToLecturerID:   to.ID,
+		StartsAt:       now.Add(-time.Hour),
+		EndsAt:         now.Add(24 * time.Hour),
+		Reason:         "dinas luar",
+	}
+	require.NoError(t, repos.VerificationDelegations.Create(d))
+	require.NotEmpty(t, d.ID)
+
+	active, err := repos.VerificationDelegations.FindActive(from.ID, "", now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{d.ID}, delegationIDs(active))
+	active, err = repos.VerificationDelegations.FindActive("", to.ID, now.Add(48*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, active)
+
+	mine, err := repos.VerificationDelegations.FindByLecturerID(to.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{d.ID}, delegationIDs(mine))
+
+	revoked, err := repos.VerificationDelegations.Revoke(d.ID, now)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	revoked, err = repos.VerificationDelegations.Revoke(d.ID, now)
+	require.NoError(t, err)
+	assert.False(t, revoked, "sudah dicabut")
+
+	active, err = repos.VerificationDelegations.FindActive(from.ID, "", now)
+	require.NoError(t, err)
+	assert.Empty(t, active)
+
+	got, err := repos.VerificationDelegations.FindByID(d.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, got.RevokedAt)
+}
+
+func delegationIDs(rows []model.VerificationDelegation) []string {
+	return ids(rows, func(d model.VerificationDelegation) string { return d.ID })
+}
+
+func testImportJobs(t *testing.T, repos *repository.Repositories) {
+	admin := newUser(t, repos, "Admin")
+	job := &model.ImportJob{FileName: "mahasiswa-" + unique() + ".csv", Status: model.ImportJobPending, CreatedBy: admin.ID}
+	require.NoError(t, repos.ImportJobs.Create(job))
+	require.NotEmpty(t, job.ID)
+
+	job.Status = model.ImportJobCompleted
+	job.TotalRows, job.FailedRows = 2, 1
+	job.Errors = []model.ImportRowError{{Row: 2, Message: "NIM kosong"}}
+	require.NoError(t, repos.ImportJobs.Save(job))
+
+	got, err := repos.ImportJobs.FindByID(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ImportJobCompleted, got.Status)
+	assert.Len(t, got.Errors, 1)
+
+	all, err := repos.ImportJobs.FindAll()
+	require.NoError(t, err)
+	for _, j := range all {
+		if j.ID == job.ID {
+			assert.Empty(t, j.Errors, "list tanpa detail error")
+			return
+		}
+	}
+	t.Fatalf("job %s tidak ada di FindAll", job.ID)
+}
+
+func testImportJobsFailStale(t *testing.T, repos *repository.Repositories) {
+	admin := newUser(t, repos, "Admin")
+	running := &model.ImportJob{FileName: "running-" + unique() + ".csv", Status: model.ImportJobRunning, CreatedBy: admin.ID}
+	require.NoError(t, repos.ImportJobs.Create(running))
+	done := &model.ImportJob{FileName: "done-" + unique() + ".csv", Status: model.ImportJobCompleted, CreatedBy: admin.ID}
+	require.NoError(t, repos.ImportJobs.Create(done))
+
+	// batas di masa lalu: job yang baru diperbarui tidak tersentuh
+	_, err := repos.ImportJobs.FailStale(time.Now().Add(-time.Hour), "interrupted")
+	require.NoError(t, err)
+	got, err := repos.ImportJobs.FindByID(running.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ImportJobRunning, got.Status)
+
+	n, err := repos.ImportJobs.FailStale(time.Now().Add(time.Minute), "interrupted")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, n, int64(1))
+
+	got, err = repos.ImportJobs.FindByID(running.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ImportJobFailed, got.Status)
+	assert.NotNil(t, got.FinishedAt)
+	require.Len(t, got.Errors, 1)
+	assert.Equal(t, "interrupted", got.Errors[0].Message)
+
+	// progress dari proses yang masih mengira job-nya jalan tidak menimpa status failed
+	running.Status, running.ProcessedRows = model.ImportJobRunning, 5
+	saved, err := repos.ImportJobs.SaveActive(running)
+	require.NoError(t, err)
+	assert.False(t, saved)
+	got, err = repos.ImportJobs.FindByID(running.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ImportJobFailed, got.Status)
+	assert.Equal(t, 0, got.ProcessedRows)
+
+	active := &model.ImportJob{FileName: "active-" + unique() + ".csv", Status: model.ImportJobPending, CreatedBy: admin.ID}
+	require.NoError(t, repos.ImportJobs.Create(active))
+	active.Status, active.ProcessedRows = model.ImportJobRunning, 3
+	saved, err = repos.ImportJobs.SaveActive(active)
+	require.NoError(t, err)
+	assert.True(t, saved)
+	got, err = repos.ImportJobs.FindByID(active.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ImportJobRunning, got.Status)
+	assert.Equal(t, 3, got.ProcessedRows)
+
+	got, err = repos.ImportJobs.FindByID(done.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ImportJobCompleted, got.Status)
+}
+
+func testAPITokens(t *testing.T, repos *repository.Repositories) {
+	owner := newUser(t, repos, "Admin")
+	perms, err := repos.Users.GetPermissionsByUserID(owner.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, perms)
+
+	hash := "hash-" + unique()
+	token := &model.APIToken{
+		UserID:      owner.ID,
+		Name:        "portal",
+		TokenPrefix: "pat_" + unique()[:4],
+		TokenHash:   hash,
+		Scopes:      perms[:1],
+		ExpiresAt:   time.Now().Add(24 * time.Hour),
+	}
+	require.NoError(t, repos.APITokens.Create(token))
+	require.NotEmpty(t, token.ID)
+
+	dup := *token
+	dup.ID = ""
+	assert.Error(t, repos.APITokens.Create(&dup), "token_hash unik")
+
+	got, err := repos.APITokens.FindByHash(hash)
+	require.NoError(t, err)
+	assert.Equal(t, token.ID, got.ID)
+	require.Len(t, got.Scopes, 1)
+	assert.Equal(t, perms[0].Name, got.Scopes[0].Name)
+
+	mine, err := repos.APITokens.FindByUserID(owner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{token.ID}, ids(mine, func(t model.APIToken) string { return t.ID }))
+
+	// last_used_at hanya diperbarui paling sering sekali per menit
+	used := time.Now().Truncate(time.Second)
+	require.NoError(t, repos.APITokens.TouchLastUsed(token.ID, "10.0.0.1", used))
+	require.NoError(t, repos.APITokens.TouchLastUsed(token.ID, "10.0.0.2", used.Add(10*time.Second)))
+	got, err = repos.APITokens.FindByID(token.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.LastUsedAt)
+	timeEqual(t, used, *got.LastUsedAt)
+	assert.Equal(t, "10.0.0.1", got.LastUsedIP)
+
+	revoked, err := repos.APITokens.Revoke(token.ID, time.Now())
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	revoked, err = repos.APITokens.Revoke(token.ID, time.Now())
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func testAuditLogs(t *testing.T, repos *repository.Repositories) {
+	actor := newUser(t, repos, "Admin")
+	requestID := "req-" + unique()
+
+	var lastSeq int64
+	for _, action := range []string{"POST /admin/users", "PUT /admin/users/:id/role"} {
+		entry := &model.AuditLog{
+			ActorID:    &actor.ID,
+			Action:     action,
+			TargetType: "user",
+			TargetID:   actor.ID,
+			StatusCode: 200,
+			RequestID:  requestID,
+		}
+		require.NoError(t, repos.AuditLogs.Append(entry, func(prev *model.AuditLog) {
+			entry.Seq = 1
+			if prev != nil {
+				entry.Seq = prev.Seq + 1
+				entry.PrevHash = prev.Hash
+			}
+			entry.Hash = "hash-" + unique()
+		}))
+		assert.Greater(t, entry.Seq, lastSeq, "seq naik tanpa bercabang")
+		lastSeq = entry.Seq
+	}
+
+	entries, total, err := repos.AuditLogs.FindAll(model.AuditLogFilter{RequestID: requestID}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, entries, 2)
+	assert.Equal(t, lastSeq, entries[0].Seq, "terbaru di depan")
+
+	entries, total, err = repos.AuditLogs.FindAll(model.AuditLogFilter{RequestID: requestID, Action: "/ROLE"}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "PUT /admin/users/:id/role", entries[0].Action)
+
+	future := time.Now().Add(time.Hour)
+	_, total, err = repos.AuditLogs.FindAll(model.AuditLogFilter{RequestID: requestID, From: &future}, 0, 10)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+
+	chain, err := repos.AuditLogs.FindChain(lastSeq-2, 10)
+	require.NoError(t, err)
+	require.Len(t, chain, 2)
+	assert.Equal(t, lastSeq-1, chain[0].Seq)
+	assert.Equal(t, chain[0].Hash, chain[1].PrevHash)
+}
+
+func testLoginAudits(t *testing.T, repos *repository.Repositories) {
+	username := "Login-" + unique()
+	for _, success := range []bool{false, true} {
+		require.NoError(t, repos.LoginAudits.Create(&model.LoginAudit{
+			Username:  username,
+			IPAddress: "127.0.0.1",
+			Success:   success,
+		}))
+		time.Sleep(time.Millisecond)
+	}
+
+	entries, total, err := repos.LoginAudits.FindAll(model.LoginAuditFilter{Username: username}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, entries, 2)
+	assert.True(t, entries[0].Success, "terbaru di depan")
+
+	failed := false
+	entries, total, err = repos.LoginAudits.FindAll(model.LoginAuditFilter{Username: "login-" + username[6:], Success: &failed}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total, "username tidak peka huruf besar")
+	assert.False(t, entries[0].Success)
+}
+
+func testLoginAttempts(t *testing.T, repos *repository.Repositories) {
+	store := repos.LoginAttempts
+	window := 15 * time.Minute
+	now := time.Now().Truncate(time.Microsecond)
+	user := model.LoginThrottleUserPrefix + unique()
+
+	got, err := store.Get(user)
+	require.NoError(t, err)
+	assert.Nil(t, got, "belum pernah gagal")
+
+	// gagal di dalam window menambah counter, window_start tetap
+	for i := 1; i <= 3; i++ {
+		got, err = store.RegisterFailure(user, now.Add(time.Duration(i)*time.Minute), window)
+		require.NoError(t, err)
+		assert.Equal(t, i, got.Failures)
+		timeEqual(t, now.Add(time.Minute), got.WindowStart)
+		timeEqual(t, now.Add(time.Duration(i)*time.Minute), got.LastFailureAt)
+	}
+
+	// gagal setelah window lewat dimulai ulang dari 1
+	later := now.Add(time.Minute + window + time.Second)
+	got, err = store.RegisterFailure(user, later, window)
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.Failures)
+	timeEqual(t, later, got.WindowStart)
+
+	// lock mempertahankan counter; key tanpa riwayat gagal juga bisa dikunci
+	ip := model.LoginThrottleIPPrefix + unique()
+	require.NoError(t, store.Lock(user, now.Add(time.Hour)))
+	require.NoError(t, store.Lock(ip, now.Add(2*time.Hour)))
+	got, err = store.Get(user)
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.Failures)
+	require.NotNil(t, got.LockedUntil)
+	timeEqual(t, now.Add(time.Hour), *got.LockedUntil)
+
+	// lock yang sudah lewat tidak ikut
+	expired := model.LoginThrottleUserPrefix + unique()
+	require.NoError(t, store.Lock(expired, now.Add(-time.Minute)))
+
+	locked, err := store.FindLocked(now)
+	require.NoError(t, err)
+	var keys []string
+	for _, l := range locked {
+		if l.Key == user || l.Key == ip || l.Key == expired {
+			keys = append(keys, l.Key)
+		}
+	}
+	assert.Equal(t, []string{ip, user}, keys, "yang terkunci paling lama di depan")
+
+	// reset menghapus counter dan lock
+	require.NoError(t, store.Reset(user))
+	got, err = store.Get(user)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	got, err = store.RegisterFailure(user, later, window)
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.Failures)
+	assert.Nil(t, got.LockedUntil)
+}
+
+func testPasswordResets(t *testing.T, repos *repository.Repositories) {
+	user := newUser(t, repos, "Mahasiswa")
+	now := time.Now()
+
+	valid := &model.PasswordResetToken{UserID: user.ID, TokenHash: "reset-" + unique(), ExpiresAt: now.Add(time.Hour)}
+	expired := &model.PasswordResetToken{UserID: user.ID, TokenHash: "reset-" + unique(), ExpiresAt: now.Add(-time.Minute)}
+	require.NoError(t, repos.PasswordResets.Create(valid))
+	require.NoError(t, repos.PasswordResets.Create(expired))
+
+	got, err := repos.PasswordResets.FindValidByHash(valid.TokenHash, now)
+	require.NoError(t, err)
+	assert.Equal(t, valid.ID, got.ID)
+	_, err = repos.PasswordResets.FindValidByHash(expired.TokenHash, now)
+	assert.Error(t, err)
+
+	ok, err := repos.PasswordResets.Consume(valid.ID, now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = repos.PasswordResets.Consume(valid.ID, now)
+	require.NoError(t, err)
+	assert.False(t, ok, "sekali pakai")
+	_, err = repos.PasswordResets.FindValidByHash(valid.TokenHash, now)
+	assert.Error(t, err)
+
+	another := &model.PasswordResetToken{UserID: user.ID, TokenHash: "reset-" + unique(), ExpiresAt: now.Add(time.Hour)}
+	require.NoError(t, repos.PasswordResets.Create(another))
+	require.NoError(t, repos.PasswordResets.InvalidateByUserID(user.ID, now))
+	_, err = repos.PasswordResets.FindValidByHash(another.TokenHash, now)
+	assert.Error(t, err)
+}
+
+func testTwoFactor(t *testing.T, repos *repository.Repositories) {
+	user := newUser(t, repos, "Admin")
+
+	tf, err := repos.TwoFactor.FindByUserID(user.ID)
+	require.NoError(t, err)
+	assert.Nil(t, tf)
+
+	require.NoError(t, repos.TwoFactor.Save(&model.UserTwoFactor{UserID: user.ID, Secret: "SECRET"}))
+	require.NoError(t, repos.TwoFactor.Enable(user.ID, 100, []string{"c1", "c2"}))
+
+	tf, err = repos.TwoFactor.FindByUserID(user.ID)
+	require.NoError(t, err)
+	assert.True(t, tf.Enabled)
+	assert.NotNil(t, tf.ConfirmedAt)
+	got, err := repos.Users.FindByID(user.ID)
+	require.NoError(t, err)
+	assert.True(t, got.TwoFactorEnabled)
+
+	ok, err := repos.TwoFactor.UseStep(user.ID, 100)
+	require.NoError(t, err)
+	assert.False(t, ok, "step yang sama tidak bisa dipakai ulang")
+	ok, err = repos.TwoFactor.UseStep(user.ID, 101)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = repos.TwoFactor.ConsumeRecoveryCode(user.ID, "c1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = repos.TwoFactor.ConsumeRecoveryCode(user.ID, "c1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	n, err := repos.TwoFactor.CountUnusedRecoveryCodes(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	require.NoError(t, repos.TwoFactor.ReplaceRecoveryCodes(user.ID, []string{"c3", "c4", "c5"}))
+	n, err = repos.TwoFactor.CountUnusedRecoveryCodes(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	require.NoError(t, repos.TwoFactor.Delete(user.ID))
+	tf, err = repos.TwoFactor.FindByUserID(user.ID)
+	require.NoError(t, err)
+	assert.Nil(t, tf)
+	n, err = repos.TwoFactor.CountUnusedRecoveryCodes(user.ID)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	got, err = repos.Users.FindByID(user.ID)
+	require.NoError(t, err)
+	assert.False(t, got.TwoFactorEnabled)
+}
+
+func testUserIdentities(t *testing.T, repos *repository.Repositories) {
+	user := newUser(t, repos, "Mahasiswa")
+	subject := "sub-" + unique()
+
+	got, err := repos.UserIdentities.FindByProviderSubject("oidc", subject)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	identity := &model.UserIdentity{UserID: user.ID, Provider: "oidc", Subject: subject, Email: user.Email}
+	require.NoError(t, repos.UserIdentities.Create(identity))
+	assert.Error(t, repos.UserIdentities.Create(&model.UserIdentity{UserID: user.ID, Provider: "oidc", Subject: subject}), "(provider, subject) unik")
+
+	at := time.Now().Truncate(time.Second)
+	require.NoError(t, repos.UserIdentities.TouchLastLogin(identity.ID, at))
+	got, err = repos.UserIdentities.FindByProviderSubject("oidc", subject)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, got.UserID)
+	require.NotNil(t, got.LastLoginAt)
+	timeEqual(t, at, *got.LastLoginAt)
+}
+
+func testOIDCStates(t *testing.T, repos *repository.Repositories) {
+	now := time.Now()
+	live := &model.OIDCLoginState{State: "st-" + unique(), Nonce: "n", CodeVerifier: "v", ExpiresAt: now.Add(5 * time.Minute)}
+	stale := &model.OIDCLoginState{State: "st-" + unique(), Nonce: "n", CodeVerifier: "v", ExpiresAt: now.Add(-time.Minute)}
+	require.NoError(t, repos.OIDCStates.Create(live))
+	require.NoError(t, repos.OIDCStates.Create(stale))
+
+	got, err := repos.OIDCStates.Consume(live.State, now)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "v", got.CodeVerifier)
+
+	got, err = repos.OIDCStates.Consume(live.State, now)
+	require.NoError(t, err)
+	assert.Nil(t, got, "sekali pakai")
+
+	got, err = repos.OIDCStates.Consume(stale.State, now)
+	require.NoError(t, err)
+	assert.Nil(t, got, "kadaluarsa")
+
+	other := &model.OIDCLoginState{State: "st-" + unique(), Nonce: "n", CodeVerifier: "v", ExpiresAt: now.Add(-time.Minute)}
+	require.NoError(t, repos.OIDCStates.Create(other))
+	require.NoError(t, repos.OIDCStates.DeleteExpired(now))
+	got, err = repos.OIDCStates.Consume(other.State, now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Nil(t, got, "sudah dibersihkan")
+}
+
+func testSigningKeys(t *testing.T, repos *repository.Repositories) {
+	older := &model.SigningKey{KID: "kid-" + unique(), Algorithm: "EdDSA", PrivateKey: "pem-1", CreatedAt: time.Now().Add(-time.Hour)}
+	newer := &model.SigningKey{KID: "kid-" + unique(), Algorithm: "EdDSA", PrivateKey: "pem-2", CreatedAt: time.Now()}
+	require.NoError(t, repos.SigningKeys.SaveKey(older))
+	require.NoError(t, repos.SigningKeys.SaveKey(newer))
+
+	// simpan ulang kid yang sama hanya memperbarui retired_at / expires_at
+	retired := time.Now().Truncate(time.Second)
+	require.NoError(t, repos.SigningKeys.SaveKey(&model.SigningKey{
+		KID: older.KID, Algorithm: "EdDSA", PrivateKey: "diabaikan", CreatedAt: time.Now(), RetiredAt: &retired,
+	}))
+
+	keys, err := repos.SigningKeys.LoadKeys()
+	require.NoError(t, err)
+	kids := ids(keys, func(k model.SigningKey) string { return k.KID })
+	require.GreaterOrEqual(t, indexOf(kids, newer.KID), 0)
+	require.Less(t, indexOf(kids, newer.KID), indexOf(kids, older.KID), "terbaru di depan")
+
+	got := keys[indexOf(kids, older.KID)]
+	assert.Equal(t, "pem-1", got.PrivateKey)
+	require.NotNil(t, got.RetiredAt)
+	timeEqual(t, retired, *got.RetiredAt)
+
+	require.NoError(t, repos.SigningKeys.DeleteKey(older.KID))
+	require.NoError(t, repos.SigningKeys.DeleteKey(newer.KID))
+	keys, err = repos.SigningKeys.LoadKeys()
+	require.NoError(t, err)
+	assert.NotContains(t, ids(keys, func(k model.SigningKey) string { return k.KID }), older.KID)
+
+	// WithLock: pemegang lock tidak pernah tumpang tindih
+	var holders, overlap int32
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, repos.SigningKeys.WithLock(func() error {
+				if atomic.AddInt32(&holders, 1) > 1 {
+					atomic.StoreInt32(&overlap, 1)
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&holders, -1)
+				return nil
+			}))
+		}()
+	}
+	wg.Wait()
+	assert.Zero(t, atomic.LoadInt32(&overlap))
+}
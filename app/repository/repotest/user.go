@@ -0,0 +1,329 @@
+package repotest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+func testUsers(t *testing.T, repos *repository.Repositories) {
+	user := newUser(t, repos, "Mahasiswa")
+
+	byName, err := repos.Users.FindByUsernameOrEmail(user.Username)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, byName.ID)
+	assert.Equal(t, "Mahasiswa", byName.Role.Name)
+
+	byEmail, err := repos.Users.FindByUsernameOrEmail(user.Email)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, byEmail.ID)
+
+	_, err = repos.Users.FindByUsernameOrEmail("tidak-ada-" + unique())
+	assert.Error(t, err)
+
+	dup := &model.User{Username: user.Username, Email: unique() + "@repotest.local", PasswordHash: "x", FullName: "x", RoleID: user.RoleID, IsActive: true}
+	assert.Error(t, repos.Users.Create(dup), "username unik")
+
+	perms, err := repos.Users.GetPermissionsByUserID(user.ID)
+	require.NoError(t, err)
+	names := ids(perms, func(p model.Permission) string { return p.Name })
+	assert.Contains(t, names, "achievement:create")
+	assert.NotContains(t, names, "user:manage")
+
+	admin, err := repos.Roles.FindByName("Admin")
+	require.NoError(t, err)
+	roleChangedAt := time.Now().Truncate(time.Second)
+	require.NoError(t, repos.Users.UpdateRole(user.ID, admin.ID, roleChangedAt))
+	got, err := repos.Users.FindByID(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Admin", got.Role.Name)
+	require.NotNil(t, got.SessionsRevokedAt, "token dengan role lama dicabut")
+	assert.True(t, roleChangedAt.Equal(*got.SessionsRevokedAt))
+
+	changedAt := time.Now().Truncate(time.Second)
+	require.NoError(t, repos.Users.UpdatePassword(user.ID, "hash-baru", true, changedAt))
+	require.NoError(t, repos.Users.UpdateAuthBackend(user.ID, "ldap"))
+	got, err = repos.Users.FindByID(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "hash-baru", got.PasswordHash)
+	assert.True(t, got.MustChangePassword)
+	require.NotNil(t, got.PasswordChangedAt)
+	require.NotNil(t, got.SessionsRevokedAt, "ganti password mencabut sesi lama")
+	timeEqual(t, changedAt, *got.SessionsRevokedAt)
+	assert.False(t, got.SessionValid(changedAt.Add(-time.Second)))
+	assert.True(t, got.SessionValid(changedAt))
+	assert.Equal(t, "ldap", got.AuthBackend)
+
+	at := time.Now().Truncate(time.Second)
+	require.NoError(t, repos.Users.SetActive(user.ID, false, at))
+	got, err = repos.Users.FindByID(user.ID)
+	require.NoError(t, err)
+	assert.False(t, got.IsActive)
+	require.NotNil(t, got.DeactivatedAt)
+	require.NotNil(t, got.SessionsRevokedAt)
+	timeEqual(t, at, *got.DeactivatedAt)
+
+	require.NoError(t, repos.Users.SetActive(user.ID, true, at))
+	got, err = repos.Users.FindByID(user.ID)
+	require.NoError(t, err)
+	assert.True(t, got.IsActive)
+	assert.Nil(t, got.DeactivatedAt)
+	assert.NotNil(t, got.SessionsRevokedAt, "sesi lama tetap dicabut")
+
+	f, d, _ := newOrgUnits(t, repos)
+	scopeChangedAt := time.Now().Add(time.Minute).Truncate(time.Second)
+	require.NoError(t, repos.Users.UpdateScope(user.ID, &f.ID, &d.ID, scopeChangedAt))
+	got, err = repos.Users.FindByID(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &f.ID, got.ScopeFacultyID)
+	assert.Equal(t, &d.ID, got.ScopeDepartmentID)
+	require.NotNil(t, got.SessionsRevokedAt, "token dengan scope lama dicabut")
+	assert.True(t, scopeChangedAt.Equal(*got.SessionsRevokedAt))
+
+	got.FullName = "Nama Baru"
+	require.NoError(t, repos.Users.Update(got))
+	got, err = repos.Users.FindByID(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Nama Baru", got.FullName)
+
+	svc := newUser(t, repos, "Admin")
+	svc.IsServiceAccount = true
+	require.NoError(t, repos.Users.Update(svc))
+	accounts, err := repos.Users.FindServiceAccounts()
+	require.NoError(t, err)
+	accountIDs := ids(accounts, func(u model.User) string { return u.ID })
+	assert.Contains(t, accountIDs, svc.ID)
+	assert.NotContains(t, accountIDs, user.ID)
+
+	all, err := repos.Users.FindAll()
+	require.NoError(t, err)
+	assert.Subset(t, ids(all, func(u model.User) string { return u.ID }), []string{user.ID, svc.ID})
+}
+
+func testRoles(t *testing.T, repos *repository.Repositories) {
+	roles, err := repos.Roles.FindAll()
+	require.NoError(t, err)
+	assert.Subset(t, ids(roles, func(r model.Role) string { return r.Name }), []string{"Admin", "Mahasiswa", "Dosen Wali"})
+
+	role, err := repos.Roles.FindByName("Dosen Wali")
+	require.NoError(t, err)
+	byID, err := repos.Roles.FindByID(role.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Dosen Wali", byID.Name)
+
+	_, err = repos.Roles.FindByName("Tidak Ada " + unique())
+	assert.Error(t, err)
+
+	require.NoError(t, repos.Roles.UpdateRequireTwoFactor(role.ID, true))
+	byID, err = repos.Roles.FindByID(role.ID)
+	require.NoError(t, err)
+	assert.True(t, byID.RequireTwoFactor)
+	require.NoError(t, repos.Roles.UpdateRequireTwoFactor(role.ID, false))
+}
+
+func testProfiles(t *testing.T, repos *repository.Repositories) {
+	lecturer := newLecturer(t, repos)
+	student := newStudent(t, repos, lecturer.ID)
+
+	got, err := repos.Students.FindByNIM(student.StudentID)
+	require.NoError(t, err)
+	assert.Equal(t, student.ID, got.ID)
+	assert.Equal(t, student.UserID, got.User.ID)
+
+	byUser, err := repos.Students.FindByUserID(student.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, student.ID, byUser.ID)
+
+	advisees, err := repos.Students.FindByAdvisorID(lecturer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{student.ID}, ids(advisees, func(s model.Student) string { return s.ID }))
+
+	byLecturer, err := repos.Lecturers.FindByNIDN(lecturer.LecturerID)
+	require.NoError(t, err)
+	assert.Equal(t, lecturer.ID, byLecturer.ID)
+	assert.Equal(t, "Dosen Wali", byLecturer.User.Role.Name)
+
+	// NIM unik
+	role, err := repos.Roles.FindByName("Mahasiswa")
+	require.NoError(t, err)
+	s := unique()
+	dupUser := &model.User{Username: "m" + s, Email: "m" + s + "@repotest.local", PasswordHash: "x", FullName: "x", RoleID: role.ID, IsActive: true}
+	assert.Error(t, repos.Profiles.SaveStudentAccount(dupUser, &model.Student{StudentID: student.StudentID}))
+
+	// update profil lewat akun yang sama
+	_, _, program := newOrgUnits(t, repos)
+	got.StudyProgramID = &program.ID
+	got.ProgramStudy = program.Name
+	user := got.User
+	require.NoError(t, repos.Profiles.SaveStudentAccount(&user, got))
+	inProgram, err := repos.Students.FindByStudyProgramID(program.ID)
+	require.NoError(t, err)
+	require.Len(t, inProgram, 1)
+	assert.Equal(t, student.ID, inProgram[0].ID)
+
+	anon := model.AnonymizedAccount{
+		Username: "anon-" + s, Email: "anon-" + s + "@repotest.local", FullName: "Anonim",
+		PasswordHash: "-", ProfileNumber: "X" + s, At: time.Now(),
+	}
+	// jejak login (termasuk gagal tanpa user_id) dan audit log milik user ikut dipseudonimkan
+	require.NoError(t, repos.LoginAudits.Create(&model.LoginAudit{
+		UserID: &student.UserID, Username: got.User.Username, IPAddress: "10.0.0.1", UserAgent: "curl", Success: true,
+	}))
+	require.NoError(t, repos.LoginAudits.Create(&model.LoginAudit{
+		Username: strings.ToUpper(got.User.Email), IPAddress: "10.0.0.2", UserAgent: "curl", Reason: "invalid_password",
+	}))
+	requestID := "req-" + s
+	entry := &model.AuditLog{ActorID: &student.UserID, ActorUsername: got.User.Username, Action: "PUT /profile", StatusCode: 200, RequestID: requestID}
+	require.NoError(t, repos.AuditLogs.Append(entry, func(prev *model.AuditLog) {
+		entry.Seq = 1
+		if prev != nil {
+			entry.Seq, entry.PrevHash = prev.Seq+1, prev.Hash
+		}
+		entry.Hash = "hash-" + unique()
+	}))
+
+	require.NoError(t, repos.Profiles.AnonymizeAccount(student.UserID, anon))
+
+	logins, _, err := repos.LoginAudits.FindAll(model.LoginAuditFilter{Username: anon.Username}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, logins, 2)
+	for _, l := range logins {
+		assert.Empty(t, l.IPAddress)
+		assert.Empty(t, l.UserAgent)
+	}
+	audits, _, err := repos.AuditLogs.FindAll(model.AuditLogFilter{RequestID: requestID}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, audits, 1)
+	assert.Equal(t, anon.Username, audits[0].ActorUsername)
+	assert.Equal(t, entry.Hash, audits[0].Hash)
+
+	got, err = repos.Students.FindByID(student.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "X"+s, got.StudentID)
+	assert.Equal(t, "Anonim", got.User.FullName)
+	assert.False(t, got.User.IsActive)
+	assert.NotNil(t, got.User.AnonymizedAt)
+	assert.Equal(t, lecturer.ID, got.AdvisorID, "dosen wali tetap")
+}
+
+func testOrganizations(t *testing.T, repos *repository.Repositories) {
+	f, d, p := newOrgUnits(t, repos)
+
+	assert.Error(t, repos.Organizations.CreateFaculty(&model.Faculty{Code: f.Code, Name: "Duplikat"}), "kode unik")
+
+	depts, err := repos.Organizations.FindDepartments(f.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{d.ID}, ids(depts, func(d model.Department) string { return d.ID }))
+
+	programs, err := repos.Organizations.FindStudyPrograms(d.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{p.ID}, ids(programs, func(p model.StudyProgram) string { return p.ID }))
+
+	got, err := repos.Organizations.FindStudyProgramByID(p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, p.Code, got.Code)
+
+	// teks prodi lama tanpa FK
+	student := newStudent(t, repos, "")
+	student.ProgramStudy = "Prodi Lama " + unique()
+	user, err := repos.Users.FindByID(student.UserID)
+	require.NoError(t, err)
+	require.NoError(t, repos.Profiles.SaveStudentAccount(user, student))
+
+	unmapped, err := repos.Organizations.UnmappedStudentPrograms()
+	require.NoError(t, err)
+	assert.Contains(t, unmapped, model.UnitValueCount{Value: student.ProgramStudy, Count: 1})
+
+	student.StudyProgramID = &p.ID
+	require.NoError(t, repos.Profiles.SaveStudentAccount(user, student))
+	unmapped, err = repos.Organizations.UnmappedStudentPrograms()
+	require.NoError(t, err)
+	assert.NotContains(t, unmapped, model.UnitValueCount{Value: student.ProgramStudy, Count: 1})
+	count, err := repos.Organizations.CountStudentsByProgram(p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// prodi yang masih dipakai mahasiswa tidak bisa dihapus
+	assert.Error(t, repos.Organizations.DeleteStudyProgram(p.ID))
+
+	empty := &model.StudyProgram{DepartmentID: d.ID, Code: "E" + unique(), Name: "Kosong"}
+	require.NoError(t, repos.Organizations.CreateStudyProgram(empty))
+	require.NoError(t, repos.Organizations.DeleteStudyProgram(empty.ID))
+	_, err = repos.Organizations.FindStudyProgramByID(empty.ID)
+	assert.Error(t, err)
+
+	f.Name = "Fakultas Baru"
+	require.NoError(t, repos.Organizations.SaveFaculty(f))
+	gotF, err := repos.Organizations.FindFacultyByID(f.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Fakultas Baru", gotF.Name)
+}
+
+func testAcademicPeriods(t *testing.T, repos *repository.Repositories) {
+	year, y := academicYear()
+	start := time.Date(y, 8, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(y+1, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	p := &model.AcademicPeriod{AcademicYear: year, Semester: model.SemesterGanjil, StartDate: start, EndDate: end}
+	require.NoError(t, repos.AcademicPeriods.Create(p))
+	require.NotEmpty(t, p.ID)
+
+	dup := &model.AcademicPeriod{AcademicYear: year, Semester: model.SemesterGanjil, StartDate: start, EndDate: end}
+	assert.Error(t, repos.AcademicPeriods.Create(dup), "(tahun, semester) unik")
+
+	got, err := repos.AcademicPeriods.FindByDate(time.Date(y, 10, 15, 13, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, p.ID, got.ID)
+
+	overlap, err := repos.AcademicPeriods.FindOverlapping(end, end.AddDate(0, 3, 0), "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{p.ID}, ids(overlap, func(p model.AcademicPeriod) string { return p.ID }))
+	overlap, err = repos.AcademicPeriods.FindOverlapping(end, end.AddDate(0, 3, 0), p.ID)
+	require.NoError(t, err)
+	assert.Empty(t, overlap)
+
+	genap := &model.AcademicPeriod{AcademicYear: year, Semester: model.SemesterGenap, StartDate: end.AddDate(0, 0, 1), EndDate: end.AddDate(0, 6, 0)}
+	require.NoError(t, repos.AcademicPeriods.Create(genap))
+
+	require.NoError(t, repos.AcademicPeriods.SetActive(p.ID))
+	require.NoError(t, repos.AcademicPeriods.SetActive(genap.ID))
+	active, err := repos.AcademicPeriods.FindActive()
+	require.NoError(t, err)
+	assert.Equal(t, genap.ID, active.ID, "hanya satu periode aktif")
+	got, err = repos.AcademicPeriods.FindByID(p.ID)
+	require.NoError(t, err)
+	assert.False(t, got.IsActive)
+
+	all, err := repos.AcademicPeriods.FindAll()
+	require.NoError(t, err)
+	allIDs := ids(all, func(p model.AcademicPeriod) string { return p.ID })
+	assert.Less(t, indexOf(allIDs, genap.ID), indexOf(allIDs, p.ID), "mulai terbaru di depan")
+
+	// reference yang memakai periode tetap ada, period_id dikosongkan
+	student := newStudent(t, repos, "")
+	ref, err := repos.AchievementReferences.CreateDraft(student.ID, mongoID(), &p.ID)
+	require.NoError(t, err)
+	require.NoError(t, repos.AcademicPeriods.Delete(p.ID))
+	_, err = repos.AcademicPeriods.FindByID(p.ID)
+	assert.Error(t, err)
+	gotRef, err := repos.AchievementReferences.GetByID(ref.ID)
+	require.NoError(t, err)
+	assert.Nil(t, gotRef.PeriodID)
+
+	require.NoError(t, repos.AcademicPeriods.Delete(genap.ID))
+}
+
+func indexOf(list []string, v string) int {
+	for i, e := range list {
+		if e == v {
+			return i
+		}
+	}
+	return -1
+}
@@ -9,6 +9,8 @@ import (
 type RoleRepository interface {
 	FindAll() ([]model.Role, error)
 	FindByID(id string) (*model.Role, error)
+	FindByName(name string) (*model.Role, error)
+	UpdateRequireTwoFactor(id string, required bool) error
 }
 
 type roleRepository struct {
@@ -33,3 +35,16 @@ func (r *roleRepository) FindByID(id string) (*model.Role, error) {
 	}
 	return &role, nil
 }
+func (r *roleRepository) FindByName(name string) (*model.Role, error) {
+	var role model.Role
+	if err := r.db.Where("name = ?", name).First(&role).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) UpdateRequireTwoFactor(id string, required bool) error {
+	return r.db.Model(&model.Role{}).
+		Where("id = ?", id).
+		Update("require_two_factor", required).Error
+}
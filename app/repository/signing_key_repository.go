@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// kunci advisory Postgres untuk rotasi kunci JWT (lihat auditChainLockID)
+const signingKeyLockID = 41_0002
+
+// SigningKeyRepository: kunci JWT di Postgres, dipakai bersama semua instance (memenuhi utils.KeyStore)
+type SigningKeyRepository interface {
+	LoadKeys() ([]model.SigningKey, error)
+	SaveKey(key *model.SigningKey) error
+	DeleteKey(kid string) error
+	// WithLock: fn dijalankan selama transaksi pemegang advisory lock rotasi terbuka
+	WithLock(fn func() error) error
+}
+
+type signingKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewSigningKeyRepository(db *gorm.DB) SigningKeyRepository {
+	return &signingKeyRepository{db: db}
+}
+
+func (r *signingKeyRepository) LoadKeys() ([]model.SigningKey, error) {
+	var keys []model.SigningKey
+	err := r.db.Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+func (r *signingKeyRepository) SaveKey(key *model.SigningKey) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "kid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"retired_at", "expires_at"}),
+	}).Create(key).Error
+}
+
+func (r *signingKeyRepository) DeleteKey(kid string) error {
+	return r.db.Delete(&model.SigningKey{}, "kid = ?", kid).Error
+}
+
+// WithLock: lock dilepas saat transaksi selesai; fn memakai koneksi lain, jadi tulisannya
+// sudah terlihat instance berikut yang mendapat lock
+func (r *signingKeyRepository) WithLock(fn func() error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", signingKeyLockID).Error; err != nil {
+			return err
+		}
+		return fn()
+	})
+}
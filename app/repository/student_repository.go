@@ -7,11 +7,13 @@ import (
 
 type StudentRepository interface {
 	FindAll() ([]model.Student, error)
+	FindAllInScope(scope model.UnitScope) ([]model.Student, error)
 	FindByUserID(userID string) (*model.Student, error)
 	FindByID(id string) (*model.Student, error)
 	FindByAdvisorLecturerID(lecturerID string) ([]model.Student, error)
-	UpdateAdvisor(studentID, lecturerID string) error
 	FindByAdvisorID(advisorID string) ([]model.Student, error)
+	FindByNIM(nim string) (*model.Student, error)
+	FindByStudyProgramID(programID string) ([]model.Student, error)
 }
 
 type studentRepository struct {
@@ -36,6 +38,7 @@ func (r *studentRepository) FindByID(id string) (*model.Student, error) {
 	var student model.Student
 	if err := r.db.
 		Preload("User.Role").
+		Preload("StudyProgram.Department").
 		Where("id = ?", id).
 		First(&student).Error; err != nil {
 		return nil, err
@@ -49,19 +52,20 @@ func (r *studentRepository) FindByAdvisorLecturerID(lecturerID string) ([]model.
     }
     return students, nil
 }
-func (r *studentRepository) UpdateAdvisor(studentID, lecturerID string) error {
-	return r.db.
-		Model(&model.Student{}).
-		Where("id = ?", studentID).
-		Update("advisor_id", lecturerID).
-		Error
-}
 func (r *studentRepository) FindAll() ([]model.Student, error) {
 	var students []model.Student
 	err := r.db.Preload("User").Find(&students).Error
 	return students, err
 }
 
+// FindAllInScope: mahasiswa dalam unit admin fakultas/jurusan (scope kosong = semua)
+func (r *studentRepository) FindAllInScope(scope model.UnitScope) ([]model.Student, error) {
+	var students []model.Student
+	q := r.db.Preload("User").Preload("StudyProgram.Department")
+	err := applyStudentScope(q, scope, "students.id").Find(&students).Error
+	return students, err
+}
+
 func (r *studentRepository) FindByAdvisorID(advisorID string) ([]model.Student, error) {
 	var students []model.Student
 	err := r.db.
@@ -71,3 +75,22 @@ func (r *studentRepository) FindByAdvisorID(advisorID string) ([]model.Student,
 	return students, err
 }
 
+func (r *studentRepository) FindByNIM(nim string) (*model.Student, error) {
+	var student model.Student
+	if err := r.db.
+		Preload("User.Role").
+		Where("student_id = ?", nim).
+		First(&student).Error; err != nil {
+		return nil, err
+	}
+	return &student, nil
+}
+
+func (r *studentRepository) FindByStudyProgramID(programID string) ([]model.Student, error) {
+	var students []model.Student
+	err := r.db.
+		Preload("User").
+		Where("study_program_id = ?", programID).
+		Find(&students).Error
+	return students, err
+}
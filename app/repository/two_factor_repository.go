@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+)
+
+type TwoFactorRepository interface {
+	// FindByUserID: nil, nil kalau user belum pernah setup 2FA
+	FindByUserID(userID string) (*model.UserTwoFactor, error)
+	Save(tf *model.UserTwoFactor) error
+	// Enable: aktifkan 2FA + ganti semua kode cadangan (satu transaksi)
+	Enable(userID string, step int64, codeHashes []string) error
+	// Delete: hapus secret + kode cadangan, 2FA user jadi nonaktif
+	Delete(userID string) error
+	ReplaceRecoveryCodes(userID string, codeHashes []string) error
+	// ConsumeRecoveryCode: false kalau kode tidak ada / sudah dipakai
+	ConsumeRecoveryCode(userID, codeHash string) (bool, error)
+	CountUnusedRecoveryCodes(userID string) (int64, error)
+	// UseStep: false kalau step <= step terakhir (kode dipakai ulang)
+	UseStep(userID string, step int64) (bool, error)
+}
+
+type twoFactorRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorRepository(db *gorm.DB) TwoFactorRepository {
+	return &twoFactorRepository{db: db}
+}
+
+func (r *twoFactorRepository) FindByUserID(userID string) (*model.UserTwoFactor, error) {
+	var tf model.UserTwoFactor
+	err := r.db.First(&tf, "user_id = ?", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tf, nil
+}
+
+func (r *twoFactorRepository) Save(tf *model.UserTwoFactor) error {
+	return r.db.Save(tf).Error
+}
+
+func (r *twoFactorRepository) Enable(userID string, step int64, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(&model.UserTwoFactor{}).
+			Where("user_id = ?", userID).
+			Updates(map[string]any{
+				"enabled":        true,
+				"confirmed_at":   now,
+				"last_used_step": step,
+				"updated_at":     now,
+			}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.User{}).
+			Where("id = ?", userID).
+			Update("two_factor_enabled", true).Error; err != nil {
+			return err
+		}
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+}
+
+func (r *twoFactorRepository) Delete(userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.TwoFactorRecoveryCode{}, "user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&model.UserTwoFactor{}, "user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.User{}).
+			Where("id = ?", userID).
+			Update("two_factor_enabled", false).Error
+	})
+}
+
+func (r *twoFactorRepository) ReplaceRecoveryCodes(userID string, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID string, codeHashes []string) error {
+	if err := tx.Delete(&model.TwoFactorRecoveryCode{}, "user_id = ?", userID).Error; err != nil {
+		return err
+	}
+	codes := make([]model.TwoFactorRecoveryCode, 0, len(codeHashes))
+	for _, h := range codeHashes {
+		codes = append(codes, model.TwoFactorRecoveryCode{UserID: userID, CodeHash: h})
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	return tx.Create(&codes).Error
+}
+
+func (r *twoFactorRepository) ConsumeRecoveryCode(userID, codeHash string) (bool, error) {
+	res := r.db.Model(&model.TwoFactorRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *twoFactorRepository) CountUnusedRecoveryCodes(userID string) (int64, error) {
+	var n int64
+	err := r.db.Model(&model.TwoFactorRecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&n).Error
+	return n, err
+}
+
+func (r *twoFactorRepository) UseStep(userID string, step int64) (bool, error) {
+	res := r.db.Model(&model.UserTwoFactor{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	return res.RowsAffected > 0, res.Error
+}
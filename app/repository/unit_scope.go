@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+)
+
+// applyStudentScope: batasi query ke mahasiswa dalam unit scope (kolom = kolom students.id di query)
+func applyStudentScope(q *gorm.DB, scope model.UnitScope, studentIDColumn string) *gorm.DB {
+	if scope.IsGlobal() {
+		return q
+	}
+
+	sub := q.Session(&gorm.Session{NewDB: true}).
+		Table("students s").
+		Select("s.id").
+		Joins("JOIN study_programs sp ON sp.id = s.study_program_id").
+		Joins("JOIN departments d ON d.id = sp.department_id")
+
+	if scope.DepartmentID != "" {
+		sub = sub.Where("d.id = ?", scope.DepartmentID)
+	} else {
+		sub = sub.Where("d.faculty_id = ?", scope.FacultyID)
+	}
+
+	return q.Where(studentIDColumn+" IN (?)", sub)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserIdentityRepository interface {
+	// FindByProviderSubject: nil, nil kalau akun IdP belum terhubung
+	FindByProviderSubject(provider, subject string) (*model.UserIdentity, error)
+	Create(identity *model.UserIdentity) error
+	TouchLastLogin(id string, at time.Time) error
+}
+
+type userIdentityRepository struct {
+	db *gorm.DB
+}
+
+func NewUserIdentityRepository(db *gorm.DB) UserIdentityRepository {
+	return &userIdentityRepository{db: db}
+}
+
+func (r *userIdentityRepository) FindByProviderSubject(provider, subject string) (*model.UserIdentity, error) {
+	var identity model.UserIdentity
+	err := r.db.First(&identity, "provider = ? AND subject = ?", provider, subject).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (r *userIdentityRepository) Create(identity *model.UserIdentity) error {
+	return r.db.Create(identity).Error
+}
+
+func (r *userIdentityRepository) TouchLastLogin(id string, at time.Time) error {
+	return r.db.Model(&model.UserIdentity{}).
+		Where("id = ?", id).
+		Update("last_login_at", at).Error
+}
+
+type OIDCStateRepository interface {
+	Create(state *model.OIDCLoginState) error
+	// Consume: ambil + hapus state; nil, nil kalau tidak ada / kadaluarsa / sudah dipakai
+	Consume(state string, now time.Time) (*model.OIDCLoginState, error)
+	DeleteExpired(now time.Time) error
+}
+
+type oidcStateRepository struct {
+	db *gorm.DB
+}
+
+func NewOIDCStateRepository(db *gorm.DB) OIDCStateRepository {
+	return &oidcStateRepository{db: db}
+}
+
+func (r *oidcStateRepository) Create(state *model.OIDCLoginState) error {
+	return r.db.Create(state).Error
+}
+
+func (r *oidcStateRepository) Consume(state string, now time.Time) (*model.OIDCLoginState, error) {
+	var rows []model.OIDCLoginState
+	// DELETE ... RETURNING supaya callback yang sama tidak bisa diproses dua kali
+	if err := r.db.Clauses(clause.Returning{}).
+		Where("state = ?", state).
+		Delete(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 || !rows[0].ExpiresAt.After(now) {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+func (r *oidcStateRepository) DeleteExpired(now time.Time) error {
+	return r.db.Where("expires_at <= ?", now).Delete(&model.OIDCLoginState{}).Error
+}
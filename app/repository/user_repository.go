@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/nerhays/prestasi_uas/app/model"
 
 	"gorm.io/gorm"
@@ -13,8 +15,15 @@ type UserRepository interface {
 	FindAll() ([]model.User, error)
 	Create(user *model.User) error
 	Update(user *model.User) error
-	Delete(id string) error
-	UpdateRole(userID, roleID string) error
+	// SetActive: nonaktif = deactivated_at + sessions_revoked_at diisi at; aktif = deactivated_at dikosongkan
+	SetActive(userID string, active bool, at time.Time) error
+	// UpdateRole / UpdateScope: role & scope ada di claim JWT, jadi sessions_revoked_at ikut diisi at
+	UpdateRole(userID, roleID string, at time.Time) error
+	UpdateScope(userID string, facultyID, departmentID *string, at time.Time) error
+	// UpdatePassword: password_changed_at + sessions_revoked_at diisi at, token yang terbit sebelumnya tidak berlaku
+	UpdatePassword(userID, passwordHash string, mustChange bool, at time.Time) error
+	UpdateAuthBackend(userID, backend string) error
+	FindServiceAccounts() ([]model.User, error)
 }
 
 type userRepository struct {
@@ -69,6 +78,15 @@ func (r *userRepository) FindAll() ([]model.User, error) {
 	return users, err
 }
 
+func (r *userRepository) FindServiceAccounts() ([]model.User, error) {
+	var users []model.User
+	err := r.db.Preload("Role").
+		Where("is_service_account = ?", true).
+		Order("created_at ASC").
+		Find(&users).Error
+	return users, err
+}
+
 func (r *userRepository) Create(user *model.User) error {
 	return r.db.Create(user).Error
 }
@@ -77,12 +95,50 @@ func (r *userRepository) Update(user *model.User) error {
 	return r.db.Save(user).Error
 }
 
-func (r *userRepository) Delete(id string) error {
-	return r.db.Delete(&model.User{}, "id = ?", id).Error
+func (r *userRepository) SetActive(userID string, active bool, at time.Time) error {
+	updates := map[string]any{"is_active": active, "deactivated_at": nil}
+	if !active {
+		updates["deactivated_at"] = at
+		updates["sessions_revoked_at"] = at
+	}
+	return r.db.Model(&model.User{}).
+		Where("id = ?", userID).
+		Updates(updates).Error
+}
+
+func (r *userRepository) UpdateRole(userID, roleID string, at time.Time) error {
+	return r.db.Model(&model.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]any{
+			"role_id":             roleID,
+			"sessions_revoked_at": at,
+		}).Error
+}
+
+func (r *userRepository) UpdateScope(userID string, facultyID, departmentID *string, at time.Time) error {
+	return r.db.Model(&model.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]any{
+			"scope_faculty_id":    facultyID,
+			"scope_department_id": departmentID,
+			"sessions_revoked_at": at,
+		}).Error
+}
+
+// UpdatePassword: ganti hash password + flag wajib ganti password
+func (r *userRepository) UpdatePassword(userID, passwordHash string, mustChange bool, at time.Time) error {
+	return r.db.Model(&model.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]any{
+			"password_hash":        passwordHash,
+			"must_change_password": mustChange,
+			"password_changed_at":  at,
+			"sessions_revoked_at":  at,
+		}).Error
 }
 
-func (r *userRepository) UpdateRole(userID, roleID string) error {
+func (r *userRepository) UpdateAuthBackend(userID, backend string) error {
 	return r.db.Model(&model.User{}).
 		Where("id = ?", userID).
-		Update("role_id", roleID).Error
+		Update("auth_backend", backend).Error
 }
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"gorm.io/gorm"
+)
+
+type VerificationDelegationRepository interface {
+	Create(d *model.VerificationDelegation) error
+	FindByID(id string) (*model.VerificationDelegation, error)
+	// FindByLecturerID: delegasi yang diberikan atau diterima dosen ini, terbaru dulu
+	FindByLecturerID(lecturerID string) ([]model.VerificationDelegation, error)
+	// FindActive: delegasi yang berlaku pada waktu at; ID kosong = tidak difilter
+	FindActive(fromLecturerID, toLecturerID string, at time.Time) ([]model.VerificationDelegation, error)
+	// Revoke: false kalau delegasi tidak ada / sudah dicabut
+	Revoke(id string, at time.Time) (bool, error)
+}
+
+type verificationDelegationRepository struct {
+	db *gorm.DB
+}
+
+func NewVerificationDelegationRepository(db *gorm.DB) VerificationDelegationRepository {
+	return &verificationDelegationRepository{db: db}
+}
+
+func (r *verificationDelegationRepository) Create(d *model.VerificationDelegation) error {
+	return r.db.Omit("FromLecturer", "ToLecturer").Create(d).Error
+}
+
+func (r *verificationDelegationRepository) FindByID(id string) (*model.VerificationDelegation, error) {
+	var d model.VerificationDelegation
+	if err := r.db.
+		Preload("FromLecturer.User").
+		Preload("ToLecturer.User").
+		First(&d, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *verificationDelegationRepository) FindByLecturerID(lecturerID string) ([]model.VerificationDelegation, error) {
+	var rows []model.VerificationDelegation
+	err := r.db.
+		Preload("FromLecturer.User").
+		Preload("ToLecturer.User").
+		Where("from_lecturer_id = ? OR to_lecturer_id = ?", lecturerID, lecturerID).
+		Order("starts_at DESC").
+		Find(&rows).Error
+	return rows, err
+}
+
+func (r *verificationDelegationRepository) FindActive(fromLecturerID, toLecturerID string, at time.Time) ([]model.VerificationDelegation, error) {
+	q := r.db.Where("revoked_at IS NULL AND starts_at <= ? AND ends_at > ?", at, at)
+	if fromLecturerID != "" {
+		q = q.Where("from_lecturer_id = ?", fromLecturerID)
+	}
+	if toLecturerID != "" {
+		q = q.Where("to_lecturer_id = ?", toLecturerID)
+	}
+
+	var rows []model.VerificationDelegation
+	err := q.Order("starts_at ASC").Find(&rows).Error
+	return rows, err
+}
+
+func (r *verificationDelegationRepository) Revoke(id string, at time.Time) (bool, error) {
+	res := r.db.Model(&model.VerificationDelegation{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	return res.RowsAffected > 0, res.Error
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+var (
+	ErrPeriodNotFound          = errors.New("academic period not found")
+	ErrInvalidAcademicYear     = errors.New("academic_year must look like 2024/2025")
+	ErrInvalidSemester         = errors.New("semester must be ganjil or genap")
+	ErrInvalidPeriodRange      = errors.New("start_date must not be after end_date")
+	ErrInvalidSubmissionWindow = errors.New("submission_opens_at must not be after submission_closes_at")
+	ErrPeriodOverlap           = errors.New("academic period overlaps an existing period")
+)
+
+var academicYearPattern = regexp.MustCompile(`^(\d{4})/(\d{4})$`)
+
+type AcademicPeriodInput struct {
+	AcademicYear       string
+	Semester           model.Semester
+	StartDate          time.Time
+	EndDate            time.Time
+	SubmissionOpensAt  *time.Time
+	SubmissionClosesAt *time.Time
+}
+
+// AcademicPeriodService: master periode akademik (semester) + jendela pengajuan prestasi
+type AcademicPeriodService struct {
+	periodRepo repository.AcademicPeriodRepository
+}
+
+func NewAcademicPeriodService(periodRepo repository.AcademicPeriodRepository) *AcademicPeriodService {
+	return &AcademicPeriodService{periodRepo: periodRepo}
+}
+
+func (s *AcademicPeriodService) GetAll() ([]model.AcademicPeriod, error) {
+	return s.periodRepo.FindAll()
+}
+
+func (s *AcademicPeriodService) GetByID(id string) (*model.AcademicPeriod, error) {
+	p, err := s.periodRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrPeriodNotFound
+	}
+	return p, nil
+}
+
+func (s *AcademicPeriodService) GetActive() (*model.AcademicPeriod, error) {
+	p, err := s.periodRepo.FindActive()
+	if err != nil {
+		return nil, ErrPeriodNotFound
+	}
+	return p, nil
+}
+
+func (s *AcademicPeriodService) Create(input AcademicPeriodInput) (*model.AcademicPeriod, error) {
+	if err := s.validate(input, ""); err != nil {
+		return nil, err
+	}
+
+	p := &model.AcademicPeriod{}
+	applyPeriodInput(p, input)
+
+	if err := s.periodRepo.Create(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *AcademicPeriodService) Update(id string, input AcademicPeriodInput) (*model.AcademicPeriod, error) {
+	p, err := s.periodRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrPeriodNotFound
+	}
+
+	if err := s.validate(input, id); err != nil {
+		return nil, err
+	}
+
+	applyPeriodInput(p, input)
+
+	if err := s.periodRepo.Save(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Delete: prestasi yang tercatat di periode ini jadi tanpa periode (ON DELETE SET NULL)
+func (s *AcademicPeriodService) Delete(id string) error {
+	if _, err := s.periodRepo.FindByID(id); err != nil {
+		return ErrPeriodNotFound
+	}
+	return s.periodRepo.Delete(id)
+}
+
+// Activate: jadikan periode aktif, periode lain otomatis nonaktif
+func (s *AcademicPeriodService) Activate(id string) (*model.AcademicPeriod, error) {
+	if _, err := s.periodRepo.FindByID(id); err != nil {
+		return nil, ErrPeriodNotFound
+	}
+	if err := s.periodRepo.SetActive(id); err != nil {
+		return nil, err
+	}
+	return s.periodRepo.FindByID(id)
+}
+
+func (s *AcademicPeriodService) validate(input AcademicPeriodInput, excludeID string) error {
+	m := academicYearPattern.FindStringSubmatch(input.AcademicYear)
+	if m == nil {
+		return ErrInvalidAcademicYear
+	}
+	first, _ := strconv.Atoi(m[1])
+	second, _ := strconv.Atoi(m[2])
+	if second != first+1 {
+		return ErrInvalidAcademicYear
+	}
+
+	if input.Semester != model.SemesterGanjil && input.Semester != model.SemesterGenap {
+		return ErrInvalidSemester
+	}
+
+	if input.StartDate.IsZero() || input.EndDate.IsZero() || input.StartDate.After(input.EndDate) {
+		return ErrInvalidPeriodRange
+	}
+
+	if input.SubmissionOpensAt != nil && input.SubmissionClosesAt != nil &&
+		input.SubmissionOpensAt.After(*input.SubmissionClosesAt) {
+		return ErrInvalidSubmissionWindow
+	}
+
+	// satu tanggal event hanya boleh masuk ke satu periode
+	overlaps, err := s.periodRepo.FindOverlapping(input.StartDate, input.EndDate, excludeID)
+	if err != nil {
+		return err
+	}
+	if len(overlaps) > 0 {
+		return fmt.Errorf("%w: %s", ErrPeriodOverlap, overlaps[0].Label())
+	}
+
+	return nil
+}
+
+func applyPeriodInput(p *model.AcademicPeriod, input AcademicPeriodInput) {
+	p.AcademicYear = input.AcademicYear
+	p.Semester = input.Semester
+	p.StartDate = input.StartDate
+	p.EndDate = input.EndDate
+	p.SubmissionOpensAt = input.SubmissionOpensAt
+	p.SubmissionClosesAt = input.SubmissionClosesAt
+}
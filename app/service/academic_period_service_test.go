@@ -0,0 +1,79 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func ganjilInput() AcademicPeriodInput {
+	return AcademicPeriodInput{
+		AcademicYear: "2024/2025",
+		Semester:     model.SemesterGanjil,
+		StartDate:    time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:      time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestCreateAcademicPeriod_Success(t *testing.T) {
+	periodRepo := new(mocks.AcademicPeriodRepositoryMock)
+	svc := NewAcademicPeriodService(periodRepo)
+
+	in := ganjilInput()
+	periodRepo.On("FindOverlapping", in.StartDate, in.EndDate, "").Return([]model.AcademicPeriod{}, nil)
+	periodRepo.On("Create", mock.AnythingOfType("*model.AcademicPeriod")).Return(nil)
+
+	p, err := svc.Create(in)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2024/2025 Ganjil", p.Label())
+}
+
+func TestCreateAcademicPeriod_InvalidInput(t *testing.T) {
+	svc := NewAcademicPeriodService(new(mocks.AcademicPeriodRepositoryMock))
+
+	in := ganjilInput()
+	in.AcademicYear = "2024/2026"
+	_, err := svc.Create(in)
+	assert.ErrorIs(t, err, ErrInvalidAcademicYear)
+
+	in = ganjilInput()
+	in.Semester = "pendek"
+	_, err = svc.Create(in)
+	assert.ErrorIs(t, err, ErrInvalidSemester)
+
+	in = ganjilInput()
+	in.StartDate, in.EndDate = in.EndDate, in.StartDate
+	_, err = svc.Create(in)
+	assert.ErrorIs(t, err, ErrInvalidPeriodRange)
+}
+
+func TestUpdateAcademicPeriod_Overlap(t *testing.T) {
+	periodRepo := new(mocks.AcademicPeriodRepositoryMock)
+	svc := NewAcademicPeriodService(periodRepo)
+
+	in := ganjilInput()
+	periodRepo.On("FindByID", "per-2").Return(&model.AcademicPeriod{ID: "per-2"}, nil)
+	periodRepo.On("FindOverlapping", in.StartDate, in.EndDate, "per-2").Return([]model.AcademicPeriod{
+		{ID: "per-1", AcademicYear: "2024/2025", Semester: model.SemesterGanjil},
+	}, nil)
+
+	_, err := svc.Update("per-2", in)
+
+	assert.ErrorIs(t, err, ErrPeriodOverlap)
+	periodRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestAcademicPeriodSubmissionOpen(t *testing.T) {
+	opens := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+	closes := time.Date(2025, 1, 15, 23, 59, 0, 0, time.UTC)
+	p := model.AcademicPeriod{SubmissionOpensAt: &opens, SubmissionClosesAt: &closes}
+
+	assert.False(t, p.SubmissionOpen(opens.Add(-time.Hour)))
+	assert.True(t, p.SubmissionOpen(opens.AddDate(0, 1, 0)))
+	assert.False(t, p.SubmissionOpen(closes.Add(time.Minute)))
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+
+	// reference dibaca per halaman supaya export besar tidak memuat semuanya sekaligus dari Postgres
+	exportPageSize = 500
+)
+
+var ErrExportFormat = errors.New("export format must be csv or json")
+
+// AchievementExportRow: satu baris export = satu reference (anggota tim punya baris sendiri)
+type AchievementExportRow struct {
+	ReferenceID      string     `json:"reference_id"`
+	AchievementID    string     `json:"achievement_id"`
+	NIM              string     `json:"nim"`
+	StudentName      string     `json:"student_name"`
+	ProgramStudy     string     `json:"program_study"`
+	Period           string     `json:"period,omitempty"`
+	Status           string     `json:"status"`
+	AchievementType  string     `json:"achievement_type"`
+	Title            string     `json:"title"`
+	CompetitionLevel string     `json:"competition_level,omitempty"`
+	EventDate        *time.Time `json:"event_date,omitempty"`
+	Points           float64    `json:"points"`
+	PointShare       *float64   `json:"point_share,omitempty"`
+	SubmittedAt      *time.Time `json:"submitted_at,omitempty"`
+	VerifiedAt       *time.Time `json:"verified_at,omitempty"`
+}
+
+var achievementExportHeader = []string{
+	"reference_id", "achievement_id", "nim", "student_name", "program_study", "period", "status",
+	"achievement_type", "title", "competition_level", "event_date", "points", "point_share",
+	"submitted_at", "verified_at",
+}
+
+// AchievementExportService: export semua prestasi (Postgres + Mongo) ke CSV / JSON, dipakai CLI
+type AchievementExportService struct {
+	refRepo         repository.AchievementReferenceRepository
+	achievementRepo repository.AchievementRepository
+	studentRepo     repository.StudentRepository
+}
+
+func NewAchievementExportService(
+	refRepo repository.AchievementReferenceRepository,
+	achievementRepo repository.AchievementRepository,
+	studentRepo repository.StudentRepository,
+) *AchievementExportService {
+	return &AchievementExportService{
+		refRepo:         refRepo,
+		achievementRepo: achievementRepo,
+		studentRepo:     studentRepo,
+	}
+}
+
+// Export: tulis prestasi sesuai filter ke w; return jumlah baris.
+// Reference yang dokumen Mongo-nya hilang tetap ditulis (judul kosong), cek dengan `check consistency`.
+func (s *AchievementExportService) Export(ctx context.Context, w io.Writer, format string, filter repository.AchievementReferenceFilter) (int, error) {
+	if format != ExportFormatCSV && format != ExportFormatJSON {
+		return 0, ErrExportFormat
+	}
+
+	rows, err := s.collect(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	if format == ExportFormatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return len(rows), enc.Encode(rows)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(achievementExportHeader); err != nil {
+		return 0, err
+	}
+	for _, row := range rows {
+		if err := cw.Write(row.csvRecord()); err != nil {
+			return 0, err
+		}
+	}
+	cw.Flush()
+	return len(rows), cw.Error()
+}
+
+func (s *AchievementExportService) collect(ctx context.Context, filter repository.AchievementReferenceFilter) ([]AchievementExportRow, error) {
+	students, err := s.studentRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	studentByID := make(map[string]model.Student, len(students))
+	for _, st := range students {
+		studentByID[st.ID] = st
+	}
+
+	rows := []AchievementExportRow{}
+	for offset := 0; ; offset += exportPageSize {
+		refs, _, err := s.refRepo.FindAll(offset, exportPageSize, filter)
+		if err != nil {
+			return nil, err
+		}
+		if len(refs) == 0 {
+			break
+		}
+
+		mongoIDs := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			mongoIDs = append(mongoIDs, ref.MongoAchievementID)
+		}
+		docs, err := s.achievementRepo.FindByIDs(ctx, mongoIDs)
+		if err != nil {
+			return nil, err
+		}
+		docByID := make(map[string]model.Achievement, len(docs))
+		for _, doc := range docs {
+			docByID[doc.ID.Hex()] = doc
+		}
+
+		for _, ref := range refs {
+			doc := docByID[ref.MongoAchievementID]
+			rows = append(rows, newAchievementExportRow(ref, doc, studentByID[ref.StudentID]))
+		}
+
+		if len(refs) < exportPageSize {
+			break
+		}
+	}
+	return rows, nil
+}
+
+func newAchievementExportRow(ref model.AchievementReference, doc model.Achievement, student model.Student) AchievementExportRow {
+	row := AchievementExportRow{
+		ReferenceID:      ref.ID,
+		AchievementID:    ref.MongoAchievementID,
+		NIM:              student.StudentID,
+		StudentName:      student.User.FullName,
+		ProgramStudy:     student.ProgramStudy,
+		Status:           string(ref.Status),
+		AchievementType:  doc.AchievementType,
+		Title:            doc.Title,
+		CompetitionLevel: doc.DetailString("competitionLevel"),
+		Points:           doc.Points,
+		PointShare:       ref.PointShare,
+		SubmittedAt:      ref.SubmittedAt,
+		VerifiedAt:       ref.VerifiedAt,
+	}
+	if ref.Period != nil {
+		row.Period = ref.Period.Label()
+	}
+	if at, ok := doc.EventDate(); ok {
+		row.EventDate = &at
+	}
+	return row
+}
+
+func (r AchievementExportRow) csvRecord() []string {
+	pointShare := ""
+	if r.PointShare != nil {
+		pointShare = strconv.FormatFloat(*r.PointShare, 'f', -1, 64)
+	}
+	return []string{
+		r.ReferenceID,
+		r.AchievementID,
+		r.NIM,
+		r.StudentName,
+		r.ProgramStudy,
+		r.Period,
+		r.Status,
+		r.AchievementType,
+		r.Title,
+		r.CompetitionLevel,
+		formatExportTime(r.EventDate, "2006-01-02"),
+		strconv.FormatFloat(r.Points, 'f', -1, 64),
+		pointShare,
+		formatExportTime(r.SubmittedAt, time.RFC3339),
+		formatExportTime(r.VerifiedAt, time.RFC3339),
+	}
+}
+
+func formatExportTime(t *time.Time, layout string) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+// ParseExportFormat: format dari flag CLI; kosong = csv
+func ParseExportFormat(format string) (string, error) {
+	switch format {
+	case "", ExportFormatCSV:
+		return ExportFormatCSV, nil
+	case ExportFormatJSON:
+		return ExportFormatJSON, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrExportFormat, format)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newExportTestService() *AchievementExportService {
+	refRepo := new(mocks.AchievementReferenceRepositoryMock)
+	achievementRepo := new(mocks.AchievementRepositoryMock)
+	studentRepo := new(mocks.StudentRepositoryMock)
+
+	docID := primitive.NewObjectID()
+	share := 12.5
+	refRepo.On("FindAll", 0, exportPageSize, mock.Anything).Return([]model.AchievementReference{
+		{
+			ID:                 "ref-1",
+			StudentID:          "student-1",
+			MongoAchievementID: docID.Hex(),
+			Status:             model.AchievementStatusVerified,
+			PointShare:         &share,
+			Period:             &model.AcademicPeriod{AcademicYear: "2024/2025", Semester: model.SemesterGenap},
+		},
+	}, int64(1), nil)
+	achievementRepo.On("FindByIDs", mock.Anything, []string{docID.Hex()}).Return([]model.Achievement{
+		{
+			ID:              docID,
+			AchievementType: "competition",
+			Title:           "Juara 1, Lomba \"Robotik\"",
+			Points:          25,
+			Details:         map[string]any{"competitionLevel": "national"},
+		},
+	}, nil)
+	studentRepo.On("FindAll").Return([]model.Student{
+		{ID: "student-1", StudentID: "2101", ProgramStudy: "Teknik Informatika", User: model.User{FullName: "Budi"}},
+	}, nil)
+
+	return NewAchievementExportService(refRepo, achievementRepo, studentRepo)
+}
+
+func TestExportAchievements_CSV(t *testing.T) {
+	svc := newExportTestService()
+
+	var buf bytes.Buffer
+	n, err := svc.Export(context.Background(), &buf, ExportFormatCSV, repository.AchievementReferenceFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, achievementExportHeader, records[0])
+	assert.Equal(t, "Juara 1, Lomba \"Robotik\"", records[1][8])
+	assert.Equal(t, "2024/2025 Genap", records[1][5])
+	assert.Equal(t, "12.5", records[1][12])
+}
+
+func TestExportAchievements_JSONAndInvalidFormat(t *testing.T) {
+	svc := newExportTestService()
+
+	var buf bytes.Buffer
+	_, err := svc.Export(context.Background(), &buf, ExportFormatJSON, repository.AchievementReferenceFilter{})
+	assert.NoError(t, err)
+
+	var rows []AchievementExportRow
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &rows))
+	assert.Equal(t, "Budi", rows[0].StudentName)
+	assert.Equal(t, "national", rows[0].CompetitionLevel)
+
+	_, err = svc.Export(context.Background(), &buf, "xml", repository.AchievementReferenceFilter{})
+	assert.ErrorIs(t, err, ErrExportFormat)
+}
@@ -3,8 +3,12 @@ package service
 import (
 	"context"
 	"errors"
+	"log"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"gorm.io/gorm"
+
 	"github.com/nerhays/prestasi_uas/app/model"
 	"github.com/nerhays/prestasi_uas/app/repository"
 )
@@ -18,6 +22,8 @@ var (
 	ErrStudentNoAdvisor        = errors.New("student has no advisor assigned")
 	ErrLecturerNotFound        = errors.New("lecturer record not found")
 	ErrForbidden = errors.New("forbidden")
+	ErrSubmissionClosed = errors.New("submission window for this academic period is closed")
+	ErrNoOpenPeriod     = errors.New("no academic period is open for submission")
 )
 
 type AchievementService struct {
@@ -27,6 +33,15 @@ type AchievementService struct {
 	userRepo        repository.UserRepository
 	lecturerRepo    repository.LecturerRepository
 	logRepo         repository.AchievementStatusLogRepository
+	periodRepo      repository.AcademicPeriodRepository
+	teamRepo        repository.AchievementTeamRepository
+	versionRepo     repository.AchievementVersionRepository
+	duplicateSvc    *DuplicateService
+	// advisorSvc: delegasi verifikasi; nil = hanya dosen wali pembimbing
+	advisorSvc *AdvisorAssignmentService
+
+	// defaultPointSplit: aturan bagi poin prestasi tim jika tidak diisi mahasiswa
+	defaultPointSplit model.TeamPointSplit
 }
 
 func NewAchievementService(
@@ -36,6 +51,12 @@ func NewAchievementService(
 	userRepo repository.UserRepository,
 	lecturerRepo    repository.LecturerRepository,
 	logRepo repository.AchievementStatusLogRepository,
+	periodRepo repository.AcademicPeriodRepository,
+	teamRepo repository.AchievementTeamRepository,
+	versionRepo repository.AchievementVersionRepository,
+	duplicateSvc *DuplicateService,
+	advisorSvc *AdvisorAssignmentService,
+	defaultPointSplit model.TeamPointSplit,
 ) *AchievementService {
 	return &AchievementService{
 		achievementRepo: achievementRepo,
@@ -44,6 +65,13 @@ func NewAchievementService(
 		userRepo:        userRepo,
 		lecturerRepo:    lecturerRepo,
 		logRepo:         logRepo,
+		periodRepo:      periodRepo,
+		teamRepo:        teamRepo,
+		versionRepo:     versionRepo,
+		duplicateSvc:    duplicateSvc,
+		advisorSvc:      advisorSvc,
+
+		defaultPointSplit: defaultPointSplit,
 	}
 }
 
@@ -51,7 +79,7 @@ func NewAchievementService(
 // - userID dari JWT
 // - cari student by userID
 // - insert achievement ke Mongo (pakai student.ID)
-// - insert achievement_reference ke Postgres (status draft)
+// - insert achievement_reference ke Postgres (status draft) + periode akademik dari eventDate
 func (s *AchievementService) CreateAchievementForUser(
 	ctx context.Context,
 	userID string,
@@ -91,7 +119,7 @@ func (s *AchievementService) CreateAchievementForUser(
 	}
 
 	// 6. Insert reference ke Postgres (status: draft)
-	ref, err := s.refRepo.CreateDraft(student.ID, createdAc.ID.Hex())
+	ref, err := s.refRepo.CreateDraft(student.ID, createdAc.ID.Hex(), s.resolvePeriodID(ac.Details))
 	if err != nil {
 		// Mongo sudah terbuat, tapi ref gagal
 		return createdAc, nil, err
@@ -104,6 +132,7 @@ func (s *AchievementService) CreateAchievementForUser(
 		userID,
 		nil,
 	)
+	s.recordVersion(ctx, createdAc, userID)
 
 	return createdAc, ref, nil
 }
@@ -138,8 +167,28 @@ func (s *AchievementService) SubmitAchievement(ctx context.Context, userID, refI
 		return nil, ErrInvalidStatus
 	}
 
-	old := ref.Status
+	// prestasi tim hanya disubmit oleh ketua, sekaligus untuk semua anggota
+	if isTeamMemberRef(ref) {
+		return nil, ErrNotTeamLeader
+	}
+
 	now := time.Now()
+
+	// jendela pengajuan periode akademik (mis. cut-off SKPI); periode ditentukan ulang saat submit
+	period, err := s.submissionPeriod(ctx, ref, now)
+	if err != nil {
+		return nil, err
+	}
+	if !period.SubmissionOpen(now) {
+		return nil, ErrSubmissionClosed
+	}
+	ref.PeriodID = &period.ID
+
+	if ref.TeamRole != nil {
+		return s.submitTeam(ctx, ref, userID, now)
+	}
+
+	old := ref.Status
 	ref.Status = model.AchievementStatusSubmitted
 	ref.SubmittedAt = &now
 
@@ -153,6 +202,7 @@ func (s *AchievementService) SubmitAchievement(ctx context.Context, userID, refI
 		userID,
 		nil,
 	)
+	s.checkDuplicates(ctx, ref.MongoAchievementID, ref.ID)
 
 	return ref, nil
 }
@@ -187,8 +237,9 @@ func (s *AchievementService) VerifyAchievement(ctx context.Context, verifierUser
 	}
 
 	// Jika verifier role = Dosen Wali, hanya boleh verifikasi jika lecturer.user_id == verifier.ID
+	// atau verifier sedang memegang delegasi verifikasi dari dosen wali tsb
 	if verifier.Role.Name == "Dosen Wali" {
-    if lect.UserID != verifier.ID {
+    if !s.actsForAdvisor(lect, verifier.ID) {
         return nil, ErrNotAdvisor
     }
 }
@@ -204,6 +255,7 @@ func (s *AchievementService) VerifyAchievement(ctx context.Context, verifierUser
     ref.Status = model.AchievementStatusVerified
     ref.VerifiedAt = &now
     ref.VerifiedBy = &verifierUserID
+    ref.VerifiedVersion = s.currentVersion(ctx, ref.MongoAchievementID)
     ref.RejectionNote = nil
 
     if err := s.refRepo.Save(ref); err != nil {
@@ -247,7 +299,7 @@ func (s *AchievementService) RejectAchievement(ctx context.Context, verifierUser
 	}
 
 	if verifier.Role.Name == "Dosen Wali" {
-    if lect.UserID != verifier.ID {
+    if !s.actsForAdvisor(lect, verifier.ID) {
         return nil, ErrNotAdvisor
     }
 }
@@ -301,6 +353,10 @@ func (s *AchievementService) DeleteDraftAchievement(ctx context.Context, userID,
 		return ErrInvalidStatus
 	}
 
+	if isTeamMemberRef(ref) {
+		return ErrNotTeamLeader
+	}
+
 	// 1. Soft delete di Mongo
 	if err := s.achievementRepo.SoftDelete(ctx, ref.MongoAchievementID); err != nil {
 		return err
@@ -308,7 +364,16 @@ func (s *AchievementService) DeleteDraftAchievement(ctx context.Context, userID,
 
 	// 2. Update status reference di Postgres
 	ref.Status = model.AchievementStatusDeleted
-	return s.refRepo.Save(ref)
+	if err := s.refRepo.Save(ref); err != nil {
+		return err
+	}
+	s.logStatusChange(ref.ID, model.AchievementStatusDraft, ref.Status, userID, nil)
+
+	// 3. Prestasi tim: draft anggota ikut terhapus, undangan yang belum dijawab batal
+	if ref.TeamRole != nil {
+		return s.deleteTeamMembers(ref)
+	}
+	return nil
 }
 
 func (s *AchievementService) GetDeletedAchievements(ctx context.Context, userID string) ([]model.Achievement, error) {
@@ -320,6 +385,47 @@ func (s *AchievementService) GetDeletedAchievements(ctx context.Context, userID
     return s.achievementRepo.FindDeletedByStudentID(ctx, student.ID)
 }
 
+// RestoreAchievement: prestasi di tempat sampah kembali menjadi draft (pemilik atau admin).
+// Setelah lewat masa retensi dokumen sudah dihapus permanen dan tidak bisa dipulihkan.
+func (s *AchievementService) RestoreAchievement(ctx context.Context, userID, role, refID string) (*model.AchievementReference, error) {
+	ref, err := s.refRepo.GetByID(refID)
+	if err != nil {
+		return nil, ErrRefNotFound
+	}
+
+	if role != "Admin" {
+		student, err := s.studentRepo.FindByUserID(userID)
+		if err != nil || ref.StudentID != student.ID {
+			return nil, ErrNotOwner
+		}
+	}
+
+	if ref.Status != model.AchievementStatusDeleted {
+		return nil, ErrInvalidStatus
+	}
+
+	if isTeamMemberRef(ref) {
+		return nil, ErrNotTeamLeader
+	}
+
+	if err := s.achievementRepo.Restore(ctx, ref.MongoAchievementID); err != nil {
+		return nil, err
+	}
+
+	ref.Status = model.AchievementStatusDraft
+	if err := s.refRepo.Save(ref); err != nil {
+		return nil, err
+	}
+	s.logStatusChange(ref.ID, model.AchievementStatusDeleted, ref.Status, userID, nil)
+
+	if ref.TeamRole != nil {
+		if err := s.restoreTeamMembers(ref, userID); err != nil {
+			return nil, err
+		}
+	}
+	return ref, nil
+}
+
 func (s *AchievementService) GetBimbinganAchievements(ctx context.Context, verifierUserID string, page, perPage int, status *model.AchievementStatus) (int64, []map[string]interface{}, error) {
     // 1. find lecturer by user id
     lect, err := s.lecturerRepo.FindByUserID(verifierUserID) 
@@ -327,8 +433,8 @@ func (s *AchievementService) GetBimbinganAchievements(ctx context.Context, verif
         return 0, nil, err
     }
 
-    // 2. get students by advisor_id = lect.ID
-    students, err := s.studentRepo.FindByAdvisorLecturerID(lect.ID)
+    // 2. get students by advisor_id = lect.ID (+ mahasiswa dosen yang mendelegasikan verifikasi)
+    students, err := s.advisedStudents(lect.ID)
     if err != nil {
         return 0, nil, err
     }
@@ -374,7 +480,17 @@ func (s *AchievementService) GetBimbinganAchievements(ctx context.Context, verif
         achMap[a.ID.Hex()] = a
     }
 
-    // 8. combine refs + achievement doc into result rows
+    // 8. peringatan duplikat yang belum ditinjau
+    refIDs := make([]string, 0, len(refs))
+    for _, r := range refs {
+        refIDs = append(refIDs, r.ID)
+    }
+    warnings, err := s.duplicateSvc.OpenFlagsByReference(refIDs)
+    if err != nil {
+        return 0, nil, err
+    }
+
+    // 9. combine refs + achievement doc into result rows
     results := make([]map[string]interface{}, 0, len(refs))
     for _, r := range refs {
         item := map[string]interface{}{"reference": r}
@@ -383,6 +499,7 @@ func (s *AchievementService) GetBimbinganAchievements(ctx context.Context, verif
         } else {
             item["achievement"] = nil
         }
+        item["duplicate_warnings"] = warnings[r.ID]
         results = append(results, item)
     }
 
@@ -395,6 +512,7 @@ func (s *AchievementService) UploadAttachment(
 	filename string,
 	fileURL string,
 	fileType string,
+	fileHash string,
 ) (*model.Attachment, error) {
 
 	ref, err := s.refRepo.GetByID(refID)
@@ -411,19 +529,101 @@ func (s *AchievementService) UploadAttachment(
 		return nil, ErrForbidden
 	}
 
+	if isTeamMemberRef(ref) {
+		return nil, ErrNotTeamLeader
+	}
+
 	att := model.Attachment{
 		FileName:   filename,
 		FileURL:    fileURL,
 		FileType:   fileType,
+		Hash:       fileHash,
 		UploadedAt: time.Now(),
 	}
 
+	s.ensureBaselineVersion(ctx, ref.MongoAchievementID)
 	if err := s.achievementRepo.AddAttachment(ctx, ref.MongoAchievementID, att); err != nil {
 		return nil, err
 	}
+	if ac, err := s.achievementRepo.FindByID(ctx, ref.MongoAchievementID); err == nil {
+		s.recordVersion(ctx, ac, userID)
+	}
 
 	return &att, nil
 }
+// submissionPeriod: periode tanggal kegiatan (details.eventDate); tanggal kosong / di luar semua periode
+// → periode yang berjalan saat submit. Tidak ada periode sama sekali = pengajuan ditolak.
+func (s *AchievementService) submissionPeriod(ctx context.Context, ref *model.AchievementReference, now time.Time) (*model.AcademicPeriod, error) {
+	ac, err := s.achievementRepo.FindByID(ctx, ref.MongoAchievementID)
+	if err != nil {
+		return nil, err
+	}
+
+	if date, ok := eventDateOf(ac.Details); ok {
+		period, err := s.periodRepo.FindByDate(date)
+		if err == nil {
+			return period, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	period, err := s.periodRepo.FindByDate(now)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNoOpenPeriod
+	}
+	if err != nil {
+		return nil, err
+	}
+	return period, nil
+}
+
+// eventDateOf: details.eventDate dari JSON (string) maupun dokumen Mongo (DateTime)
+func eventDateOf(details map[string]any) (time.Time, bool) {
+	switch v := details["eventDate"].(type) {
+	case time.Time:
+		return v, true
+	case primitive.DateTime:
+		return v.Time(), true
+	case string:
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// resolvePeriodID: periode akademik dari details.eventDate (atau hari ini), nil jika belum ada periode
+func (s *AchievementService) resolvePeriodID(details map[string]any) *string {
+	date := time.Now()
+	if t, ok := eventDateOf(details); ok {
+		date = t
+	}
+
+	period, err := s.periodRepo.FindByDate(date)
+	if err != nil {
+		return nil
+	}
+	return &period.ID
+}
+
+func sameID(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// checkDuplicates: deteksi duplikat tidak menggagalkan submit, hasilnya dicatat sebagai flag
+func (s *AchievementService) checkDuplicates(ctx context.Context, mongoID string, refIDs ...string) {
+	if _, err := s.duplicateSvc.CheckSubmission(ctx, mongoID, refIDs); err != nil {
+		log.Printf("[DUPLICATE] check %s failed: %v", mongoID, err)
+	}
+}
+
 func (s *AchievementService) logStatusChange(
 	refID string,
 	oldStatus model.AchievementStatus,
@@ -445,12 +645,12 @@ func (s *AchievementService) GetAchievementHistory(ctx context.Context, refID st
 func (s *AchievementService) GetAllAchievements(
 	ctx context.Context,
 	page, limit int,
-	status *string,
+	filter repository.AchievementReferenceFilter,
 ) ([]map[string]interface{}, int64, error) {
 
 	offset := (page - 1) * limit
 
-	refs, total, err := s.refRepo.FindAll(offset, limit, status)
+	refs, total, err := s.refRepo.FindAll(offset, limit, filter)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -468,6 +668,7 @@ func (s *AchievementService) GetAllAchievements(
 			"status":       ref.Status,
 			"student":      ref.Student,
 			"achievement":  ac,
+			"period":       ref.Period,
 			"submitted_at": ref.SubmittedAt,
 			"verified_at":  ref.VerifiedAt,
 		})
@@ -500,12 +701,23 @@ func (s *AchievementService) GetStatistics(ctx context.Context) (*model.Achievem
 func (s *AchievementService) GetAchievementsByStudentID(
 	ctx context.Context,
 	studentID string,
+	scope model.UnitScope,
 ) ([]model.Achievement, error) {
+	if !scope.IsGlobal() {
+		student, err := s.studentRepo.FindByID(studentID)
+		if err != nil {
+			return nil, ErrStudentProfileNotFound
+		}
+		if !scope.CoversStudent(student) {
+			return nil, ErrOutOfScope
+		}
+	}
 	return s.achievementRepo.FindByStudentID(ctx, studentID)
 }
 func (s *AchievementService) GetStudentReport(
 	ctx context.Context,
 	studentID string,
+	scope model.UnitScope,
 ) (map[string]interface{}, error) {
 
 	// ambil student
@@ -513,6 +725,9 @@ func (s *AchievementService) GetStudentReport(
 	if err != nil {
 		return nil, err
 	}
+	if !scope.CoversStudent(student) {
+		return nil, ErrOutOfScope
+	}
 
 	// ambil semua achievement mongo
 	achievements, err := s.achievementRepo.FindByStudentID(ctx, studentID)
@@ -557,46 +772,93 @@ func (s *AchievementService) GetAchievementDetail(
 	}
 
 	// 2. RBAC
+	if err := s.authorizeRefAccess(ref, userID, role); err != nil {
+		return nil, err
+	}
+
+	// 3. ambil detail Mongo
+	ach, err := s.achievementRepo.FindByID(ctx, ref.MongoAchievementID)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"reference": ref,
+		"achievement": ach,
+	}, nil
+}
+
+// actsForAdvisor: user adalah dosen wali tsb, atau dosen yang sedang memegang delegasi verifikasinya
+func (s *AchievementService) actsForAdvisor(advisor *model.Lecturer, userID string) bool {
+	if advisor.UserID == userID {
+		return true
+	}
+	delegate, err := s.lecturerRepo.FindByUserID(userID)
+	if err != nil {
+		return false
+	}
+	return s.isDelegate(advisor.ID, delegate.ID)
+}
+
+func (s *AchievementService) isDelegate(advisorLecturerID, delegateLecturerID string) bool {
+	if s.advisorSvc == nil {
+		return false
+	}
+	return s.advisorSvc.IsDelegate(advisorLecturerID, delegateLecturerID, time.Now())
+}
+
+// advisedStudents: mahasiswa bimbingan sendiri + mahasiswa dosen yang sedang mendelegasikan verifikasi
+func (s *AchievementService) advisedStudents(lecturerID string) ([]model.Student, error) {
+	students, err := s.studentRepo.FindByAdvisorLecturerID(lecturerID)
+	if err != nil || s.advisorSvc == nil {
+		return students, err
+	}
+
+	advisorIDs, err := s.advisorSvc.DelegatingAdvisorIDs(lecturerID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range advisorIDs {
+		delegated, err := s.studentRepo.FindByAdvisorLecturerID(id)
+		if err != nil {
+			return nil, err
+		}
+		students = append(students, delegated...)
+	}
+	return students, nil
+}
+
+// authorizeRefAccess: mahasiswa pemilik, dosen wali pembimbingnya (atau delegasinya), atau admin
+func (s *AchievementService) authorizeRefAccess(ref *model.AchievementReference, userID, role string) error {
 	switch role {
 	case "Mahasiswa":
 		student, err := s.studentRepo.FindByUserID(userID)
 		if err != nil || ref.StudentID != student.ID {
-			return nil, ErrNotOwner
+			return ErrNotOwner
 		}
 
 	case "Dosen Wali":
 		lect, err := s.lecturerRepo.FindByUserID(userID)
 		if err != nil {
-			return nil, ErrNotAdvisor
+			return ErrNotAdvisor
 		}
 
 		student, err := s.studentRepo.FindByID(ref.StudentID)
 		if err != nil {
-			return nil, ErrStudentProfileNotFound
+			return ErrStudentProfileNotFound
 		}
 
-		if student.AdvisorID != lect.ID {
-			return nil, ErrNotAdvisor
+		if student.AdvisorID != lect.ID && !s.isDelegate(student.AdvisorID, lect.ID) {
+			return ErrNotAdvisor
 		}
 
-
 	case "Admin":
 		// bebas
 
 	default:
-		return nil, ErrForbidden
+		return ErrForbidden
 	}
-
-	// 3. ambil detail Mongo
-	ach, err := s.achievementRepo.FindByID(ctx, ref.MongoAchievementID)
-	if err != nil {
-		return nil, err
-	}
-
-	return map[string]any{
-		"reference": ref,
-		"achievement": ach,
-	}, nil
+	return nil
 }
 func (s *AchievementService) UpdateAchievementDraft(
 	ctx context.Context,
@@ -618,6 +880,16 @@ func (s *AchievementService) UpdateAchievementDraft(
 		return nil, ErrNotOwner
 	}
 
+	if isTeamMemberRef(ref) {
+		return nil, ErrNotTeamLeader
+	}
+
+	// pemilik & anggota tim tidak boleh diubah lewat payload
+	payload.StudentID = ref.StudentID
+	payload.Team = nil
+	payload.PointSplit = ""
+	payload.DeletedAt = nil
+
 	// ✅ FIX Mongo validation
 	if v, ok := payload.Details["eventDate"]; ok {
 		if sDate, ok := v.(string); ok {
@@ -627,11 +899,28 @@ func (s *AchievementService) UpdateAchievementDraft(
 			}
 			payload.Details["eventDate"] = t
 		}
+
+		// eventDate berubah -> periode akademik ikut disesuaikan
+		periodID := s.resolvePeriodID(payload.Details)
+		if !sameID(ref.PeriodID, periodID) {
+			ref.PeriodID = periodID
+			if err := s.refRepo.Save(ref); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	payload.UpdatedAt = time.Now()
 
-	return s.achievementRepo.Update(ctx, ref.MongoAchievementID, payload)
+	// prestasi lama (sebelum ada versi): simpan isi sekarang dulu sebagai versi 1
+	s.ensureBaselineVersion(ctx, ref.MongoAchievementID)
+	updated, err := s.achievementRepo.Update(ctx, ref.MongoAchievementID, payload)
+	if err != nil {
+		return nil, err
+	}
+	s.recordVersion(ctx, updated, userID)
+
+	return updated, nil
 }
 
 func (s *AchievementService) GetAchievementsByRole(
@@ -660,7 +949,7 @@ func (s *AchievementService) GetAchievementsByRole(
 			return nil, ErrNotAdvisor
 		}
 
-		students, err := s.studentRepo.FindByAdvisorLecturerID(lect.ID)
+		students, err := s.advisedStudents(lect.ID)
 		if err != nil {
 			return nil, err
 		}
@@ -682,7 +971,7 @@ func (s *AchievementService) GetAchievementsByRole(
 		return s.combineRefsWithMongo(ctx, refs)
 
 	case "Admin":
-		refs, _, err := s.refRepo.FindAll(0, 1000, nil)
+		refs, _, err := s.refRepo.FindAll(0, 1000, repository.AchievementReferenceFilter{})
 		if err != nil {
 			return nil, err
 		}
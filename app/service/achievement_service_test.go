@@ -3,12 +3,14 @@ package service
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/nerhays/prestasi_uas/app/model"
 	"github.com/nerhays/prestasi_uas/app/repository/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"gorm.io/gorm"
 )
 
 func TestCreateAchievementForUser_Success(t *testing.T) {
@@ -18,6 +20,7 @@ func TestCreateAchievementForUser_Success(t *testing.T) {
 	userRepo := new(mocks.UserRepositoryMock)
 	lectRepo := new(mocks.LecturerRepositoryMock)
 	logRepo := new(mocks.AchievementStatusLogRepositoryMock)
+	periodRepo := new(mocks.AcademicPeriodRepositoryMock)
 
 	svc := NewAchievementService(
 		achRepo,
@@ -26,6 +29,12 @@ func TestCreateAchievementForUser_Success(t *testing.T) {
 		userRepo,
 		lectRepo,
 		logRepo,
+		periodRepo,
+		new(mocks.AchievementTeamRepositoryMock),
+		nil,
+		NewDuplicateService(achRepo, new(mocks.DuplicateFlagRepositoryMock)),
+		nil,
+		model.TeamPointSplitEqual,
 	)
 
 	userID := "user-1"
@@ -49,8 +58,13 @@ func TestCreateAchievementForUser_Success(t *testing.T) {
 
 	ref := &model.AchievementReference{ID: "ref-1"}
 
+	periodID := "period-1"
+	periodRepo.
+		On("FindByDate", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)).
+		Return(&model.AcademicPeriod{ID: periodID}, nil)
+
 	refRepo.
-		On("CreateDraft", student.ID, mock.Anything).
+		On("CreateDraft", student.ID, mock.Anything, &periodID).
 		Return(ref, nil)
 
 	logRepo.
@@ -71,9 +85,15 @@ func TestSubmitAchievement_Success(t *testing.T) {
 	userRepo := new(mocks.UserRepositoryMock)
 	lectRepo := new(mocks.LecturerRepositoryMock)
 	logRepo := new(mocks.AchievementStatusLogRepositoryMock)
+	periodRepo := new(mocks.AcademicPeriodRepositoryMock)
 
 	svc := NewAchievementService(
-		achRepo, studentRepo, refRepo, userRepo, lectRepo, logRepo,
+		achRepo, studentRepo, refRepo, userRepo, lectRepo, logRepo, periodRepo,
+		new(mocks.AchievementTeamRepositoryMock),
+		nil,
+		NewDuplicateService(achRepo, new(mocks.DuplicateFlagRepositoryMock)),
+		nil,
+		model.TeamPointSplitEqual,
 	)
 
 	userID := "user-1"
@@ -95,10 +115,16 @@ func TestSubmitAchievement_Success(t *testing.T) {
 		mock.AnythingOfType("*model.AchievementStatusLog"),
 	).Return(nil)
 
+	ac := &model.Achievement{StudentID: student.ID, Title: "Juara 1 Gemastik"}
+	achRepo.On("FindByID", mock.Anything, ref.MongoAchievementID).Return(ac, nil)
+	achRepo.On("FindDuplicateCandidates", mock.Anything, ac, mock.Anything).Return([]model.Achievement{}, nil)
+	periodRepo.On("FindByDate", mock.Anything).Return(&model.AcademicPeriod{ID: "period-1"}, nil)
+
 	updated, err := svc.SubmitAchievement(context.Background(), userID, refID)
 
 	assert.NoError(t, err)
 	assert.Equal(t, model.AchievementStatusSubmitted, updated.Status)
+	assert.Equal(t, "period-1", *updated.PeriodID)
 }
 func TestVerifyAchievement_ByAdvisor_Success(t *testing.T) {
 	refRepo := new(mocks.AchievementReferenceRepositoryMock)
@@ -107,9 +133,15 @@ func TestVerifyAchievement_ByAdvisor_Success(t *testing.T) {
 	userRepo := new(mocks.UserRepositoryMock)
 	achRepo := new(mocks.AchievementRepositoryMock)
 	logRepo := new(mocks.AchievementStatusLogRepositoryMock)
+	periodRepo := new(mocks.AcademicPeriodRepositoryMock)
 
 	svc := NewAchievementService(
-		achRepo, studentRepo, refRepo, userRepo, lectRepo, logRepo,
+		achRepo, studentRepo, refRepo, userRepo, lectRepo, logRepo, periodRepo,
+		new(mocks.AchievementTeamRepositoryMock),
+		nil,
+		NewDuplicateService(achRepo, new(mocks.DuplicateFlagRepositoryMock)),
+		nil,
+		model.TeamPointSplitEqual,
 	)
 
 	ref := &model.AchievementReference{
@@ -151,3 +183,94 @@ func TestVerifyAchievement_ByAdvisor_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, model.AchievementStatusVerified, updated.Status)
 }
+
+func TestSubmitAchievement_SubmissionWindowClosed(t *testing.T) {
+	studentRepo := new(mocks.StudentRepositoryMock)
+	refRepo := new(mocks.AchievementReferenceRepositoryMock)
+	achRepo := new(mocks.AchievementRepositoryMock)
+	periodRepo := new(mocks.AcademicPeriodRepositoryMock)
+
+	svc := NewAchievementService(
+		achRepo,
+		studentRepo,
+		refRepo,
+		new(mocks.UserRepositoryMock),
+		new(mocks.LecturerRepositoryMock),
+		new(mocks.AchievementStatusLogRepositoryMock),
+		periodRepo,
+		new(mocks.AchievementTeamRepositoryMock),
+		nil,
+		nil,
+		nil,
+		model.TeamPointSplitEqual,
+	)
+
+	periodID := "period-1"
+	closedAt := time.Now().Add(-24 * time.Hour)
+	student := &model.Student{ID: "student-1"}
+	ref := &model.AchievementReference{
+		ID:        "ref-1",
+		StudentID: student.ID,
+		PeriodID:  &periodID,
+		Status:    model.AchievementStatusDraft,
+	}
+
+	studentRepo.On("FindByUserID", "user-1").Return(student, nil)
+	refRepo.On("GetByID", "ref-1").Return(ref, nil)
+	achRepo.On("FindByID", mock.Anything, ref.MongoAchievementID).Return(&model.Achievement{}, nil)
+	periodRepo.On("FindByDate", mock.Anything).
+		Return(&model.AcademicPeriod{ID: periodID, SubmissionClosesAt: &closedAt}, nil)
+
+	_, err := svc.SubmitAchievement(context.Background(), "user-1", "ref-1")
+
+	assert.ErrorIs(t, err, ErrSubmissionClosed)
+	assert.Equal(t, model.AchievementStatusDraft, ref.Status)
+	refRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestSubmitAchievement_NoPeriodOrLookupFailed(t *testing.T) {
+	studentRepo := new(mocks.StudentRepositoryMock)
+	refRepo := new(mocks.AchievementReferenceRepositoryMock)
+	achRepo := new(mocks.AchievementRepositoryMock)
+	periodRepo := new(mocks.AcademicPeriodRepositoryMock)
+
+	svc := NewAchievementService(
+		achRepo,
+		studentRepo,
+		refRepo,
+		new(mocks.UserRepositoryMock),
+		new(mocks.LecturerRepositoryMock),
+		new(mocks.AchievementStatusLogRepositoryMock),
+		periodRepo,
+		new(mocks.AchievementTeamRepositoryMock),
+		nil,
+		nil,
+		nil,
+		model.TeamPointSplitEqual,
+	)
+
+	eventDate := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+	student := &model.Student{ID: "student-1"}
+	ref := &model.AchievementReference{ID: "ref-1", StudentID: student.ID, Status: model.AchievementStatusDraft}
+
+	studentRepo.On("FindByUserID", "user-1").Return(student, nil)
+	refRepo.On("GetByID", "ref-1").Return(ref, nil)
+	achRepo.On("FindByID", mock.Anything, ref.MongoAchievementID).
+		Return(&model.Achievement{Details: map[string]any{"eventDate": eventDate.Format("2006-01-02")}}, nil)
+
+	// tanggal kegiatan di luar periode dan hari ini juga tidak ada periode
+	periodRepo.On("FindByDate", eventDate).Return(nil, gorm.ErrRecordNotFound).Once()
+	periodRepo.On("FindByDate", mock.Anything).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := svc.SubmitAchievement(context.Background(), "user-1", "ref-1")
+	assert.ErrorIs(t, err, ErrNoOpenPeriod)
+
+	// lookup gagal: tidak dilewati
+	periodRepo.On("FindByDate", eventDate).Return(nil, assert.AnError).Once()
+
+	_, err = svc.SubmitAchievement(context.Background(), "user-1", "ref-1")
+	assert.ErrorIs(t, err, assert.AnError)
+
+	assert.Equal(t, model.AchievementStatusDraft, ref.Status)
+	refRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
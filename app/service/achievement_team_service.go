@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+)
+
+// Prestasi tim: satu dokumen Mongo (studentId = ketua, team = semua anggota),
+// setiap anggota punya achievement_reference sendiri yang diverifikasi dosen wali masing-masing.
+
+var (
+	ErrTeamMembersRequired   = errors.New("team achievement needs at least one other member")
+	ErrTeamMemberNotFound    = errors.New("team member not found")
+	ErrInvalidPointSplit     = errors.New("point split must be one of: equal, full, leader_weighted")
+	ErrNotTeamLeader         = errors.New("only the team leader can change or submit a team achievement")
+	ErrTeamInvitationPending = errors.New("all team invitations must be answered before submission")
+	ErrInvitationNotFound    = errors.New("team invitation not found")
+	ErrInvitationAnswered    = errors.New("team invitation has already been answered")
+)
+
+// CreateTeamAchievement: ketua (user login) membuat prestasi tim dan mengundang anggota berdasarkan NIM.
+// Draft ketua langsung dibuat; draft anggota dibuat saat undangan diterima.
+func (s *AchievementService) CreateTeamAchievement(
+	ctx context.Context,
+	userID string,
+	ac *model.Achievement,
+	memberNIMs []string,
+) (*model.Achievement, *model.AchievementReference, []model.AchievementTeamMember, error) {
+	leader, err := s.studentRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, nil, nil, ErrStudentProfileNotFound
+	}
+
+	if ac.PointSplit == "" {
+		ac.PointSplit = s.defaultPointSplit
+	}
+	if !ac.PointSplit.Valid() {
+		return nil, nil, nil, ErrInvalidPointSplit
+	}
+
+	// anggota unik, tanpa ketua
+	seen := map[string]bool{leader.ID: true}
+	var members []*model.Student
+	for _, nim := range memberNIMs {
+		nim = strings.TrimSpace(nim)
+		if nim == "" {
+			continue
+		}
+		st, err := s.studentRepo.FindByNIM(nim)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%w: %s", ErrTeamMemberNotFound, nim)
+		}
+		if seen[st.ID] {
+			continue
+		}
+		seen[st.ID] = true
+		members = append(members, st)
+	}
+	if len(members) == 0 {
+		return nil, nil, nil, ErrTeamMembersRequired
+	}
+
+	ac.Team = []model.TeamMember{{StudentID: leader.ID, Role: model.TeamRoleLeader}}
+	for _, st := range members {
+		ac.Team = append(ac.Team, model.TeamMember{StudentID: st.ID, Role: model.TeamRoleMember})
+	}
+
+	createdAc, ref, err := s.CreateAchievementForUser(ctx, userID, ac)
+	if err != nil {
+		return createdAc, ref, nil, err
+	}
+
+	leaderRole := model.TeamRoleLeader
+	ref.TeamRole = &leaderRole
+	if err := s.refRepo.Save(ref); err != nil {
+		return createdAc, ref, nil, err
+	}
+
+	now := time.Now()
+	team := []model.AchievementTeamMember{{
+		MongoAchievementID: ref.MongoAchievementID,
+		StudentID:          leader.ID,
+		Role:               model.TeamRoleLeader,
+		Status:             model.TeamInvitationAccepted,
+		ReferenceID:        &ref.ID,
+		InvitedBy:          leader.ID,
+		RespondedAt:        &now,
+	}}
+	for _, st := range members {
+		team = append(team, model.AchievementTeamMember{
+			MongoAchievementID: ref.MongoAchievementID,
+			StudentID:          st.ID,
+			Role:               model.TeamRoleMember,
+			Status:             model.TeamInvitationPending,
+			InvitedBy:          leader.ID,
+		})
+	}
+	if err := s.teamRepo.CreateMembers(team); err != nil {
+		return createdAc, ref, nil, err
+	}
+
+	return createdAc, ref, team, nil
+}
+
+// GetMyTeamInvitations: undangan tim yang belum dijawab mahasiswa login, beserta dokumen prestasinya
+func (s *AchievementService) GetMyTeamInvitations(ctx context.Context, userID string) ([]map[string]any, error) {
+	student, err := s.studentRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, ErrStudentProfileNotFound
+	}
+
+	invitations, err := s.teamRepo.FindPendingByStudentID(student.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	mongoIDs := make([]string, 0, len(invitations))
+	for _, inv := range invitations {
+		mongoIDs = append(mongoIDs, inv.MongoAchievementID)
+	}
+	achs, err := s.achievementRepo.FindByIDs(ctx, mongoIDs)
+	if err != nil {
+		return nil, err
+	}
+	achMap := map[string]model.Achievement{}
+	for _, a := range achs {
+		achMap[a.ID.Hex()] = a
+	}
+
+	result := make([]map[string]any, 0, len(invitations))
+	for _, inv := range invitations {
+		ac, ok := achMap[inv.MongoAchievementID]
+		if !ok {
+			// prestasi sudah dihapus
+			continue
+		}
+		result = append(result, map[string]any{
+			"invitation":  inv,
+			"achievement": ac,
+		})
+	}
+	return result, nil
+}
+
+// RespondTeamInvitation: terima (buat draft reference anggota) atau tolak (keluar dari tim)
+func (s *AchievementService) RespondTeamInvitation(
+	ctx context.Context,
+	userID, invitationID string,
+	accept bool,
+) (*model.AchievementTeamMember, error) {
+	student, err := s.studentRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, ErrStudentProfileNotFound
+	}
+
+	inv, err := s.teamRepo.FindByID(invitationID)
+	if err != nil || inv.StudentID != student.ID {
+		return nil, ErrInvitationNotFound
+	}
+	if inv.Status != model.TeamInvitationPending {
+		return nil, ErrInvitationAnswered
+	}
+
+	members, err := s.teamRepo.FindByMongoID(inv.MongoAchievementID)
+	if err != nil {
+		return nil, err
+	}
+	leaderRef, err := s.teamLeaderRef(members)
+	if err != nil {
+		return nil, err
+	}
+	// tim sudah disubmit / dihapus
+	if leaderRef.Status != model.AchievementStatusDraft {
+		return nil, ErrInvalidStatus
+	}
+
+	now := time.Now()
+	if accept {
+		ref, err := s.refRepo.CreateDraft(student.ID, inv.MongoAchievementID, leaderRef.PeriodID)
+		if err != nil {
+			return nil, err
+		}
+		memberRole := model.TeamRoleMember
+		ref.TeamRole = &memberRole
+		if err := s.refRepo.Save(ref); err != nil {
+			return nil, err
+		}
+		s.logStatusChange(ref.ID, "", model.AchievementStatusDraft, userID, nil)
+
+		inv.Status = model.TeamInvitationAccepted
+		inv.ReferenceID = &ref.ID
+	} else {
+		team := make([]model.TeamMember, 0, len(members))
+		for _, m := range members {
+			if m.ID == inv.ID || m.Status == model.TeamInvitationDeclined {
+				continue
+			}
+			team = append(team, model.TeamMember{StudentID: m.StudentID, Role: m.Role})
+		}
+		if err := s.achievementRepo.SetTeam(ctx, inv.MongoAchievementID, team); err != nil {
+			return nil, err
+		}
+
+		inv.Status = model.TeamInvitationDeclined
+	}
+
+	inv.RespondedAt = &now
+	if err := s.teamRepo.Save(inv); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// GetTeamMembers: anggota tim + status undangan; akses mengikuti aturan detail prestasi
+func (s *AchievementService) GetTeamMembers(
+	ctx context.Context,
+	refID, userID, role string,
+) ([]model.AchievementTeamMember, error) {
+	detail, err := s.GetAchievementDetail(ctx, refID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := detail["reference"].(*model.AchievementReference)
+	if ref.TeamRole == nil {
+		return []model.AchievementTeamMember{}, nil
+	}
+	return s.teamRepo.FindByMongoID(ref.MongoAchievementID)
+}
+
+// submitTeam: submit draft semua anggota yang sudah menerima undangan + hitung bagian poin
+func (s *AchievementService) submitTeam(
+	ctx context.Context,
+	leaderRef *model.AchievementReference,
+	userID string,
+	now time.Time,
+) (*model.AchievementReference, error) {
+	members, err := s.teamRepo.FindByMongoID(leaderRef.MongoAchievementID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := map[string]model.TeamRole{}
+	for _, m := range members {
+		if m.Status == model.TeamInvitationPending {
+			return nil, ErrTeamInvitationPending
+		}
+		if m.Status == model.TeamInvitationAccepted && m.ReferenceID != nil {
+			roles[m.StudentID] = m.Role
+		}
+	}
+
+	ac, err := s.achievementRepo.FindByID(ctx, leaderRef.MongoAchievementID)
+	if err != nil {
+		return nil, err
+	}
+	shares := ac.PointSplit.Shares(ac.Points, roles)
+
+	var submitted []string
+	for _, m := range members {
+		if _, ok := roles[m.StudentID]; !ok {
+			continue
+		}
+
+		ref := leaderRef
+		if *m.ReferenceID != leaderRef.ID {
+			if ref, err = s.refRepo.GetByID(*m.ReferenceID); err != nil {
+				return nil, err
+			}
+		}
+		if ref.Status != model.AchievementStatusDraft {
+			continue
+		}
+
+		share := shares[m.StudentID]
+		old := ref.Status
+		ref.Status = model.AchievementStatusSubmitted
+		ref.SubmittedAt = &now
+		ref.PointShare = &share
+		ref.PeriodID = leaderRef.PeriodID
+		if err := s.refRepo.Save(ref); err != nil {
+			return nil, err
+		}
+		s.logStatusChange(ref.ID, old, ref.Status, userID, nil)
+		submitted = append(submitted, ref.ID)
+	}
+	s.checkDuplicates(ctx, leaderRef.MongoAchievementID, submitted...)
+
+	return leaderRef, nil
+}
+
+// deleteTeamMembers: dipanggil setelah draft ketua dihapus
+func (s *AchievementService) deleteTeamMembers(leaderRef *model.AchievementReference) error {
+	members, err := s.teamRepo.FindByMongoID(leaderRef.MongoAchievementID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range members {
+		m := &members[i]
+		switch {
+		case m.Status == model.TeamInvitationPending:
+			m.Status = model.TeamInvitationDeclined
+			m.RespondedAt = &now
+			if err := s.teamRepo.Save(m); err != nil {
+				return err
+			}
+		case m.ReferenceID != nil && *m.ReferenceID != leaderRef.ID:
+			ref, err := s.refRepo.GetByID(*m.ReferenceID)
+			if err != nil || ref.Status != model.AchievementStatusDraft {
+				continue
+			}
+			ref.Status = model.AchievementStatusDeleted
+			if err := s.refRepo.Save(ref); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// restoreTeamMembers: draft anggota yang ikut terhapus kembali jadi draft;
+// undangan yang dibatalkan saat penghapusan tetap declined
+func (s *AchievementService) restoreTeamMembers(leaderRef *model.AchievementReference, userID string) error {
+	members, err := s.teamRepo.FindByMongoID(leaderRef.MongoAchievementID)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range members {
+		if m.ReferenceID == nil || *m.ReferenceID == leaderRef.ID {
+			continue
+		}
+		ref, err := s.refRepo.GetByID(*m.ReferenceID)
+		if err != nil || ref.Status != model.AchievementStatusDeleted {
+			continue
+		}
+		ref.Status = model.AchievementStatusDraft
+		if err := s.refRepo.Save(ref); err != nil {
+			return err
+		}
+		s.logStatusChange(ref.ID, model.AchievementStatusDeleted, ref.Status, userID, nil)
+	}
+	return nil
+}
+
+func (s *AchievementService) teamLeaderRef(members []model.AchievementTeamMember) (*model.AchievementReference, error) {
+	for _, m := range members {
+		if m.Role == model.TeamRoleLeader && m.ReferenceID != nil {
+			ref, err := s.refRepo.GetByID(*m.ReferenceID)
+			if err != nil {
+				return nil, ErrRefNotFound
+			}
+			return ref, nil
+		}
+	}
+	return nil, ErrRefNotFound
+}
+
+func isTeamMemberRef(ref *model.AchievementReference) bool {
+	return ref.TeamRole != nil && *ref.TeamRole == model.TeamRoleMember
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type teamTestRepos struct {
+	achRepo     *mocks.AchievementRepositoryMock
+	studentRepo *mocks.StudentRepositoryMock
+	refRepo     *mocks.AchievementReferenceRepositoryMock
+	logRepo     *mocks.AchievementStatusLogRepositoryMock
+	periodRepo  *mocks.AcademicPeriodRepositoryMock
+	teamRepo    *mocks.AchievementTeamRepositoryMock
+}
+
+func newTeamTestService() (*AchievementService, teamTestRepos) {
+	r := teamTestRepos{
+		achRepo:     new(mocks.AchievementRepositoryMock),
+		studentRepo: new(mocks.StudentRepositoryMock),
+		refRepo:     new(mocks.AchievementReferenceRepositoryMock),
+		logRepo:     new(mocks.AchievementStatusLogRepositoryMock),
+		periodRepo:  new(mocks.AcademicPeriodRepositoryMock),
+		teamRepo:    new(mocks.AchievementTeamRepositoryMock),
+	}
+	svc := NewAchievementService(
+		r.achRepo,
+		r.studentRepo,
+		r.refRepo,
+		new(mocks.UserRepositoryMock),
+		new(mocks.LecturerRepositoryMock),
+		r.logRepo,
+		r.periodRepo,
+		r.teamRepo,
+		nil,
+		NewDuplicateService(r.achRepo, new(mocks.DuplicateFlagRepositoryMock)),
+		nil,
+		model.TeamPointSplitEqual,
+	)
+	return svc, r
+}
+
+func strPtr(s string) *string { return &s }
+
+func teamRolePtr(r model.TeamRole) *model.TeamRole { return &r }
+
+func TestTeamPointSplit_Shares(t *testing.T) {
+	roles := map[string]model.TeamRole{
+		"leader": model.TeamRoleLeader,
+		"m1":     model.TeamRoleMember,
+		"m2":     model.TeamRoleMember,
+	}
+
+	equal := model.TeamPointSplitEqual.Shares(90, roles)
+	assert.Equal(t, 30.0, equal["leader"])
+	assert.Equal(t, 30.0, equal["m1"])
+
+	full := model.TeamPointSplitFull.Shares(90, roles)
+	assert.Equal(t, 90.0, full["m2"])
+
+	weighted := model.TeamPointSplitLeaderWeighted.Shares(100, roles)
+	assert.Equal(t, 50.0, weighted["leader"])
+	assert.Equal(t, 25.0, weighted["m1"])
+}
+
+func TestCreateTeamAchievement_InvitesMembers(t *testing.T) {
+	svc, r := newTeamTestService()
+
+	leader := &model.Student{ID: "student-1"}
+	member := &model.Student{ID: "student-2"}
+	r.studentRepo.On("FindByUserID", "user-1").Return(leader, nil)
+	r.studentRepo.On("FindByNIM", "2201").Return(member, nil)
+	r.studentRepo.On("FindByNIM", "2200").Return(leader, nil)
+	r.periodRepo.On("FindByDate", mock.Anything).Return(nil, assert.AnError)
+
+	ach := &model.Achievement{Title: "Gemastik", Points: 60}
+	r.achRepo.On("Create", mock.Anything, ach).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*model.Achievement).ID = primitive.NewObjectID()
+		}).
+		Return(ach, nil)
+
+	ref := &model.AchievementReference{ID: "ref-1", StudentID: leader.ID}
+	r.refRepo.On("CreateDraft", leader.ID, mock.Anything, (*string)(nil)).Return(ref, nil)
+	r.refRepo.On("Save", ref).Return(nil)
+	r.logRepo.On("Create", mock.Anything).Return(nil)
+	r.teamRepo.On("CreateMembers", mock.Anything).Return(nil)
+
+	// NIM ketua sendiri & duplikat diabaikan
+	ac, created, team, err := svc.CreateTeamAchievement(context.Background(), "user-1", ach, []string{"2201", " 2201 ", "2200"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.TeamPointSplitEqual, ac.PointSplit)
+	assert.Len(t, ac.Team, 2)
+	assert.Equal(t, model.TeamRoleLeader, *created.TeamRole)
+	assert.Len(t, team, 2)
+	assert.Equal(t, model.TeamInvitationAccepted, team[0].Status)
+	assert.Equal(t, model.TeamInvitationPending, team[1].Status)
+	assert.Equal(t, member.ID, team[1].StudentID)
+}
+
+func TestCreateTeamAchievement_RequiresMembers(t *testing.T) {
+	svc, r := newTeamTestService()
+
+	r.studentRepo.On("FindByUserID", "user-1").Return(&model.Student{ID: "student-1"}, nil)
+
+	_, _, _, err := svc.CreateTeamAchievement(context.Background(), "user-1", &model.Achievement{}, nil)
+
+	assert.ErrorIs(t, err, ErrTeamMembersRequired)
+}
+
+func TestSubmitTeamAchievement_PendingInvitation(t *testing.T) {
+	svc, r := newTeamTestService()
+
+	leaderRef := &model.AchievementReference{
+		ID:                 "ref-1",
+		StudentID:          "student-1",
+		MongoAchievementID: "mongo-1",
+		Status:             model.AchievementStatusDraft,
+		TeamRole:           teamRolePtr(model.TeamRoleLeader),
+	}
+	r.studentRepo.On("FindByUserID", "user-1").Return(&model.Student{ID: "student-1"}, nil)
+	r.refRepo.On("GetByID", "ref-1").Return(leaderRef, nil)
+	r.teamRepo.On("FindByMongoID", "mongo-1").Return([]model.AchievementTeamMember{
+		{StudentID: "student-1", Role: model.TeamRoleLeader, Status: model.TeamInvitationAccepted, ReferenceID: strPtr("ref-1")},
+		{StudentID: "student-2", Role: model.TeamRoleMember, Status: model.TeamInvitationPending},
+	}, nil)
+	r.achRepo.On("FindByID", mock.Anything, "mongo-1").Return(&model.Achievement{}, nil)
+	r.periodRepo.On("FindByDate", mock.Anything).Return(&model.AcademicPeriod{ID: "period-1"}, nil)
+
+	_, err := svc.SubmitAchievement(context.Background(), "user-1", "ref-1")
+
+	assert.ErrorIs(t, err, ErrTeamInvitationPending)
+	r.refRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestSubmitTeamAchievement_SplitsPoints(t *testing.T) {
+	svc, r := newTeamTestService()
+
+	leaderRef := &model.AchievementReference{
+		ID:                 "ref-1",
+		StudentID:          "student-1",
+		MongoAchievementID: "mongo-1",
+		Status:             model.AchievementStatusDraft,
+		TeamRole:           teamRolePtr(model.TeamRoleLeader),
+	}
+	memberRef := &model.AchievementReference{
+		ID:                 "ref-2",
+		StudentID:          "student-2",
+		MongoAchievementID: "mongo-1",
+		Status:             model.AchievementStatusDraft,
+		TeamRole:           teamRolePtr(model.TeamRoleMember),
+	}
+	r.studentRepo.On("FindByUserID", "user-1").Return(&model.Student{ID: "student-1"}, nil)
+	r.refRepo.On("GetByID", "ref-1").Return(leaderRef, nil)
+	r.refRepo.On("GetByID", "ref-2").Return(memberRef, nil)
+	r.refRepo.On("Save", mock.Anything).Return(nil)
+	r.logRepo.On("Create", mock.Anything).Return(nil)
+	r.teamRepo.On("FindByMongoID", "mongo-1").Return([]model.AchievementTeamMember{
+		{StudentID: "student-1", Role: model.TeamRoleLeader, Status: model.TeamInvitationAccepted, ReferenceID: strPtr("ref-1")},
+		{StudentID: "student-2", Role: model.TeamRoleMember, Status: model.TeamInvitationAccepted, ReferenceID: strPtr("ref-2")},
+		{StudentID: "student-3", Role: model.TeamRoleMember, Status: model.TeamInvitationDeclined},
+	}, nil)
+	r.achRepo.On("FindByID", mock.Anything, "mongo-1").Return(&model.Achievement{
+		Points:     90,
+		PointSplit: model.TeamPointSplitLeaderWeighted,
+	}, nil)
+	r.achRepo.On("FindDuplicateCandidates", mock.Anything, mock.Anything, mock.Anything).Return([]model.Achievement{}, nil)
+	r.periodRepo.On("FindByDate", mock.Anything).Return(&model.AcademicPeriod{ID: "period-1"}, nil)
+
+	res, err := svc.SubmitAchievement(context.Background(), "user-1", "ref-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.AchievementStatusSubmitted, res.Status)
+	assert.Equal(t, 60.0, *leaderRef.PointShare)
+	assert.Equal(t, model.AchievementStatusSubmitted, memberRef.Status)
+	assert.Equal(t, 30.0, *memberRef.PointShare)
+	assert.Equal(t, "period-1", *memberRef.PeriodID)
+}
+
+func TestSubmitTeamAchievement_MemberCannotSubmit(t *testing.T) {
+	svc, r := newTeamTestService()
+
+	memberRef := &model.AchievementReference{
+		ID:        "ref-2",
+		StudentID: "student-2",
+		Status:    model.AchievementStatusDraft,
+		TeamRole:  teamRolePtr(model.TeamRoleMember),
+	}
+	r.studentRepo.On("FindByUserID", "user-2").Return(&model.Student{ID: "student-2"}, nil)
+	r.refRepo.On("GetByID", "ref-2").Return(memberRef, nil)
+
+	_, err := svc.SubmitAchievement(context.Background(), "user-2", "ref-2")
+
+	assert.ErrorIs(t, err, ErrNotTeamLeader)
+}
+
+func TestRespondTeamInvitation_AcceptCreatesMemberDraft(t *testing.T) {
+	svc, r := newTeamTestService()
+
+	periodID := "period-1"
+	leaderRef := &model.AchievementReference{ID: "ref-1", PeriodID: &periodID, Status: model.AchievementStatusDraft}
+	inv := &model.AchievementTeamMember{
+		ID:                 "inv-2",
+		MongoAchievementID: "mongo-1",
+		StudentID:          "student-2",
+		Role:               model.TeamRoleMember,
+		Status:             model.TeamInvitationPending,
+	}
+	memberRef := &model.AchievementReference{ID: "ref-2", StudentID: "student-2"}
+
+	r.studentRepo.On("FindByUserID", "user-2").Return(&model.Student{ID: "student-2"}, nil)
+	r.teamRepo.On("FindByID", "inv-2").Return(inv, nil)
+	r.teamRepo.On("FindByMongoID", "mongo-1").Return([]model.AchievementTeamMember{
+		{StudentID: "student-1", Role: model.TeamRoleLeader, Status: model.TeamInvitationAccepted, ReferenceID: strPtr("ref-1")},
+		*inv,
+	}, nil)
+	r.refRepo.On("GetByID", "ref-1").Return(leaderRef, nil)
+	r.refRepo.On("CreateDraft", "student-2", "mongo-1", &periodID).Return(memberRef, nil)
+	r.refRepo.On("Save", memberRef).Return(nil)
+	r.logRepo.On("Create", mock.Anything).Return(nil)
+	r.teamRepo.On("Save", inv).Return(nil)
+
+	res, err := svc.RespondTeamInvitation(context.Background(), "user-2", "inv-2", true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.TeamInvitationAccepted, res.Status)
+	assert.Equal(t, "ref-2", *res.ReferenceID)
+	assert.Equal(t, model.TeamRoleMember, *memberRef.TeamRole)
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+// jumlah dokumen yang dihapus permanen per putaran purge
+const trashPurgeBatch = 100
+
+// AchievementTrashService: tempat sampah prestasi (soft delete) dan penghapusan permanen setelah masa retensi
+type AchievementTrashService struct {
+	achievementRepo repository.AchievementRepository
+	refRepo         repository.AchievementReferenceRepository
+	versionRepo     repository.AchievementVersionRepository
+	// removeFile: hapus file lampiran dari URL-nya (utils.RemoveUpload)
+	removeFile func(fileURL string) error
+	// retention: 0 = tidak pernah dihapus permanen
+	retention time.Duration
+}
+
+func NewAchievementTrashService(
+	achievementRepo repository.AchievementRepository,
+	refRepo repository.AchievementReferenceRepository,
+	versionRepo repository.AchievementVersionRepository,
+	removeFile func(fileURL string) error,
+	retention time.Duration,
+) *AchievementTrashService {
+	return &AchievementTrashService{
+		achievementRepo: achievementRepo,
+		refRepo:         refRepo,
+		versionRepo:     versionRepo,
+		removeFile:      removeFile,
+		retention:       retention,
+	}
+}
+
+// GetTrash: prestasi berstatus deleted semua mahasiswa (sesuai scope admin), beserta jadwal hapus permanen
+func (s *AchievementTrashService) GetTrash(
+	ctx context.Context,
+	page, limit int,
+	scope model.UnitScope,
+) ([]map[string]interface{}, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	status := string(model.AchievementStatusDeleted)
+	refs, total, err := s.refRepo.FindAll((page-1)*limit, limit, repository.AchievementReferenceFilter{
+		Status: &status,
+		Scope:  scope,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := []map[string]interface{}{}
+	for _, ref := range refs {
+		item := map[string]interface{}{
+			"reference_id": ref.ID,
+			"student":      ref.Student,
+			"team_role":    ref.TeamRole,
+			"achievement":  nil,
+			"deleted_at":   nil,
+			"purge_at":     nil,
+		}
+		if ac, err := s.achievementRepo.FindByID(ctx, ref.MongoAchievementID); err == nil {
+			item["achievement"] = ac
+			if ac.DeletedAt != nil {
+				item["deleted_at"] = ac.DeletedAt
+				if s.retention > 0 {
+					item["purge_at"] = ac.DeletedAt.Add(s.retention)
+				}
+			}
+		}
+		result = append(result, item)
+	}
+
+	return result, total, nil
+}
+
+// PurgeExpired: hapus permanen prestasi yang sudah di tempat sampah lebih lama dari masa retensi:
+// reference + log status + anggota tim (Postgres), versi + dokumen (Mongo), dan file lampiran
+func (s *AchievementTrashService) PurgeExpired(ctx context.Context, now time.Time) (int, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+
+	docs, err := s.achievementRepo.FindDeletedBefore(ctx, now.Add(-s.retention), trashPurgeBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for i := range docs {
+		ok, err := s.purge(ctx, &docs[i])
+		if err != nil {
+			log.Printf("[TRASH] purge %s failed: %v", docs[i].ID.Hex(), err)
+			continue
+		}
+		if ok {
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (s *AchievementTrashService) purge(ctx context.Context, ac *model.Achievement) (bool, error) {
+	mongoID := ac.ID.Hex()
+
+	// dokumen bisa dipakai reference yang masih aktif (mis. anggota tim yang sudah submit) → jangan dihapus
+	refs, err := s.refRepo.FindByMongoID(mongoID)
+	if err != nil {
+		return false, err
+	}
+	for _, ref := range refs {
+		if ref.Status != model.AchievementStatusDeleted {
+			return false, nil
+		}
+	}
+
+	// Postgres dulu: kalau gagal, dokumen Mongo masih ada dan dicoba lagi di putaran berikutnya
+	if err := s.refRepo.PurgeByMongoID(mongoID); err != nil {
+		return false, err
+	}
+	if s.versionRepo != nil {
+		if err := s.versionRepo.DeleteByAchievementID(ctx, mongoID); err != nil {
+			return false, err
+		}
+	}
+	for _, url := range ac.AttachmentURLs() {
+		if err := s.removeFile(url); err != nil {
+			log.Printf("[TRASH] remove attachment %s failed: %v", url, err)
+		}
+	}
+	if err := s.achievementRepo.HardDelete(ctx, mongoID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *AchievementTrashService) RunPurgeLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			n, err := s.PurgeExpired(ctx, now)
+			if err != nil {
+				log.Printf("[TRASH] %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("[TRASH] purged %d achievement(s) past retention", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPurgeExpired_DeletesEverythingOfExpiredAchievement(t *testing.T) {
+	achRepo := new(mocks.AchievementRepositoryMock)
+	refRepo := new(mocks.AchievementReferenceRepositoryMock)
+	versionRepo := new(mocks.AchievementVersionRepositoryMock)
+	var removed []string
+	svc := NewAchievementTrashService(achRepo, refRepo, versionRepo, func(url string) error {
+		removed = append(removed, url)
+		return nil
+	}, 30*24*time.Hour)
+
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	expired := model.Achievement{
+		ID:          primitive.NewObjectID(),
+		Attachments: []any{primitive.D{{Key: "fileUrl", Value: "/uploads/achievements/a.pdf"}}},
+	}
+	// anggota tim masih punya reference aktif → tidak boleh dihapus
+	shared := model.Achievement{ID: primitive.NewObjectID()}
+
+	achRepo.On("FindDeletedBefore", mock.Anything, now.Add(-30*24*time.Hour), trashPurgeBatch).
+		Return([]model.Achievement{expired, shared}, nil)
+	refRepo.On("FindByMongoID", expired.ID.Hex()).
+		Return([]model.AchievementReference{{ID: "ref-1", Status: model.AchievementStatusDeleted}}, nil)
+	refRepo.On("FindByMongoID", shared.ID.Hex()).Return([]model.AchievementReference{
+		{ID: "ref-2", Status: model.AchievementStatusDeleted},
+		{ID: "ref-3", Status: model.AchievementStatusVerified},
+	}, nil)
+	refRepo.On("PurgeByMongoID", expired.ID.Hex()).Return(nil)
+	versionRepo.On("DeleteByAchievementID", mock.Anything, expired.ID.Hex()).Return(nil)
+	achRepo.On("HardDelete", mock.Anything, expired.ID.Hex()).Return(nil)
+
+	n, err := svc.PurgeExpired(context.Background(), now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"/uploads/achievements/a.pdf"}, removed)
+	refRepo.AssertNotCalled(t, "PurgeByMongoID", shared.ID.Hex())
+	achRepo.AssertNotCalled(t, "HardDelete", mock.Anything, shared.ID.Hex())
+}
+
+func TestPurgeExpired_DisabledWithoutRetention(t *testing.T) {
+	achRepo := new(mocks.AchievementRepositoryMock)
+	svc := NewAchievementTrashService(achRepo, new(mocks.AchievementReferenceRepositoryMock), nil, nil, 0)
+
+	n, err := svc.PurgeExpired(context.Background(), time.Now())
+
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+	achRepo.AssertNotCalled(t, "FindDeletedBefore", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRestoreAchievement_TeamLeaderRestoresMemberDrafts(t *testing.T) {
+	svc, r := newTeamTestService()
+	leader := model.TeamRoleLeader
+	leaderRef := &model.AchievementReference{
+		ID:                 "ref-leader",
+		StudentID:          "student-1",
+		MongoAchievementID: "mongo-1",
+		Status:             model.AchievementStatusDeleted,
+		TeamRole:           &leader,
+	}
+	memberRefID := "ref-member"
+	memberRef := &model.AchievementReference{ID: memberRefID, Status: model.AchievementStatusDeleted}
+
+	r.refRepo.On("GetByID", leaderRef.ID).Return(leaderRef, nil)
+	r.refRepo.On("GetByID", memberRefID).Return(memberRef, nil)
+	r.studentRepo.On("FindByUserID", "user-1").Return(&model.Student{ID: "student-1"}, nil)
+	r.achRepo.On("Restore", mock.Anything, "mongo-1").Return(nil)
+	r.refRepo.On("Save", mock.Anything).Return(nil)
+	r.teamRepo.On("FindByMongoID", "mongo-1").Return([]model.AchievementTeamMember{
+		{Role: model.TeamRoleLeader, ReferenceID: &leaderRef.ID},
+		{Role: model.TeamRoleMember, ReferenceID: &memberRefID},
+		{Role: model.TeamRoleMember, Status: model.TeamInvitationDeclined},
+	}, nil)
+	var logs []*model.AchievementStatusLog
+	r.logRepo.On("Create", mock.AnythingOfType("*model.AchievementStatusLog")).
+		Run(func(args mock.Arguments) { logs = append(logs, args.Get(0).(*model.AchievementStatusLog)) }).
+		Return(nil)
+
+	ref, err := svc.RestoreAchievement(context.Background(), "user-1", "Mahasiswa", leaderRef.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.AchievementStatusDraft, ref.Status)
+	assert.Equal(t, model.AchievementStatusDraft, memberRef.Status)
+	if assert.Len(t, logs, 2) {
+		assert.Equal(t, "deleted", logs[0].OldStatus)
+		assert.Equal(t, "draft", logs[0].NewStatus)
+		assert.Equal(t, memberRefID, logs[1].AchievementReferenceID)
+	}
+}
+
+func TestRestoreAchievement_RejectsNotDeletedAndOtherStudent(t *testing.T) {
+	svc, r := newTeamTestService()
+	draft := &model.AchievementReference{ID: "ref-1", StudentID: "student-1", Status: model.AchievementStatusDraft}
+	r.refRepo.On("GetByID", draft.ID).Return(draft, nil)
+	r.studentRepo.On("FindByUserID", "user-1").Return(&model.Student{ID: "student-1"}, nil)
+	r.studentRepo.On("FindByUserID", "user-2").Return(&model.Student{ID: "student-2"}, nil)
+
+	_, err := svc.RestoreAchievement(context.Background(), "user-1", "Mahasiswa", draft.ID)
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+
+	draft.Status = model.AchievementStatusDeleted
+	_, err = svc.RestoreAchievement(context.Background(), "user-2", "Mahasiswa", draft.ID)
+	assert.ErrorIs(t, err, ErrNotOwner)
+
+	r.achRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrVersionNotFound = errors.New("achievement_version_not_found")
+
+// GetAchievementVersions: riwayat isi prestasi, versi 1 = isi saat dibuat
+func (s *AchievementService) GetAchievementVersions(
+	ctx context.Context,
+	refID, userID, role string,
+) (*model.AchievementReference, []model.AchievementVersion, error) {
+	ref, err := s.refRepo.GetByID(refID)
+	if err != nil {
+		return nil, nil, ErrRefNotFound
+	}
+	if err := s.authorizeRefAccess(ref, userID, role); err != nil {
+		return nil, nil, err
+	}
+
+	versions, err := s.versionRepo.FindByAchievementID(ctx, ref.MongoAchievementID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if versions == nil {
+		versions = []model.AchievementVersion{}
+	}
+	return ref, versions, nil
+}
+
+// DiffAchievementVersions: field yang berubah dari versi from ke versi to
+func (s *AchievementService) DiffAchievementVersions(
+	ctx context.Context,
+	refID, userID, role string,
+	from, to int,
+) (*model.AchievementVersionDiff, error) {
+	ref, err := s.refRepo.GetByID(refID)
+	if err != nil {
+		return nil, ErrRefNotFound
+	}
+	if err := s.authorizeRefAccess(ref, userID, role); err != nil {
+		return nil, err
+	}
+
+	old, err := s.versionRepo.FindOne(ctx, ref.MongoAchievementID, from)
+	if err != nil {
+		return nil, ErrVersionNotFound
+	}
+	cur, err := s.versionRepo.FindOne(ctx, ref.MongoAchievementID, to)
+	if err != nil {
+		return nil, ErrVersionNotFound
+	}
+
+	changes, err := DiffAchievementSnapshots(&old.Snapshot, &cur.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return &model.AchievementVersionDiff{
+		AchievementID: ref.MongoAchievementID,
+		From:          from,
+		To:            to,
+		Changes:       changes,
+	}, nil
+}
+
+// DiffAchievementSnapshots: perbandingan per field; isi details dibandingkan per key ("details.rank")
+func DiffAchievementSnapshots(old, cur *model.Achievement) (map[string]model.AuditChange, error) {
+	before, err := json.Marshal(versionFields(old))
+	if err != nil {
+		return nil, err
+	}
+	after, err := json.Marshal(versionFields(cur))
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := AuditDiff(before, after)
+	if err != nil {
+		return nil, err
+	}
+	changes := map[string]model.AuditChange{}
+	if raw != nil {
+		if err := json.Unmarshal(raw, &changes); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+func versionFields(ac *model.Achievement) map[string]any {
+	fields := map[string]any{
+		"achievementType": ac.AchievementType,
+		"title":           ac.Title,
+		"description":     ac.Description,
+		"points":          ac.Points,
+		"attachments":     normalizeBSONValue(ac.Attachments),
+		"team":            ac.Team,
+		"pointSplit":      ac.PointSplit,
+	}
+	for k, v := range ac.Details {
+		fields["details."+k] = normalizeBSONValue(v)
+	}
+	return fields
+}
+
+// normalizeBSONValue: hasil decode Mongo (D, A, DateTime) → bentuk JSON biasa, tanggal dalam UTC
+func normalizeBSONValue(v any) any {
+	switch t := v.(type) {
+	case primitive.D:
+		m := make(map[string]any, len(t))
+		for _, e := range t {
+			m[e.Key] = normalizeBSONValue(e.Value)
+		}
+		return m
+	case primitive.M:
+		return normalizeBSONValue(map[string]any(t))
+	case map[string]any:
+		m := make(map[string]any, len(t))
+		for k, x := range t {
+			m[k] = normalizeBSONValue(x)
+		}
+		return m
+	case primitive.A:
+		return normalizeBSONValue([]any(t))
+	case []any:
+		out := make([]any, len(t))
+		for i, x := range t {
+			out[i] = normalizeBSONValue(x)
+		}
+		return out
+	case primitive.DateTime:
+		return t.Time().UTC()
+	case time.Time:
+		return t.UTC().Truncate(time.Millisecond)
+	}
+	return v
+}
+
+// recordVersion: simpan isi prestasi sebagai versi berikutnya.
+// Gagal menyimpan versi tidak menggagalkan perubahan yang sudah tersimpan, cukup dicatat di log.
+func (s *AchievementService) recordVersion(ctx context.Context, ac *model.Achievement, editedBy string) *model.AchievementVersion {
+	if s.versionRepo == nil || ac == nil {
+		return nil
+	}
+	mongoID := ac.ID.Hex()
+
+	latest, err := s.versionRepo.Latest(ctx, mongoID)
+	if err != nil {
+		log.Printf("[VERSION] read latest version of %s failed: %v", mongoID, err)
+		return nil
+	}
+	next := 1
+	if latest != nil {
+		next = latest.Version + 1
+	}
+
+	v := &model.AchievementVersion{
+		AchievementID: mongoID,
+		Version:       next,
+		Snapshot:      *ac,
+		EditedBy:      editedBy,
+		CreatedAt:     time.Now(),
+	}
+	if err := s.versionRepo.Create(ctx, v); err != nil {
+		log.Printf("[VERSION] save version %d of %s failed: %v", next, mongoID, err)
+		return nil
+	}
+	return v
+}
+
+// ensureBaselineVersion: versi terakhir; prestasi yang dibuat sebelum ada versi disnapshot dulu sebagai versi 1
+func (s *AchievementService) ensureBaselineVersion(ctx context.Context, mongoID string) *model.AchievementVersion {
+	if s.versionRepo == nil {
+		return nil
+	}
+	latest, err := s.versionRepo.Latest(ctx, mongoID)
+	if err != nil || latest != nil {
+		return latest
+	}
+	ac, err := s.achievementRepo.FindByID(ctx, mongoID)
+	if err != nil {
+		return nil
+	}
+	return s.recordVersion(ctx, ac, "")
+}
+
+// currentVersion: nomor versi isi prestasi saat ini, dipin ke reference saat verifikasi
+func (s *AchievementService) currentVersion(ctx context.Context, mongoID string) *int {
+	v := s.ensureBaselineVersion(ctx, mongoID)
+	if v == nil {
+		return nil
+	}
+	return &v.Version
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newVersionTestService() (*AchievementService, teamTestRepos, *mocks.AchievementVersionRepositoryMock) {
+	r := teamTestRepos{
+		achRepo:     new(mocks.AchievementRepositoryMock),
+		studentRepo: new(mocks.StudentRepositoryMock),
+		refRepo:     new(mocks.AchievementReferenceRepositoryMock),
+		logRepo:     new(mocks.AchievementStatusLogRepositoryMock),
+		periodRepo:  new(mocks.AcademicPeriodRepositoryMock),
+		teamRepo:    new(mocks.AchievementTeamRepositoryMock),
+	}
+	versionRepo := new(mocks.AchievementVersionRepositoryMock)
+	svc := NewAchievementService(
+		r.achRepo,
+		r.studentRepo,
+		r.refRepo,
+		new(mocks.UserRepositoryMock),
+		new(mocks.LecturerRepositoryMock),
+		r.logRepo,
+		r.periodRepo,
+		r.teamRepo,
+		versionRepo,
+		nil,
+		nil,
+		model.TeamPointSplitEqual,
+	)
+	return svc, r, versionRepo
+}
+
+func TestUpdateAchievementDraft_SnapshotsLegacyAndNewVersion(t *testing.T) {
+	svc, r, versionRepo := newVersionTestService()
+	oid := primitive.NewObjectID()
+	student := &model.Student{ID: "student-1"}
+	ref := &model.AchievementReference{
+		ID:                 "ref-1",
+		StudentID:          student.ID,
+		MongoAchievementID: oid.Hex(),
+		Status:             model.AchievementStatusDraft,
+	}
+	old := &model.Achievement{ID: oid, StudentID: student.ID, Title: "Juara 2 Gemastik"}
+	updated := &model.Achievement{ID: oid, StudentID: student.ID, Title: "Juara 1 Gemastik"}
+
+	r.refRepo.On("GetByID", ref.ID).Return(ref, nil)
+	r.studentRepo.On("FindByUserID", "user-1").Return(student, nil)
+	r.achRepo.On("FindByID", mock.Anything, oid.Hex()).Return(old, nil)
+	r.achRepo.On("Update", mock.Anything, oid.Hex(), mock.Anything).Return(updated, nil)
+
+	// prestasi lama belum punya versi → isi lama jadi versi 1, hasil update versi 2
+	versionRepo.On("Latest", mock.Anything, oid.Hex()).Return(nil, nil).Twice()
+	versionRepo.On("Latest", mock.Anything, oid.Hex()).
+		Return(&model.AchievementVersion{AchievementID: oid.Hex(), Version: 1}, nil).Once()
+	var saved []*model.AchievementVersion
+	versionRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.AchievementVersion")).
+		Run(func(args mock.Arguments) { saved = append(saved, args.Get(1).(*model.AchievementVersion)) }).
+		Return(nil)
+
+	_, err := svc.UpdateAchievementDraft(context.Background(), ref.ID, "user-1", &model.Achievement{Title: "Juara 1 Gemastik"})
+
+	assert.NoError(t, err)
+	assert.Len(t, saved, 2)
+	assert.Equal(t, 1, saved[0].Version)
+	assert.Equal(t, "Juara 2 Gemastik", saved[0].Snapshot.Title)
+	assert.Empty(t, saved[0].EditedBy)
+	assert.Equal(t, 2, saved[1].Version)
+	assert.Equal(t, "Juara 1 Gemastik", saved[1].Snapshot.Title)
+	assert.Equal(t, "user-1", saved[1].EditedBy)
+}
+
+func TestDiffAchievementVersions_FieldLevel(t *testing.T) {
+	svc, r, versionRepo := newVersionTestService()
+	student := &model.Student{ID: "student-1"}
+	ref := &model.AchievementReference{ID: "ref-1", StudentID: student.ID, MongoAchievementID: "mongo-1"}
+	eventDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	v1 := &model.AchievementVersion{Version: 1, Snapshot: model.Achievement{
+		Title:   "Gemastik",
+		Points:  50,
+		Details: map[string]any{"rank": int32(2), "eventDate": eventDate, "location": "Surabaya"},
+	}}
+	// versi dari Mongo: tanggal sebagai DateTime, isi sama tidak boleh dianggap berubah
+	v3 := &model.AchievementVersion{Version: 3, Snapshot: model.Achievement{
+		Title:   "Gemastik",
+		Points:  75,
+		Details: map[string]any{"rank": int32(1), "eventDate": primitive.NewDateTimeFromTime(eventDate)},
+	}}
+
+	r.refRepo.On("GetByID", ref.ID).Return(ref, nil)
+	r.studentRepo.On("FindByUserID", "user-1").Return(student, nil)
+	versionRepo.On("FindOne", mock.Anything, "mongo-1", 1).Return(v1, nil)
+	versionRepo.On("FindOne", mock.Anything, "mongo-1", 3).Return(v3, nil)
+
+	diff, err := svc.DiffAchievementVersions(context.Background(), ref.ID, "user-1", "Mahasiswa", 1, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, diff.From)
+	assert.Equal(t, 3, diff.To)
+	assert.Len(t, diff.Changes, 3)
+	assert.Equal(t, float64(2), diff.Changes["details.rank"].Old)
+	assert.Equal(t, float64(1), diff.Changes["details.rank"].New)
+	assert.Equal(t, "Surabaya", diff.Changes["details.location"].Old)
+	assert.Nil(t, diff.Changes["details.location"].New)
+	assert.Contains(t, diff.Changes, "points")
+	assert.NotContains(t, diff.Changes, "details.eventDate")
+}
+
+func TestDiffAchievementVersions_NotOwner(t *testing.T) {
+	svc, r, versionRepo := newVersionTestService()
+	ref := &model.AchievementReference{ID: "ref-1", StudentID: "student-1", MongoAchievementID: "mongo-1"}
+
+	r.refRepo.On("GetByID", ref.ID).Return(ref, nil)
+	r.studentRepo.On("FindByUserID", "user-2").Return(&model.Student{ID: "student-2"}, nil)
+
+	_, err := svc.DiffAchievementVersions(context.Background(), ref.ID, "user-2", "Mahasiswa", 1, 2)
+
+	assert.ErrorIs(t, err, ErrNotOwner)
+	versionRepo.AssertNotCalled(t, "FindOne", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVerifyAchievement_PinsCurrentVersion(t *testing.T) {
+	refRepo := new(mocks.AchievementReferenceRepositoryMock)
+	studentRepo := new(mocks.StudentRepositoryMock)
+	lectRepo := new(mocks.LecturerRepositoryMock)
+	userRepo := new(mocks.UserRepositoryMock)
+	logRepo := new(mocks.AchievementStatusLogRepositoryMock)
+	versionRepo := new(mocks.AchievementVersionRepositoryMock)
+
+	svc := NewAchievementService(
+		new(mocks.AchievementRepositoryMock), studentRepo, refRepo, userRepo, lectRepo, logRepo,
+		new(mocks.AcademicPeriodRepositoryMock),
+		new(mocks.AchievementTeamRepositoryMock),
+		versionRepo,
+		nil,
+		nil,
+		model.TeamPointSplitEqual,
+	)
+
+	ref := &model.AchievementReference{
+		ID:                 "ref-1",
+		StudentID:          "student-1",
+		MongoAchievementID: "mongo-1",
+		Status:             model.AchievementStatusSubmitted,
+	}
+	verifier := &model.User{ID: "user-admin", Role: model.Role{Name: "Admin"}}
+
+	refRepo.On("GetByID", ref.ID).Return(ref, nil)
+	studentRepo.On("FindByID", ref.StudentID).Return(&model.Student{ID: "student-1", AdvisorID: "lect-1"}, nil)
+	lectRepo.On("FindByID", "lect-1").Return(&model.Lecturer{ID: "lect-1", UserID: "user-lect"}, nil)
+	userRepo.On("FindByID", verifier.ID).Return(verifier, nil)
+	versionRepo.On("Latest", mock.Anything, "mongo-1").Return(&model.AchievementVersion{Version: 3}, nil)
+	refRepo.On("Save", ref).Return(nil)
+	logRepo.On("Create", mock.AnythingOfType("*model.AchievementStatusLog")).Return(nil)
+
+	updated, err := svc.VerifyAchievement(context.Background(), verifier.ID, ref.ID)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, updated.VerifiedVersion) {
+		assert.Equal(t, 3, *updated.VerifiedVersion)
+	}
+}
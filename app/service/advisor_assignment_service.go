@@ -0,0 +1,521 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/mailer"
+)
+
+var (
+	ErrReassignTarget         = errors.New("to_lecturer_id is required")
+	ErrReassignSelector       = errors.New("choose students by student_ids, or by from_lecturer_id and/or study_program_id")
+	ErrNotDosenWali           = errors.New("selected user is not a dosen wali")
+	ErrAdvisorInactive        = errors.New("selected dosen wali is inactive")
+	ErrEffectiveDateInFuture  = errors.New("effective_from cannot be in the future")
+	ErrDelegationInput        = errors.New("to_lecturer_id, starts_at and ends_at are required")
+	ErrDelegationRange        = errors.New("ends_at must be after starts_at and in the future")
+	ErrDelegationSelf         = errors.New("cannot delegate verification to the same lecturer")
+	ErrDelegationNotFound     = errors.New("delegation not found")
+	ErrDelegationNotRevocable = errors.New("delegation already revoked")
+)
+
+type ReassignAdvisorInput struct {
+	ToLecturerID string
+	// pemilih mahasiswa: StudentIDs, atau FromLecturerID dan/atau StudyProgramID
+	StudentIDs     []string
+	FromLecturerID string
+	StudyProgramID string
+	// zero = sekarang; boleh mundur (pencatatan), tidak boleh di masa depan
+	EffectiveFrom time.Time
+	Reason        string
+	ActorID       string
+}
+
+type ReassignedStudent struct {
+	StudentID      string `json:"student_id"`
+	FromLecturerID string `json:"from_lecturer_id,omitempty"`
+	// prestasi submitted yang sekarang menunggu verifikasi dosen wali baru
+	PendingHandedOver int `json:"pending_handed_over"`
+}
+
+type ReassignAdvisorResult struct {
+	ToLecturerID string `json:"to_lecturer_id"`
+	Reassigned   int    `json:"reassigned"`
+	// mahasiswa yang sudah dibimbing dosen tujuan
+	Skipped           int                 `json:"skipped"`
+	PendingHandedOver int                 `json:"pending_handed_over"`
+	Students          []ReassignedStudent `json:"students"`
+}
+
+type DelegationInput struct {
+	FromLecturerID string
+	ToLecturerID   string
+	StartsAt       time.Time
+	EndsAt         time.Time
+	Reason         string
+	ActorID        string
+}
+
+// AdvisorAssignmentService: pindah dosen wali (satu / massal) dengan riwayat + serah terima prestasi
+// yang masih menunggu verifikasi, dan delegasi sementara hak verifikasi ke dosen lain
+type AdvisorAssignmentService struct {
+	assignmentRepo  repository.AdvisorAssignmentRepository
+	delegationRepo  repository.VerificationDelegationRepository
+	studentRepo     repository.StudentRepository
+	lecturerRepo    repository.LecturerRepository
+	refRepo         repository.AchievementReferenceRepository
+	achievementRepo repository.AchievementRepository
+	mailer          mailer.Mailer
+}
+
+func NewAdvisorAssignmentService(
+	assignmentRepo repository.AdvisorAssignmentRepository,
+	delegationRepo repository.VerificationDelegationRepository,
+	studentRepo repository.StudentRepository,
+	lecturerRepo repository.LecturerRepository,
+	refRepo repository.AchievementReferenceRepository,
+	achievementRepo repository.AchievementRepository,
+	mailer mailer.Mailer,
+) *AdvisorAssignmentService {
+	return &AdvisorAssignmentService{
+		assignmentRepo:  assignmentRepo,
+		delegationRepo:  delegationRepo,
+		studentRepo:     studentRepo,
+		lecturerRepo:    lecturerRepo,
+		refRepo:         refRepo,
+		achievementRepo: achievementRepo,
+		mailer:          mailer,
+	}
+}
+
+// Reassign: prestasi submitted ikut pindah ke dosen wali baru; dosen lama, dosen baru dan mahasiswa
+// diberi tahu daftar prestasi yang diserahterimakan
+func (s *AdvisorAssignmentService) Reassign(ctx context.Context, input ReassignAdvisorInput) (*ReassignAdvisorResult, error) {
+	if input.ToLecturerID == "" {
+		return nil, ErrReassignTarget
+	}
+	target, err := s.activeAdvisor(input.ToLecturerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	effective := input.EffectiveFrom
+	if effective.IsZero() {
+		effective = now
+	}
+	if effective.After(now) {
+		return nil, ErrEffectiveDateInFuture
+	}
+
+	students, err := s.selectStudents(input)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReassignAdvisorResult{ToLecturerID: target.ID, Students: []ReassignedStudent{}}
+	var assignments []model.AdvisorAssignment
+	var moved []model.Student
+	pendingByStudent := map[string][]model.AchievementReference{}
+
+	for _, st := range students {
+		if st.AdvisorID == target.ID {
+			result.Skipped++
+			continue
+		}
+
+		pending, err := s.pendingRefs(st.ID)
+		if err != nil {
+			return nil, err
+		}
+		pendingByStudent[st.ID] = pending
+
+		assignments = append(assignments, model.AdvisorAssignment{
+			StudentID:         st.ID,
+			LecturerID:        target.ID,
+			EffectiveFrom:     effective,
+			Reason:            input.Reason,
+			PendingHandedOver: len(pending),
+			AssignedBy:        optionalString(input.ActorID),
+		})
+		moved = append(moved, st)
+		result.Students = append(result.Students, ReassignedStudent{
+			StudentID:         st.ID,
+			FromLecturerID:    st.AdvisorID,
+			PendingHandedOver: len(pending),
+		})
+		result.PendingHandedOver += len(pending)
+	}
+
+	if err := s.assignmentRepo.Reassign(assignments); err != nil {
+		return nil, err
+	}
+	result.Reassigned = len(moved)
+
+	s.notifyReassignment(ctx, target, moved, pendingByStudent)
+	return result, nil
+}
+
+// GetHistory: riwayat dosen wali satu mahasiswa, terbaru dulu
+func (s *AdvisorAssignmentService) GetHistory(studentID string) ([]model.AdvisorAssignment, error) {
+	if _, err := s.studentRepo.FindByID(studentID); err != nil {
+		return nil, ErrStudentProfileNotFound
+	}
+	return s.assignmentRepo.FindByStudentID(studentID)
+}
+
+// CreateDelegation: hak verifikasi mahasiswa bimbingan FromLecturerID ikut dimiliki ToLecturerID
+// selama [StartsAt, EndsAt); dosen wali asli tetap bisa memverifikasi
+func (s *AdvisorAssignmentService) CreateDelegation(ctx context.Context, input DelegationInput) (*model.VerificationDelegation, error) {
+	if input.ToLecturerID == "" || input.StartsAt.IsZero() || input.EndsAt.IsZero() {
+		return nil, ErrDelegationInput
+	}
+	if !input.EndsAt.After(input.StartsAt) || !input.EndsAt.After(time.Now()) {
+		return nil, ErrDelegationRange
+	}
+	if input.FromLecturerID == input.ToLecturerID {
+		return nil, ErrDelegationSelf
+	}
+
+	from, err := s.lecturerRepo.FindByID(input.FromLecturerID)
+	if err != nil {
+		return nil, ErrLecturerNotFound
+	}
+	to, err := s.activeAdvisor(input.ToLecturerID)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &model.VerificationDelegation{
+		FromLecturerID: from.ID,
+		ToLecturerID:   to.ID,
+		StartsAt:       input.StartsAt,
+		EndsAt:         input.EndsAt,
+		Reason:         input.Reason,
+		CreatedBy:      optionalString(input.ActorID),
+	}
+	if err := s.delegationRepo.Create(d); err != nil {
+		return nil, err
+	}
+	d.FromLecturer = from
+	d.ToLecturer = to
+
+	s.notifyDelegation(ctx, d, "Delegasi verifikasi prestasi", "mulai berlaku")
+	return d, nil
+}
+
+// CreateMyDelegation: dosen wali yang login mendelegasikan hak verifikasinya
+func (s *AdvisorAssignmentService) CreateMyDelegation(ctx context.Context, userID string, input DelegationInput) (*model.VerificationDelegation, error) {
+	lect, err := s.lecturerRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, ErrLecturerNotFound
+	}
+	input.FromLecturerID = lect.ID
+	input.ActorID = userID
+	return s.CreateDelegation(ctx, input)
+}
+
+// GetDelegations: delegasi yang diberikan atau diterima dosen (lecturers.id)
+func (s *AdvisorAssignmentService) GetDelegations(lecturerID string) ([]model.VerificationDelegation, error) {
+	if _, err := s.lecturerRepo.FindByID(lecturerID); err != nil {
+		return nil, ErrLecturerNotFound
+	}
+	return s.delegationRepo.FindByLecturerID(lecturerID)
+}
+
+func (s *AdvisorAssignmentService) GetMyDelegations(userID string) ([]model.VerificationDelegation, error) {
+	lect, err := s.lecturerRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, ErrLecturerNotFound
+	}
+	return s.delegationRepo.FindByLecturerID(lect.ID)
+}
+
+// RevokeDelegation: admin (ownerUserID kosong) atau dosen pemberi delegasi
+func (s *AdvisorAssignmentService) RevokeDelegation(ctx context.Context, id, ownerUserID string) error {
+	d, err := s.delegationRepo.FindByID(id)
+	if err != nil {
+		return ErrDelegationNotFound
+	}
+	if ownerUserID != "" && (d.FromLecturer == nil || d.FromLecturer.UserID != ownerUserID) {
+		return ErrDelegationNotFound
+	}
+
+	ok, err := s.delegationRepo.Revoke(d.ID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrDelegationNotRevocable
+	}
+
+	s.notifyDelegation(ctx, d, "Delegasi verifikasi prestasi dicabut", "dicabut")
+	return nil
+}
+
+// IsDelegate: delegateLecturerID sedang memegang delegasi verifikasi dari advisorLecturerID
+func (s *AdvisorAssignmentService) IsDelegate(advisorLecturerID, delegateLecturerID string, at time.Time) bool {
+	if advisorLecturerID == "" || delegateLecturerID == "" {
+		return false
+	}
+	rows, err := s.delegationRepo.FindActive(advisorLecturerID, delegateLecturerID, at)
+	if err != nil {
+		log.Printf("[DELEGATION] lookup %s -> %s failed: %v", advisorLecturerID, delegateLecturerID, err)
+		return false
+	}
+	return len(rows) > 0
+}
+
+// DelegatingAdvisorIDs: dosen wali yang sedang mendelegasikan verifikasi ke delegateLecturerID
+func (s *AdvisorAssignmentService) DelegatingAdvisorIDs(delegateLecturerID string, at time.Time) ([]string, error) {
+	rows, err := s.delegationRepo.FindActive("", delegateLecturerID, at)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	ids := make([]string, 0, len(rows))
+	for _, d := range rows {
+		if !seen[d.FromLecturerID] {
+			seen[d.FromLecturerID] = true
+			ids = append(ids, d.FromLecturerID)
+		}
+	}
+	return ids, nil
+}
+
+// Verifiers: dosen aktif yang saat at boleh memverifikasi prestasi mahasiswa bimbingan advisorLecturerID,
+// yaitu dosen wali itu sendiri dan penerima delegasinya (aturan yang sama dengan jalur verifikasi)
+func (s *AdvisorAssignmentService) Verifiers(advisorLecturerID string, at time.Time) ([]model.Lecturer, error) {
+	advisor, err := s.lecturerRepo.FindByID(advisorLecturerID)
+	if err != nil {
+		return nil, ErrLecturerNotFound
+	}
+	var verifiers []model.Lecturer
+	if advisor.User.IsActive {
+		verifiers = append(verifiers, *advisor)
+	}
+
+	rows, err := s.delegationRepo.FindActive(advisor.ID, "", at)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{advisor.ID: true}
+	for _, d := range rows {
+		if seen[d.ToLecturerID] {
+			continue
+		}
+		seen[d.ToLecturerID] = true
+		delegate, err := s.lecturerRepo.FindByID(d.ToLecturerID)
+		if err != nil || !delegate.User.IsActive {
+			continue
+		}
+		verifiers = append(verifiers, *delegate)
+	}
+	return verifiers, nil
+}
+
+func (s *AdvisorAssignmentService) activeAdvisor(lecturerID string) (*model.Lecturer, error) {
+	lect, err := s.lecturerRepo.FindByID(lecturerID)
+	if err != nil {
+		return nil, ErrLecturerNotFound
+	}
+	if lect.User.Role.Name != "Dosen Wali" {
+		return nil, ErrNotDosenWali
+	}
+	if !lect.User.IsActive {
+		return nil, ErrAdvisorInactive
+	}
+	return lect, nil
+}
+
+func (s *AdvisorAssignmentService) selectStudents(input ReassignAdvisorInput) ([]model.Student, error) {
+	if len(input.StudentIDs) > 0 {
+		if input.FromLecturerID != "" || input.StudyProgramID != "" {
+			return nil, ErrReassignSelector
+		}
+		students := make([]model.Student, 0, len(input.StudentIDs))
+		seen := map[string]bool{}
+		for _, id := range input.StudentIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			st, err := s.studentRepo.FindByID(id)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrStudentProfileNotFound, id)
+			}
+			students = append(students, *st)
+		}
+		return students, nil
+	}
+
+	switch {
+	case input.StudyProgramID != "":
+		students, err := s.studentRepo.FindByStudyProgramID(input.StudyProgramID)
+		if err != nil || input.FromLecturerID == "" {
+			return students, err
+		}
+		filtered := students[:0]
+		for _, st := range students {
+			if st.AdvisorID == input.FromLecturerID {
+				filtered = append(filtered, st)
+			}
+		}
+		return filtered, nil
+	case input.FromLecturerID != "":
+		if _, err := s.lecturerRepo.FindByID(input.FromLecturerID); err != nil {
+			return nil, ErrLecturerNotFound
+		}
+		return s.studentRepo.FindByAdvisorID(input.FromLecturerID)
+	}
+	return nil, ErrReassignSelector
+}
+
+func (s *AdvisorAssignmentService) pendingRefs(studentID string) ([]model.AchievementReference, error) {
+	refs, err := s.refRepo.FindByStudentID(studentID)
+	if err != nil {
+		return nil, err
+	}
+	pending := make([]model.AchievementReference, 0)
+	for _, r := range refs {
+		if r.Status == model.AchievementStatusSubmitted {
+			pending = append(pending, r)
+		}
+	}
+	return pending, nil
+}
+
+// notifyReassignment: email best effort, kegagalan hanya dicatat di log
+func (s *AdvisorAssignmentService) notifyReassignment(
+	ctx context.Context,
+	target *model.Lecturer,
+	moved []model.Student,
+	pending map[string][]model.AchievementReference,
+) {
+	if len(moved) == 0 {
+		return
+	}
+
+	var allPending []model.AchievementReference
+	for _, refs := range pending {
+		allPending = append(allPending, refs...)
+	}
+	titles := s.titlesOf(ctx, allPending)
+
+	studentLine := func(b *strings.Builder, st model.Student) {
+		fmt.Fprintf(b, "- %s (%s)", st.User.FullName, st.StudentID)
+		if n := len(pending[st.ID]); n > 0 {
+			fmt.Fprintf(b, ", %d prestasi menunggu verifikasi:", n)
+			for _, r := range pending[st.ID] {
+				fmt.Fprintf(b, "\n    * %s", titleOr(titles, r))
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	// dosen wali baru: semua mahasiswa + prestasi yang sekarang jadi tanggung jawabnya
+	var body strings.Builder
+	fmt.Fprintf(&body, "Yth. %s,\n\n", target.User.FullName)
+	fmt.Fprintf(&body, "Anda ditetapkan sebagai dosen wali untuk %d mahasiswa berikut:\n\n", len(moved))
+	for _, st := range moved {
+		studentLine(&body, st)
+	}
+	s.send(ctx, target.User.Email, fmt.Sprintf("[Prestasi] %d mahasiswa bimbingan baru", len(moved)), body.String())
+
+	// dosen wali lama: mahasiswa yang dipindahkan + prestasi yang diserahterimakan
+	byOld := map[string][]model.Student{}
+	for _, st := range moved {
+		if st.AdvisorID != "" {
+			byOld[st.AdvisorID] = append(byOld[st.AdvisorID], st)
+		}
+	}
+	for oldID, list := range byOld {
+		old, err := s.lecturerRepo.FindByID(oldID)
+		if err != nil {
+			continue
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "Yth. %s,\n\n", old.User.FullName)
+		fmt.Fprintf(&b, "%d mahasiswa bimbingan Anda dipindahkan ke %s. Prestasi yang belum diverifikasi ikut diserahkan:\n\n", len(list), target.User.FullName)
+		for _, st := range list {
+			studentLine(&b, st)
+		}
+		s.send(ctx, old.User.Email, fmt.Sprintf("[Prestasi] %d mahasiswa bimbingan dipindahkan", len(list)), b.String())
+	}
+
+	for _, st := range moved {
+		var b strings.Builder
+		fmt.Fprintf(&b, "Halo %s,\n\n", st.User.FullName)
+		fmt.Fprintf(&b, "Dosen wali Anda sekarang %s.", target.User.FullName)
+		if n := len(pending[st.ID]); n > 0 {
+			fmt.Fprintf(&b, " %d prestasi yang sedang menunggu verifikasi akan diverifikasi oleh dosen wali baru.", n)
+		}
+		b.WriteString("\n")
+		s.send(ctx, st.User.Email, "[Prestasi] Perubahan dosen wali", b.String())
+	}
+}
+
+func (s *AdvisorAssignmentService) notifyDelegation(ctx context.Context, d *model.VerificationDelegation, subject, state string) {
+	if d.FromLecturer == nil || d.ToLecturer == nil {
+		return
+	}
+	period := fmt.Sprintf("%s s.d. %s", d.StartsAt.Format("2006-01-02 15:04"), d.EndsAt.Format("2006-01-02 15:04"))
+
+	body := fmt.Sprintf("Yth. %s,\n\nDelegasi verifikasi prestasi mahasiswa bimbingan %s kepada Anda (%s) %s.\n",
+		d.ToLecturer.User.FullName, d.FromLecturer.User.FullName, period, state)
+	s.send(ctx, d.ToLecturer.User.Email, "[Prestasi] "+subject, body)
+
+	body = fmt.Sprintf("Yth. %s,\n\nDelegasi verifikasi prestasi mahasiswa bimbingan Anda kepada %s (%s) %s.\n",
+		d.FromLecturer.User.FullName, d.ToLecturer.User.FullName, period, state)
+	s.send(ctx, d.FromLecturer.User.Email, "[Prestasi] "+subject, body)
+}
+
+func (s *AdvisorAssignmentService) send(ctx context.Context, to, subject, body string) {
+	if s.mailer == nil || to == "" {
+		return
+	}
+	if err := s.mailer.Send(ctx, mailer.Message{To: []string{to}, Subject: subject, Body: body}); err != nil {
+		log.Printf("[ADVISOR] failed to notify %s: %v", to, err)
+	}
+}
+
+func (s *AdvisorAssignmentService) titlesOf(ctx context.Context, refs []model.AchievementReference) map[string]string {
+	titles := map[string]string{}
+	if len(refs) == 0 || s.achievementRepo == nil {
+		return titles
+	}
+	ids := make([]string, 0, len(refs))
+	for _, r := range refs {
+		ids = append(ids, r.MongoAchievementID)
+	}
+	achs, err := s.achievementRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		log.Printf("[ADVISOR] failed to load achievement titles: %v", err)
+		return titles
+	}
+	for _, a := range achs {
+		titles[a.ID.Hex()] = a.Title
+	}
+	return titles
+}
+
+func titleOr(titles map[string]string, r model.AchievementReference) string {
+	if t := titles[r.MongoAchievementID]; t != "" {
+		return t
+	}
+	return "prestasi " + r.ID
+}
+
+func optionalString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
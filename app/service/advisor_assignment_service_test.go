@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type advisorMocks struct {
+	assigns     *mocks.AdvisorAssignmentRepositoryMock
+	delegations *mocks.VerificationDelegationRepositoryMock
+	students    *mocks.StudentRepositoryMock
+	lecturers   *mocks.LecturerRepositoryMock
+	refs        *mocks.AchievementReferenceRepositoryMock
+	mail        *fakeMailer
+}
+
+func newAdvisorTestService() (*AdvisorAssignmentService, advisorMocks) {
+	m := advisorMocks{
+		assigns:     new(mocks.AdvisorAssignmentRepositoryMock),
+		delegations: new(mocks.VerificationDelegationRepositoryMock),
+		students:    new(mocks.StudentRepositoryMock),
+		lecturers:   new(mocks.LecturerRepositoryMock),
+		refs:        new(mocks.AchievementReferenceRepositoryMock),
+		mail:        &fakeMailer{},
+	}
+	svc := NewAdvisorAssignmentService(m.assigns, m.delegations, m.students, m.lecturers, m.refs, nil, m.mail)
+	return svc, m
+}
+
+func dosenWali(id, userID, email string) *model.Lecturer {
+	return &model.Lecturer{
+		ID:     id,
+		UserID: userID,
+		User: model.User{
+			ID:       userID,
+			Email:    email,
+			IsActive: true,
+			Role:     model.Role{Name: "Dosen Wali"},
+		},
+	}
+}
+
+func TestReassign_SingleStudentHandsOverPending(t *testing.T) {
+	svc, m := newAdvisorTestService()
+
+	m.students.On("FindByID", "student-1").Return(&model.Student{
+		ID:        "student-1",
+		AdvisorID: "lect-old",
+		User:      model.User{Email: "mhs@example.com"},
+	}, nil)
+	m.lecturers.On("FindByID", "lect-1").Return(dosenWali("lect-1", "user-new", "new@example.com"), nil)
+	m.lecturers.On("FindByID", "lect-old").Return(dosenWali("lect-old", "user-old", "old@example.com"), nil)
+	m.refs.On("FindByStudentID", "student-1").Return([]model.AchievementReference{
+		{ID: "ref-1", Status: model.AchievementStatusSubmitted},
+		{ID: "ref-2", Status: model.AchievementStatusVerified},
+	}, nil)
+	m.assigns.On("Reassign", mock.MatchedBy(func(rows []model.AdvisorAssignment) bool {
+		return len(rows) == 1 && rows[0].StudentID == "student-1" && rows[0].PendingHandedOver == 1
+	})).Return(nil)
+
+	result, err := svc.Reassign(context.Background(), ReassignAdvisorInput{
+		ToLecturerID: "lect-1",
+		StudentIDs:   []string{"student-1"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Reassigned)
+	assert.Equal(t, 1, result.PendingHandedOver)
+	assert.Equal(t, "lect-old", result.Students[0].FromLecturerID)
+	// dosen baru, dosen lama, mahasiswa
+	assert.Len(t, m.mail.sent, 3)
+	m.assigns.AssertExpectations(t)
+}
+
+func TestReassign_NotDosenWali(t *testing.T) {
+	svc, m := newAdvisorTestService()
+
+	lect := dosenWali("lect-1", "user-1", "")
+	lect.User.Role.Name = "Mahasiswa"
+	m.lecturers.On("FindByID", "lect-1").Return(lect, nil)
+
+	_, err := svc.Reassign(context.Background(), ReassignAdvisorInput{
+		ToLecturerID: "lect-1",
+		StudentIDs:   []string{"student-1"},
+	})
+
+	assert.ErrorIs(t, err, ErrNotDosenWali)
+	m.assigns.AssertNotCalled(t, "Reassign", mock.Anything)
+}
+
+func TestReassign_ByProgramFromLecturerSkipsCurrentAdvisees(t *testing.T) {
+	svc, m := newAdvisorTestService()
+
+	m.lecturers.On("FindByID", "lect-1").Return(dosenWali("lect-1", "user-1", ""), nil)
+	m.lecturers.On("FindByID", "lect-old").Return(dosenWali("lect-old", "user-old", ""), nil)
+	m.students.On("FindByStudyProgramID", "prodi-1").Return([]model.Student{
+		{ID: "s1", AdvisorID: "lect-old"},
+		{ID: "s2", AdvisorID: "lect-other"},
+		{ID: "s3", AdvisorID: "lect-old"},
+	}, nil)
+	m.refs.On("FindByStudentID", mock.Anything).Return([]model.AchievementReference{}, nil)
+	m.assigns.On("Reassign", mock.MatchedBy(func(rows []model.AdvisorAssignment) bool {
+		return len(rows) == 2 && rows[0].StudentID == "s1" && rows[1].StudentID == "s3"
+	})).Return(nil)
+
+	result, err := svc.Reassign(context.Background(), ReassignAdvisorInput{
+		ToLecturerID:   "lect-1",
+		FromLecturerID: "lect-old",
+		StudyProgramID: "prodi-1",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Reassigned)
+	assert.Equal(t, 0, result.Skipped)
+	m.assigns.AssertExpectations(t)
+}
+
+func TestReassign_RejectsFutureEffectiveDateAndMixedSelector(t *testing.T) {
+	svc, m := newAdvisorTestService()
+	m.lecturers.On("FindByID", "lect-1").Return(dosenWali("lect-1", "user-1", ""), nil)
+
+	_, err := svc.Reassign(context.Background(), ReassignAdvisorInput{
+		ToLecturerID:  "lect-1",
+		StudentIDs:    []string{"s1"},
+		EffectiveFrom: time.Now().Add(48 * time.Hour),
+	})
+	assert.ErrorIs(t, err, ErrEffectiveDateInFuture)
+
+	_, err = svc.Reassign(context.Background(), ReassignAdvisorInput{
+		ToLecturerID:   "lect-1",
+		StudentIDs:     []string{"s1"},
+		FromLecturerID: "lect-old",
+	})
+	assert.ErrorIs(t, err, ErrReassignSelector)
+}
+
+func TestCreateMyDelegation_Validation(t *testing.T) {
+	svc, m := newAdvisorTestService()
+	m.lecturers.On("FindByUserID", "user-1").Return(dosenWali("lect-1", "user-1", ""), nil)
+
+	now := time.Now()
+	_, err := svc.CreateMyDelegation(context.Background(), "user-1", DelegationInput{
+		ToLecturerID: "lect-1",
+		StartsAt:     now,
+		EndsAt:       now.Add(time.Hour),
+	})
+	assert.ErrorIs(t, err, ErrDelegationSelf)
+
+	_, err = svc.CreateMyDelegation(context.Background(), "user-1", DelegationInput{
+		ToLecturerID: "lect-2",
+		StartsAt:     now,
+		EndsAt:       now.Add(-time.Hour),
+	})
+	assert.ErrorIs(t, err, ErrDelegationRange)
+	m.delegations.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestRevokeDelegation_OnlyOwner(t *testing.T) {
+	svc, m := newAdvisorTestService()
+
+	m.delegations.On("FindByID", "del-1").Return(&model.VerificationDelegation{
+		ID:             "del-1",
+		FromLecturerID: "lect-1",
+		FromLecturer:   dosenWali("lect-1", "user-1", ""),
+	}, nil)
+	m.delegations.On("Revoke", "del-1", mock.Anything).Return(true, nil)
+
+	assert.ErrorIs(t, svc.RevokeDelegation(context.Background(), "del-1", "user-other"), ErrDelegationNotFound)
+	assert.NoError(t, svc.RevokeDelegation(context.Background(), "del-1", "user-1"))
+	m.delegations.AssertNumberOfCalls(t, "Revoke", 1)
+}
+
+func TestVerifyAchievement_ByDelegate(t *testing.T) {
+	refRepo := new(mocks.AchievementReferenceRepositoryMock)
+	studentRepo := new(mocks.StudentRepositoryMock)
+	lectRepo := new(mocks.LecturerRepositoryMock)
+	userRepo := new(mocks.UserRepositoryMock)
+	logRepo := new(mocks.AchievementStatusLogRepositoryMock)
+	delegations := new(mocks.VerificationDelegationRepositoryMock)
+
+	advisorSvc := NewAdvisorAssignmentService(nil, delegations, studentRepo, lectRepo, refRepo, nil, nil)
+	svc := NewAchievementService(
+		new(mocks.AchievementRepositoryMock), studentRepo, refRepo, userRepo, lectRepo, logRepo,
+		new(mocks.AcademicPeriodRepositoryMock),
+		new(mocks.AchievementTeamRepositoryMock),
+		nil,
+		nil,
+		advisorSvc,
+		model.TeamPointSplitEqual,
+	)
+
+	ref := &model.AchievementReference{ID: "ref-1", Status: model.AchievementStatusSubmitted, StudentID: "student-1"}
+	refRepo.On("GetByID", "ref-1").Return(ref, nil)
+	refRepo.On("Save", ref).Return(nil)
+	studentRepo.On("FindByID", "student-1").Return(&model.Student{ID: "student-1", AdvisorID: "lect-1"}, nil)
+	lectRepo.On("FindByID", "lect-1").Return(&model.Lecturer{ID: "lect-1", UserID: "user-advisor"}, nil)
+	lectRepo.On("FindByUserID", "user-delegate").Return(&model.Lecturer{ID: "lect-2", UserID: "user-delegate"}, nil)
+	lectRepo.On("FindByUserID", "user-stranger").Return(&model.Lecturer{ID: "lect-3", UserID: "user-stranger"}, nil)
+	userRepo.On("FindByID", "user-delegate").Return(&model.User{ID: "user-delegate", Role: model.Role{Name: "Dosen Wali"}}, nil)
+	userRepo.On("FindByID", "user-stranger").Return(&model.User{ID: "user-stranger", Role: model.Role{Name: "Dosen Wali"}}, nil)
+	delegations.On("FindActive", "lect-1", "lect-2", mock.Anything).Return([]model.VerificationDelegation{{ID: "del-1"}}, nil)
+	delegations.On("FindActive", "lect-1", "lect-3", mock.Anything).Return([]model.VerificationDelegation{}, nil)
+	logRepo.On("Create", mock.AnythingOfType("*model.AchievementStatusLog")).Return(nil)
+
+	_, err := svc.VerifyAchievement(context.Background(), "user-stranger", "ref-1")
+	assert.ErrorIs(t, err, ErrNotAdvisor)
+
+	updated, err := svc.VerifyAchievement(context.Background(), "user-delegate", "ref-1")
+	assert.NoError(t, err)
+	assert.Equal(t, model.AchievementStatusVerified, updated.Status)
+}
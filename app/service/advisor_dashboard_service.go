@@ -0,0 +1,411 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/mailer"
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+const (
+	// throughput dosen dihitung dari N hari terakhir
+	advisorThroughputDays = 30
+	// reminder untuk item yang sama tidak dikirim ulang sebelum jeda ini
+	reminderCooldown = 24 * time.Hour
+)
+
+// AdvisorDashboardService: beban verifikasi dosen wali + SLA + reminder
+type AdvisorDashboardService struct {
+	achievementRepo repository.AchievementRepository
+	refRepo         repository.AchievementReferenceRepository
+	studentRepo     repository.StudentRepository
+	lecturerRepo    repository.LecturerRepository
+	mailer          mailer.Mailer
+	sla             time.Duration
+	now             utils.Clock
+	advisorSvc      *AdvisorAssignmentService
+}
+
+func NewAdvisorDashboardService(
+	achievementRepo repository.AchievementRepository,
+	refRepo repository.AchievementReferenceRepository,
+	studentRepo repository.StudentRepository,
+	lecturerRepo repository.LecturerRepository,
+	mailer mailer.Mailer,
+	sla time.Duration,
+	now utils.Clock,
+	advisorSvc *AdvisorAssignmentService,
+) *AdvisorDashboardService {
+	return &AdvisorDashboardService{
+		achievementRepo: achievementRepo,
+		refRepo:         refRepo,
+		studentRepo:     studentRepo,
+		lecturerRepo:    lecturerRepo,
+		mailer:          mailer,
+		sla:             sla,
+		now:             now,
+		advisorSvc:      advisorSvc,
+	}
+}
+
+// GetMyDashboard: dashboard dosen wali yang sedang login
+func (s *AdvisorDashboardService) GetMyDashboard(ctx context.Context, userID string) (*model.AdvisorDashboard, error) {
+	lect, err := s.lecturerRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, ErrLecturerNotFound
+	}
+	return s.buildDashboard(ctx, lect)
+}
+
+// GetLecturerDashboard: admin melihat dashboard dosen tertentu (lecturers.id)
+func (s *AdvisorDashboardService) GetLecturerDashboard(ctx context.Context, lecturerID string) (*model.AdvisorDashboard, error) {
+	lect, err := s.lecturerRepo.FindByID(lecturerID)
+	if err != nil {
+		return nil, ErrLecturerNotFound
+	}
+	return s.buildDashboard(ctx, lect)
+}
+
+func (s *AdvisorDashboardService) buildDashboard(ctx context.Context, lect *model.Lecturer) (*model.AdvisorDashboard, error) {
+	students, err := s.studentRepo.FindByAdvisorID(lect.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dash := &model.AdvisorDashboard{
+		AdvisorWorkload: model.AdvisorWorkload{
+			LecturerID: lect.ID,
+			Name:       lect.User.FullName,
+			Advisees:   len(students),
+		},
+		SLAHours:       s.sla.Hours(),
+		ThroughputDays: advisorThroughputDays,
+		AdviseeDetails: []model.AdviseeWorkload{},
+	}
+
+	since := s.now().AddDate(0, 0, -advisorThroughputDays)
+	reviewed, err := s.refRepo.CountReviewedBy([]string{lect.UserID}, since)
+	if err != nil {
+		return nil, err
+	}
+	dash.Verified = reviewed[lect.UserID][string(model.AchievementStatusVerified)]
+	dash.Rejected = reviewed[lect.UserID][string(model.AchievementStatusRejected)]
+
+	if len(students) == 0 {
+		return dash, nil
+	}
+
+	studentIDs := make([]string, 0, len(students))
+	for _, st := range students {
+		studentIDs = append(studentIDs, st.ID)
+	}
+
+	submitted := model.AchievementStatusSubmitted
+	total, err := s.refRepo.CountByStudentIDs(studentIDs, &submitted)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := s.refRepo.FindByStudentIDs(studentIDs, &submitted, int(total), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	titles, err := s.titlesOf(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	byStudent := map[string][]model.PendingVerification{}
+	now := s.now()
+	for _, r := range refs {
+		item := s.pendingItem(r, titles, now)
+		byStudent[r.StudentID] = append(byStudent[r.StudentID], item)
+
+		dash.Pending++
+		if item.Overdue {
+			dash.Overdue++
+		}
+		if item.AgeHours > dash.OldestPendingHours {
+			dash.OldestPendingHours = item.AgeHours
+		}
+	}
+
+	for _, st := range students {
+		items := byStudent[st.ID]
+		if items == nil {
+			items = []model.PendingVerification{}
+		}
+		// paling lama menunggu di atas
+		sort.Slice(items, func(i, j int) bool { return items[i].AgeHours > items[j].AgeHours })
+
+		w := model.AdviseeWorkload{
+			StudentID: st.ID,
+			NIM:       st.StudentID,
+			Name:      st.User.FullName,
+			Pending:   len(items),
+			Items:     items,
+		}
+		for _, it := range items {
+			if it.Overdue {
+				w.Overdue++
+			}
+		}
+		dash.AdviseeDetails = append(dash.AdviseeDetails, w)
+	}
+
+	sort.SliceStable(dash.AdviseeDetails, func(i, j int) bool {
+		a, b := dash.AdviseeDetails[i], dash.AdviseeDetails[j]
+		if a.Overdue != b.Overdue {
+			return a.Overdue > b.Overdue
+		}
+		return a.Pending > b.Pending
+	})
+
+	return dash, nil
+}
+
+// GetAllWorkloads: backlog semua dosen wali (admin), yang paling banyak overdue di atas
+func (s *AdvisorDashboardService) GetAllWorkloads(ctx context.Context) ([]model.AdvisorWorkload, error) {
+	lecturers, err := s.lecturerRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	students, err := s.studentRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.refRepo.FindPendingWithStudent()
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(lecturers))
+	for _, l := range lecturers {
+		userIDs = append(userIDs, l.UserID)
+	}
+	since := s.now().AddDate(0, 0, -advisorThroughputDays)
+	reviewed, err := s.refRepo.CountReviewedBy(userIDs, since)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := map[string]*model.AdvisorWorkload{}
+	result := make([]model.AdvisorWorkload, len(lecturers))
+	for i, l := range lecturers {
+		result[i] = model.AdvisorWorkload{
+			LecturerID: l.ID,
+			Name:       l.User.FullName,
+			Verified:   reviewed[l.UserID][string(model.AchievementStatusVerified)],
+			Rejected:   reviewed[l.UserID][string(model.AchievementStatusRejected)],
+		}
+		byID[l.ID] = &result[i]
+	}
+
+	for _, st := range students {
+		if w, ok := byID[st.AdvisorID]; ok {
+			w.Advisees++
+		}
+	}
+
+	now := s.now()
+	for _, r := range pending {
+		w, ok := byID[r.Student.AdvisorID]
+		if !ok || r.SubmittedAt == nil {
+			continue
+		}
+
+		age := now.Sub(*r.SubmittedAt)
+		w.Pending++
+		if age > s.sla {
+			w.Overdue++
+		}
+		if age.Hours() > w.OldestPendingHours {
+			w.OldestPendingHours = age.Hours()
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Overdue != result[j].Overdue {
+			return result[i].Overdue > result[j].Overdue
+		}
+		return result[i].Pending > result[j].Pending
+	})
+
+	return result, nil
+}
+
+// SendOverdueReminders: kirim email ke dosen wali yang punya item melewati SLA, return jumlah email
+func (s *AdvisorDashboardService) SendOverdueReminders(ctx context.Context) (int, error) {
+	pending, err := s.refRepo.FindPendingWithStudent()
+	if err != nil {
+		return 0, err
+	}
+
+	// presisi mikrodetik seperti kolom Postgres, supaya ReleaseReminders cocok dengan nilai yang diklaim
+	now := s.now().Truncate(time.Microsecond)
+	byAdvisor := map[string][]model.AchievementReference{}
+	for _, r := range pending {
+		if r.SubmittedAt == nil || r.Student.AdvisorID == "" {
+			continue
+		}
+		if now.Sub(*r.SubmittedAt) <= s.sla {
+			continue
+		}
+		if r.LastRemindedAt != nil && now.Sub(*r.LastRemindedAt) < reminderCooldown {
+			continue
+		}
+		byAdvisor[r.Student.AdvisorID] = append(byAdvisor[r.Student.AdvisorID], r)
+	}
+
+	sent := 0
+	for advisorID, refs := range byAdvisor {
+		// penerima = dosen yang benar-benar bisa memverifikasi: dosen wali aktif + penerima delegasi aktif
+		verifiers, err := s.advisorSvc.Verifiers(advisorID, now)
+		if err != nil {
+			log.Printf("[REMINDER] failed to resolve verifiers of %s: %v", advisorID, err)
+			continue
+		}
+		var names, to []string
+		for _, v := range verifiers {
+			if v.User.Email != "" {
+				names = append(names, v.User.FullName)
+				to = append(to, v.User.Email)
+			}
+		}
+		if len(to) == 0 {
+			log.Printf("[REMINDER] no active verifier for advisees of %s, %d overdue item(s) left on the dashboard", advisorID, len(refs))
+			continue
+		}
+
+		// klaim dulu sebelum kirim: di deployment multi-instance hanya satu instance yang mengirim
+		claimedIDs, err := s.refRepo.ClaimReminders(refIDsOf(refs), now, now.Add(-reminderCooldown))
+		if err != nil {
+			return sent, err
+		}
+		refs = filterRefs(refs, claimedIDs)
+		if len(refs) == 0 {
+			continue
+		}
+
+		titles, err := s.titlesOf(ctx, refs)
+		if err != nil {
+			_ = s.refRepo.ReleaseReminders(claimedIDs, now)
+			return sent, err
+		}
+
+		var body strings.Builder
+		fmt.Fprintf(&body, "Yth. %s,\n\n", strings.Join(names, ", "))
+		fmt.Fprintf(&body, "Ada %d prestasi mahasiswa bimbingan yang menunggu verifikasi lebih dari %.0f jam:\n\n", len(refs), s.sla.Hours())
+		for _, r := range refs {
+			item := s.pendingItem(r, titles, now)
+			fmt.Fprintf(&body, "- %s (%s) - %s, menunggu %.0f jam\n", r.Student.User.FullName, r.Student.StudentID, item.Title, item.AgeHours)
+		}
+
+		err = s.mailer.Send(ctx, mailer.Message{
+			To:      to,
+			Subject: fmt.Sprintf("[Prestasi] %d prestasi menunggu verifikasi", len(refs)),
+			Body:    body.String(),
+		})
+		if err != nil {
+			log.Printf("[REMINDER] failed to send to %s: %v", strings.Join(to, ", "), err)
+			if err := s.refRepo.ReleaseReminders(claimedIDs, now); err != nil {
+				log.Printf("[REMINDER] release claim failed: %v", err)
+			}
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// RunReminderLoop: cek SLA berkala sampai ctx dibatalkan
+func (s *AdvisorDashboardService) RunReminderLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.SendOverdueReminders(ctx)
+			if err != nil {
+				log.Printf("[REMINDER] %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("[REMINDER] sent %d overdue reminder(s)", n)
+			}
+		}
+	}
+}
+
+func (s *AdvisorDashboardService) pendingItem(
+	r model.AchievementReference,
+	titles map[string]string,
+	now time.Time,
+) model.PendingVerification {
+	item := model.PendingVerification{
+		ReferenceID: r.ID,
+		Title:       titles[r.MongoAchievementID],
+	}
+	if r.SubmittedAt != nil {
+		age := now.Sub(*r.SubmittedAt)
+		item.SubmittedAt = *r.SubmittedAt
+		item.AgeHours = age.Hours()
+		item.Overdue = age > s.sla
+	}
+	return item
+}
+
+func (s *AdvisorDashboardService) titlesOf(ctx context.Context, refs []model.AchievementReference) (map[string]string, error) {
+	ids := make([]string, 0, len(refs))
+	for _, r := range refs {
+		ids = append(ids, r.MongoAchievementID)
+	}
+
+	titles := map[string]string{}
+	if len(ids) == 0 {
+		return titles, nil
+	}
+
+	achs, err := s.achievementRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range achs {
+		titles[a.ID.Hex()] = a.Title
+	}
+	return titles, nil
+}
+
+func refIDsOf(refs []model.AchievementReference) []string {
+	ids := make([]string, 0, len(refs))
+	for _, r := range refs {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+// filterRefs: hanya reference dengan id di ids, urutan asli dipertahankan
+func filterRefs(refs []model.AchievementReference, ids []string) []model.AchievementReference {
+	keep := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		keep[id] = true
+	}
+	out := refs[:0:0]
+	for _, r := range refs {
+		if keep[r.ID] {
+			out = append(out, r)
+		}
+	}
+	return out
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/nerhays/prestasi_uas/mailer"
+	"github.com/nerhays/prestasi_uas/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeMailer struct {
+	sent []mailer.Message
+	err  error
+}
+
+func (f *fakeMailer) Send(ctx context.Context, msg mailer.Message) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+// reminderAdvisorService: penentu penerima pengingat; delegations = delegasi aktif dari semua dosen wali
+func reminderAdvisorService(lecturerRepo *mocks.LecturerRepositoryMock, delegations []model.VerificationDelegation) *AdvisorAssignmentService {
+	delegationRepo := new(mocks.VerificationDelegationRepositoryMock)
+	delegationRepo.On("FindActive", mock.Anything, "", mock.Anything).Return(delegations, nil)
+	return NewAdvisorAssignmentService(
+		new(mocks.AdvisorAssignmentRepositoryMock),
+		delegationRepo,
+		new(mocks.StudentRepositoryMock),
+		lecturerRepo,
+		new(mocks.AchievementReferenceRepositoryMock),
+		nil,
+		nil,
+	)
+}
+
+func hoursAgo(h int) *time.Time {
+	t := time.Now().Add(-time.Duration(h) * time.Hour)
+	return &t
+}
+
+func TestGetMyDashboard_PendingAndOverdue(t *testing.T) {
+	achRepo := new(mocks.AchievementRepositoryMock)
+	refRepo := new(mocks.AchievementReferenceRepositoryMock)
+	studentRepo := new(mocks.StudentRepositoryMock)
+	lecturerRepo := new(mocks.LecturerRepositoryMock)
+
+	svc := NewAdvisorDashboardService(achRepo, refRepo, studentRepo, lecturerRepo, &fakeMailer{}, 72*time.Hour, time.Now, nil)
+
+	lect := &model.Lecturer{ID: "lec-1", UserID: "user-lec", User: model.User{FullName: "Dr. Andi"}}
+	lecturerRepo.On("FindByUserID", "user-lec").Return(lect, nil)
+	studentRepo.On("FindByAdvisorID", "lec-1").Return([]model.Student{
+		{ID: "st-1", StudentID: "2201", User: model.User{FullName: "Budi"}},
+		{ID: "st-2", StudentID: "2202", User: model.User{FullName: "Sari"}},
+	}, nil)
+	refRepo.On("CountReviewedBy", []string{"user-lec"}, mock.Anything).
+		Return(map[string]map[string]int64{"user-lec": {"verified": 4, "rejected": 1}}, nil)
+
+	oid := primitive.NewObjectID()
+	submitted := model.AchievementStatusSubmitted
+	refRepo.On("CountByStudentIDs", []string{"st-1", "st-2"}, &submitted).Return(int64(2), nil)
+	refRepo.On("FindByStudentIDs", []string{"st-1", "st-2"}, &submitted, 2, 0).Return([]model.AchievementReference{
+		{ID: "ref-1", StudentID: "st-2", MongoAchievementID: oid.Hex(), SubmittedAt: hoursAgo(100)},
+		{ID: "ref-2", StudentID: "st-2", SubmittedAt: hoursAgo(5)},
+	}, nil)
+	achRepo.On("FindByIDs", mock.Anything, mock.Anything).
+		Return([]model.Achievement{{ID: oid, Title: "Juara 1 Hackathon"}}, nil)
+
+	dash, err := svc.GetMyDashboard(context.Background(), "user-lec")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, dash.Advisees)
+	assert.Equal(t, 2, dash.Pending)
+	assert.Equal(t, 1, dash.Overdue)
+	assert.Equal(t, int64(4), dash.Verified)
+	assert.Equal(t, "Sari", dash.AdviseeDetails[0].Name)
+	assert.Equal(t, "Juara 1 Hackathon", dash.AdviseeDetails[0].Items[0].Title)
+	assert.True(t, dash.AdviseeDetails[0].Items[0].Overdue)
+	assert.Empty(t, dash.AdviseeDetails[1].Items)
+}
+
+func TestGetMyDashboard_LecturerNotFound(t *testing.T) {
+	lecturerRepo := new(mocks.LecturerRepositoryMock)
+	svc := NewAdvisorDashboardService(
+		new(mocks.AchievementRepositoryMock),
+		new(mocks.AchievementReferenceRepositoryMock),
+		new(mocks.StudentRepositoryMock),
+		lecturerRepo,
+		&fakeMailer{},
+		72*time.Hour,
+		time.Now,
+		nil,
+	)
+
+	lecturerRepo.On("FindByUserID", "x").Return((*model.Lecturer)(nil), assert.AnError)
+
+	_, err := svc.GetMyDashboard(context.Background(), "x")
+
+	assert.ErrorIs(t, err, ErrLecturerNotFound)
+}
+
+func TestSendOverdueReminders_SkipsFreshAndRecentlyReminded(t *testing.T) {
+	achRepo := new(mocks.AchievementRepositoryMock)
+	refRepo := new(mocks.AchievementReferenceRepositoryMock)
+	lecturerRepo := new(mocks.LecturerRepositoryMock)
+	mail := &fakeMailer{}
+
+	svc := NewAdvisorDashboardService(achRepo, refRepo, new(mocks.StudentRepositoryMock), lecturerRepo, mail, 72*time.Hour, time.Now, reminderAdvisorService(lecturerRepo, nil))
+
+	student := model.Student{AdvisorID: "lec-1", StudentID: "2201", User: model.User{FullName: "Budi"}}
+	refRepo.On("FindPendingWithStudent").Return([]model.AchievementReference{
+		{ID: "ref-old", Student: student, SubmittedAt: hoursAgo(100)},
+		{ID: "ref-new", Student: student, SubmittedAt: hoursAgo(10)},
+		{ID: "ref-reminded", Student: student, SubmittedAt: hoursAgo(200), LastRemindedAt: hoursAgo(2)},
+	}, nil)
+	lecturerRepo.On("FindByID", "lec-1").
+		Return(&model.Lecturer{ID: "lec-1", User: model.User{FullName: "Dr. Andi", Email: "andi@kampus.ac.id", IsActive: true}}, nil)
+	achRepo.On("FindByIDs", mock.Anything, mock.Anything).Return([]model.Achievement{}, nil)
+	refRepo.On("ClaimReminders", []string{"ref-old"}, mock.Anything, mock.Anything).Return([]string{"ref-old"}, nil)
+
+	sent, err := svc.SendOverdueReminders(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Len(t, mail.sent, 1)
+	assert.Equal(t, []string{"andi@kampus.ac.id"}, mail.sent[0].To)
+	assert.Contains(t, mail.sent[0].Body, "Budi")
+	refRepo.AssertExpectations(t)
+}
+
+func TestSendOverdueReminders_ClaimedElsewhereOrSendFailed(t *testing.T) {
+	achRepo := new(mocks.AchievementRepositoryMock)
+	refRepo := new(mocks.AchievementReferenceRepositoryMock)
+	lecturerRepo := new(mocks.LecturerRepositoryMock)
+	mail := &fakeMailer{}
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	svc := NewAdvisorDashboardService(achRepo, refRepo, new(mocks.StudentRepositoryMock), lecturerRepo, mail, 72*time.Hour, utils.FixedClock(now), reminderAdvisorService(lecturerRepo, nil))
+
+	submitted := now.Add(-100 * time.Hour)
+	student := model.Student{AdvisorID: "lec-1", StudentID: "2201", User: model.User{FullName: "Budi"}}
+	refRepo.On("FindPendingWithStudent").Return([]model.AchievementReference{
+		{ID: "ref-old", Student: student, SubmittedAt: &submitted},
+	}, nil)
+	lecturerRepo.On("FindByID", "lec-1").
+		Return(&model.Lecturer{ID: "lec-1", User: model.User{FullName: "Dr. Andi", Email: "andi@kampus.ac.id", IsActive: true}}, nil)
+	achRepo.On("FindByIDs", mock.Anything, mock.Anything).Return([]model.Achievement{}, nil)
+
+	// instance lain sudah mengklaim: tidak ada email
+	refRepo.On("ClaimReminders", []string{"ref-old"}, now, now.Add(-reminderCooldown)).Return([]string{}, nil).Once()
+
+	sent, err := svc.SendOverdueReminders(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Empty(t, mail.sent)
+
+	// klaim berhasil tapi email gagal: klaim dilepas lagi
+	mail.err = errors.New("smtp down")
+	refRepo.On("ClaimReminders", []string{"ref-old"}, now, now.Add(-reminderCooldown)).Return([]string{"ref-old"}, nil).Once()
+	refRepo.On("ReleaseReminders", []string{"ref-old"}, now).Return(nil).Once()
+
+	sent, err = svc.SendOverdueReminders(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	refRepo.AssertExpectations(t)
+}
+
+func TestSendOverdueReminders_GoesToActiveVerifiers(t *testing.T) {
+	achRepo := new(mocks.AchievementRepositoryMock)
+	refRepo := new(mocks.AchievementReferenceRepositoryMock)
+	lecturerRepo := new(mocks.LecturerRepositoryMock)
+	mail := &fakeMailer{}
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	// lec-1 nonaktif dan mendelegasikan ke lec-2; lec-3 nonaktif tanpa delegasi
+	advisorSvc := reminderAdvisorService(lecturerRepo, nil)
+	delegationRepo := new(mocks.VerificationDelegationRepositoryMock)
+	delegationRepo.On("FindActive", "lec-1", "", now).Return([]model.VerificationDelegation{{FromLecturerID: "lec-1", ToLecturerID: "lec-2"}}, nil)
+	delegationRepo.On("FindActive", "lec-3", "", now).Return([]model.VerificationDelegation{}, nil)
+	advisorSvc.delegationRepo = delegationRepo
+	svc := NewAdvisorDashboardService(achRepo, refRepo, new(mocks.StudentRepositoryMock), lecturerRepo, mail, 72*time.Hour, utils.FixedClock(now), advisorSvc)
+
+	submitted := now.Add(-100 * time.Hour)
+	refRepo.On("FindPendingWithStudent").Return([]model.AchievementReference{
+		{ID: "ref-1", Student: model.Student{AdvisorID: "lec-1", User: model.User{FullName: "Budi"}}, SubmittedAt: &submitted},
+		{ID: "ref-3", Student: model.Student{AdvisorID: "lec-3", User: model.User{FullName: "Sari"}}, SubmittedAt: &submitted},
+	}, nil)
+	lecturerRepo.On("FindByID", "lec-1").Return(&model.Lecturer{ID: "lec-1", User: model.User{Email: "andi@kampus.ac.id"}}, nil)
+	lecturerRepo.On("FindByID", "lec-2").
+		Return(&model.Lecturer{ID: "lec-2", User: model.User{FullName: "Dr. Rina", Email: "rina@kampus.ac.id", IsActive: true}}, nil)
+	lecturerRepo.On("FindByID", "lec-3").Return(&model.Lecturer{ID: "lec-3", User: model.User{Email: "joko@kampus.ac.id"}}, nil)
+	achRepo.On("FindByIDs", mock.Anything, mock.Anything).Return([]model.Achievement{}, nil)
+	refRepo.On("ClaimReminders", []string{"ref-1"}, now, mock.Anything).Return([]string{"ref-1"}, nil)
+
+	sent, err := svc.SendOverdueReminders(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Len(t, mail.sent, 1)
+	assert.Equal(t, []string{"rina@kampus.ac.id"}, mail.sent[0].To)
+	assert.Contains(t, mail.sent[0].Body, "Budi")
+	// tanpa verifikator aktif tidak diklaim, tetap terlihat di dashboard admin
+	refRepo.AssertNotCalled(t, "ClaimReminders", []string{"ref-3"}, mock.Anything, mock.Anything)
+}
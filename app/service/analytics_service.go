@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+var ErrInvalidGroupBy = errors.New("group_by must be one of: month, semester, program_study, academic_year, competition_level")
+
+const (
+	GroupByMonth            = "month"
+	GroupBySemester         = "semester"
+	GroupByProgramStudy     = "program_study"
+	GroupByAcademicYear     = "academic_year"
+	GroupByCompetitionLevel = "competition_level"
+)
+
+type AnalyticsQuery struct {
+	From    time.Time
+	To      time.Time // eksklusif
+	GroupBy string
+	Limit   int // jumlah entri leaderboard
+	// PeriodID: jika diisi, hanya prestasi di periode akademik ini (From/To diambil dari periode)
+	PeriodID string
+	// Scope: admin fakultas/jurusan hanya melihat mahasiswa unitnya
+	Scope model.UnitScope
+}
+
+// AnalyticsService: tren & leaderboard prestasi terverifikasi (Postgres + agregasi Mongo)
+type AnalyticsService struct {
+	achievementRepo repository.AchievementRepository
+	refRepo         repository.AchievementReferenceRepository
+	logRepo         repository.AchievementStatusLogRepository
+	periodRepo      repository.AcademicPeriodRepository
+}
+
+func NewAnalyticsService(
+	achievementRepo repository.AchievementRepository,
+	refRepo repository.AchievementReferenceRepository,
+	logRepo repository.AchievementStatusLogRepository,
+	periodRepo repository.AcademicPeriodRepository,
+) *AnalyticsService {
+	return &AnalyticsService{
+		achievementRepo: achievementRepo,
+		refRepo:         refRepo,
+		logRepo:         logRepo,
+		periodRepo:      periodRepo,
+	}
+}
+
+func (s *AnalyticsService) GetAnalytics(ctx context.Context, q AnalyticsQuery) (*model.AchievementAnalytics, error) {
+	if q.GroupBy == "" {
+		q.GroupBy = GroupByMonth
+	}
+	switch q.GroupBy {
+	case GroupByMonth, GroupBySemester, GroupByProgramStudy, GroupByAcademicYear, GroupByCompetitionLevel:
+	default:
+		return nil, ErrInvalidGroupBy
+	}
+	if q.Limit < 1 {
+		q.Limit = 10
+	}
+
+	// 1. prestasi terverifikasi di rentang tanggal / periode akademik (Postgres)
+	var refs []model.AchievementReference
+	if q.PeriodID != "" {
+		period, err := s.periodRepo.FindByID(q.PeriodID)
+		if err != nil {
+			return nil, ErrPeriodNotFound
+		}
+		q.From = period.StartDate
+		q.To = period.EndDate.AddDate(0, 0, 1)
+
+		refs, err = s.refRepo.FindVerifiedByPeriod(period.ID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		refs, err = s.refRepo.FindVerifiedBetween(q.From, q.To)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !q.Scope.IsGlobal() {
+		scoped := refs[:0]
+		for _, r := range refs {
+			if q.Scope.CoversStudent(&r.Student) {
+				scoped = append(scoped, r)
+			}
+		}
+		refs = scoped
+	}
+
+	mongoIDs := make([]string, 0, len(refs))
+	for _, r := range refs {
+		mongoIDs = append(mongoIDs, r.MongoAchievementID)
+	}
+
+	// 2. poin per achievement (Mongo)
+	points := map[string]float64{}
+	var err error
+	if len(mongoIDs) > 0 {
+		points, err = s.achievementRepo.PointsByIDs(ctx, mongoIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &model.AchievementAnalytics{
+		From:          q.From,
+		To:            q.To,
+		GroupBy:       q.GroupBy,
+		TotalVerified: int64(len(refs)),
+		Series:        []model.AnalyticsBucket{},
+		TopStudents:   []model.LeaderboardEntry{},
+		TopPrograms:   []model.LeaderboardEntry{},
+	}
+	for _, r := range refs {
+		result.TotalPoints += refPoints(r, points)
+	}
+
+	// 3. series sesuai group_by
+	if q.GroupBy == GroupByCompetitionLevel {
+		if len(mongoIDs) > 0 {
+			result.Series, err = s.achievementRepo.GroupByField(ctx, mongoIDs, "details.competitionLevel")
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		result.Series = groupRefs(refs, points, func(r model.AchievementReference) string {
+			switch q.GroupBy {
+			case GroupBySemester:
+				if r.Period != nil {
+					return r.Period.Label()
+				}
+				return semesterLabel(*r.VerifiedAt)
+			case GroupByProgramStudy:
+				return programName(r.Student)
+			case GroupByAcademicYear:
+				return r.Student.AcademicYear
+			default:
+				return r.VerifiedAt.Format("2006-01")
+			}
+		})
+	}
+
+	// 4. rata-rata waktu verifikasi dari status log
+	avgSeconds, samples, err := s.logRepo.AverageTurnaround(q.From, q.To, q.Scope)
+	if err != nil {
+		return nil, err
+	}
+	result.AvgTurnaroundHours = avgSeconds / 3600
+	result.TurnaroundSamples = samples
+
+	// 5. leaderboard mahasiswa & prodi berdasarkan poin
+	students := map[string]*model.LeaderboardEntry{}
+	programs := map[string]*model.LeaderboardEntry{}
+	for _, r := range refs {
+		p := refPoints(r, points)
+
+		st, ok := students[r.StudentID]
+		if !ok {
+			st = &model.LeaderboardEntry{ID: r.StudentID, Name: r.Student.User.FullName}
+			students[r.StudentID] = st
+		}
+		st.Count++
+		st.Points += p
+
+		progName := programName(r.Student)
+		prog, ok := programs[progName]
+		if !ok {
+			prog = &model.LeaderboardEntry{ID: progName, Name: progName}
+			if r.Student.StudyProgramID != nil {
+				prog.ID = *r.Student.StudyProgramID
+			}
+			programs[progName] = prog
+		}
+		prog.Count++
+		prog.Points += p
+	}
+	result.TopStudents = topEntries(students, q.Limit)
+	result.TopPrograms = topEntries(programs, q.Limit)
+
+	return result, nil
+}
+
+func groupRefs(
+	refs []model.AchievementReference,
+	points map[string]float64,
+	keyOf func(model.AchievementReference) string,
+) []model.AnalyticsBucket {
+	index := map[string]int{}
+	series := []model.AnalyticsBucket{}
+
+	for _, r := range refs {
+		key := keyOf(r)
+		if key == "" {
+			key = "unknown"
+		}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(series)
+			index[key] = i
+			series = append(series, model.AnalyticsBucket{Key: key})
+		}
+		series[i].Count++
+		series[i].Points += refPoints(r, points)
+	}
+
+	sort.SliceStable(series, func(i, j int) bool { return series[i].Key < series[j].Key })
+	return series
+}
+
+func topEntries(entries map[string]*model.LeaderboardEntry, limit int) []model.LeaderboardEntry {
+	list := make([]model.LeaderboardEntry, 0, len(entries))
+	for _, e := range entries {
+		list = append(list, *e)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Points != list[j].Points {
+			return list[i].Points > list[j].Points
+		}
+		return list[i].Name < list[j].Name
+	})
+
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
+
+// programName: nama prodi dari master study_programs, fallback ke teks lama program_study
+func programName(st model.Student) string {
+	if st.StudyProgram != nil {
+		return st.StudyProgram.Name
+	}
+	return st.ProgramStudy
+}
+
+// semesterLabel: fallback untuk prestasi tanpa periode akademik,
+// semester ganjil Agustus-Januari, genap Februari-Juli
+func semesterLabel(t time.Time) string {
+	year := t.Year()
+	switch {
+	case t.Month() >= time.August:
+		return fmt.Sprintf("%d/%d Ganjil", year, year+1)
+	case t.Month() == time.January:
+		return fmt.Sprintf("%d/%d Ganjil", year-1, year)
+	default:
+		return fmt.Sprintf("%d/%d Genap", year-1, year)
+	}
+}
+
+// refPoints: poin yang dikreditkan ke reference; anggota prestasi tim memakai bagian poinnya
+func refPoints(r model.AchievementReference, points map[string]float64) float64 {
+	if r.PointShare != nil {
+		return *r.PointShare
+	}
+	return points[r.MongoAchievementID]
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func analyticsFixture() []model.AchievementReference {
+	at := func(s string) *time.Time {
+		t, _ := time.Parse("2006-01-02", s)
+		return &t
+	}
+	budi := model.Student{ID: "st-1", ProgramStudy: "Informatika", AcademicYear: "2022", User: model.User{FullName: "Budi"}}
+	sari := model.Student{ID: "st-2", ProgramStudy: "Sistem Informasi", AcademicYear: "2023", User: model.User{FullName: "Sari"}}
+
+	return []model.AchievementReference{
+		{MongoAchievementID: "a1", StudentID: "st-1", Student: budi, VerifiedAt: at("2024-09-10")},
+		{MongoAchievementID: "a2", StudentID: "st-1", Student: budi, VerifiedAt: at("2024-09-20")},
+		{MongoAchievementID: "a3", StudentID: "st-2", Student: sari, VerifiedAt: at("2025-03-01")},
+	}
+}
+
+func TestGetAnalytics_ByMonthWithLeaderboard(t *testing.T) {
+	achRepo := new(mocks.AchievementRepositoryMock)
+	refRepo := new(mocks.AchievementReferenceRepositoryMock)
+	logRepo := new(mocks.AchievementStatusLogRepositoryMock)
+
+	svc := NewAnalyticsService(achRepo, refRepo, logRepo, new(mocks.AcademicPeriodRepositoryMock))
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	refRepo.On("FindVerifiedBetween", from, to).Return(analyticsFixture(), nil)
+	achRepo.On("PointsByIDs", mock.Anything, []string{"a1", "a2", "a3"}).
+		Return(map[string]float64{"a1": 10, "a2": 5, "a3": 40}, nil)
+	logRepo.On("AverageTurnaround", from, to, model.UnitScope{}).Return(float64(7200), int64(3), nil)
+
+	res, err := svc.GetAnalytics(context.Background(), AnalyticsQuery{From: from, To: to})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), res.TotalVerified)
+	assert.Equal(t, float64(55), res.TotalPoints)
+	assert.Equal(t, []model.AnalyticsBucket{
+		{Key: "2024-09", Count: 2, Points: 15},
+		{Key: "2025-03", Count: 1, Points: 40},
+	}, res.Series)
+	assert.Equal(t, float64(2), res.AvgTurnaroundHours)
+	assert.Equal(t, "Sari", res.TopStudents[0].Name)
+	assert.Equal(t, "Informatika", res.TopPrograms[1].Name)
+	assert.Equal(t, int64(2), res.TopPrograms[1].Count)
+}
+
+func TestGetAnalytics_BySemester(t *testing.T) {
+	achRepo := new(mocks.AchievementRepositoryMock)
+	refRepo := new(mocks.AchievementReferenceRepositoryMock)
+	logRepo := new(mocks.AchievementStatusLogRepositoryMock)
+
+	svc := NewAnalyticsService(achRepo, refRepo, logRepo, new(mocks.AcademicPeriodRepositoryMock))
+
+	refRepo.On("FindVerifiedBetween", mock.Anything, mock.Anything).Return(analyticsFixture(), nil)
+	achRepo.On("PointsByIDs", mock.Anything, mock.Anything).Return(map[string]float64{}, nil)
+	logRepo.On("AverageTurnaround", mock.Anything, mock.Anything, mock.Anything).Return(float64(0), int64(0), nil)
+
+	res, err := svc.GetAnalytics(context.Background(), AnalyticsQuery{GroupBy: GroupBySemester})
+
+	assert.NoError(t, err)
+	assert.Len(t, res.Series, 2)
+	assert.Equal(t, "2024/2025 Ganjil", res.Series[0].Key)
+	assert.Equal(t, "2024/2025 Genap", res.Series[1].Key)
+}
+
+func TestGetAnalytics_InvalidGroupBy(t *testing.T) {
+	svc := NewAnalyticsService(
+		new(mocks.AchievementRepositoryMock),
+		new(mocks.AchievementReferenceRepositoryMock),
+		new(mocks.AchievementStatusLogRepositoryMock),
+		new(mocks.AcademicPeriodRepositoryMock),
+	)
+
+	_, err := svc.GetAnalytics(context.Background(), AnalyticsQuery{GroupBy: "weekday"})
+
+	assert.ErrorIs(t, err, ErrInvalidGroupBy)
+}
+
+func TestGetAnalytics_ByPeriod(t *testing.T) {
+	achRepo := new(mocks.AchievementRepositoryMock)
+	refRepo := new(mocks.AchievementReferenceRepositoryMock)
+	logRepo := new(mocks.AchievementStatusLogRepositoryMock)
+	periodRepo := new(mocks.AcademicPeriodRepositoryMock)
+
+	svc := NewAnalyticsService(achRepo, refRepo, logRepo, periodRepo)
+
+	period := &model.AcademicPeriod{
+		ID:           "per-1",
+		AcademicYear: "2024/2025",
+		Semester:     model.SemesterGanjil,
+		StartDate:    time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:      time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+	}
+	refs := analyticsFixture()[:2]
+	for i := range refs {
+		refs[i].Period = period
+	}
+
+	periodRepo.On("FindByID", "per-1").Return(period, nil)
+	refRepo.On("FindVerifiedByPeriod", "per-1").Return(refs, nil)
+	achRepo.On("PointsByIDs", mock.Anything, mock.Anything).Return(map[string]float64{}, nil)
+	logRepo.On("AverageTurnaround", period.StartDate, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), model.UnitScope{}).
+		Return(float64(0), int64(0), nil)
+
+	res, err := svc.GetAnalytics(context.Background(), AnalyticsQuery{PeriodID: "per-1", GroupBy: GroupBySemester})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), res.TotalVerified)
+	assert.Equal(t, []model.AnalyticsBucket{{Key: "2024/2025 Ganjil", Count: 2}}, res.Series)
+	refRepo.AssertNotCalled(t, "FindVerifiedBetween", mock.Anything, mock.Anything)
+}
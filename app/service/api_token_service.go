@@ -0,0 +1,261 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+const (
+	apiTokenBytes      = 32
+	apiTokenDefaultTTL = 90 * 24 * time.Hour
+	apiTokenMaxTTL     = 365 * 24 * time.Hour
+
+	// bukan hash bcrypt yang valid → login password akun layanan selalu gagal
+	serviceAccountPasswordHash = "!"
+	serviceAccountEmailDomain  = "service-accounts.invalid"
+)
+
+var (
+	ErrAPITokenInvalid        = errors.New("invalid_api_token")
+	ErrAPITokenNotFound       = errors.New("api token not found")
+	ErrAPITokenName           = errors.New("token name is required")
+	ErrAPITokenScopes         = errors.New("at least one scope is required")
+	ErrAPITokenTTL            = errors.New("expires_in_days must be between 1 and 365")
+	ErrServiceAccountNotFound = errors.New("service account not found")
+	ErrServiceAccountInput    = errors.New("username and role_id are required")
+	ErrServiceAccountRole     = errors.New("role not found")
+)
+
+// ErrScopeNotAllowed: scope tidak ada di permission pemilik token
+type ErrScopeNotAllowed struct {
+	Scope string
+}
+
+func (e *ErrScopeNotAllowed) Error() string {
+	return fmt.Sprintf("scope not allowed: %s", e.Scope)
+}
+
+type CreateAPITokenInput struct {
+	Name   string
+	Scopes []string
+	// 0 = default 90 hari
+	ExpiresInDays int
+}
+
+type CreateServiceAccountInput struct {
+	Username string
+	FullName string
+	RoleID   string
+}
+
+type APITokenService struct {
+	tokenRepo repository.APITokenRepository
+	userRepo  repository.UserRepository
+	roleRepo  repository.RoleRepository
+}
+
+func NewAPITokenService(
+	tokenRepo repository.APITokenRepository,
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+) *APITokenService {
+	return &APITokenService{tokenRepo, userRepo, roleRepo}
+}
+
+// CreatePersonalToken: token milik user login sendiri, scope maksimal = permission role-nya
+func (s *APITokenService) CreatePersonalToken(userID string, input CreateAPITokenInput) (*model.APIToken, string, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, "", err
+	}
+	return s.createToken(user, userID, input)
+}
+
+// CreateServiceAccountToken: token akun layanan, dibuat oleh admin
+func (s *APITokenService) CreateServiceAccountToken(adminID, accountID string, input CreateAPITokenInput) (*model.APIToken, string, error) {
+	account, err := s.serviceAccount(accountID)
+	if err != nil {
+		return nil, "", err
+	}
+	return s.createToken(account, adminID, input)
+}
+
+func (s *APITokenService) createToken(owner *model.User, createdBy string, input CreateAPITokenInput) (*model.APIToken, string, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, "", ErrAPITokenName
+	}
+	if len(input.Scopes) == 0 {
+		return nil, "", ErrAPITokenScopes
+	}
+	ttl := apiTokenDefaultTTL
+	if input.ExpiresInDays != 0 {
+		ttl = time.Duration(input.ExpiresInDays) * 24 * time.Hour
+		if ttl <= 0 || ttl > apiTokenMaxTTL {
+			return nil, "", ErrAPITokenTTL
+		}
+	}
+
+	perms, err := s.userRepo.GetPermissionsByUserID(owner.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	byName := make(map[string]model.Permission, len(perms))
+	for _, p := range perms {
+		byName[p.Name] = p
+	}
+	scopes := make([]model.Permission, 0, len(input.Scopes))
+	seen := map[string]bool{}
+	for _, name := range input.Scopes {
+		p, ok := byName[name]
+		if !ok {
+			return nil, "", &ErrScopeNotAllowed{Scope: name}
+		}
+		if !seen[name] {
+			seen[name] = true
+			scopes = append(scopes, p)
+		}
+	}
+
+	random, err := utils.RandomToken(apiTokenBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	plain := model.APITokenPrefix + random
+
+	token := &model.APIToken{
+		UserID:      owner.ID,
+		Name:        name,
+		TokenPrefix: plain[:len(model.APITokenPrefix)+8],
+		TokenHash:   utils.SHA256Hex(plain),
+		Scopes:      scopes,
+		CreatedBy:   &createdBy,
+		ExpiresAt:   time.Now().Add(ttl),
+	}
+	if err := s.tokenRepo.Create(token); err != nil {
+		return nil, "", err
+	}
+	return token, plain, nil
+}
+
+func (s *APITokenService) ListTokens(userID string) ([]model.APIToken, error) {
+	return s.tokenRepo.FindByUserID(userID)
+}
+
+// RevokePersonalToken: user hanya bisa mencabut token miliknya
+func (s *APITokenService) RevokePersonalToken(userID, tokenID string) error {
+	token, err := s.tokenRepo.FindByID(tokenID)
+	if err != nil || token.UserID != userID {
+		return ErrAPITokenNotFound
+	}
+	return s.RevokeToken(tokenID)
+}
+
+// RevokeToken: cabut token siapa pun (admin)
+func (s *APITokenService) RevokeToken(tokenID string) error {
+	ok, err := s.tokenRepo.Revoke(tokenID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrAPITokenNotFound
+	}
+	return nil
+}
+
+func (s *APITokenService) CreateServiceAccount(input CreateServiceAccountInput) (*model.User, error) {
+	username := strings.TrimSpace(input.Username)
+	if username == "" || input.RoleID == "" {
+		return nil, ErrServiceAccountInput
+	}
+	if _, err := s.roleRepo.FindByID(input.RoleID); err != nil {
+		return nil, ErrServiceAccountRole
+	}
+	fullName := strings.TrimSpace(input.FullName)
+	if fullName == "" {
+		fullName = username
+	}
+
+	account := &model.User{
+		Username:         username,
+		Email:            username + "@" + serviceAccountEmailDomain,
+		PasswordHash:     serviceAccountPasswordHash,
+		FullName:         fullName,
+		RoleID:           input.RoleID,
+		IsActive:         true,
+		AuthBackend:      model.AuthBackendLocal,
+		IsServiceAccount: true,
+	}
+	if err := s.userRepo.Create(account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func (s *APITokenService) ListServiceAccounts() ([]model.User, error) {
+	return s.userRepo.FindServiceAccounts()
+}
+
+func (s *APITokenService) ListServiceAccountTokens(accountID string) ([]model.APIToken, error) {
+	if _, err := s.serviceAccount(accountID); err != nil {
+		return nil, err
+	}
+	return s.tokenRepo.FindByUserID(accountID)
+}
+
+func (s *APITokenService) serviceAccount(id string) (*model.User, error) {
+	user, err := s.userRepo.FindByID(id)
+	if err != nil || !user.IsServiceAccount {
+		return nil, ErrServiceAccountNotFound
+	}
+	return user, nil
+}
+
+// VerifyAPIToken: dipakai middleware.Auth; permission = scope token ∩ permission pemilik saat ini
+func (s *APITokenService) VerifyAPIToken(plain, ip string) (*model.APITokenPrincipal, error) {
+	now := time.Now()
+	token, err := s.tokenRepo.FindByHash(utils.SHA256Hex(plain))
+	if err != nil || !token.Active(now) {
+		return nil, ErrAPITokenInvalid
+	}
+	owner, err := s.userRepo.FindByID(token.UserID)
+	if err != nil || !owner.IsActive {
+		return nil, ErrAPITokenInvalid
+	}
+
+	// role pemilik bisa berubah setelah token dibuat
+	ownerPerms, err := s.userRepo.GetPermissionsByUserID(owner.ID)
+	if err != nil {
+		return nil, err
+	}
+	has := make(map[string]bool, len(ownerPerms))
+	for _, p := range ownerPerms {
+		has[p.Name] = true
+	}
+	perms := make([]string, 0, len(token.Scopes))
+	for _, p := range token.Scopes {
+		if has[p.Name] {
+			perms = append(perms, p.Name)
+		}
+	}
+
+	if err := s.tokenRepo.TouchLastUsed(token.ID, ip, now); err != nil {
+		log.Printf("[API TOKEN] failed to record usage of token %s: %v", token.ID, err)
+	}
+
+	return &model.APITokenPrincipal{
+		TokenID:     token.ID,
+		UserID:      owner.ID,
+		Username:    owner.Username,
+		Role:        owner.Role.Name,
+		Permissions: perms,
+		Scope:       owner.Scope(),
+	}, nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/nerhays/prestasi_uas/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreatePersonalToken_StoresHashAndRejectsForeignScope(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.APITokenRepositoryMock)
+	svc := NewAPITokenService(tokenRepo, userRepo, new(mocks.RoleRepositoryMock))
+
+	userRepo.On("FindByID", "user-1").Return(&model.User{ID: "user-1", IsActive: true}, nil)
+	userRepo.On("GetPermissionsByUserID", "user-1").Return([]model.Permission{
+		{ID: "p-report", Name: "report:read"},
+	}, nil)
+
+	var saved *model.APIToken
+	tokenRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(*model.APIToken)
+	}).Return(nil)
+
+	_, _, err := svc.CreatePersonalToken("user-1", CreateAPITokenInput{Name: "laporan", Scopes: []string{"user:manage"}})
+	var scopeErr *ErrScopeNotAllowed
+	assert.ErrorAs(t, err, &scopeErr)
+	assert.Equal(t, "user:manage", scopeErr.Scope)
+
+	_, _, err = svc.CreatePersonalToken("user-1", CreateAPITokenInput{Name: "laporan", Scopes: []string{"report:read"}, ExpiresInDays: 400})
+	assert.ErrorIs(t, err, ErrAPITokenTTL)
+
+	token, plain, err := svc.CreatePersonalToken("user-1", CreateAPITokenInput{Name: "laporan", Scopes: []string{"report:read", "report:read"}})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(plain, model.APITokenPrefix))
+	assert.True(t, strings.HasPrefix(plain, token.TokenPrefix))
+	assert.Equal(t, utils.SHA256Hex(plain), saved.TokenHash)
+	assert.Len(t, saved.Scopes, 1)
+	assert.WithinDuration(t, time.Now().Add(apiTokenDefaultTTL), saved.ExpiresAt, time.Minute)
+}
+
+func TestVerifyAPIToken_ScopesLimitedToOwnerPermissions(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.APITokenRepositoryMock)
+	svc := NewAPITokenService(tokenRepo, userRepo, new(mocks.RoleRepositoryMock))
+
+	plain := model.APITokenPrefix + "abc"
+	token := &model.APIToken{
+		ID:        "tok-1",
+		UserID:    "svc-1",
+		Scopes:    []model.Permission{{Name: "report:read"}, {Name: "student:read"}},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	tokenRepo.On("FindByHash", utils.SHA256Hex(plain)).Return(token, nil)
+	tokenRepo.On("FindByHash", mock.Anything).Return(nil, assert.AnError)
+	tokenRepo.On("TouchLastUsed", "tok-1", "10.0.0.5", mock.Anything).Return(nil)
+	userRepo.On("FindByID", "svc-1").Return(&model.User{
+		ID: "svc-1", Username: "portal-fakultas", IsActive: true, IsServiceAccount: true,
+		Role: model.Role{Name: "Admin"},
+	}, nil)
+	// role akun layanan sudah tidak punya student:read
+	userRepo.On("GetPermissionsByUserID", "svc-1").Return([]model.Permission{{Name: "report:read"}}, nil)
+
+	principal, err := svc.VerifyAPIToken(plain, "10.0.0.5")
+	assert.NoError(t, err)
+	assert.Equal(t, "Admin", principal.Role)
+	assert.Equal(t, []string{"report:read"}, principal.Permissions)
+	tokenRepo.AssertCalled(t, "TouchLastUsed", "tok-1", "10.0.0.5", mock.Anything)
+
+	_, err = svc.VerifyAPIToken(model.APITokenPrefix+"unknown", "10.0.0.5")
+	assert.ErrorIs(t, err, ErrAPITokenInvalid)
+
+	revoked := time.Now()
+	token.RevokedAt = &revoked
+	_, err = svc.VerifyAPIToken(plain, "10.0.0.5")
+	assert.ErrorIs(t, err, ErrAPITokenInvalid)
+}
+
+func TestLogin_ServiceAccountCannotUsePassword(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	auditRepo := new(mocks.LoginAuditRepositoryMock)
+	auditRepo.On("Create", mock.Anything).Return(nil)
+	svc := NewAuthService(userRepo, nil, NewLoginGuard(repository.NewMemoryLoginAttemptStore(), auditRepo, userRepo, DefaultLockoutPolicy()), nil, nil, testKeys)
+
+	hashed, _ := utils.HashPassword("password123")
+	userRepo.On("FindByUsernameOrEmail", "portal").Return(&model.User{
+		ID: "svc-1", Username: "portal", PasswordHash: hashed, IsActive: true, IsServiceAccount: true,
+	}, nil)
+
+	_, err := svc.Login(LoginInput{Username: "portal", Password: "password123", IP: "10.0.0.1"})
+	assert.EqualError(t, err, "invalid_credentials")
+}
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+const auditVerifyBatch = 1000
+
+// field yang selalu berubah saat update, tidak dicatat sebagai perubahan
+var auditIgnoredFields = map[string]bool{"updated_at": true, "updatedAt": true}
+
+// data pribadi tidak pernah disimpan di audit log: isinya ikut di-hash dan tabelnya append-only,
+// jadi tidak bisa dihapus saat user dianonimkan. Yang tercatat hanya nama field yang berubah.
+// student_id / lecturer_id berisi NIM / NIDN di profil; yang berformat UUID (foreign key) tetap disimpan.
+var auditPersonalFields = map[string]bool{
+	"username": true, "email": true, "full_name": true, "fullName": true,
+	"student_id": true, "studentId": true, "lecturer_id": true, "lecturerId": true, "nim": true, "nidn": true,
+}
+
+const auditRedacted = "[REDACTED]"
+
+type AuditService struct {
+	repo repository.AuditLogRepository
+}
+
+func NewAuditService(repo repository.AuditLogRepository) *AuditService {
+	return &AuditService{repo}
+}
+
+// Record: hitung diff before/after, buang data pribadi, lalu sambungkan entri ke rantai hash
+func (s *AuditService) Record(entry *model.AuditLog) error {
+	// presisi timestamp Postgres = mikrodetik; disamakan dulu supaya hash cocok saat dibaca ulang
+	entry.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	if entry.Changes == nil {
+		changes, err := AuditDiff(entry.Before, entry.After)
+		if err != nil {
+			return err
+		}
+		entry.Changes = changes
+	}
+	entry.Before = redactAuditPersonal(entry.Before, false)
+	entry.After = redactAuditPersonal(entry.After, false)
+	entry.Changes = redactAuditPersonal(entry.Changes, true)
+
+	return s.repo.Append(entry, func(prev *model.AuditLog) {
+		entry.Seq, entry.PrevHash = 1, ""
+		if prev != nil {
+			entry.Seq, entry.PrevHash = prev.Seq+1, prev.Hash
+		}
+		entry.Hash = AuditHash(entry)
+	})
+}
+
+func (s *AuditService) GetLogs(filter model.AuditLogFilter, page, limit int) ([]model.AuditLog, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	return s.repo.FindAll(filter, (page-1)*limit, limit)
+}
+
+// VerifyChain: baca seluruh rantai dari awal; berhenti di entri pertama yang tidak cocok
+func (s *AuditService) VerifyChain() (*model.AuditChainReport, error) {
+	report := &model.AuditChainReport{Valid: true}
+	var prev *model.AuditLog
+
+	for {
+		batch, err := s.repo.FindChain(report.Checked, auditVerifyBatch)
+		if err != nil {
+			return nil, err
+		}
+		for i := range batch {
+			entry := &batch[i]
+			if reason := checkAuditLink(prev, entry); reason != "" {
+				report.Valid, report.BrokenAt, report.Reason = false, entry.Seq, reason
+				return report, nil
+			}
+			report.Checked = entry.Seq
+			prev = entry
+		}
+		if len(batch) < auditVerifyBatch {
+			return report, nil
+		}
+	}
+}
+
+func checkAuditLink(prev, entry *model.AuditLog) string {
+	wantSeq, wantPrev := int64(1), ""
+	if prev != nil {
+		wantSeq, wantPrev = prev.Seq+1, prev.Hash
+	}
+	switch {
+	case entry.Seq != wantSeq:
+		return fmt.Sprintf("missing entries before seq %d", entry.Seq)
+	case entry.PrevHash != wantPrev:
+		return "prev_hash does not match previous entry"
+	case entry.Hash != AuditHash(entry):
+		return "entry content does not match its hash"
+	}
+	return ""
+}
+
+// AuditHash: SHA-256(prev_hash + isi entri kanonik); JSON dinormalisasi supaya tahan format ulang jsonb.
+// actor_username tidak ikut di-hash: aktor sudah terwakili actor_id, dan username dipseudonimkan saat anonimisasi.
+func AuditHash(e *model.AuditLog) string {
+	payload, _ := json.Marshal(struct {
+		Seq        int64  `json:"seq"`
+		ActorID    string `json:"actor_id"`
+		ActorRole  string `json:"actor_role"`
+		APITokenID string `json:"api_token_id"`
+		Action     string `json:"action"`
+		TargetType string `json:"target_type"`
+		TargetID   string `json:"target_id"`
+		Before     string `json:"before"`
+		After      string `json:"after"`
+		Changes    string `json:"changes"`
+		StatusCode int    `json:"status_code"`
+		IPAddress  string `json:"ip_address"`
+		UserAgent  string `json:"user_agent"`
+		RequestID  string `json:"request_id"`
+		CreatedAt  string `json:"created_at"`
+	}{
+		Seq:        e.Seq,
+		ActorID:    derefString(e.ActorID),
+		ActorRole:  e.ActorRole,
+		APITokenID: derefString(e.APITokenID),
+		Action:     e.Action,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetID,
+		Before:     canonicalJSON(e.Before),
+		After:      canonicalJSON(e.After),
+		Changes:    canonicalJSON(e.Changes),
+		StatusCode: e.StatusCode,
+		IPAddress:  e.IPAddress,
+		UserAgent:  e.UserAgent,
+		RequestID:  e.RequestID,
+		CreatedAt:  e.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	return utils.SHA256Hex(e.PrevHash + string(payload))
+}
+
+// AuditDiff: field level atas yang berbeda antara dua snapshot objek JSON
+func AuditDiff(before, after json.RawMessage) (json.RawMessage, error) {
+	old, _ := decodeAuditObject(before)
+	cur, _ := decodeAuditObject(after)
+	if old == nil || cur == nil {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(old)+len(cur))
+	for k := range old {
+		keys = append(keys, k)
+	}
+	for k := range cur {
+		if _, ok := old[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	changes := map[string]model.AuditChange{}
+	for _, k := range keys {
+		if auditIgnoredFields[k] || reflect.DeepEqual(old[k], cur[k]) {
+			continue
+		}
+		changes[k] = model.AuditChange{Old: old[k], New: cur[k]}
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(changes)
+}
+
+// redactAuditPersonal: nilai field pribadi (di kedalaman mana pun) diganti penanda.
+// changes = true: raw adalah hasil AuditDiff; field pribadi tetap tercatat berubah, old / new disensor
+func redactAuditPersonal(raw json.RawMessage, changes bool) json.RawMessage {
+	if isNullJSON(raw) {
+		return raw
+	}
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil
+	}
+
+	if obj, ok := v.(map[string]interface{}); ok && changes {
+		for k, x := range obj {
+			if change, _ := x.(map[string]interface{}); auditPersonalFields[k] &&
+				!(isAuditReference(change["old"]) && isAuditReference(change["new"])) {
+				obj[k] = model.AuditChange{Old: auditRedacted, New: auditRedacted}
+				continue
+			}
+			obj[k] = redactPersonalValue(x)
+		}
+	} else {
+		v = redactPersonalValue(v)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+func redactPersonalValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, x := range t {
+			if auditPersonalFields[k] && !isAuditReference(x) {
+				t[k] = auditRedacted
+				continue
+			}
+			t[k] = redactPersonalValue(x)
+		}
+	case []interface{}:
+		for i, x := range t {
+			t[i] = redactPersonalValue(x)
+		}
+	}
+	return v
+}
+
+// isAuditReference: UUID (foreign key) atau kosong, bukan data pribadi
+func isAuditReference(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		if t == "" {
+			return true
+		}
+		_, err := uuid.Parse(t)
+		return err == nil
+	}
+	return false
+}
+
+func decodeAuditObject(raw json.RawMessage) (map[string]interface{}, error) {
+	if isNullJSON(raw) {
+		return nil, nil
+	}
+	var obj map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	normalizeJSONNumbers(obj)
+	return obj, nil
+}
+
+func canonicalJSON(raw json.RawMessage) string {
+	if isNullJSON(raw) {
+		return ""
+	}
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return string(raw)
+	}
+	v = normalizeJSONNumbers(v)
+	out, _ := json.Marshal(v)
+	return string(out)
+}
+
+// normalizeJSONNumbers: 1.0 / 1e0 / 1 → bentuk yang sama (jsonb menulis ulang angka)
+func normalizeJSONNumbers(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, x := range t {
+			t[k] = normalizeJSONNumbers(x)
+		}
+	case []interface{}:
+		for i, x := range t {
+			t[i] = normalizeJSONNumbers(x)
+		}
+	case json.Number:
+		if f, err := strconv.ParseFloat(string(t), 64); err == nil {
+			return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
+		}
+	}
+	return v
+}
+
+func isNullJSON(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -2,6 +2,9 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"log"
+	"time"
 
 	"github.com/nerhays/prestasi_uas/app/model"
 	"github.com/nerhays/prestasi_uas/app/repository"
@@ -9,56 +12,296 @@ import (
 )
 
 type AuthService struct {
-	userRepo repository.UserRepository
+	userRepo       repository.UserRepository
+	roleRepo       repository.RoleRepository
+	guard          *LoginGuard
+	twoFactorSvc   *TwoFactorService
+	authenticators *Authenticators
+	keys           *utils.KeyManager
 }
 
-func NewAuthService(userRepo repository.UserRepository) *AuthService {
-	return &AuthService{userRepo: userRepo}
+// authenticators nil = semua user memakai password lokal (bcrypt)
+func NewAuthService(
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	guard *LoginGuard,
+	twoFactorSvc *TwoFactorService,
+	authenticators *Authenticators,
+	keys *utils.KeyManager,
+) *AuthService {
+	if authenticators == nil {
+		authenticators = NewAuthenticators(model.AuthBackendLocal)
+	}
+	return &AuthService{
+		userRepo:       userRepo,
+		roleRepo:       roleRepo,
+		guard:          guard,
+		twoFactorSvc:   twoFactorSvc,
+		authenticators: authenticators,
+		keys:           keys,
+	}
 }
 
 type LoginInput struct {
-	Username string
-	Password string
+	Username  string
+	Password  string
+	IP        string
+	UserAgent string
 }
 
 type LoginOutput struct {
 	Token       string
 	User        *model.User
 	Permissions []model.Permission
+	// 2FA aktif: Token kosong, client lanjut ke VerifyTwoFactor dengan ChallengeToken
+	TwoFactorRequired bool
+	ChallengeToken    string
+	ChallengeTTL      time.Duration
+}
+
+type TwoFactorLoginInput struct {
+	ChallengeToken string
+	Code           string
+	IP             string
+	UserAgent      string
 }
 
 func (s *AuthService) Login(input LoginInput) (*LoginOutput, error) {
-	user, err := s.userRepo.FindByUsernameOrEmail(input.Username)
+	now := time.Now()
+	audit := model.LoginAudit{
+		Username:  input.Username,
+		IPAddress: input.IP,
+		UserAgent: input.UserAgent,
+	}
+
+	// counter akun memakai username asli, jadi login via email dihitung ke akun yang sama
+	user, lookupErr := s.userRepo.FindByUsernameOrEmail(input.Username)
+	account := input.Username
+	if lookupErr == nil {
+		account = user.Username
+		audit.UserID = &user.ID
+	} else {
+		user = nil
+	}
+
+	// akun / IP sedang dikunci atau masih dalam jeda
+	if err := s.guard.Check(account, input.IP, now); err != nil {
+		audit.Reason = err.Error()
+		s.guard.Audit(audit)
+		return nil, err
+	}
+
+	// alasan asli hanya dicatat di audit; client selalu menerima invalid_credentials
+	// supaya status akun tidak bisa ditebak tanpa password yang benar
+	fail := func(reason string) error {
+		s.guard.RegisterFailure(account, input.IP, now)
+		audit.Reason = reason
+		s.guard.Audit(audit)
+		return ErrInvalidCredentials
+	}
+
+	_, authenticator, err := s.authenticators.For(user)
 	if err != nil {
-		return nil, errors.New("invalid_credentials")
+		return nil, err
+	}
+	identity, err := authenticator.Authenticate(input.Username, user, input.Password)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCredentials) {
+			return nil, fail("invalid_credentials")
+		}
+		// direktori tidak bisa dihubungi: bukan salah user, counter gagal tidak bertambah
+		log.Printf("[WARN] login %s: %v", account, err)
+		audit.Reason = ErrAuthBackendUnavailable.Error()
+		s.guard.Audit(audit)
+		return nil, ErrAuthBackendUnavailable
 	}
 
-	if !user.IsActive {
-		return nil, errors.New("user_inactive")
+	// status akun baru dicek setelah password terbukti benar
+	if user != nil && !user.IsActive {
+		return nil, fail("user_inactive")
+	}
+	// akun layanan hanya boleh memakai API token
+	if user != nil && user.IsServiceAccount {
+		return nil, fail("service_account")
+	}
+
+	// user direktori yang belum ada di DB lokal dibuat otomatis kalau grupnya terpetakan ke role
+	if user == nil {
+		if user, err = s.provisionExternalUser(identity); err != nil {
+			if errors.Is(err, ErrInvalidCredentials) {
+				return nil, fail("invalid_credentials")
+			}
+			return nil, err
+		}
+		audit.UserID = &user.ID
+	} else if err := s.syncExternalRole(user, identity); err != nil {
+		return nil, err
 	}
 
-	if !utils.CheckPassword(user.PasswordHash, input.Password) {
-		return nil, errors.New("invalid_credentials")
+	// password benar, tapi login baru selesai setelah kode 2FA; counter gagal belum direset
+	if user.TwoFactorEnabled {
+		challenge, err := s.keys.GenerateChallengeToken(user.ID, challengeTTL)
+		if err != nil {
+			return nil, err
+		}
+		audit.Reason = "2fa_required"
+		s.guard.Audit(audit)
+		return &LoginOutput{
+			User:              user,
+			TwoFactorRequired: true,
+			ChallengeToken:    challenge,
+			ChallengeTTL:      challengeTTL,
+		}, nil
+	}
+
+	return s.completeLogin(user, account, audit)
+}
+
+// VerifyTwoFactor: langkah kedua login, tukar token tantangan + kode TOTP / cadangan dengan access token
+func (s *AuthService) VerifyTwoFactor(input TwoFactorLoginInput) (*LoginOutput, error) {
+	claims, err := s.keys.ParseChallengeToken(input.ChallengeToken)
+	if err != nil {
+		return nil, ErrInvalidChallenge
+	}
+	user, err := s.userRepo.FindByID(claims.UserID)
+	if err != nil || !user.IsActive {
+		return nil, ErrInvalidChallenge
 	}
 
+	now := time.Now()
+	audit := model.LoginAudit{
+		UserID:    &user.ID,
+		Username:  user.Username,
+		IPAddress: input.IP,
+		UserAgent: input.UserAgent,
+	}
+
+	if err := s.guard.Check(user.Username, input.IP, now); err != nil {
+		audit.Reason = err.Error()
+		s.guard.Audit(audit)
+		return nil, err
+	}
+
+	if err := s.twoFactorSvc.Verify(user.ID, input.Code); err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) {
+			s.guard.RegisterFailure(user.Username, input.IP, now)
+			audit.Reason = err.Error()
+			s.guard.Audit(audit)
+		}
+		return nil, err
+	}
+
+	return s.completeLogin(user, user.Username, audit)
+}
+
+// provisionExternalUser: user baru dari data direktori; tanpa role hasil mapping grup → ditolak
+func (s *AuthService) provisionExternalUser(identity *AuthIdentity) (*model.User, error) {
+	if identity.Role == "" || identity.Email == "" {
+		return nil, ErrInvalidCredentials
+	}
+	role, err := s.roleRepo.FindByName(identity.Role)
+	if err != nil {
+		return nil, fmt.Errorf("mapped role %q not found", identity.Role)
+	}
+
+	// password lokal acak, login selalu lewat direktori
+	secret, err := utils.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := utils.HashPassword(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	fullName := identity.FullName
+	if fullName == "" {
+		fullName = identity.Username
+	}
+	user := &model.User{
+		Username:     identity.Username,
+		Email:        identity.Email,
+		PasswordHash: hash,
+		FullName:     fullName,
+		RoleID:       role.ID,
+		IsActive:     true,
+		AuthBackend:  model.AuthBackendLDAP,
+	}
+	if err := s.userRepo.Create(user); err != nil {
+		return nil, err
+	}
+	user.Role = *role
+	return user, nil
+}
+
+// syncExternalRole: role user diikutkan ke mapping grup direktori (kalau ada)
+func (s *AuthService) syncExternalRole(user *model.User, identity *AuthIdentity) error {
+	if identity.Role == "" || identity.Role == user.Role.Name {
+		return nil
+	}
+	role, err := s.roleRepo.FindByName(identity.Role)
+	if err != nil {
+		return fmt.Errorf("mapped role %q not found", identity.Role)
+	}
+	// token lama (role sebelumnya) dicabut; token yang terbit setelah ini tetap berlaku
+	if err := s.userRepo.UpdateRole(user.ID, role.ID, time.Now()); err != nil {
+		return err
+	}
+	user.RoleID = role.ID
+	user.Role = *role
+	return nil
+}
+
+// LoginExternal: identitas sudah diverifikasi pihak lain (SSO); lock akun/IP dan status aktif tetap berlaku.
+// 2FA lokal dilewati, MFA jadi tanggung jawab IdP.
+func (s *AuthService) LoginExternal(user *model.User, ip, userAgent string) (*LoginOutput, error) {
+	now := time.Now()
+	audit := model.LoginAudit{
+		UserID:    &user.ID,
+		Username:  user.Username,
+		IPAddress: ip,
+		UserAgent: userAgent,
+	}
+
+	if err := s.guard.Check(user.Username, ip, now); err != nil {
+		audit.Reason = err.Error()
+		s.guard.Audit(audit)
+		return nil, err
+	}
+	if !user.IsActive {
+		audit.Reason = "user_inactive"
+		s.guard.Audit(audit)
+		return nil, errors.New(audit.Reason)
+	}
+
+	return s.completeLogin(user, user.Username, audit)
+}
+
+func (s *AuthService) completeLogin(user *model.User, account string, audit model.LoginAudit) (*LoginOutput, error) {
 	perms, err := s.userRepo.GetPermissionsByUserID(user.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	token, err := utils.GenerateToken(user, perms)
+	token, err := s.keys.GenerateToken(user, perms)
 	if err != nil {
 		return nil, err
 	}
 
+	s.guard.RegisterSuccess(account)
+	audit.Success = true
+	audit.Reason = ""
+	s.guard.Audit(audit)
+
 	return &LoginOutput{
 		Token:       token,
 		User:        user,
 		Permissions: perms,
 	}, nil
 }
+// RefreshToken: claim (role, scope, wajib ganti password / daftar 2FA) dihitung ulang dari DB
 func (s *AuthService) RefreshToken(oldToken string) (string, error) {
-	claims, err := utils.ParseToken(oldToken)
+	claims, err := s.keys.ParseToken(oldToken)
 	if err != nil {
 		return "", errors.New("invalid or expired token")
 	}
@@ -67,13 +310,21 @@ func (s *AuthService) RefreshToken(oldToken string) (string, error) {
 	if err != nil || !user.IsActive {
 		return "", errors.New("user not found or inactive")
 	}
+	// token terbit sebelum user dinonaktifkan tidak bisa diperpanjang walau user sudah aktif lagi
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+	if !user.SessionValid(issuedAt) {
+		return "", ErrSessionRevoked
+	}
 
 	perms, err := s.userRepo.GetPermissionsByUserID(user.ID)
 	if err != nil {
 		return "", err
 	}
 
-	return utils.GenerateToken(user, perms)
+	return s.keys.GenerateToken(user, perms)
 }
 func (s *AuthService) GetProfile(userID string) (*model.User, error) {
 	user, err := s.userRepo.FindByID(userID)
@@ -5,12 +5,38 @@ import (
 	"testing"
 
 	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
 	"github.com/nerhays/prestasi_uas/app/repository/mocks"
 	"github.com/nerhays/prestasi_uas/app/service"
 	"github.com/nerhays/prestasi_uas/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+// testKeys: kunci JWT di memori untuk test di package ini
+var testKeys = func() *utils.KeyManager {
+	keys, err := utils.NewKeyManager(utils.NewMemoryKeyStore(), utils.KeyManagerConfig{Algorithm: utils.AlgEdDSA})
+	if err != nil {
+		panic(err)
+	}
+	return keys
+}()
+
+func newTestLoginGuard(userRepo *mocks.UserRepositoryMock) *service.LoginGuard {
+	auditRepo := new(mocks.LoginAuditRepositoryMock)
+	auditRepo.On("Create", mock.Anything).Return(nil)
+	return service.NewLoginGuard(
+		repository.NewMemoryLoginAttemptStore(),
+		auditRepo,
+		userRepo,
+		service.DefaultLockoutPolicy(),
+	)
+}
+
+func newTestTwoFactorService(userRepo *mocks.UserRepositoryMock) *service.TwoFactorService {
+	return service.NewTwoFactorService(userRepo, new(mocks.TwoFactorRepositoryMock), "Prestasi", testKeys)
+}
+
 func TestLoginSuccess(t *testing.T) {
 	userRepo := new(mocks.UserRepositoryMock)
 
@@ -35,7 +61,7 @@ func TestLoginSuccess(t *testing.T) {
 	userRepo.On("GetPermissionsByUserID", "user-1").
 		Return(perms, nil)
 
-	authSvc := service.NewAuthService(userRepo)
+	authSvc := service.NewAuthService(userRepo, nil, newTestLoginGuard(userRepo), newTestTwoFactorService(userRepo), nil, testKeys)
 
 	res, err := authSvc.Login(service.LoginInput{
 		Username: "admin",
@@ -61,7 +87,7 @@ func TestLoginWrongPassword(t *testing.T) {
 			IsActive:     true,
 		}, nil)
 
-	authSvc := service.NewAuthService(userRepo)
+	authSvc := service.NewAuthService(userRepo, nil, newTestLoginGuard(userRepo), newTestTwoFactorService(userRepo), nil, testKeys)
 
 	res, err := authSvc.Login(service.LoginInput{
 		Username: "admin",
@@ -75,21 +101,26 @@ func TestLoginWrongPassword(t *testing.T) {
 func TestLoginUserInactive(t *testing.T) {
 	userRepo := new(mocks.UserRepositoryMock)
 
+	hashed, _ := utils.HashPassword("password")
 	userRepo.On("FindByUsernameOrEmail", "admin").
 		Return(&model.User{
-			ID:       "user-1",
-			IsActive: false,
+			ID:           "user-1",
+			PasswordHash: hashed,
+			IsActive:     false,
 		}, nil)
 
-	authSvc := service.NewAuthService(userRepo)
+	authSvc := service.NewAuthService(userRepo, nil, newTestLoginGuard(userRepo), newTestTwoFactorService(userRepo), nil, testKeys)
 
-	res, err := authSvc.Login(service.LoginInput{
-		Username: "admin",
-		Password: "password",
-	})
+	// password salah maupun benar: jawaban sama, status akun tidak bocor
+	for _, password := range []string{"wrong", "password"} {
+		res, err := authSvc.Login(service.LoginInput{
+			Username: "admin",
+			Password: password,
+		})
 
-	assert.Nil(t, res)
-	assert.EqualError(t, err, "user_inactive")
+		assert.Nil(t, res)
+		assert.EqualError(t, err, "invalid_credentials")
+	}
 }
 
 func TestRefreshTokenSuccess(t *testing.T) {
@@ -105,12 +136,12 @@ func TestRefreshTokenSuccess(t *testing.T) {
 	}
 
 	perms := []model.Permission{{Name: "read"}}
-	token, _ := utils.GenerateToken(user, perms)
+	token, _ := testKeys.GenerateToken(user, perms)
 
 	userRepo.On("FindByID", "user-1").Return(user, nil)
 	userRepo.On("GetPermissionsByUserID", "user-1").Return(perms, nil)
 
-	authSvc := service.NewAuthService(userRepo)
+	authSvc := service.NewAuthService(userRepo, nil, newTestLoginGuard(userRepo), newTestTwoFactorService(userRepo), nil, testKeys)
 
 	newToken, err := authSvc.RefreshToken(token)
 
@@ -128,7 +159,7 @@ func TestGetProfileSuccess(t *testing.T) {
 
 	userRepo.On("FindByID", "user-1").Return(user, nil)
 
-	authSvc := service.NewAuthService(userRepo)
+	authSvc := service.NewAuthService(userRepo, nil, newTestLoginGuard(userRepo), newTestTwoFactorService(userRepo), nil, testKeys)
 
 	res, err := authSvc.GetProfile("user-1")
 
@@ -142,7 +173,7 @@ func TestGetProfileNotFound(t *testing.T) {
 	userRepo.On("FindByID", "x").
 		Return(nil, errors.New("not found"))
 
-	authSvc := service.NewAuthService(userRepo)
+	authSvc := service.NewAuthService(userRepo, nil, newTestLoginGuard(userRepo), newTestTwoFactorService(userRepo), nil, testKeys)
 
 	res, err := authSvc.GetProfile("x")
 
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+var (
+	ErrInvalidCredentials     = errors.New("invalid_credentials")
+	ErrAuthBackendUnavailable = errors.New("auth_backend_unavailable")
+	ErrUnknownAuthBackend     = errors.New("unknown auth backend")
+)
+
+// AuthIdentity: data akun dari backend; Role diisi backend yang memetakan grup ke role (LDAP)
+type AuthIdentity struct {
+	Username string
+	Email    string
+	FullName string
+	Groups   []string
+	Role     string
+}
+
+// Authenticator: verifikasi username + password.
+// user nil kalau belum ada di DB lokal; error ErrInvalidCredentials kalau password salah.
+type Authenticator interface {
+	Authenticate(login string, user *model.User, password string) (*AuthIdentity, error)
+}
+
+// PasswordAuthenticator: password bcrypt di tabel users
+type PasswordAuthenticator struct{}
+
+// dummyPasswordHash: hash bcrypt dengan cost yang sama dengan HashPassword, dipakai kalau user tidak ada
+// supaya waktu respons login tidak membocorkan username mana yang terdaftar
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, err := utils.HashPassword("dummy-password-for-unknown-users")
+	if err != nil {
+		panic(err)
+	}
+	return hash
+})
+
+func (PasswordAuthenticator) Authenticate(login string, user *model.User, password string) (*AuthIdentity, error) {
+	if user == nil || user.PasswordHash == "" {
+		utils.CheckPassword(dummyPasswordHash(), password)
+		return nil, ErrInvalidCredentials
+	}
+	if !utils.CheckPassword(user.PasswordHash, password) {
+		return nil, ErrInvalidCredentials
+	}
+	return &AuthIdentity{Username: user.Username, Email: user.Email, FullName: user.FullName}, nil
+}
+
+// Authenticators: backend per user (users.auth_backend) dengan fallback ke backend global
+type Authenticators struct {
+	defaultBackend string
+	backends       map[string]Authenticator
+}
+
+// NewAuthenticators: backend local selalu terdaftar
+func NewAuthenticators(defaultBackend string) *Authenticators {
+	if defaultBackend == "" {
+		defaultBackend = model.AuthBackendLocal
+	}
+	return &Authenticators{
+		defaultBackend: defaultBackend,
+		backends:       map[string]Authenticator{model.AuthBackendLocal: PasswordAuthenticator{}},
+	}
+}
+
+func (a *Authenticators) Register(name string, auth Authenticator) {
+	a.backends[name] = auth
+}
+
+func (a *Authenticators) Has(name string) bool {
+	_, ok := a.backends[name]
+	return ok
+}
+
+// For: backend untuk user (nil = user belum ada di DB lokal)
+func (a *Authenticators) For(user *model.User) (string, Authenticator, error) {
+	name := a.defaultBackend
+	if user != nil && user.AuthBackend != "" {
+		name = user.AuthBackend
+	}
+	auth, ok := a.backends[name]
+	if !ok {
+		return name, nil, fmt.Errorf("%w: %s", ErrUnknownAuthBackend, name)
+	}
+	return name, auth, nil
+}
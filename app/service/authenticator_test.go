@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordAuthenticator_UnknownUserUsesDummyHashAtSameCost(t *testing.T) {
+	hashed, err := utils.HashPassword("password123")
+	require.NoError(t, err)
+
+	realCost, err := bcrypt.Cost([]byte(hashed))
+	require.NoError(t, err)
+	dummyCost, err := bcrypt.Cost([]byte(dummyPasswordHash()))
+	require.NoError(t, err)
+	assert.Equal(t, realCost, dummyCost, "user tidak ada harus sama mahalnya dengan password salah")
+
+	auth := PasswordAuthenticator{}
+	for _, user := range []*model.User{nil, {Username: "ldap-only"}} {
+		identity, err := auth.Authenticate("ghost", user, "password123")
+		assert.Nil(t, identity)
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+
+	identity, err := auth.Authenticate("budi", &model.User{Username: "budi", PasswordHash: hashed}, "password123")
+	require.NoError(t, err)
+	assert.Equal(t, "budi", identity.Username)
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+// jenis temuan pemeriksaan konsistensi
+const (
+	IssueMissingDocument        = "missing_document"
+	IssueOrphanDocument         = "orphan_document"
+	IssueDeletedStatusMismatch  = "deleted_status_mismatch"
+	IssueVerificationIncomplete = "verification_incomplete"
+	IssueSubmissionIncomplete   = "submission_incomplete"
+	IssueAdvisorMissing         = "advisor_missing"
+	IssueAdvisorInactive        = "advisor_inactive"
+)
+
+// ConsistencyIssue: satu data yang tidak sinkron antara Postgres dan Mongo (atau di dalam Postgres)
+type ConsistencyIssue struct {
+	Kind    string `json:"kind"`
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// ConsistencyService: pemeriksaan read-only yang dipakai `prestasi check consistency`
+type ConsistencyService struct {
+	refRepo         repository.AchievementReferenceRepository
+	achievementRepo repository.AchievementRepository
+	studentRepo     repository.StudentRepository
+	lecturerRepo    repository.LecturerRepository
+}
+
+func NewConsistencyService(
+	refRepo repository.AchievementReferenceRepository,
+	achievementRepo repository.AchievementRepository,
+	studentRepo repository.StudentRepository,
+	lecturerRepo repository.LecturerRepository,
+) *ConsistencyService {
+	return &ConsistencyService{
+		refRepo:         refRepo,
+		achievementRepo: achievementRepo,
+		studentRepo:     studentRepo,
+		lecturerRepo:    lecturerRepo,
+	}
+}
+
+// Check: semua temuan; slice kosong = konsisten
+func (s *ConsistencyService) Check(ctx context.Context) ([]ConsistencyIssue, error) {
+	issues := []ConsistencyIssue{}
+
+	achievementIssues, err := s.checkAchievements(ctx)
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, achievementIssues...)
+
+	advisorIssues, err := s.checkAdvisors()
+	if err != nil {
+		return nil, err
+	}
+	return append(issues, advisorIssues...), nil
+}
+
+// checkAchievements: reference vs dokumen Mongo (FindAll / FindByIDs tidak memuat dokumen soft delete)
+func (s *ConsistencyService) checkAchievements(ctx context.Context) ([]ConsistencyIssue, error) {
+	var refs []model.AchievementReference
+	for offset := 0; ; offset += exportPageSize {
+		page, _, err := s.refRepo.FindAll(offset, exportPageSize, repository.AchievementReferenceFilter{})
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, page...)
+		if len(page) < exportPageSize {
+			break
+		}
+	}
+
+	docs, err := s.achievementRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	activeDocs := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		activeDocs[doc.ID.Hex()] = true
+	}
+
+	var issues []ConsistencyIssue
+	referenced := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		referenced[ref.MongoAchievementID] = true
+		deleted := ref.Status == model.AchievementStatusDeleted
+
+		switch {
+		case !deleted && !activeDocs[ref.MongoAchievementID]:
+			issues = append(issues, ConsistencyIssue{
+				Kind:    IssueMissingDocument,
+				ID:      ref.ID,
+				Message: "achievement document " + ref.MongoAchievementID + " is missing or soft deleted in mongo",
+			})
+		case deleted && activeDocs[ref.MongoAchievementID]:
+			issues = append(issues, ConsistencyIssue{
+				Kind:    IssueDeletedStatusMismatch,
+				ID:      ref.ID,
+				Message: "reference is deleted but achievement document " + ref.MongoAchievementID + " is still active",
+			})
+		}
+
+		if ref.Status == model.AchievementStatusVerified && (ref.VerifiedAt == nil || ref.VerifiedBy == nil) {
+			issues = append(issues, ConsistencyIssue{
+				Kind:    IssueVerificationIncomplete,
+				ID:      ref.ID,
+				Message: "verified reference has no verified_at or verified_by",
+			})
+		}
+		if (ref.Status == model.AchievementStatusSubmitted || ref.Status == model.AchievementStatusVerified) && ref.SubmittedAt == nil {
+			issues = append(issues, ConsistencyIssue{
+				Kind:    IssueSubmissionIncomplete,
+				ID:      ref.ID,
+				Message: "reference with status " + string(ref.Status) + " has no submitted_at",
+			})
+		}
+	}
+
+	for _, doc := range docs {
+		if !referenced[doc.ID.Hex()] {
+			issues = append(issues, ConsistencyIssue{
+				Kind:    IssueOrphanDocument,
+				ID:      doc.ID.Hex(),
+				Message: "achievement document has no reference in postgres",
+			})
+		}
+	}
+	return issues, nil
+}
+
+// checkAdvisors: advisor_id mahasiswa harus menunjuk dosen yang ada dan aktif
+func (s *ConsistencyService) checkAdvisors() ([]ConsistencyIssue, error) {
+	students, err := s.studentRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	lecturers, err := s.lecturerRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]model.Lecturer, len(lecturers))
+	for _, l := range lecturers {
+		byID[l.ID] = l
+	}
+
+	var issues []ConsistencyIssue
+	for _, st := range students {
+		if !st.User.IsActive {
+			continue
+		}
+		lect, ok := byID[st.AdvisorID]
+		switch {
+		case !ok:
+			issues = append(issues, ConsistencyIssue{
+				Kind:    IssueAdvisorMissing,
+				ID:      st.ID,
+				Message: "student " + st.StudentID + " has no valid advisor",
+			})
+		case !lect.User.IsActive:
+			issues = append(issues, ConsistencyIssue{
+				Kind:    IssueAdvisorInactive,
+				ID:      st.ID,
+				Message: "student " + st.StudentID + " is advised by inactive lecturer " + lect.LecturerID,
+			})
+		}
+	}
+	return issues, nil
+}
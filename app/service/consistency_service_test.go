@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestConsistencyCheck_ReportsMismatches(t *testing.T) {
+	refRepo := new(mocks.AchievementReferenceRepositoryMock)
+	achievementRepo := new(mocks.AchievementRepositoryMock)
+	studentRepo := new(mocks.StudentRepositoryMock)
+	lecturerRepo := new(mocks.LecturerRepositoryMock)
+	svc := NewConsistencyService(refRepo, achievementRepo, studentRepo, lecturerRepo)
+
+	okDoc := primitive.NewObjectID()
+	orphanDoc := primitive.NewObjectID()
+	deletedDoc := primitive.NewObjectID()
+	now := time.Now()
+	verifier := "user-dosen"
+
+	refRepo.On("FindAll", 0, exportPageSize, mock.Anything).Return([]model.AchievementReference{
+		{ID: "ref-ok", MongoAchievementID: okDoc.Hex(), Status: model.AchievementStatusVerified, SubmittedAt: &now, VerifiedAt: &now, VerifiedBy: &verifier},
+		{ID: "ref-missing", MongoAchievementID: primitive.NewObjectID().Hex(), Status: model.AchievementStatusDraft},
+		{ID: "ref-deleted", MongoAchievementID: deletedDoc.Hex(), Status: model.AchievementStatusDeleted},
+		{ID: "ref-unverified", MongoAchievementID: okDoc.Hex(), Status: model.AchievementStatusVerified, SubmittedAt: &now},
+	}, int64(4), nil)
+	achievementRepo.On("FindAll", mock.Anything).Return([]model.Achievement{
+		{ID: okDoc}, {ID: orphanDoc}, {ID: deletedDoc},
+	}, nil)
+	studentRepo.On("FindAll").Return([]model.Student{
+		{ID: "s1", StudentID: "001", AdvisorID: "lect-1", User: model.User{IsActive: true}},
+		{ID: "s2", StudentID: "002", AdvisorID: "lect-gone", User: model.User{IsActive: true}},
+		{ID: "s3", StudentID: "003", AdvisorID: "lect-2", User: model.User{IsActive: true}},
+		{ID: "s4", StudentID: "004", AdvisorID: "lect-gone", User: model.User{IsActive: false}},
+	}, nil)
+	lecturerRepo.On("FindAll").Return([]model.Lecturer{
+		{ID: "lect-1", User: model.User{IsActive: true}},
+		{ID: "lect-2", LecturerID: "NIDN2", User: model.User{IsActive: false}},
+	}, nil)
+
+	issues, err := svc.Check(context.Background())
+	assert.NoError(t, err)
+
+	kinds := map[string]string{}
+	for _, issue := range issues {
+		kinds[issue.ID] = issue.Kind
+	}
+	assert.Equal(t, map[string]string{
+		"ref-missing":    IssueMissingDocument,
+		"ref-deleted":    IssueDeletedStatusMismatch,
+		"ref-unverified": IssueVerificationIncomplete,
+		orphanDoc.Hex():  IssueOrphanDocument,
+		"s2":             IssueAdvisorMissing,
+		"s3":             IssueAdvisorInactive,
+	}, kinds)
+}
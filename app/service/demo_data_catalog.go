@@ -0,0 +1,159 @@
+package service
+
+// data mentah generator demo; urutan penting: seed RNG yang sama harus memilih nilai yang sama
+
+type demoFaculty struct {
+	Code        string
+	Name        string
+	Departments []demoDepartment
+}
+
+type demoDepartment struct {
+	Code     string
+	Name     string
+	Programs []demoProgram
+}
+
+type demoProgram struct {
+	Code string
+	Name string
+}
+
+var demoFaculties = []demoFaculty{
+	{"FIK", "Fakultas Ilmu Komputer", []demoDepartment{
+		{"IF", "Informatika", []demoProgram{{"IF-S1", "S1 Teknik Informatika"}, {"SI-S1", "S1 Sistem Informasi"}}},
+		{"DS", "Sains Data", []demoProgram{{"DS-S1", "S1 Sains Data"}}},
+	}},
+	{"FT", "Fakultas Teknik", []demoDepartment{
+		{"TE", "Teknik Elektro", []demoProgram{{"TE-S1", "S1 Teknik Elektro"}, {"TT-D3", "D3 Teknik Telekomunikasi"}}},
+		{"TS", "Teknik Sipil", []demoProgram{{"TS-S1", "S1 Teknik Sipil"}}},
+	}},
+	{"FEB", "Fakultas Ekonomi dan Bisnis", []demoDepartment{
+		{"MJ", "Manajemen", []demoProgram{{"MJ-S1", "S1 Manajemen"}}},
+		{"AK", "Akuntansi", []demoProgram{{"AK-S1", "S1 Akuntansi"}, {"AK-D3", "D3 Akuntansi"}}},
+	}},
+	{"FV", "Fakultas Vokasi", []demoDepartment{
+		{"TRPL", "Teknologi Rekayasa Perangkat Lunak", []demoProgram{{"TRPL-D4", "D4 Teknologi Rekayasa Perangkat Lunak"}}},
+	}},
+}
+
+var demoFirstNames = []string{
+	"Adi", "Ayu", "Bagus", "Citra", "Dewi", "Dimas", "Eka", "Fajar", "Fitri", "Gilang",
+	"Hana", "Indah", "Intan", "Joko", "Kartika", "Lestari", "Maya", "Nanda", "Nur", "Putri",
+	"Rani", "Reza", "Rizky", "Sari", "Satria", "Taufik", "Tri", "Wahyu", "Wulan", "Yoga",
+}
+
+var demoLastNames = []string{
+	"Pratama", "Saputra", "Wijaya", "Santoso", "Hidayat", "Kurniawan", "Setiawan", "Lestari",
+	"Permata", "Nugroho", "Rahmawati", "Siregar", "Nasution", "Hakim", "Susanto", "Wibowo",
+	"Maharani", "Firmansyah", "Utami", "Ramadhan",
+}
+
+var demoLecturerTitles = []string{"S.T., M.T.", "S.Kom., M.Kom.", "Dr.", "M.Sc.", "S.E., M.M.", "Ph.D."}
+
+var demoCompetitions = []struct {
+	Name      string
+	Organizer string
+}{
+	{"Gemastik", "Kemendikbudristek"},
+	{"Kompetisi Robot Indonesia", "Puspresnas"},
+	{"Pekan Ilmiah Mahasiswa Nasional", "Kemendikbudristek"},
+	{"ICPC Asia Jakarta Regional", "ICPC Foundation"},
+	{"Hackathon Bank Indonesia", "Bank Indonesia"},
+	{"National University Debating Championship", "Puspresnas"},
+	{"Business Plan Competition", "Himpunan Mahasiswa Manajemen"},
+	{"Lomba Karya Tulis Ilmiah", "BEM Universitas"},
+	{"Olimpiade Nasional MIPA", "Puspresnas"},
+	{"Capture The Flag Cyber Jawara", "BSSN"},
+}
+
+// demoCompetitionLevel: Weight = peluang relatif, Points = poin juara 1
+type demoCompetitionLevel struct {
+	Level  string
+	Weight int
+	Points float64
+}
+
+var demoCompetitionLevels = []demoCompetitionLevel{
+	{"international", 1, 50},
+	{"national", 4, 30},
+	{"regional", 3, 20},
+	{"local", 2, 10},
+}
+
+var demoCities = []string{"Surabaya", "Jakarta", "Bandung", "Yogyakarta", "Malang", "Semarang", "Denpasar", "Makassar", "Medan", "Kuala Lumpur"}
+
+var demoPublications = []struct {
+	Type      string
+	Publisher string
+	Points    float64
+}{
+	{"journal", "Jurnal Ilmiah Teknologi Informasi", 30},
+	{"journal", "Jurnal Ekonomi dan Bisnis", 30},
+	{"conference", "IEEE International Conference on Information Technology", 25},
+	{"conference", "Seminar Nasional Teknologi dan Rekayasa", 15},
+	{"book", "Penerbit Andi", 25},
+}
+
+var demoResearchTopics = []string{
+	"Deteksi Penyakit Daun Padi Menggunakan CNN",
+	"Analisis Sentimen Ulasan Aplikasi Transportasi Daring",
+	"Sistem Monitoring Kualitas Air Berbasis IoT",
+	"Pengaruh Literasi Keuangan terhadap Minat Investasi Mahasiswa",
+	"Optimasi Rute Distribusi dengan Algoritma Genetika",
+	"Perancangan Jembatan Rangka Baja Ringan",
+	"Penerapan Blockchain untuk Rantai Pasok UMKM",
+	"Prediksi Kelulusan Mahasiswa dengan Random Forest",
+}
+
+var demoOrganizations = []string{
+	"Badan Eksekutif Mahasiswa", "Himpunan Mahasiswa Jurusan", "Unit Kegiatan Mahasiswa Robotika",
+	"Paduan Suara Mahasiswa", "Kelompok Studi Pasar Modal", "Unit Kegiatan Mahasiswa Pecinta Alam",
+}
+
+var demoPositions = []struct {
+	Name   string
+	Points float64
+}{
+	{"Ketua", 20}, {"Wakil Ketua", 15}, {"Sekretaris", 12}, {"Bendahara", 12}, {"Koordinator Divisi", 10}, {"Staf", 5},
+}
+
+var demoCertifications = []struct {
+	Name     string
+	IssuedBy string
+	Points   float64
+}{
+	{"AWS Certified Cloud Practitioner", "Amazon Web Services", 15},
+	{"Cisco Certified Network Associate", "Cisco", 15},
+	{"TOEFL ITP", "ETS", 10},
+	{"Oracle Certified Associate Java Programmer", "Oracle", 15},
+	{"Certified Associate in Project Management", "PMI", 15},
+	{"Sertifikasi Kompetensi Junior Web Developer", "BNSP", 10},
+}
+
+var demoAcademicAwards = []struct {
+	Title     string
+	Organizer string
+	Points    float64
+}{
+	{"Mahasiswa Berprestasi Tingkat Fakultas", "Fakultas", 20},
+	{"Penerima Beasiswa Prestasi", "Universitas", 15},
+	{"Asisten Laboratorium Terbaik", "Laboratorium Jurusan", 10},
+	{"Lulusan Program Pertukaran Mahasiswa Merdeka", "Kemendikbudristek", 15},
+	{"Peserta Studi Independen Bersertifikat", "Kampus Merdeka", 15},
+}
+
+var demoOtherActivities = []string{
+	"Relawan Pengabdian Masyarakat Desa Binaan",
+	"Panitia Seminar Nasional",
+	"Relawan Tanggap Bencana",
+	"Mentor Pelatihan Coding untuk SMA",
+	"Duta Kampus",
+}
+
+var demoRejectionNotes = []string{
+	"Sertifikat tidak terbaca, mohon unggah ulang dengan resolusi lebih baik",
+	"Tingkat kompetisi tidak sesuai dengan bukti yang dilampirkan",
+	"Kegiatan di luar periode studi",
+	"Bukti keikutsertaan tidak mencantumkan nama mahasiswa",
+}
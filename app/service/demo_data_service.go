@@ -0,0 +1,666 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+var (
+	ErrDemoDataExists = errors.New("demo data already exists, use another -seed or reset the database")
+	ErrDemoConfig     = errors.New("demo config needs at least 1 faculty, 1 lecturer and 1 year")
+)
+
+// DemoDataConfig: ukuran data demo; Seed + Until yang sama selalu menghasilkan isi yang sama
+type DemoDataConfig struct {
+	Seed      int64
+	Faculties int
+	Lecturers int
+	Students  int
+	// AchievementsPerStudent: rata-rata; tiap mahasiswa dapat 0..2x nilai ini
+	AchievementsPerStudent int
+	// Years: jumlah tahun akademik ke belakang dari Until
+	Years    int
+	Until    time.Time
+	Password string
+}
+
+// DefaultDemoDataConfig: cukup untuk mencoba laporan dan paginasi
+func DefaultDemoDataConfig() DemoDataConfig {
+	now := time.Now().UTC()
+	return DemoDataConfig{
+		Seed:                   1,
+		Faculties:              2,
+		Lecturers:              12,
+		Students:               120,
+		AchievementsPerStudent: 3,
+		Years:                  4,
+		Until:                  time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		Password:               "demo12345",
+	}
+}
+
+// DemoDataSummary: jumlah data yang dibuat
+type DemoDataSummary struct {
+	Faculties     int            `json:"faculties"`
+	Departments   int            `json:"departments"`
+	StudyPrograms int            `json:"study_programs"`
+	Periods       int            `json:"periods"`
+	Lecturers     int            `json:"lecturers"`
+	Students      int            `json:"students"`
+	Achievements  int            `json:"achievements"`
+	ByType        map[string]int `json:"by_type"`
+	ByStatus      map[string]int `json:"by_status"`
+}
+
+// DemoDataService: generator data palsu tapi realistis, ditulis lewat repository ke Postgres + Mongo
+type DemoDataService struct {
+	roleRepo        repository.RoleRepository
+	orgRepo         repository.OrganizationRepository
+	profileRepo     repository.ProfileRepository
+	lecturerRepo    repository.LecturerRepository
+	periodRepo      repository.AcademicPeriodRepository
+	achievementRepo repository.AchievementRepository
+	refRepo         repository.AchievementReferenceRepository
+	logRepo         repository.AchievementStatusLogRepository
+}
+
+func NewDemoDataService(
+	roleRepo repository.RoleRepository,
+	orgRepo repository.OrganizationRepository,
+	profileRepo repository.ProfileRepository,
+	lecturerRepo repository.LecturerRepository,
+	periodRepo repository.AcademicPeriodRepository,
+	achievementRepo repository.AchievementRepository,
+	refRepo repository.AchievementReferenceRepository,
+	logRepo repository.AchievementStatusLogRepository,
+) *DemoDataService {
+	return &DemoDataService{
+		roleRepo:        roleRepo,
+		orgRepo:         orgRepo,
+		profileRepo:     profileRepo,
+		lecturerRepo:    lecturerRepo,
+		periodRepo:      periodRepo,
+		achievementRepo: achievementRepo,
+		refRepo:         refRepo,
+		logRepo:         logRepo,
+	}
+}
+
+// demoRun: state satu kali generate
+type demoRun struct {
+	cfg          DemoDataConfig
+	rng          *rand.Rand
+	passwordHash string
+	summary      *DemoDataSummary
+
+	programs  []demoUnitProgram
+	periods   []model.AcademicPeriod
+	lecturers []demoPerson
+	firstYear int
+}
+
+type demoUnitProgram struct {
+	model.StudyProgram
+	DepartmentName string
+}
+
+type demoPerson struct {
+	UserID       string
+	ProfileID    string
+	FullName     string
+	DepartmentID string
+}
+
+type demoStudent struct {
+	demoPerson
+	Advisor   demoPerson
+	EntryYear int
+}
+
+// Generate: organisasi → periode → dosen → mahasiswa → prestasi; berhenti di error pertama
+func (s *DemoDataService) Generate(ctx context.Context, cfg DemoDataConfig) (*DemoDataSummary, error) {
+	if cfg.Faculties < 1 || cfg.Lecturers < 1 || cfg.Years < 1 || cfg.Students < 0 || cfg.AchievementsPerStudent < 0 {
+		return nil, ErrDemoConfig
+	}
+	if cfg.Faculties > len(demoFaculties) {
+		cfg.Faculties = len(demoFaculties)
+	}
+	if _, err := s.lecturerRepo.FindByNIDN(demoLecturerNIDN(cfg.Seed, 1)); err == nil {
+		return nil, ErrDemoDataExists
+	}
+
+	// bcrypt sekali untuk semua akun demo; ratusan hash terpisah terlalu lambat
+	hash, err := utils.HashPassword(cfg.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &demoRun{
+		cfg:          cfg,
+		rng:          rand.New(rand.NewSource(cfg.Seed)),
+		passwordHash: hash,
+		summary: &DemoDataSummary{
+			ByType:   map[string]int{},
+			ByStatus: map[string]int{},
+		},
+		firstYear: academicYearOf(cfg.Until) - cfg.Years + 1,
+	}
+
+	if err := s.seedOrganization(run); err != nil {
+		return nil, fmt.Errorf("organization: %w", err)
+	}
+	if err := s.seedPeriods(run); err != nil {
+		return nil, fmt.Errorf("academic periods: %w", err)
+	}
+	if err := s.seedLecturers(run); err != nil {
+		return nil, fmt.Errorf("lecturers: %w", err)
+	}
+	students, err := s.seedStudents(run)
+	if err != nil {
+		return nil, fmt.Errorf("students: %w", err)
+	}
+	for _, st := range students {
+		if err := ctx.Err(); err != nil {
+			return run.summary, err
+		}
+		if err := s.seedAchievements(ctx, run, st); err != nil {
+			return run.summary, fmt.Errorf("achievements: %w", err)
+		}
+	}
+	return run.summary, nil
+}
+
+// seedOrganization: unit dengan kode yang sudah ada dipakai ulang
+func (s *DemoDataService) seedOrganization(run *demoRun) error {
+	existingFaculties, err := s.orgRepo.FindAllFaculties()
+	if err != nil {
+		return err
+	}
+
+	for _, f := range demoFaculties[:run.cfg.Faculties] {
+		faculty := findByCode(existingFaculties, f.Code, func(x model.Faculty) string { return x.Code })
+		if faculty == nil {
+			faculty = &model.Faculty{Code: f.Code, Name: f.Name}
+			if err := s.orgRepo.CreateFaculty(faculty); err != nil {
+				return err
+			}
+			run.summary.Faculties++
+		}
+
+		existingDepts, err := s.orgRepo.FindDepartments(faculty.ID)
+		if err != nil {
+			return err
+		}
+		for _, d := range f.Departments {
+			dept := findByCode(existingDepts, d.Code, func(x model.Department) string { return x.Code })
+			if dept == nil {
+				dept = &model.Department{FacultyID: faculty.ID, Code: d.Code, Name: d.Name}
+				if err := s.orgRepo.CreateDepartment(dept); err != nil {
+					return err
+				}
+				run.summary.Departments++
+			}
+
+			existingPrograms, err := s.orgRepo.FindStudyPrograms(dept.ID)
+			if err != nil {
+				return err
+			}
+			for _, p := range d.Programs {
+				program := findByCode(existingPrograms, p.Code, func(x model.StudyProgram) string { return x.Code })
+				if program == nil {
+					program = &model.StudyProgram{DepartmentID: dept.ID, Code: p.Code, Name: p.Name}
+					if err := s.orgRepo.CreateStudyProgram(program); err != nil {
+						return err
+					}
+					run.summary.StudyPrograms++
+				}
+				run.programs = append(run.programs, demoUnitProgram{StudyProgram: *program, DepartmentName: dept.Name})
+			}
+		}
+	}
+	return nil
+}
+
+// seedPeriods: ganjil Agu–Jan, genap Feb–Jul; periode yang bertabrakan dengan data lama dipakai apa adanya
+func (s *DemoDataService) seedPeriods(run *demoRun) error {
+	for year := run.firstYear; year <= academicYearOf(run.cfg.Until); year++ {
+		label := fmt.Sprintf("%d/%d", year, year+1)
+		for _, p := range []model.AcademicPeriod{
+			{AcademicYear: label, Semester: model.SemesterGanjil, StartDate: utcDate(year, time.August, 1), EndDate: utcDate(year+1, time.January, 31)},
+			{AcademicYear: label, Semester: model.SemesterGenap, StartDate: utcDate(year+1, time.February, 1), EndDate: utcDate(year+1, time.July, 31)},
+		} {
+			overlapping, err := s.periodRepo.FindOverlapping(p.StartDate, p.EndDate, "")
+			if err != nil {
+				return err
+			}
+			if len(overlapping) > 0 {
+				run.periods = append(run.periods, overlapping...)
+				continue
+			}
+
+			period := p
+			if err := s.periodRepo.Create(&period); err != nil {
+				return err
+			}
+			run.periods = append(run.periods, period)
+			run.summary.Periods++
+		}
+	}
+	return nil
+}
+
+func (s *DemoDataService) seedLecturers(run *demoRun) error {
+	role, err := s.roleRepo.FindByName(lecturerRoleName)
+	if err != nil {
+		return ErrRoleNotFound
+	}
+
+	for i := 1; i <= run.cfg.Lecturers; i++ {
+		// dosen dibagi rata ke jurusan yang punya prodi
+		program := run.programs[(i-1)%len(run.programs)]
+		first, last := run.personName()
+		nidn := demoLecturerNIDN(run.cfg.Seed, i)
+		fullName := first + " " + last + ", " + demoLecturerTitles[run.rng.Intn(len(demoLecturerTitles))]
+
+		user := &model.User{
+			Username:     fmt.Sprintf("dosen.%s", nidn),
+			Email:        fmt.Sprintf("%s.%s.%s@demo.prestasi.ac.id", strings.ToLower(first), strings.ToLower(last), nidn),
+			FullName:     fullName,
+			PasswordHash: run.passwordHash,
+			RoleID:       role.ID,
+			IsActive:     true,
+		}
+		deptID := program.DepartmentID
+		lecturer := &model.Lecturer{
+			LecturerID:   nidn,
+			Department:   program.DepartmentName,
+			DepartmentID: &deptID,
+		}
+		if err := s.profileRepo.SaveLecturerAccount(user, lecturer); err != nil {
+			return err
+		}
+
+		run.lecturers = append(run.lecturers, demoPerson{
+			UserID:       user.ID,
+			ProfileID:    lecturer.ID,
+			FullName:     fullName,
+			DepartmentID: deptID,
+		})
+		run.summary.Lecturers++
+	}
+	return nil
+}
+
+func (s *DemoDataService) seedStudents(run *demoRun) ([]demoStudent, error) {
+	role, err := s.roleRepo.FindByName(studentRoleName)
+	if err != nil {
+		return nil, ErrRoleNotFound
+	}
+
+	lastYear := academicYearOf(run.cfg.Until)
+	students := make([]demoStudent, 0, run.cfg.Students)
+	for i := 1; i <= run.cfg.Students; i++ {
+		programIdx := run.rng.Intn(len(run.programs))
+		program := run.programs[programIdx]
+		// angkatan: sampai 3 tahun sebelum rentang data supaya mahasiswa tingkat akhir ikut terisi
+		entryYear := run.firstYear - 3 + run.rng.Intn(lastYear-run.firstYear+4)
+		advisor := run.advisorFor(program.DepartmentID)
+		first, last := run.personName()
+		nim := fmt.Sprintf("%02d%02d%02d%04d", entryYear%100, run.cfg.Seed%100, programIdx+1, i)
+
+		programID := program.ID
+		user := &model.User{
+			Username:     nim,
+			Email:        fmt.Sprintf("%s.%s.%s@demo.prestasi.ac.id", strings.ToLower(first), strings.ToLower(last), nim),
+			FullName:     first + " " + last,
+			PasswordHash: run.passwordHash,
+			RoleID:       role.ID,
+			IsActive:     true,
+		}
+		student := &model.Student{
+			StudentID:      nim,
+			ProgramStudy:   program.Name,
+			StudyProgramID: &programID,
+			AcademicYear:   fmt.Sprint(entryYear),
+			AdvisorID:      advisor.ProfileID,
+		}
+		if err := s.profileRepo.SaveStudentAccount(user, student); err != nil {
+			return nil, err
+		}
+
+		students = append(students, demoStudent{
+			demoPerson: demoPerson{
+				UserID:       user.ID,
+				ProfileID:    student.ID,
+				FullName:     user.FullName,
+				DepartmentID: program.DepartmentID,
+			},
+			Advisor:   advisor,
+			EntryYear: entryYear,
+		})
+		run.summary.Students++
+	}
+	return students, nil
+}
+
+// seedAchievements: dokumen Mongo + reference + riwayat status dengan tanggal mundur sesuai eventDate
+func (s *DemoDataService) seedAchievements(ctx context.Context, run *demoRun, st demoStudent) error {
+	count := 0
+	if run.cfg.AchievementsPerStudent > 0 {
+		count = run.rng.Intn(2*run.cfg.AchievementsPerStudent + 1)
+	}
+
+	from := utcDate(st.EntryYear, time.August, 1)
+	if rangeStart := utcDate(run.firstYear, time.August, 1); from.Before(rangeStart) {
+		from = rangeStart
+	}
+	if !from.Before(run.cfg.Until) {
+		return nil
+	}
+
+	for i := 0; i < count; i++ {
+		eventDate := run.dateBetween(from, run.cfg.Until)
+		ac := run.achievement(st, eventDate)
+
+		created, err := s.achievementRepo.Create(ctx, ac)
+		if err != nil {
+			return err
+		}
+		ref, err := s.refRepo.CreateDraft(st.ProfileID, created.ID.Hex(), run.periodIDFor(eventDate))
+		if err != nil {
+			return err
+		}
+
+		createdAt := run.after(eventDate, 1, 30)
+		ref.CreatedAt = createdAt
+		history := []model.AchievementStatusLog{{NewStatus: string(model.AchievementStatusDraft), ChangedBy: st.UserID, CreatedAt: createdAt}}
+		history = append(history, run.statusFlow(ref, st, createdAt)...)
+
+		if ref.Status == model.AchievementStatusDeleted {
+			if err := s.achievementRepo.SoftDelete(ctx, created.ID.Hex()); err != nil {
+				return err
+			}
+		}
+		if err := s.refRepo.Save(ref); err != nil {
+			return err
+		}
+		for i := range history {
+			entry := history[i]
+			entry.AchievementReferenceID = ref.ID
+			if err := s.logRepo.Create(&entry); err != nil {
+				return err
+			}
+		}
+
+		run.summary.Achievements++
+		run.summary.ByType[ac.AchievementType]++
+		run.summary.ByStatus[string(ref.Status)]++
+	}
+	return nil
+}
+
+// statusFlow: draft → (deleted | submitted → verified | rejected); langkah yang jatuh setelah Until tidak terjadi
+func (run *demoRun) statusFlow(ref *model.AchievementReference, st demoStudent, createdAt time.Time) []model.AchievementStatusLog {
+	var history []model.AchievementStatusLog
+	step := func(status model.AchievementStatus, by string, at time.Time, note *string) {
+		history = append(history, model.AchievementStatusLog{
+			OldStatus: string(ref.Status),
+			NewStatus: string(status),
+			ChangedBy: by,
+			Note:      note,
+			CreatedAt: at,
+		})
+		ref.Status = status
+	}
+
+	roll := run.rng.Float64()
+	if roll < 0.05 {
+		if at := run.after(createdAt, 1, 20); at.Before(run.cfg.Until) {
+			step(model.AchievementStatusDeleted, st.UserID, at, nil)
+		}
+		return history
+	}
+	if roll < 0.15 {
+		return history // masih draft
+	}
+
+	submittedAt := run.after(createdAt, 0, 14)
+	if !submittedAt.Before(run.cfg.Until) {
+		return history
+	}
+	step(model.AchievementStatusSubmitted, st.UserID, submittedAt, nil)
+	ref.SubmittedAt = &submittedAt
+
+	reviewedAt := run.after(submittedAt, 1, 21)
+	if !reviewedAt.Before(run.cfg.Until) || run.rng.Float64() < 0.1 {
+		return history // menunggu verifikasi
+	}
+
+	advisorID := st.Advisor.UserID
+	ref.VerifiedAt = &reviewedAt
+	ref.VerifiedBy = &advisorID
+	if run.rng.Float64() < 0.12 {
+		note := demoRejectionNotes[run.rng.Intn(len(demoRejectionNotes))]
+		ref.RejectionNote = &note
+		step(model.AchievementStatusRejected, advisorID, reviewedAt, &note)
+		return history
+	}
+	step(model.AchievementStatusVerified, advisorID, reviewedAt, nil)
+	return history
+}
+
+// achievement: isi dokumen sesuai achievementType (proporsi kira-kira seperti data nyata: lomba paling banyak)
+func (run *demoRun) achievement(st demoStudent, eventDate time.Time) *model.Achievement {
+	ac := &model.Achievement{
+		StudentID: st.ProfileID,
+		Details:   map[string]any{"eventDate": eventDate},
+	}
+
+	switch roll := run.rng.Intn(100); {
+	case roll < 35:
+		comp := demoCompetitions[run.rng.Intn(len(demoCompetitions))]
+		level := run.competitionLevel()
+		rank := 1 + run.rng.Intn(5)
+		ac.AchievementType = "competition"
+		ac.Title = fmt.Sprintf("Juara %d %s %d", rank, comp.Name, eventDate.Year())
+		ac.Description = fmt.Sprintf("Meraih peringkat %d pada %s tingkat %s.", rank, comp.Name, level.Level)
+		ac.Points = level.Points * (1 - float64(rank-1)*0.15)
+		ac.Details["competitionName"] = comp.Name
+		ac.Details["competitionLevel"] = level.Level
+		ac.Details["rank"] = rank
+		ac.Details["organizer"] = comp.Organizer
+		ac.Details["location"] = demoCities[run.rng.Intn(len(demoCities))]
+		if medal := demoMedal(rank); medal != "" {
+			ac.Details["medalType"] = medal
+		}
+
+	case roll < 50:
+		award := demoAcademicAwards[run.rng.Intn(len(demoAcademicAwards))]
+		ac.AchievementType = "academic"
+		ac.Title = fmt.Sprintf("%s %d", award.Title, eventDate.Year())
+		ac.Description = award.Title + "."
+		ac.Points = award.Points
+		ac.Details["organizer"] = award.Organizer
+		ac.Details["score"] = 3.5 + float64(run.rng.Intn(51))/100
+
+	case roll < 65:
+		org := demoOrganizations[run.rng.Intn(len(demoOrganizations))]
+		pos := demoPositions[run.rng.Intn(len(demoPositions))]
+		end := eventDate.AddDate(1, 0, 0)
+		ac.AchievementType = "organization"
+		ac.Title = fmt.Sprintf("%s %s periode %d/%d", pos.Name, org, eventDate.Year(), end.Year())
+		ac.Description = fmt.Sprintf("Menjabat sebagai %s di %s.", strings.ToLower(pos.Name), org)
+		ac.Points = pos.Points
+		ac.Details["organizationName"] = org
+		ac.Details["position"] = pos.Name
+		ac.Details["period"] = map[string]any{"start": eventDate, "end": end}
+
+	case roll < 75:
+		pub := demoPublications[run.rng.Intn(len(demoPublications))]
+		topic := demoResearchTopics[run.rng.Intn(len(demoResearchTopics))]
+		ac.AchievementType = "publication"
+		ac.Title = topic
+		ac.Description = fmt.Sprintf("Publikasi %s di %s.", pub.Type, pub.Publisher)
+		ac.Points = pub.Points
+		ac.Details["publicationType"] = pub.Type
+		ac.Details["publicationTitle"] = topic
+		ac.Details["authors"] = []string{st.FullName, st.Advisor.FullName}
+		ac.Details["publisher"] = pub.Publisher
+		if pub.Type == "journal" {
+			ac.Details["issn"] = fmt.Sprintf("%04d-%04d", 1000+run.rng.Intn(9000), 1000+run.rng.Intn(9000))
+		}
+
+	case roll < 90:
+		cert := demoCertifications[run.rng.Intn(len(demoCertifications))]
+		ac.AchievementType = "certification"
+		ac.Title = cert.Name
+		ac.Description = "Sertifikasi " + cert.Name + " dari " + cert.IssuedBy + "."
+		ac.Points = cert.Points
+		ac.Details["certificationName"] = cert.Name
+		ac.Details["issuedBy"] = cert.IssuedBy
+		ac.Details["certificationNumber"] = fmt.Sprintf("%s-%d-%06d", strings.ToUpper(cert.IssuedBy[:3]), eventDate.Year(), run.rng.Intn(1000000))
+		ac.Details["validUntil"] = eventDate.AddDate(2+run.rng.Intn(2), 0, 0)
+
+	default:
+		activity := demoOtherActivities[run.rng.Intn(len(demoOtherActivities))]
+		ac.AchievementType = "other"
+		ac.Title = fmt.Sprintf("%s %d", activity, eventDate.Year())
+		ac.Description = activity + "."
+		ac.Points = 5
+		ac.Details["location"] = demoCities[run.rng.Intn(len(demoCities))]
+		ac.Details["customFields"] = map[string]any{"hours": 8 + run.rng.Intn(40)}
+	}
+
+	ac.Attachments = run.attachments(ac, eventDate)
+	return ac
+}
+
+// attachments: 0-2 lampiran; file fisik tidak dibuat, hash unik per lampiran supaya tidak terdeteksi duplikat
+func (run *demoRun) attachments(ac *model.Achievement, eventDate time.Time) []any {
+	n := run.rng.Intn(3)
+	out := make([]any, 0, n)
+	for i := 0; i < n; i++ {
+		sum := sha256.Sum256([]byte(fmt.Sprintf("demo:%d:%s:%s:%d:%d", run.cfg.Seed, ac.StudentID, ac.Title, i, run.rng.Int63())))
+		hash := hex.EncodeToString(sum[:])
+
+		name, ext, fileType := "sertifikat", "pdf", "application/pdf"
+		if i == 1 {
+			name, ext, fileType = "dokumentasi", "jpg", "image/jpeg"
+		}
+		out = append(out, model.Attachment{
+			FileName:   fmt.Sprintf("%s-%s.%s", name, ac.AchievementType, ext),
+			FileURL:    fmt.Sprintf("/uploads/achievements/demo-%s.%s", hash[:16], ext),
+			FileType:   fileType,
+			Hash:       hash,
+			UploadedAt: eventDate,
+		})
+	}
+	return out
+}
+
+func (run *demoRun) competitionLevel() demoCompetitionLevel {
+	total := 0
+	for _, l := range demoCompetitionLevels {
+		total += l.Weight
+	}
+	roll := run.rng.Intn(total)
+	for _, l := range demoCompetitionLevels {
+		if roll < l.Weight {
+			return l
+		}
+		roll -= l.Weight
+	}
+	return demoCompetitionLevels[len(demoCompetitionLevels)-1]
+}
+
+// advisorFor: dosen wali dari jurusan yang sama kalau ada
+func (run *demoRun) advisorFor(departmentID string) demoPerson {
+	var candidates []demoPerson
+	for _, l := range run.lecturers {
+		if l.DepartmentID == departmentID {
+			candidates = append(candidates, l)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = run.lecturers
+	}
+	return candidates[run.rng.Intn(len(candidates))]
+}
+
+func (run *demoRun) periodIDFor(t time.Time) *string {
+	for _, p := range run.periods {
+		if p.Contains(t) {
+			id := p.ID
+			return &id
+		}
+	}
+	return nil
+}
+
+func (run *demoRun) personName() (string, string) {
+	return demoFirstNames[run.rng.Intn(len(demoFirstNames))], demoLastNames[run.rng.Intn(len(demoLastNames))]
+}
+
+// dateBetween: tanggal (tanpa jam) acak di [from, to)
+func (run *demoRun) dateBetween(from, to time.Time) time.Time {
+	days := int(to.Sub(from).Hours() / 24)
+	if days <= 0 {
+		return from
+	}
+	return from.AddDate(0, 0, run.rng.Intn(days))
+}
+
+// after: t + minDays..maxDays hari + jam kerja acak
+func (run *demoRun) after(t time.Time, minDays, maxDays int) time.Time {
+	days := minDays + run.rng.Intn(maxDays-minDays+1)
+	hour := 8 + run.rng.Intn(9)
+	return time.Date(t.Year(), t.Month(), t.Day()+days, hour, run.rng.Intn(60), 0, 0, time.UTC)
+}
+
+func demoMedal(rank int) string {
+	switch rank {
+	case 1:
+		return "gold"
+	case 2:
+		return "silver"
+	case 3:
+		return "bronze"
+	}
+	return ""
+}
+
+// demoLecturerNIDN: prefix 99 tidak dipakai NIDN asli; seed ikut di nomor supaya beberapa seed bisa hidup berdampingan
+func demoLecturerNIDN(seed int64, i int) string {
+	return fmt.Sprintf("99%04d%04d", seed%10000, i)
+}
+
+// academicYearOf: tahun awal tahun akademik (Agustus) yang memuat t
+func academicYearOf(t time.Time) int {
+	if t.Month() >= time.August {
+		return t.Year()
+	}
+	return t.Year() - 1
+}
+
+func utcDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func findByCode[T any](items []T, code string, codeOf func(T) string) *T {
+	for i := range items {
+		if codeOf(items[i]) == code {
+			return &items[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// demoAchievementRepo: Create harus mengembalikan dokumen ber-ID, tidak bisa lewat Return statis
+type demoAchievementRepo struct {
+	*mocks.AchievementRepositoryMock
+	docs []model.Achievement
+}
+
+func (r *demoAchievementRepo) Create(ctx context.Context, ac *model.Achievement) (*model.Achievement, error) {
+	ac.ID = primitive.NewObjectID()
+	r.docs = append(r.docs, *ac)
+	return ac, nil
+}
+
+type demoRefRepo struct {
+	*mocks.AchievementReferenceRepositoryMock
+	refs map[string]*model.AchievementReference
+}
+
+func (r *demoRefRepo) CreateDraft(studentID, mongoID string, periodID *string) (*model.AchievementReference, error) {
+	ref := &model.AchievementReference{
+		ID:                 fmt.Sprintf("ref-%d", len(r.refs)+1),
+		StudentID:          studentID,
+		MongoAchievementID: mongoID,
+		PeriodID:           periodID,
+		Status:             model.AchievementStatusDraft,
+	}
+	r.refs[ref.ID] = ref
+	return ref, nil
+}
+
+func (r *demoRefRepo) Save(ref *model.AchievementReference) error {
+	r.refs[ref.ID] = ref
+	return nil
+}
+
+type demoTestRun struct {
+	svc          *DemoDataService
+	achievements *demoAchievementRepo
+	refs         *demoRefRepo
+	logs         []model.AchievementStatusLog
+	students     []model.Student
+	lecturers    []model.Lecturer
+}
+
+func newDemoTestRun() *demoTestRun {
+	run := &demoTestRun{
+		achievements: &demoAchievementRepo{AchievementRepositoryMock: new(mocks.AchievementRepositoryMock)},
+		refs:         &demoRefRepo{AchievementReferenceRepositoryMock: new(mocks.AchievementReferenceRepositoryMock), refs: map[string]*model.AchievementReference{}},
+	}
+
+	roleRepo := new(mocks.RoleRepositoryMock)
+	roleRepo.On("FindByName", "Dosen Wali").Return(&model.Role{ID: "role-dosen", Name: "Dosen Wali"}, nil)
+	roleRepo.On("FindByName", "Mahasiswa").Return(&model.Role{ID: "role-mhs", Name: "Mahasiswa"}, nil)
+
+	ids := 0
+	nextID := func(prefix string) string {
+		ids++
+		return fmt.Sprintf("%s-%d", prefix, ids)
+	}
+
+	orgRepo := new(mocks.OrganizationRepositoryMock)
+	orgRepo.On("FindAllFaculties").Return([]model.Faculty{}, nil)
+	orgRepo.On("FindDepartments", mock.Anything).Return([]model.Department{}, nil)
+	orgRepo.On("FindStudyPrograms", mock.Anything).Return([]model.StudyProgram{}, nil)
+	orgRepo.On("CreateFaculty", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*model.Faculty).ID = nextID("fac")
+	}).Return(nil)
+	orgRepo.On("CreateDepartment", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*model.Department).ID = nextID("dept")
+	}).Return(nil)
+	orgRepo.On("CreateStudyProgram", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*model.StudyProgram).ID = nextID("prodi")
+	}).Return(nil)
+
+	profileRepo := new(mocks.ProfileRepositoryMock)
+	profileRepo.On("SaveLecturerAccount", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		user, lect := args.Get(0).(*model.User), args.Get(1).(*model.Lecturer)
+		user.ID, lect.ID = nextID("user"), nextID("lect")
+		run.lecturers = append(run.lecturers, *lect)
+	}).Return(nil)
+	profileRepo.On("SaveStudentAccount", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		user, st := args.Get(0).(*model.User), args.Get(1).(*model.Student)
+		user.ID, st.ID = nextID("user"), nextID("student")
+		run.students = append(run.students, *st)
+	}).Return(nil)
+
+	lecturerRepo := new(mocks.LecturerRepositoryMock)
+	lecturerRepo.On("FindByNIDN", mock.Anything).Return(nil, errors.New("not found"))
+
+	periodRepo := new(mocks.AcademicPeriodRepositoryMock)
+	periodRepo.On("FindOverlapping", mock.Anything, mock.Anything, "").Return([]model.AcademicPeriod{}, nil)
+	periodRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*model.AcademicPeriod).ID = nextID("period")
+	}).Return(nil)
+
+	run.achievements.On("SoftDelete", mock.Anything, mock.Anything).Return(nil)
+
+	logRepo := new(mocks.AchievementStatusLogRepositoryMock)
+	logRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		run.logs = append(run.logs, *args.Get(0).(*model.AchievementStatusLog))
+	}).Return(nil)
+
+	run.svc = NewDemoDataService(roleRepo, orgRepo, profileRepo, lecturerRepo, periodRepo, run.achievements, run.refs, logRepo)
+	return run
+}
+
+func demoTestConfig() DemoDataConfig {
+	return DemoDataConfig{
+		Seed:                   7,
+		Faculties:              2,
+		Lecturers:              4,
+		Students:               40,
+		AchievementsPerStudent: 3,
+		Years:                  3,
+		Until:                  time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+		Password:               "demo12345",
+	}
+}
+
+func TestDemoDataGenerate_DeterministicAndRealistic(t *testing.T) {
+	cfg := demoTestConfig()
+
+	first := newDemoTestRun()
+	summary, err := first.svc.Generate(context.Background(), cfg)
+	assert.NoError(t, err)
+	second := newDemoTestRun()
+	_, err = second.svc.Generate(context.Background(), cfg)
+	assert.NoError(t, err)
+
+	// seed sama → isi sama
+	assert.Equal(t, len(first.achievements.docs), len(second.achievements.docs))
+	for i := range first.achievements.docs {
+		a, b := first.achievements.docs[i], second.achievements.docs[i]
+		assert.Equal(t, a.Title, b.Title)
+		assert.Equal(t, a.Details["eventDate"], b.Details["eventDate"])
+		assert.Equal(t, a.AttachmentHashes(), b.AttachmentHashes())
+	}
+	assert.Equal(t, summary.ByStatus, func() map[string]int {
+		out := map[string]int{}
+		for _, ref := range second.refs.refs {
+			out[string(ref.Status)]++
+		}
+		return out
+	}())
+
+	// semua tipe prestasi dan status utama muncul
+	for _, typ := range []string{"academic", "competition", "organization", "publication", "certification", "other"} {
+		assert.Positive(t, summary.ByType[typ], typ)
+	}
+	for _, st := range []model.AchievementStatus{model.AchievementStatusDraft, model.AchievementStatusSubmitted, model.AchievementStatusVerified, model.AchievementStatusRejected} {
+		assert.Positive(t, summary.ByStatus[string(st)], st)
+	}
+	assert.Equal(t, 40, summary.Students)
+	assert.Equal(t, 6, summary.Periods)
+
+	rangeStart := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	for _, doc := range first.achievements.docs {
+		at, ok := doc.EventDate()
+		assert.True(t, ok)
+		assert.False(t, at.Before(rangeStart) || !at.Before(cfg.Until), "eventDate %s out of range", at)
+	}
+
+	lecturerIDs := map[string]bool{}
+	for _, l := range first.lecturers {
+		lecturerIDs[l.ID] = true
+	}
+	for _, st := range first.students {
+		assert.True(t, lecturerIDs[st.AdvisorID], "student %s has unknown advisor", st.StudentID)
+	}
+
+	// riwayat status: tiap reference mulai dari draft, status akhir log = status reference
+	lastStatus := map[string]string{}
+	for _, l := range first.logs {
+		if l.OldStatus == "" {
+			assert.Equal(t, string(model.AchievementStatusDraft), l.NewStatus)
+		} else {
+			assert.Equal(t, lastStatus[l.AchievementReferenceID], l.OldStatus)
+		}
+		lastStatus[l.AchievementReferenceID] = l.NewStatus
+	}
+	for id, ref := range first.refs.refs {
+		assert.Equal(t, string(ref.Status), lastStatus[id])
+		if ref.Status == model.AchievementStatusVerified {
+			assert.NotNil(t, ref.VerifiedBy)
+			assert.True(t, ref.VerifiedAt.After(*ref.SubmittedAt))
+		}
+	}
+}
+
+func TestDemoDataGenerate_RefusesUsedSeed(t *testing.T) {
+	run := newDemoTestRun()
+	lecturerRepo := new(mocks.LecturerRepositoryMock)
+	lecturerRepo.On("FindByNIDN", demoLecturerNIDN(7, 1)).Return(&model.Lecturer{ID: "lect-1"}, nil)
+	run.svc.lecturerRepo = lecturerRepo
+
+	_, err := run.svc.Generate(context.Background(), demoTestConfig())
+	assert.ErrorIs(t, err, ErrDemoDataExists)
+
+	cfg := demoTestConfig()
+	cfg.Lecturers = 0
+	_, err = newDemoTestRun().svc.Generate(context.Background(), cfg)
+	assert.ErrorIs(t, err, ErrDemoConfig)
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+var (
+	ErrDuplicateFlagNotFound    = errors.New("duplicate flag not found")
+	ErrDuplicateFlagReviewed    = errors.New("duplicate flag has already been reviewed")
+	ErrInvalidDuplicateDecision = errors.New("decision must be dismissed or confirmed")
+	ErrInvalidDuplicateStatus   = errors.New("status must be open, dismissed or confirmed")
+)
+
+const (
+	duplicateCandidateLimit  = 200
+	titleSimilarityThreshold = 0.8
+)
+
+type DuplicateService struct {
+	achievementRepo repository.AchievementRepository
+	flagRepo        repository.DuplicateFlagRepository
+}
+
+func NewDuplicateService(
+	achievementRepo repository.AchievementRepository,
+	flagRepo repository.DuplicateFlagRepository,
+) *DuplicateService {
+	return &DuplicateService{
+		achievementRepo: achievementRepo,
+		flagRepo:        flagRepo,
+	}
+}
+
+// Detect: bandingkan ac dengan kandidat dari Mongo, skor tertinggi di depan
+func (s *DuplicateService) Detect(ctx context.Context, ac *model.Achievement) ([]model.DuplicateMatch, error) {
+	candidates, err := s.achievementRepo.FindDuplicateCandidates(ctx, ac, duplicateCandidateLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []model.DuplicateMatch{}
+	for i := range candidates {
+		c := &candidates[i]
+		reasons, score := compareAchievements(ac, c)
+		if len(reasons) == 0 {
+			continue
+		}
+		matches = append(matches, model.DuplicateMatch{
+			MongoAchievementID: c.ID.Hex(),
+			StudentID:          c.StudentID,
+			Title:              c.Title,
+			Reasons:            reasons,
+			Score:              score,
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	return matches, nil
+}
+
+// CheckSubmission: dijalankan saat submit; setiap kecocokan dicatat sebagai flag untuk tiap reference
+func (s *DuplicateService) CheckSubmission(
+	ctx context.Context,
+	mongoID string,
+	refIDs []string,
+) ([]model.AchievementDuplicateFlag, error) {
+	ac, err := s.achievementRepo.FindByID(ctx, mongoID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := s.Detect(ctx, ac)
+	if err != nil {
+		return nil, err
+	}
+
+	var flags []model.AchievementDuplicateFlag
+	for _, refID := range refIDs {
+		for _, m := range matches {
+			flag := model.AchievementDuplicateFlag{
+				ReferenceID:               refID,
+				MongoAchievementID:        mongoID,
+				MatchedMongoAchievementID: m.MongoAchievementID,
+				MatchedStudentID:          m.StudentID,
+				MatchedTitle:              m.Title,
+				Reasons:                   m.Reasons,
+				Score:                     m.Score,
+				Status:                    model.DuplicateFlagOpen,
+			}
+			if err := s.flagRepo.Upsert(&flag); err != nil {
+				return nil, err
+			}
+			flags = append(flags, flag)
+		}
+	}
+	return flags, nil
+}
+
+// OpenFlagsByReference: peringatan duplikat yang belum ditinjau, dikelompokkan per reference
+func (s *DuplicateService) OpenFlagsByReference(refIDs []string) (map[string][]model.AchievementDuplicateFlag, error) {
+	flags, err := s.flagRepo.FindOpenByReferenceIDs(refIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]model.AchievementDuplicateFlag)
+	for _, f := range flags {
+		result[f.ReferenceID] = append(result[f.ReferenceID], f)
+	}
+	return result, nil
+}
+
+// GetReviewQueue: antrian review admin; status kosong = semua
+func (s *DuplicateService) GetReviewQueue(status string, page, limit int) ([]model.AchievementDuplicateFlag, int64, error) {
+	var filter *model.DuplicateFlagStatus
+	if status != "" {
+		st := model.DuplicateFlagStatus(status)
+		switch st {
+		case model.DuplicateFlagOpen, model.DuplicateFlagDismissed, model.DuplicateFlagConfirmed:
+		default:
+			return nil, 0, ErrInvalidDuplicateStatus
+		}
+		filter = &st
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	return s.flagRepo.FindAll(filter, (page-1)*limit, limit)
+}
+
+// Review: admin menandai flag sebagai bukan duplikat (dismissed) atau duplikat (confirmed).
+// Penolakan prestasinya tetap lewat endpoint reject biasa.
+func (s *DuplicateService) Review(id, reviewerUserID, decision, note string) (*model.AchievementDuplicateFlag, error) {
+	status := model.DuplicateFlagStatus(decision)
+	if status != model.DuplicateFlagDismissed && status != model.DuplicateFlagConfirmed {
+		return nil, ErrInvalidDuplicateDecision
+	}
+
+	flag, err := s.flagRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrDuplicateFlagNotFound
+	}
+	if flag.Status != model.DuplicateFlagOpen {
+		return nil, ErrDuplicateFlagReviewed
+	}
+
+	now := time.Now()
+	flag.Status = status
+	flag.ReviewedBy = &reviewerUserID
+	flag.ReviewedAt = &now
+	if note = strings.TrimSpace(note); note != "" {
+		flag.ReviewNote = &note
+	}
+
+	if err := s.flagRepo.Save(flag); err != nil {
+		return nil, err
+	}
+	return flag, nil
+}
+
+// compareAchievements: alasan + skor (0..1) kemiripan dua prestasi
+func compareAchievements(a, b *model.Achievement) ([]string, float64) {
+	var reasons []string
+	var score float64
+
+	sim := textSimilarity(a.Title, b.Title)
+	if ca, cb := a.DetailString("competitionName"), b.DetailString("competitionName"); ca != "" && cb != "" {
+		sim = math.Max(sim, textSimilarity(ca, cb))
+	}
+	if sim >= titleSimilarityThreshold {
+		reasons = append(reasons, model.DuplicateReasonSimilarTitle)
+		score += 0.4 * sim
+	}
+
+	da, okA := a.EventDate()
+	db, okB := b.EventDate()
+	oa, ob := normalizeTitle(a.DetailString("organizer")), normalizeTitle(b.DetailString("organizer"))
+	if okA && okB && sameDay(da, db) && oa != "" && oa == ob {
+		reasons = append(reasons, model.DuplicateReasonSameEvent)
+		score += 0.4
+	}
+
+	if cert := a.DetailString("certificationNumber"); cert != "" &&
+		strings.EqualFold(cert, b.DetailString("certificationNumber")) {
+		reasons = append(reasons, model.DuplicateReasonSameCertification)
+		score += 1
+	}
+
+	if sharesAny(a.AttachmentHashes(), b.AttachmentHashes()) {
+		reasons = append(reasons, model.DuplicateReasonSameAttachment)
+		score += 1
+	}
+
+	return reasons, math.Round(math.Min(score, 1)*100) / 100
+}
+
+// textSimilarity: koefisien Dice bigram huruf (0..1), tahan terhadap beda huruf besar/tanda baca
+func textSimilarity(a, b string) float64 {
+	a, b = normalizeTitle(a), normalizeTitle(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	bigrams := func(s string) map[string]int {
+		r := []rune(s)
+		m := make(map[string]int)
+		for i := 0; i+1 < len(r); i++ {
+			m[string(r[i:i+2])]++
+		}
+		return m
+	}
+
+	ba, bb := bigrams(a), bigrams(b)
+	var overlap, total int
+	for k, n := range ba {
+		total += n
+		if m, ok := bb[k]; ok {
+			overlap += min(n, m)
+		}
+	}
+	for _, n := range bb {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	return 2 * float64(overlap) / float64(total)
+}
+
+func normalizeTitle(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.UTC().Format("2006-01-02") == b.UTC().Format("2006-01-02")
+}
+
+func sharesAny(a, b []string) bool {
+	set := make(map[string]bool, len(a))
+	for _, h := range a {
+		set[h] = true
+	}
+	for _, h := range b {
+		if set[h] {
+			return true
+		}
+	}
+	return false
+}
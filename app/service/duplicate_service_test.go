@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDetect_SameCertificateAndAttachmentAcrossStudents(t *testing.T) {
+	achRepo := new(mocks.AchievementRepositoryMock)
+	svc := NewDuplicateService(achRepo, new(mocks.DuplicateFlagRepositoryMock))
+
+	ac := &model.Achievement{
+		ID:        primitive.NewObjectID(),
+		StudentID: "student-1",
+		Title:     "Sertifikasi AWS Cloud Practitioner",
+		Details:   map[string]any{"certificationNumber": "AWS-123"},
+		// lampiran hasil decode Mongo
+		Attachments: []any{primitive.D{{Key: "fileName", Value: "a.pdf"}, {Key: "hash", Value: "abc"}}},
+	}
+	other := model.Achievement{
+		ID:          primitive.NewObjectID(),
+		StudentID:   "student-2",
+		Title:       "AWS Certified",
+		Details:     map[string]any{"certificationNumber": "aws-123"},
+		Attachments: []any{primitive.M{"hash": "abc"}},
+	}
+	unrelated := model.Achievement{
+		ID:        primitive.NewObjectID(),
+		StudentID: "student-1",
+		Title:     "Juara 2 Lomba Debat",
+		Details:   map[string]any{},
+	}
+	achRepo.On("FindDuplicateCandidates", mock.Anything, ac, duplicateCandidateLimit).
+		Return([]model.Achievement{unrelated, other}, nil)
+
+	matches, err := svc.Detect(context.Background(), ac)
+
+	assert.NoError(t, err)
+	assert.Len(t, matches, 1)
+	assert.Equal(t, other.ID.Hex(), matches[0].MongoAchievementID)
+	assert.ElementsMatch(t, []string{
+		model.DuplicateReasonSameCertification,
+		model.DuplicateReasonSameAttachment,
+	}, matches[0].Reasons)
+	assert.Equal(t, 1.0, matches[0].Score)
+}
+
+func TestDetect_SimilarTitleSameEvent(t *testing.T) {
+	achRepo := new(mocks.AchievementRepositoryMock)
+	svc := NewDuplicateService(achRepo, new(mocks.DuplicateFlagRepositoryMock))
+
+	eventDate := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	ac := &model.Achievement{
+		StudentID: "student-1",
+		Title:     "Juara 1 Gemastik 2025",
+		Details:   map[string]any{"eventDate": eventDate, "organizer": "Kemendikbud"},
+	}
+	teammateCopy := model.Achievement{
+		StudentID: "student-2",
+		Title:     "Juara I GEMASTIK 2025!",
+		Details: map[string]any{
+			"eventDate": primitive.NewDateTimeFromTime(eventDate),
+			"organizer": "  kemendikbud ",
+		},
+	}
+	achRepo.On("FindDuplicateCandidates", mock.Anything, ac, duplicateCandidateLimit).
+		Return([]model.Achievement{teammateCopy}, nil)
+
+	matches, err := svc.Detect(context.Background(), ac)
+
+	assert.NoError(t, err)
+	assert.Len(t, matches, 1)
+	assert.Equal(t, []string{model.DuplicateReasonSimilarTitle, model.DuplicateReasonSameEvent}, matches[0].Reasons)
+	assert.Greater(t, matches[0].Score, 0.7)
+}
+
+func TestCheckSubmission_RecordsFlagPerReference(t *testing.T) {
+	achRepo := new(mocks.AchievementRepositoryMock)
+	flagRepo := new(mocks.DuplicateFlagRepositoryMock)
+	svc := NewDuplicateService(achRepo, flagRepo)
+
+	ac := &model.Achievement{StudentID: "student-1", Details: map[string]any{"certificationNumber": "C-1"}}
+	other := model.Achievement{ID: primitive.NewObjectID(), StudentID: "student-9", Details: map[string]any{"certificationNumber": "C-1"}}
+	achRepo.On("FindByID", mock.Anything, "mongo-1").Return(ac, nil)
+	achRepo.On("FindDuplicateCandidates", mock.Anything, ac, duplicateCandidateLimit).Return([]model.Achievement{other}, nil)
+	flagRepo.On("Upsert", mock.Anything).Return(nil)
+
+	flags, err := svc.CheckSubmission(context.Background(), "mongo-1", []string{"ref-1", "ref-2"})
+
+	assert.NoError(t, err)
+	assert.Len(t, flags, 2)
+	assert.Equal(t, "ref-2", flags[1].ReferenceID)
+	assert.Equal(t, "student-9", flags[1].MatchedStudentID)
+	assert.Equal(t, model.DuplicateFlagOpen, flags[1].Status)
+	flagRepo.AssertNumberOfCalls(t, "Upsert", 2)
+}
+
+func TestReviewDuplicate_AlreadyReviewed(t *testing.T) {
+	flagRepo := new(mocks.DuplicateFlagRepositoryMock)
+	svc := NewDuplicateService(new(mocks.AchievementRepositoryMock), flagRepo)
+
+	flagRepo.On("FindByID", "flag-1").Return(&model.AchievementDuplicateFlag{
+		ID:     "flag-1",
+		Status: model.DuplicateFlagDismissed,
+	}, nil)
+
+	_, err := svc.Review("flag-1", "admin-1", "confirmed", "")
+	assert.ErrorIs(t, err, ErrDuplicateFlagReviewed)
+
+	_, err = svc.Review("flag-1", "admin-1", "maybe", "")
+	assert.ErrorIs(t, err, ErrInvalidDuplicateDecision)
+}
@@ -0,0 +1,630 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/mail"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+var (
+	ErrImportEmptyFile     = errors.New("import file has no data rows")
+	ErrImportMissingColumn = errors.New("import file is missing a required column")
+	ErrImportJobNotFound   = errors.New("import job not found")
+	ErrImportShuttingDown  = errors.New("server is shutting down, please retry the import later")
+)
+
+const (
+	// progress job disimpan tiap N baris
+	importProgressEvery = 20
+	// ... atau paling lambat tiap interval ini, supaya job yang masih jalan tidak dianggap terputus
+	importHeartbeat = time.Minute
+	// job pending/running tanpa progress selama ini dianggap terputus (server restart / crash)
+	importStaleAfter = 10 * time.Minute
+)
+
+var importRequiredColumns = []string{"username", "email", "full_name", "role"}
+
+// ImportRow: satu baris file import (user + profil mahasiswa / dosen)
+type ImportRow struct {
+	Row          int
+	Username     string
+	Email        string
+	FullName     string
+	Password     string
+	Role         string
+	StudentID    string // NIM
+	ProgramStudy string
+	AcademicYear string
+	AdvisorID    string // NIDN dosen wali
+	LecturerID   string // NIDN
+	Department   string
+}
+
+type ImportService struct {
+	userRepo     repository.UserRepository
+	roleRepo     repository.RoleRepository
+	studentRepo  repository.StudentRepository
+	lecturerRepo repository.LecturerRepository
+	profileRepo  repository.ProfileRepository
+	jobRepo      repository.ImportJobRepository
+	orgRepo      repository.OrganizationRepository
+	policy       *PasswordPolicy
+	advisorSvc   *AdvisorAssignmentService
+
+	// import background: dibatalkan saat server shutdown, RunJobs menunggu semuanya selesai
+	mu      sync.Mutex
+	closed  bool
+	jobCtx  context.Context
+	stopJob context.CancelFunc
+	running sync.WaitGroup
+}
+
+func NewImportService(
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	studentRepo repository.StudentRepository,
+	lecturerRepo repository.LecturerRepository,
+	profileRepo repository.ProfileRepository,
+	jobRepo repository.ImportJobRepository,
+	orgRepo repository.OrganizationRepository,
+	policy *PasswordPolicy,
+	advisorSvc *AdvisorAssignmentService,
+) *ImportService {
+	jobCtx, stopJob := context.WithCancel(context.Background())
+	return &ImportService{
+		userRepo:     userRepo,
+		roleRepo:     roleRepo,
+		studentRepo:  studentRepo,
+		lecturerRepo: lecturerRepo,
+		profileRepo:  profileRepo,
+		jobRepo:      jobRepo,
+		orgRepo:      orgRepo,
+		policy:       policy,
+		advisorSvc:   advisorSvc,
+		jobCtx:       jobCtx,
+		stopJob:      stopJob,
+	}
+}
+
+// ParseImportFile: baca CSV/XLSX, baris pertama = header
+func (s *ImportService) ParseImportFile(filename string, r io.Reader) ([]ImportRow, error) {
+	records, err := utils.ReadSpreadsheet(filename, r)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, ErrImportEmptyFile
+	}
+
+	header := map[string]int{}
+	for i, col := range records[0] {
+		col = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(col, "\ufeff")))
+		header[col] = i
+	}
+	for _, col := range importRequiredColumns {
+		if _, ok := header[col]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrImportMissingColumn, col)
+		}
+	}
+
+	rows := make([]ImportRow, 0, len(records)-1)
+	for i, rec := range records[1:] {
+		get := func(col string) string {
+			idx, ok := header[col]
+			if !ok || idx >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[idx])
+		}
+
+		if strings.TrimSpace(strings.Join(rec, "")) == "" {
+			continue // baris kosong
+		}
+
+		rows = append(rows, ImportRow{
+			Row:          i + 2,
+			Username:     get("username"),
+			Email:        get("email"),
+			FullName:     get("full_name"),
+			Password:     get("password"),
+			Role:         get("role"),
+			StudentID:    get("student_id"),
+			ProgramStudy: get("program_study"),
+			AcademicYear: get("academic_year"),
+			AdvisorID:    get("advisor_id"),
+			LecturerID:   get("lecturer_id"),
+			Department:   get("department"),
+		})
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrImportEmptyFile
+	}
+	return rows, nil
+}
+
+// DryRun: validasi semua baris tanpa menulis ke database
+func (s *ImportService) DryRun(rows []ImportRow) (*model.ImportReport, error) {
+	ic, err := s.newImportContext(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.ImportReport{
+		TotalRows: len(rows),
+		Errors:    []model.ImportRowError{},
+	}
+
+	for _, row := range orderImportRows(rows) {
+		if errs := s.validateRow(ic, row); len(errs) > 0 {
+			report.FailedRows++
+			report.Errors = append(report.Errors, errs...)
+			continue
+		}
+		report.ValidRows++
+	}
+
+	return report, nil
+}
+
+// StartImport: buat job lalu proses di background, progress bisa dicek via GetJob
+func (s *ImportService) StartImport(userID, filename string, rows []ImportRow) (*model.ImportJob, error) {
+	// cek + daftar ke WaitGroup di bawah lock yang sama dengan RunJobs, supaya tidak ada import yang lolos setelah shutdown
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, ErrImportShuttingDown
+	}
+
+	job, err := s.createJob(userID, filename, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// goroutine memegang pointer job, yang dikembalikan ke handler cukup salinan
+	snapshot := *job
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+		s.runJob(s.jobCtx, job, rows)
+	}()
+
+	return &snapshot, nil
+}
+
+// RunImport: sama dengan StartImport tapi ditunggu sampai selesai (dipakai CLI)
+func (s *ImportService) RunImport(ctx context.Context, userID, filename string, rows []ImportRow) (*model.ImportJob, error) {
+	job, err := s.createJob(userID, filename, rows)
+	if err != nil {
+		return nil, err
+	}
+	s.runJob(ctx, job, rows)
+	return job, nil
+}
+
+// RunJobs: jalan selama server hidup; saat ctx dibatalkan import baru ditolak,
+// import yang sedang jalan dihentikan di baris berikutnya (job ditandai failed) lalu ditunggu
+func (s *ImportService) RunJobs(ctx context.Context) {
+	<-ctx.Done()
+
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	s.stopJob()
+	s.running.Wait()
+}
+
+func (s *ImportService) createJob(userID, filename string, rows []ImportRow) (*model.ImportJob, error) {
+	job := &model.ImportJob{
+		FileName:  filename,
+		Status:    model.ImportJobPending,
+		TotalRows: len(rows),
+		Errors:    []model.ImportRowError{},
+		CreatedBy: userID,
+	}
+	if err := s.jobRepo.Create(job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (s *ImportService) GetJob(id string) (*model.ImportJob, error) {
+	job, err := s.jobRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrImportJobNotFound
+	}
+	return job, nil
+}
+
+func (s *ImportService) GetAllJobs() ([]model.ImportJob, error) {
+	return s.jobRepo.FindAll()
+}
+
+// FailStaleJobs: job yang terputus (server mati di tengah import) ditandai failed, supaya tidak "running" selamanya
+func (s *ImportService) FailStaleJobs(now time.Time) (int64, error) {
+	return s.jobRepo.FailStale(now.Add(-importStaleAfter), "import interrupted (server restarted), please upload the file again")
+}
+
+// RunStaleJobLoop: FailStaleJobs saat start lalu berkala
+func (s *ImportService) RunStaleJobLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	now := time.Now()
+	for {
+		n, err := s.FailStaleJobs(now)
+		if err != nil {
+			log.Printf("[IMPORT] %v", err)
+		} else if n > 0 {
+			log.Printf("[IMPORT] marked %d interrupted import job(s) as failed", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case now = <-ticker.C:
+		}
+	}
+}
+
+func (s *ImportService) runJob(ctx context.Context, job *model.ImportJob, rows []ImportRow) {
+	started := time.Now()
+	job.Status = model.ImportJobRunning
+	job.StartedAt = &started
+	if !s.saveJob(job) {
+		return
+	}
+
+	// panic di satu baris tidak boleh mematikan server; job ditandai failed
+	currentRow := 0
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[IMPORT] job %s panicked at row %d: %v\n%s", job.ID, currentRow, r, debug.Stack())
+			finished := time.Now()
+			job.Status = model.ImportJobFailed
+			job.FinishedAt = &finished
+			job.Errors = append(job.Errors, model.ImportRowError{Row: currentRow, Message: "internal error, import aborted"})
+			s.saveJob(job)
+		}
+	}()
+
+	ic, err := s.newImportContext(rows)
+	if err != nil {
+		finished := time.Now()
+		job.Status = model.ImportJobFailed
+		job.FinishedAt = &finished
+		job.Errors = append(job.Errors, model.ImportRowError{Message: err.Error()})
+		s.saveJob(job)
+		return
+	}
+	ic.actorID = job.CreatedBy
+
+	lastSave := time.Now()
+	for _, row := range orderImportRows(rows) {
+		if ctx.Err() != nil {
+			finished := time.Now()
+			job.Status = model.ImportJobFailed
+			job.FinishedAt = &finished
+			job.Errors = append(job.Errors, model.ImportRowError{Message: "import interrupted (server shutting down), please upload the file again"})
+			s.saveJob(job)
+			return
+		}
+
+		currentRow = row.Row
+		if errs := s.validateRow(ic, row); len(errs) > 0 {
+			job.FailedRows++
+			job.Errors = append(job.Errors, errs...)
+		} else if created, err := s.applyRow(ic, row); err != nil {
+			job.FailedRows++
+			job.Errors = append(job.Errors, model.ImportRowError{Row: row.Row, Message: err.Error()})
+		} else if created {
+			job.CreatedRows++
+		} else {
+			job.UpdatedRows++
+		}
+
+		job.ProcessedRows++
+		if job.ProcessedRows%importProgressEvery == 0 || time.Since(lastSave) >= importHeartbeat {
+			if !s.saveJob(job) {
+				return
+			}
+			lastSave = time.Now()
+		}
+	}
+
+	finished := time.Now()
+	job.Status = model.ImportJobCompleted
+	job.FinishedAt = &finished
+	s.saveJob(job)
+}
+
+// saveJob: simpan progress hanya kalau job masih aktif di database; false = job sudah
+// ditandai failed di tempat lain (mis. FailStale instance lain) dan import harus berhenti
+func (s *ImportService) saveJob(job *model.ImportJob) bool {
+	saved, err := s.jobRepo.SaveActive(job)
+	if err != nil {
+		// gagal tulis progress tidak menghentikan import, baris berikutnya mencoba lagi
+		log.Printf("[IMPORT] job %s: save progress: %v", job.ID, err)
+		return true
+	}
+	if !saved {
+		log.Printf("[IMPORT] job %s was ended elsewhere, stopping import", job.ID)
+	}
+	return saved
+}
+
+type importContext struct {
+	roles        map[string]model.Role
+	nidnInFile   map[string]bool
+	seenUsername map[string]int
+	seenEmail    map[string]int
+	seenNIM      map[string]int
+	seenNIDN     map[string]int
+	// teks program_study / department -> unit (nama atau kode)
+	programs    map[string]model.StudyProgram
+	departments map[string]model.Department
+	// admin yang mengunggah file, dicatat di riwayat dosen wali
+	actorID string
+}
+
+func (s *ImportService) newImportContext(rows []ImportRow) (*importContext, error) {
+	roles, err := s.roleRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	ic := &importContext{
+		roles:        map[string]model.Role{},
+		nidnInFile:   map[string]bool{},
+		seenUsername: map[string]int{},
+		seenEmail:    map[string]int{},
+		seenNIM:      map[string]int{},
+		seenNIDN:     map[string]int{},
+	}
+	for _, r := range roles {
+		ic.roles[r.Name] = r
+	}
+	for _, row := range rows {
+		if row.Role == "Dosen Wali" && row.LecturerID != "" {
+			ic.nidnInFile[row.LecturerID] = true
+		}
+	}
+
+	if ic.programs, err = studyProgramIndex(s.orgRepo); err != nil {
+		return nil, err
+	}
+	if ic.departments, err = departmentIndex(s.orgRepo); err != nil {
+		return nil, err
+	}
+
+	return ic, nil
+}
+
+// orderImportRows: dosen diproses dulu supaya advisor_id mahasiswa di file yang sama bisa dipetakan
+func orderImportRows(rows []ImportRow) []ImportRow {
+	ordered := make([]ImportRow, len(rows))
+	copy(ordered, rows)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Role == "Dosen Wali" && ordered[j].Role != "Dosen Wali"
+	})
+	return ordered
+}
+
+func (s *ImportService) validateRow(ic *importContext, row ImportRow) []model.ImportRowError {
+	var errs []model.ImportRowError
+	fail := func(field, msg string) {
+		errs = append(errs, model.ImportRowError{Row: row.Row, Field: field, Message: msg})
+	}
+	duplicate := func(seen map[string]int, field, value string) {
+		if value == "" {
+			return
+		}
+		key := strings.ToLower(value)
+		if first, ok := seen[key]; ok {
+			fail(field, fmt.Sprintf("duplicate of row %d", first))
+			return
+		}
+		seen[key] = row.Row
+	}
+
+	if row.Username == "" {
+		fail("username", "username is required")
+	}
+	if row.Email == "" {
+		fail("email", "email is required")
+	} else if _, err := mail.ParseAddress(row.Email); err != nil {
+		fail("email", "invalid email address")
+	}
+	if row.FullName == "" {
+		fail("full_name", "full_name is required")
+	}
+	duplicate(ic.seenUsername, "username", row.Username)
+	duplicate(ic.seenEmail, "email", row.Email)
+
+	if _, ok := ic.roles[row.Role]; !ok {
+		fail("role", "role not found")
+		return errs
+	}
+
+	// user pemilik profil yang sudah ada (kalau upsert)
+	ownerID := ""
+
+	switch row.Role {
+	case "Mahasiswa":
+		if row.StudentID == "" {
+			fail("student_id", "student_id is required for Mahasiswa")
+		}
+		if row.ProgramStudy == "" {
+			fail("program_study", "program_study is required for Mahasiswa")
+		}
+		if len(row.AcademicYear) > 10 {
+			fail("academic_year", "academic_year is too long")
+		}
+		duplicate(ic.seenNIM, "student_id", row.StudentID)
+
+		if row.AdvisorID != "" && !ic.nidnInFile[row.AdvisorID] {
+			if _, err := s.lecturerRepo.FindByNIDN(row.AdvisorID); err != nil {
+				fail("advisor_id", "advisor lecturer not found")
+			}
+		}
+
+		if row.StudentID != "" {
+			if st, err := s.studentRepo.FindByNIM(row.StudentID); err == nil {
+				ownerID = st.UserID
+			}
+		}
+
+	case "Dosen Wali":
+		if row.LecturerID == "" {
+			fail("lecturer_id", "lecturer_id is required for Dosen Wali")
+		}
+		duplicate(ic.seenNIDN, "lecturer_id", row.LecturerID)
+
+		if row.LecturerID != "" {
+			if lect, err := s.lecturerRepo.FindByNIDN(row.LecturerID); err == nil {
+				ownerID = lect.UserID
+			}
+		}
+
+	default:
+		fail("role", "only Mahasiswa and Dosen Wali can be imported")
+		return errs
+	}
+
+	if row.Username != "" {
+		if u, err := s.userRepo.FindByUsernameOrEmail(row.Username); err == nil && u.ID != ownerID {
+			fail("username", "username already used by another account")
+		}
+	}
+	if row.Email != "" {
+		if u, err := s.userRepo.FindByUsernameOrEmail(row.Email); err == nil && u.ID != ownerID {
+			fail("email", "email already used by another account")
+		}
+	}
+
+	if ownerID == "" && row.Password == "" {
+		fail("password", "password is required for new accounts")
+	}
+	if row.Password != "" {
+		if err := s.policy.Validate(row.Password, row.Username, row.Email); err != nil {
+			fail("password", err.Error())
+		}
+	}
+
+	return errs
+}
+
+// applyRow: upsert berdasarkan NIM / NIDN, return true kalau akun baru
+func (s *ImportService) applyRow(ic *importContext, row ImportRow) (bool, error) {
+	role := ic.roles[row.Role]
+
+	fillUser := func(user *model.User) error {
+		user.Username = row.Username
+		user.Email = row.Email
+		user.FullName = row.FullName
+		user.RoleID = role.ID
+		// akun lama tetap dengan statusnya; yang nonaktif diaktifkan lewat /admin/users/:id/reactivate
+		if user.ID == "" {
+			user.IsActive = true
+		}
+
+		// password dari file = ditentukan admin → wajib diganti saat login pertama, sesi lama dicabut
+		if row.Password != "" {
+			hash, err := utils.HashPassword(row.Password)
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			user.PasswordHash = hash
+			user.MustChangePassword = true
+			user.PasswordChangedAt = &now
+			if user.ID != "" {
+				user.SessionsRevokedAt = &now
+			}
+		}
+		return nil
+	}
+
+	if row.Role == "Dosen Wali" {
+		lect, err := s.lecturerRepo.FindByNIDN(row.LecturerID)
+		created := err != nil
+		if created {
+			lect = &model.Lecturer{}
+		}
+
+		if err := fillUser(&lect.User); err != nil {
+			return false, err
+		}
+		lect.LecturerID = row.LecturerID
+		lect.Department = row.Department
+		lect.DepartmentID = nil
+		if d, ok := ic.departments[normalizeUnitText(row.Department)]; ok {
+			lect.DepartmentID = &d.ID
+		}
+
+		return created, s.profileRepo.SaveLecturerAccount(&lect.User, lect)
+	}
+
+	student, err := s.studentRepo.FindByNIM(row.StudentID)
+	created := err != nil
+	if created {
+		student = &model.Student{}
+	}
+
+	if err := fillUser(&student.User); err != nil {
+		return false, err
+	}
+	student.StudentID = row.StudentID
+	student.ProgramStudy = row.ProgramStudy
+	student.AcademicYear = row.AcademicYear
+	// teks yang tidak cocok tetap disimpan dan muncul di laporan unmapped
+	student.StudyProgramID = nil
+	if p, ok := ic.programs[normalizeUnitText(row.ProgramStudy)]; ok {
+		student.StudyProgramID = &p.ID
+	}
+
+	var advisor *model.Lecturer
+	if row.AdvisorID != "" {
+		if advisor, err = s.lecturerRepo.FindByNIDN(row.AdvisorID); err != nil {
+			return false, ErrLecturerNotFound
+		}
+		if _, err := s.advisorSvc.activeAdvisor(advisor.ID); err != nil {
+			return false, err
+		}
+	}
+
+	if err := s.profileRepo.SaveStudentAccount(&student.User, student); err != nil {
+		return false, err
+	}
+
+	// pergantian dosen wali lewat Reassign, sama seperti dari menu admin: riwayat + serah terima prestasi
+	if advisor != nil && advisor.ID != student.AdvisorID {
+		reason := "import data: perubahan dosen wali"
+		if student.AdvisorID == "" {
+			reason = "import data: penugasan awal"
+		}
+		if _, err := s.advisorSvc.Reassign(context.Background(), ReassignAdvisorInput{
+			ToLecturerID: advisor.ID,
+			StudentIDs:   []string{student.ID},
+			Reason:       reason,
+			ActorID:      ic.actorID,
+		}); err != nil {
+			return created, err
+		}
+		student.AdvisorID = advisor.ID
+	}
+	return created, nil
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newImportServiceWithMocks() (
+	*ImportService,
+	*mocks.UserRepositoryMock,
+	*mocks.RoleRepositoryMock,
+	*mocks.StudentRepositoryMock,
+	*mocks.LecturerRepositoryMock,
+	*mocks.ProfileRepositoryMock,
+	*mocks.ImportJobRepositoryMock,
+) {
+	userRepo := new(mocks.UserRepositoryMock)
+	roleRepo := new(mocks.RoleRepositoryMock)
+	studentRepo := new(mocks.StudentRepositoryMock)
+	lectRepo := new(mocks.LecturerRepositoryMock)
+	profileRepo := new(mocks.ProfileRepositoryMock)
+	jobRepo := new(mocks.ImportJobRepositoryMock)
+
+	roleRepo.On("FindAll").Return([]model.Role{
+		{ID: "role-mhs", Name: "Mahasiswa"},
+		{ID: "role-dosen", Name: "Dosen Wali"},
+		{ID: "role-admin", Name: "Admin"},
+	}, nil)
+
+	orgRepo := new(mocks.OrganizationRepositoryMock)
+	orgRepo.On("FindStudyPrograms", "").Return([]model.StudyProgram{
+		{ID: "prog-if", Code: "IF", Name: "Informatika"},
+	}, nil)
+	orgRepo.On("FindDepartments", "").Return([]model.Department{}, nil)
+
+	advisorSvc := NewAdvisorAssignmentService(
+		new(mocks.AdvisorAssignmentRepositoryMock),
+		new(mocks.VerificationDelegationRepositoryMock),
+		studentRepo,
+		lectRepo,
+		new(mocks.AchievementReferenceRepositoryMock),
+		nil,
+		nil,
+	)
+	svc := NewImportService(userRepo, roleRepo, studentRepo, lectRepo, profileRepo, jobRepo, orgRepo, NewPasswordPolicy(8, nil), advisorSvc)
+	return svc, userRepo, roleRepo, studentRepo, lectRepo, profileRepo, jobRepo
+}
+
+func TestParseImportFile_CSV(t *testing.T) {
+	svc, _, _, _, _, _, _ := newImportServiceWithMocks()
+
+	csv := "Username,Email,Full_Name,Password,Role,Student_ID,Program_Study,Academic_Year,Advisor_ID,Lecturer_ID,Department\n" +
+		"budi,budi@mail.com,Budi,secret123,Mahasiswa,2201,Informatika,2022,D01,,\n" +
+		",,,,,,,,,,\n" +
+		"sari,sari@mail.com,Sari,secret123,Dosen Wali,,,,,D01,Teknik\n"
+
+	rows, err := svc.ParseImportFile("users.csv", strings.NewReader(csv))
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, 2, rows[0].Row)
+	assert.Equal(t, "2201", rows[0].StudentID)
+	assert.Equal(t, 4, rows[1].Row)
+	assert.Equal(t, "D01", rows[1].LecturerID)
+}
+
+func TestParseImportFile_MissingColumn(t *testing.T) {
+	svc, _, _, _, _, _, _ := newImportServiceWithMocks()
+
+	_, err := svc.ParseImportFile("users.csv", strings.NewReader("username,email\nbudi,budi@mail.com\n"))
+
+	assert.ErrorIs(t, err, ErrImportMissingColumn)
+}
+
+func TestImportDryRun_ReportsRowErrors(t *testing.T) {
+	svc, userRepo, _, studentRepo, lectRepo, profileRepo, _ := newImportServiceWithMocks()
+
+	notFound := errors.New("not found")
+	userRepo.On("FindByUsernameOrEmail", mock.Anything).Return(nil, notFound)
+	studentRepo.On("FindByNIM", mock.Anything).Return(nil, notFound)
+	lectRepo.On("FindByNIDN", mock.Anything).Return(nil, notFound)
+
+	rows := []ImportRow{
+		// advisor D01 ada di file yang sama
+		{Row: 2, Username: "budi", Email: "budi@mail.com", FullName: "Budi", Password: "Rahasia-2024", Role: "Mahasiswa", StudentID: "2201", ProgramStudy: "Informatika", AdvisorID: "D01"},
+		{Row: 3, Username: "sari", Email: "sari@mail.com", FullName: "Sari", Password: "Rahasia-2024", Role: "Dosen Wali", LecturerID: "D01"},
+		// NIM duplikat + email invalid
+		{Row: 4, Username: "andi", Email: "bukan-email", FullName: "Andi", Password: "Rahasia-2024", Role: "Mahasiswa", StudentID: "2201", ProgramStudy: "Informatika"},
+		// advisor tidak ditemukan
+		{Row: 5, Username: "rina", Email: "rina@mail.com", FullName: "Rina", Password: "Rahasia-2024", Role: "Mahasiswa", StudentID: "2202", ProgramStudy: "Informatika", AdvisorID: "D99"},
+		// admin tidak bisa diimport
+		{Row: 6, Username: "root", Email: "root@mail.com", FullName: "Root", Password: "Rahasia-2024", Role: "Admin"},
+		// password tidak lolos kebijakan
+		{Row: 7, Username: "dewi", Email: "dewi@mail.com", FullName: "Dewi", Password: "dewi1", Role: "Mahasiswa", StudentID: "2203", ProgramStudy: "Informatika"},
+	}
+
+	report, err := svc.DryRun(rows)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, report.TotalRows)
+	assert.Equal(t, 2, report.ValidRows)
+	assert.Equal(t, 4, report.FailedRows)
+
+	fields := map[int][]string{}
+	for _, e := range report.Errors {
+		fields[e.Row] = append(fields[e.Row], e.Field)
+	}
+	assert.ElementsMatch(t, []string{"email", "student_id"}, fields[4])
+	assert.ElementsMatch(t, []string{"advisor_id"}, fields[5])
+	assert.ElementsMatch(t, []string{"role"}, fields[6])
+	assert.ElementsMatch(t, []string{"password"}, fields[7])
+
+	profileRepo.AssertNotCalled(t, "SaveStudentAccount", mock.Anything, mock.Anything)
+}
+
+func TestImportRunJob_UpsertsAccounts(t *testing.T) {
+	svc, userRepo, _, studentRepo, lectRepo, profileRepo, jobRepo := newImportServiceWithMocks()
+
+	notFound := errors.New("not found")
+
+	// mahasiswa 2201 sudah ada -> update
+	existing := &model.Student{
+		ID:        "student-1",
+		UserID:    "user-1",
+		StudentID: "2201",
+		User:      model.User{ID: "user-1", Username: "budi"},
+	}
+	studentRepo.On("FindByNIM", "2201").Return(existing, nil)
+	userRepo.On("FindByUsernameOrEmail", "budi").Return(&existing.User, nil)
+	userRepo.On("FindByUsernameOrEmail", mock.Anything).Return(nil, notFound)
+
+	// dosen D01 baru; setelah disimpan bisa ditemukan sebagai advisor
+	lecturer := &model.Lecturer{ID: "lect-1", LecturerID: "D01", User: model.User{IsActive: true, Role: model.Role{Name: "Dosen Wali"}}}
+	lectRepo.On("FindByNIDN", "D01").Return(nil, notFound).Twice()
+	lectRepo.On("FindByNIDN", "D01").Return(lecturer, nil)
+	lectRepo.On("FindByID", "lect-1").Return(lecturer, nil)
+
+	// dosen wali dari file dicatat lewat Reassign (riwayat + serah terima), bukan diisi langsung
+	assigns := new(mocks.AdvisorAssignmentRepositoryMock)
+	refRepo := new(mocks.AchievementReferenceRepositoryMock)
+	svc.advisorSvc = NewAdvisorAssignmentService(assigns, new(mocks.VerificationDelegationRepositoryMock), studentRepo, lectRepo, refRepo, nil, nil)
+	studentRepo.On("FindByID", "student-1").Return(existing, nil)
+	refRepo.On("FindByStudentID", "student-1").Return([]model.AchievementReference{}, nil)
+	assigns.On("Reassign", mock.MatchedBy(func(rows []model.AdvisorAssignment) bool {
+		return len(rows) == 1 && rows[0].StudentID == "student-1" && rows[0].LecturerID == "lect-1" && *rows[0].AssignedBy == "admin-1"
+	})).Return(nil)
+
+	profileRepo.On("SaveLecturerAccount", mock.Anything, mock.Anything).Return(nil)
+	profileRepo.On("SaveStudentAccount", mock.Anything, mock.Anything).Return(nil)
+	jobRepo.On("SaveActive", mock.Anything).Return(true, nil)
+
+	rows := []ImportRow{
+		{Row: 2, Username: "budi", Email: "budi@mail.com", FullName: "Budi Baru", Role: "Mahasiswa", StudentID: "2201", ProgramStudy: "Informatika", AdvisorID: "D01"},
+		{Row: 3, Username: "sari", Email: "sari@mail.com", FullName: "Sari", Password: "secret123", Role: "Dosen Wali", LecturerID: "D01"},
+	}
+	job := &model.ImportJob{TotalRows: len(rows), CreatedBy: "admin-1"}
+
+	svc.runJob(context.Background(), job, rows)
+
+	assert.Equal(t, model.ImportJobCompleted, job.Status)
+	assert.Equal(t, 2, job.ProcessedRows)
+	assert.Equal(t, 1, job.CreatedRows)
+	assert.Equal(t, 1, job.UpdatedRows)
+	assert.Equal(t, 0, job.FailedRows)
+	assert.Equal(t, "Budi Baru", existing.User.FullName)
+	assert.Equal(t, "lect-1", existing.AdvisorID)
+	assert.Equal(t, "role-mhs", existing.User.RoleID)
+	assert.Equal(t, "prog-if", *existing.StudyProgramID, "teks prodi dipetakan ke unit")
+	assert.False(t, existing.User.MustChangePassword, "password lama tidak diubah")
+	assert.False(t, existing.User.IsActive, "akun nonaktif tidak diaktifkan lagi oleh import")
+	saved := profileRepo.Calls[0].Arguments.Get(0).(*model.User)
+	assert.Equal(t, "sari", saved.Username)
+	assert.True(t, saved.IsActive)
+	assert.True(t, saved.MustChangePassword, "password dari file wajib diganti")
+	profileRepo.AssertExpectations(t)
+	assigns.AssertExpectations(t)
+}
+
+func TestImportRunJob_PanicMarksJobFailed(t *testing.T) {
+	svc, userRepo, _, studentRepo, lectRepo, _, jobRepo := newImportServiceWithMocks()
+
+	notFound := errors.New("not found")
+	userRepo.On("FindByUsernameOrEmail", mock.Anything).Return(nil, notFound)
+	lectRepo.On("FindByNIDN", mock.Anything).Return(nil, notFound)
+	studentRepo.On("FindByNIM", "2201").Run(func(mock.Arguments) { panic("boom") })
+	jobRepo.On("SaveActive", mock.Anything).Return(true, nil)
+
+	job := &model.ImportJob{TotalRows: 1}
+
+	assert.NotPanics(t, func() {
+		svc.runJob(context.Background(), job, []ImportRow{
+			{Row: 2, Username: "budi", Email: "budi@mail.com", FullName: "Budi", Password: "secret123", Role: "Mahasiswa", StudentID: "2201", ProgramStudy: "Informatika"},
+		})
+	})
+
+	assert.Equal(t, model.ImportJobFailed, job.Status)
+	assert.NotNil(t, job.FinishedAt)
+	if assert.Len(t, job.Errors, 1) {
+		assert.Equal(t, 2, job.Errors[0].Row)
+	}
+}
+
+func TestImportRunJob_StopsWhenJobEndedElsewhere(t *testing.T) {
+	svc, userRepo, _, _, _, _, jobRepo := newImportServiceWithMocks()
+
+	userRepo.On("FindByUsernameOrEmail", mock.Anything).Return(nil, errors.New("not found"))
+	// job ditandai failed oleh instance lain setelah start: progress berikutnya tidak tersimpan
+	jobRepo.On("SaveActive", mock.Anything).Return(true, nil).Once()
+	jobRepo.On("SaveActive", mock.Anything).Return(false, nil)
+
+	var rows []ImportRow
+	for i := 0; i < importProgressEvery+5; i++ {
+		rows = append(rows, ImportRow{Row: i + 2, Username: fmt.Sprintf("u%d", i), Email: fmt.Sprintf("u%d@mail.com", i), FullName: "U", Role: "Tamu"})
+	}
+	job := &model.ImportJob{TotalRows: len(rows)}
+
+	svc.runJob(context.Background(), job, rows)
+
+	assert.Equal(t, importProgressEvery, job.ProcessedRows, "import berhenti di save progress pertama yang ditolak")
+	assert.Nil(t, job.FinishedAt)
+	jobRepo.AssertNumberOfCalls(t, "SaveActive", 2)
+}
+
+func TestImportRunJobs_ShutdownWaitsAndRejectsNewImports(t *testing.T) {
+	svc, userRepo, _, _, _, _, jobRepo := newImportServiceWithMocks()
+
+	// import tertahan di persiapan sampai dilepas
+	roleRepo := new(mocks.RoleRepositoryMock)
+	release := make(chan struct{})
+	entered := make(chan struct{})
+	roleRepo.On("FindAll").Run(func(mock.Arguments) {
+		close(entered)
+		<-release
+	}).Return([]model.Role{{ID: "role-mhs", Name: "Mahasiswa"}}, nil)
+	svc.roleRepo = roleRepo
+
+	userRepo.On("FindByUsernameOrEmail", mock.Anything).Return(nil, errors.New("not found"))
+	jobRepo.On("Create", mock.Anything).Return(nil)
+	var last model.ImportJob
+	jobRepo.On("SaveActive", mock.Anything).Run(func(args mock.Arguments) {
+		last = *args.Get(0).(*model.ImportJob)
+	}).Return(true, nil)
+
+	_, err := svc.StartImport("admin-1", "mhs.csv", []ImportRow{
+		{Row: 2, Username: "budi", Email: "budi@mail.com", FullName: "Budi", Role: "Mahasiswa", StudentID: "2201"},
+	})
+	assert.NoError(t, err)
+	<-entered
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.RunJobs(ctx)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+		t.Fatal("RunJobs returned while an import was still running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// import baru setelah shutdown ditolak tanpa membuat job
+	_, err = svc.StartImport("admin-1", "lagi.csv", nil)
+	assert.ErrorIs(t, err, ErrImportShuttingDown)
+	jobRepo.AssertNumberOfCalls(t, "Create", 1)
+
+	close(release)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunJobs did not return after the import stopped")
+	}
+
+	assert.Equal(t, model.ImportJobFailed, last.Status)
+	assert.Equal(t, 0, last.ProcessedRows)
+	if assert.Len(t, last.Errors, 1) {
+		assert.Contains(t, last.Errors[0].Message, "shutting down")
+	}
+}
+
+func TestFailStaleJobs_UsesStaleThreshold(t *testing.T) {
+	svc, _, _, _, _, _, jobRepo := newImportServiceWithMocks()
+
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	jobRepo.On("FailStale", now.Add(-importStaleAfter), mock.Anything).Return(int64(2), nil)
+
+	n, err := svc.FailStaleJobs(now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+}
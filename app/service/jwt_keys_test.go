@@ -0,0 +1,123 @@
+package service
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nerhays/prestasi_uas/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClaims() *utils.JWTCustomClaims {
+	return &utils.JWTCustomClaims{
+		UserID: "user-1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+}
+
+func TestKeyManager_RotationKeepsOldTokensValidAcrossInstances(t *testing.T) {
+	store := utils.NewMemoryKeyStore()
+	cfg := utils.KeyManagerConfig{Algorithm: utils.AlgEdDSA, Issuer: "prestasi", Audience: "prestasi", TokenTTL: 1500 * time.Millisecond}
+
+	a, err := utils.NewKeyManager(store, cfg)
+	assert.NoError(t, err)
+	b, err := utils.NewKeyManager(store, cfg)
+	assert.NoError(t, err)
+
+	oldToken, err := a.Sign(newTestClaims())
+	assert.NoError(t, err)
+	oldKID := a.JWKS().Keys[0].KeyID
+
+	// instance A rotasi; instance B belum tahu kid baru
+	assert.NoError(t, a.Rotate(time.Now()))
+	newToken, err := a.Sign(newTestClaims())
+	assert.NoError(t, err)
+
+	keys := a.JWKS().Keys
+	assert.Len(t, keys, 2)
+	assert.NotEqual(t, oldKID, keys[0].KeyID)
+	assert.Equal(t, "OKP", keys[0].KeyType)
+
+	assert.NoError(t, a.Parse(oldToken, &utils.JWTCustomClaims{}))
+	time.Sleep(1100 * time.Millisecond)
+	assert.NoError(t, b.Parse(newToken, &utils.JWTCustomClaims{}))
+	assert.NoError(t, b.Parse(oldToken, &utils.JWTCustomClaims{}))
+	time.Sleep(500 * time.Millisecond)
+
+	// setelah TokenTTL lewat kunci lama tidak dipakai verifikasi lagi
+	assert.NoError(t, a.Reload())
+	assert.Len(t, a.JWKS().Keys, 1)
+	assert.ErrorIs(t, a.Parse(oldToken, &utils.JWTCustomClaims{}), utils.ErrUnknownKeyID)
+}
+
+func TestKeyManager_RejectsWrongAudienceAndAlgorithm(t *testing.T) {
+	store := utils.NewMemoryKeyStore()
+	issuer, err := utils.NewKeyManager(store, utils.KeyManagerConfig{Algorithm: utils.AlgRS256, Issuer: "prestasi", Audience: "portal"})
+	assert.NoError(t, err)
+	verifier, err := utils.NewKeyManager(store, utils.KeyManagerConfig{Algorithm: utils.AlgRS256, Issuer: "prestasi", Audience: "prestasi"})
+	assert.NoError(t, err)
+
+	token, err := issuer.Sign(newTestClaims())
+	assert.NoError(t, err)
+	assert.NoError(t, issuer.Parse(token, &utils.JWTCustomClaims{}))
+	assert.Equal(t, "RSA", issuer.JWKS().Keys[0].KeyType)
+	assert.ErrorIs(t, verifier.Parse(token, &utils.JWTCustomClaims{}), jwt.ErrTokenInvalidAudience)
+
+	// HS256 dengan kid valid tetap ditolak
+	hs := jwt.NewWithClaims(jwt.SigningMethodHS256, newTestClaims())
+	hs.Header["kid"] = issuer.JWKS().Keys[0].KeyID
+	forged, _ := hs.SignedString([]byte("secret"))
+	assert.Error(t, issuer.Parse(forged, &utils.JWTCustomClaims{}))
+
+	_, err = utils.NewKeyManager(store, utils.KeyManagerConfig{Algorithm: "HS256"})
+	assert.ErrorIs(t, err, utils.ErrUnsupportedAlgorithm)
+}
+
+func TestKeyManager_ConcurrentStartupAndRotationCreateOneKey(t *testing.T) {
+	store := utils.NewMemoryKeyStore()
+	cfg := utils.KeyManagerConfig{Algorithm: utils.AlgEdDSA, Issuer: "prestasi", Audience: "prestasi", RotationInterval: time.Hour}
+
+	// beberapa instance start bersamaan di atas store kosong
+	managers := make([]*utils.KeyManager, 4)
+	var wg sync.WaitGroup
+	for i := range managers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m, err := utils.NewKeyManager(store, cfg)
+			assert.NoError(t, err)
+			managers[i] = m
+		}(i)
+	}
+	wg.Wait()
+	keys, err := store.LoadKeys()
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	// job rotasi jalan di semua instance: hanya satu yang membuat kunci baru
+	later := time.Now().Add(2 * time.Hour)
+	var rotations int32
+	var mu sync.Mutex
+	for _, m := range managers {
+		wg.Add(1)
+		go func(m *utils.KeyManager) {
+			defer wg.Done()
+			rotated, err := m.RotateIfDue(later)
+			assert.NoError(t, err)
+			if rotated {
+				mu.Lock()
+				rotations++
+				mu.Unlock()
+			}
+		}(m)
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), rotations)
+	keys, err = store.LoadKeys()
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/nerhays/prestasi_uas/app/model"
+)
+
+// LDAPGroupRole: anggota grup (DN) mendapat role lokal ini; urutan = prioritas
+type LDAPGroupRole struct {
+	Group string
+	Role  string
+}
+
+type LDAPConfig struct {
+	URL                string // ldap://host:389 atau ldaps://host:636
+	StartTLS           bool
+	InsecureSkipVerify bool
+	// akun layanan untuk mencari DN user; kosong = search anonim
+	BindDN       string
+	BindPassword string
+	BaseDN       string
+	// filter pencarian user, %s diganti username (sudah di-escape)
+	UserFilter   string
+	UsernameAttr string
+	EmailAttr    string
+	NameAttr     string
+	GroupAttr    string
+	GroupRoles   []LDAPGroupRole
+	Timeout      time.Duration
+}
+
+// ParseLDAPGroupRoles: format "cn=dosen,ou=groups,dc=kampus,dc=ac,dc=id:Dosen Wali;cn=admin,...:Admin"
+func ParseLDAPGroupRoles(s string) []LDAPGroupRole {
+	var out []LDAPGroupRole
+	for _, part := range strings.Split(s, ";") {
+		i := strings.LastIndex(part, ":")
+		if i <= 0 {
+			continue
+		}
+		group := strings.TrimSpace(part[:i])
+		role := strings.TrimSpace(part[i+1:])
+		if group != "" && role != "" {
+			out = append(out, LDAPGroupRole{Group: group, Role: role})
+		}
+	}
+	return out
+}
+
+// LDAPAuthenticator: cari DN user dengan akun layanan, lalu bind sebagai user untuk cek password
+type LDAPAuthenticator struct {
+	cfg LDAPConfig
+}
+
+func NewLDAPAuthenticator(cfg LDAPConfig) *LDAPAuthenticator {
+	if cfg.UserFilter == "" {
+		cfg.UserFilter = "(uid=%s)"
+	}
+	if cfg.UsernameAttr == "" {
+		cfg.UsernameAttr = "uid"
+	}
+	if cfg.EmailAttr == "" {
+		cfg.EmailAttr = "mail"
+	}
+	if cfg.NameAttr == "" {
+		cfg.NameAttr = "cn"
+	}
+	if cfg.GroupAttr == "" {
+		cfg.GroupAttr = "memberOf"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	return &LDAPAuthenticator{cfg: cfg}
+}
+
+func (a *LDAPAuthenticator) Authenticate(login string, user *model.User, password string) (*AuthIdentity, error) {
+	// bind dengan password kosong = unauthenticated bind, server LDAP menganggapnya sukses
+	if password == "" {
+		return nil, ErrInvalidCredentials
+	}
+	name := login
+	if user != nil {
+		name = user.Username
+	}
+
+	conn, err := ldap.DialURL(a.cfg.URL, ldap.DialWithDialer(&net.Dialer{Timeout: a.cfg.Timeout}))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAuthBackendUnavailable, err)
+	}
+	defer conn.Close()
+	conn.SetTimeout(a.cfg.Timeout)
+
+	if a.cfg.StartTLS {
+		if err := conn.StartTLS(&tls.Config{InsecureSkipVerify: a.cfg.InsecureSkipVerify}); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrAuthBackendUnavailable, err)
+		}
+	}
+	if a.cfg.BindDN != "" {
+		if err := conn.Bind(a.cfg.BindDN, a.cfg.BindPassword); err != nil {
+			return nil, fmt.Errorf("%w: service bind: %v", ErrAuthBackendUnavailable, err)
+		}
+	}
+
+	res, err := conn.Search(ldap.NewSearchRequest(
+		a.cfg.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2, // lebih dari satu hasil = ambigu, ditolak
+		int(a.cfg.Timeout.Seconds()),
+		false,
+		fmt.Sprintf(a.cfg.UserFilter, ldap.EscapeFilter(name)),
+		[]string{a.cfg.UsernameAttr, a.cfg.EmailAttr, a.cfg.NameAttr, a.cfg.GroupAttr},
+		nil,
+	))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("%w: search: %v", ErrAuthBackendUnavailable, err)
+	}
+	if res == nil || len(res.Entries) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+	entry := res.Entries[0]
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		var ldapErr *ldap.Error
+		if errors.As(err, &ldapErr) && ldapErr.ResultCode == ldap.LDAPResultInvalidCredentials {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("%w: %v", ErrAuthBackendUnavailable, err)
+	}
+
+	identity := &AuthIdentity{
+		Username: entry.GetAttributeValue(a.cfg.UsernameAttr),
+		Email:    strings.ToLower(entry.GetAttributeValue(a.cfg.EmailAttr)),
+		FullName: entry.GetAttributeValue(a.cfg.NameAttr),
+		Groups:   entry.GetAttributeValues(a.cfg.GroupAttr),
+	}
+	if identity.Username == "" {
+		identity.Username = name
+	}
+	identity.Role = a.roleFor(identity.Groups)
+	return identity, nil
+}
+
+// roleFor: role dari mapping pertama yang grupnya dimiliki user, "" kalau tidak ada
+func (a *LDAPAuthenticator) roleFor(groups []string) string {
+	for _, m := range a.cfg.GroupRoles {
+		for _, g := range groups {
+			if strings.EqualFold(normalizeDN(g), normalizeDN(m.Group)) {
+				return m.Role
+			}
+		}
+	}
+	return ""
+}
+
+func normalizeDN(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil {
+		return strings.TrimSpace(dn)
+	}
+	return parsed.String()
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/nerhays/prestasi_uas/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testLDAPBaseDN    = "dc=kampus,dc=ac,dc=id"
+	testLDAPServiceDN = "cn=prestasi,ou=services,dc=kampus,dc=ac,dc=id"
+	testLDAPDosenDN   = "cn=dosen,ou=groups,dc=kampus,dc=ac,dc=id"
+)
+
+type fakeLDAPEntry struct {
+	DN       string
+	Password string
+	Attrs    map[string][]string
+}
+
+// fakeLDAPServer: server LDAP minimal (bind, search, unbind) di proses yang sama, cukup untuk bind-and-search
+type fakeLDAPServer struct {
+	ln      net.Listener
+	entries []fakeLDAPEntry
+}
+
+func newFakeLDAPServer(t *testing.T, entries ...fakeLDAPEntry) *fakeLDAPServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeLDAPServer{ln: ln, entries: entries}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeLDAPServer) URL() string {
+	return "ldap://" + s.ln.Addr().String()
+}
+
+func (s *fakeLDAPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	bound := false
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		id := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn, password := op.Children[1].Data.String(), op.Children[2].Data.String()
+			code := uint16(ldap.LDAPResultInvalidCredentials)
+			if s.checkBind(dn, password) {
+				code, bound = ldap.LDAPResultSuccess, true
+			}
+			conn.Write(ldapResult(id, ldap.ApplicationBindResponse, code).Bytes())
+		case ldap.ApplicationSearchRequest:
+			if !bound {
+				conn.Write(ldapResult(id, ldap.ApplicationSearchResultDone, ldap.LDAPResultInsufficientAccessRights).Bytes())
+				continue
+			}
+			base := strings.ToLower(op.Children[0].Data.String())
+			for _, e := range s.entries {
+				if strings.HasSuffix(strings.ToLower(e.DN), base) && matchLDAPFilter(op.Children[6], e) {
+					conn.Write(ldapEntry(id, e).Bytes())
+				}
+			}
+			conn.Write(ldapResult(id, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess).Bytes())
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+func (s *fakeLDAPServer) checkBind(dn, password string) bool {
+	if password == "" {
+		return false
+	}
+	if dn == testLDAPServiceDN {
+		return password == "service-secret"
+	}
+	for _, e := range s.entries {
+		if strings.EqualFold(e.DN, dn) {
+			return e.Password == password
+		}
+	}
+	return false
+}
+
+func matchLDAPFilter(f *ber.Packet, e fakeLDAPEntry) bool {
+	switch f.Tag {
+	case ldap.FilterAnd:
+		for _, c := range f.Children {
+			if !matchLDAPFilter(c, e) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, c := range f.Children {
+			if matchLDAPFilter(c, e) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterEqualityMatch:
+		for _, v := range e.Attrs[f.Children[0].Data.String()] {
+			if strings.EqualFold(v, f.Children[1].Data.String()) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		return len(e.Attrs[f.Data.String()]) > 0
+	}
+	return false
+}
+
+func ldapEnvelope(id int64, op *ber.Packet) *ber.Packet {
+	env := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	env.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+	env.AppendChild(op)
+	return env
+}
+
+func ldapResult(id int64, tag ber.Tag, code uint16) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), ""))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	return ldapEnvelope(id, op)
+}
+
+func ldapEntry(id int64, e fakeLDAPEntry) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.DN, ""))
+	attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	for name, values := range e.Attrs {
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+		for _, v := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, ""))
+		}
+		attr.AppendChild(set)
+		attrs.AppendChild(attr)
+	}
+	op.AppendChild(attrs)
+	return ldapEnvelope(id, op)
+}
+
+func newTestDirectory(t *testing.T) *fakeLDAPServer {
+	return newFakeLDAPServer(t,
+		fakeLDAPEntry{
+			DN:       "uid=andi,ou=people,dc=kampus,dc=ac,dc=id",
+			Password: "dir-secret",
+			Attrs: map[string][]string{
+				"uid":         {"andi"},
+				"mail":        {"Andi@Kampus.ac.id"},
+				"cn":          {"Andi Wijaya"},
+				"memberOf":    {"CN=Dosen,OU=Groups,DC=kampus,DC=ac,DC=id"},
+				"objectClass": {"person"},
+			},
+		},
+		fakeLDAPEntry{
+			DN:       "uid=tamu,ou=people,dc=kampus,dc=ac,dc=id",
+			Password: "tamu-secret",
+			Attrs:    map[string][]string{"uid": {"tamu"}, "mail": {"tamu@kampus.ac.id"}, "objectClass": {"person"}},
+		},
+	)
+}
+
+func newTestLDAPAuthenticator(url string) *LDAPAuthenticator {
+	return NewLDAPAuthenticator(LDAPConfig{
+		URL:          url,
+		BindDN:       testLDAPServiceDN,
+		BindPassword: "service-secret",
+		BaseDN:       testLDAPBaseDN,
+		UserFilter:   "(&(objectClass=person)(uid=%s))",
+		GroupRoles:   ParseLDAPGroupRoles(testLDAPDosenDN + ":Dosen Wali;cn=admin,ou=groups,dc=kampus,dc=ac,dc=id:Admin"),
+	})
+}
+
+func TestLDAPAuthenticator_BindSearchAndGroupMapping(t *testing.T) {
+	dir := newTestDirectory(t)
+	auth := newTestLDAPAuthenticator(dir.URL())
+
+	identity, err := auth.Authenticate("andi", nil, "dir-secret")
+	require.NoError(t, err)
+	assert.Equal(t, "andi", identity.Username)
+	assert.Equal(t, "andi@kampus.ac.id", identity.Email)
+	assert.Equal(t, "Andi Wijaya", identity.FullName)
+	// DN grup dicocokkan tanpa peduli huruf besar/kecil
+	assert.Equal(t, "Dosen Wali", identity.Role)
+
+	_, err = auth.Authenticate("andi", nil, "wrong")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = auth.Authenticate("andi", nil, "")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = auth.Authenticate("nobody", nil, "dir-secret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	// karakter filter di-escape, "*" tidak jadi wildcard
+	_, err = auth.Authenticate("*", nil, "dir-secret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	// user tanpa grup terpetakan tetap bisa bind, role kosong
+	identity, err = auth.Authenticate("tamu", nil, "tamu-secret")
+	require.NoError(t, err)
+	assert.Empty(t, identity.Role)
+
+	// direktori mati
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := ln.Addr().String()
+	ln.Close()
+	_, err = newTestLDAPAuthenticator("ldap://"+addr).Authenticate("andi", nil, "dir-secret")
+	assert.ErrorIs(t, err, ErrAuthBackendUnavailable)
+}
+
+func TestLogin_BackendSelectedPerUserWithLDAPProvisioning(t *testing.T) {
+	dir := newTestDirectory(t)
+
+	userRepo := new(mocks.UserRepositoryMock)
+	roleRepo := new(mocks.RoleRepositoryMock)
+	auditRepo := new(mocks.LoginAuditRepositoryMock)
+	auditRepo.On("Create", mock.Anything).Return(nil)
+
+	auths := NewAuthenticators(model.AuthBackendLDAP)
+	auths.Register(model.AuthBackendLDAP, newTestLDAPAuthenticator(dir.URL()))
+	guard := NewLoginGuard(repository.NewMemoryLoginAttemptStore(), auditRepo, userRepo, DefaultLockoutPolicy())
+	svc := NewAuthService(userRepo, roleRepo, guard, NewTwoFactorService(userRepo, new(mocks.TwoFactorRepositoryMock), "Prestasi", testKeys), auths, testKeys)
+
+	dosenRole := &model.Role{ID: "role-dosen", Name: "Dosen Wali"}
+	roleRepo.On("FindByName", "Dosen Wali").Return(dosenRole, nil)
+	userRepo.On("GetPermissionsByUserID", mock.Anything).Return([]model.Permission{}, nil)
+
+	// dosen hanya punya akun direktori → user lokal dibuat dengan role dari grup
+	userRepo.On("FindByUsernameOrEmail", "andi").Return(nil, errors.New("not found"))
+	res, err := svc.Login(LoginInput{Username: "andi", Password: "dir-secret"})
+	require.NoError(t, err)
+	assert.Equal(t, "Dosen Wali", res.User.Role.Name)
+	assert.Equal(t, model.AuthBackendLDAP, res.User.AuthBackend)
+	assert.Equal(t, "andi@kampus.ac.id", res.User.Email)
+
+	// akun direktori tanpa grup terpetakan tidak dibuat
+	userRepo.On("FindByUsernameOrEmail", "tamu").Return(nil, errors.New("not found"))
+	_, err = svc.Login(LoginInput{Username: "tamu", Password: "tamu-secret"})
+	assert.EqualError(t, err, "invalid_credentials")
+
+	// override per user: admin lokal tetap pakai bcrypt walau backend global ldap
+	hashed, _ := utils.HashPassword("local-pass")
+	admin := &model.User{ID: "user-admin", Username: "admin", PasswordHash: hashed, IsActive: true, AuthBackend: model.AuthBackendLocal, Role: model.Role{Name: "Admin"}}
+	userRepo.On("FindByUsernameOrEmail", "admin").Return(admin, nil)
+	_, err = svc.Login(LoginInput{Username: "admin", Password: "local-pass"})
+	assert.NoError(t, err)
+
+	// user yang sudah ada: role diikutkan ke grup direktori
+	existing := &model.User{ID: "user-andi", Username: "andi", PasswordHash: hashed, IsActive: true, Role: model.Role{Name: "Mahasiswa"}}
+	userRepo.ExpectedCalls = removeCalls(userRepo.ExpectedCalls, "FindByUsernameOrEmail", "andi")
+	userRepo.On("FindByUsernameOrEmail", "andi").Return(existing, nil)
+	userRepo.On("UpdateRole", "user-andi", "role-dosen").Return(nil)
+	_, err = svc.Login(LoginInput{Username: "andi", Password: "local-pass"})
+	assert.EqualError(t, err, "invalid_credentials")
+	res, err = svc.Login(LoginInput{Username: "andi", Password: "dir-secret"})
+	require.NoError(t, err)
+	assert.Equal(t, "role-dosen", res.User.RoleID)
+}
+
+func removeCalls(calls []*mock.Call, method string, arg any) []*mock.Call {
+	out := calls[:0]
+	for _, c := range calls {
+		if c.Method == method && len(c.Arguments) > 0 && c.Arguments[0] == arg {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+var (
+	ErrAccountLocked        = errors.New("account_locked")
+	ErrTooManyAttempts      = errors.New("too_many_attempts")
+	ErrUnlockTargetRequired = errors.New("username or ip is required")
+)
+
+// LoginBlockedError: login ditolak sebelum password dicek; RetryAfter untuk header Retry-After
+type LoginBlockedError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LoginBlockedError) Error() string { return e.Err.Error() }
+func (e *LoginBlockedError) Unwrap() error { return e.Err }
+
+// LockoutPolicy: batas gagal login per akun / per IP dalam satu window
+type LockoutPolicy struct {
+	MaxAccountFailures int
+	// IP dibuat lebih longgar: banyak mahasiswa bisa keluar lewat NAT kampus yang sama
+	MaxIPFailures   int
+	Window          time.Duration
+	LockoutDuration time.Duration
+	// jeda progresif mulai setelah DelayAfter kali gagal: BaseDelay, 2x, 4x, ... maks MaxDelay
+	DelayAfter int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+func DefaultLockoutPolicy() LockoutPolicy {
+	return LockoutPolicy{
+		MaxAccountFailures: 5,
+		MaxIPFailures:      50,
+		Window:             15 * time.Minute,
+		LockoutDuration:    15 * time.Minute,
+		DelayAfter:         3,
+		BaseDelay:          time.Second,
+		MaxDelay:           30 * time.Second,
+	}
+}
+
+// delay: jeda minimum sebelum percobaan berikutnya setelah failures kali gagal
+func (p LockoutPolicy) delay(failures int) time.Duration {
+	if p.DelayAfter <= 0 || failures < p.DelayAfter {
+		return 0
+	}
+	d := p.BaseDelay
+	for i := p.DelayAfter; i < failures && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	return min(d, p.MaxDelay)
+}
+
+type LoginGuard struct {
+	store     repository.LoginAttemptStore
+	auditRepo repository.LoginAuditRepository
+	userRepo  repository.UserRepository
+	policy    LockoutPolicy
+}
+
+func NewLoginGuard(
+	store repository.LoginAttemptStore,
+	auditRepo repository.LoginAuditRepository,
+	userRepo repository.UserRepository,
+	policy LockoutPolicy,
+) *LoginGuard {
+	return &LoginGuard{
+		store:     store,
+		auditRepo: auditRepo,
+		userRepo:  userRepo,
+		policy:    policy,
+	}
+}
+
+func userThrottleKey(username string) string {
+	return model.LoginThrottleUserPrefix + strings.ToLower(strings.TrimSpace(username))
+}
+
+func ipThrottleKey(ip string) string {
+	return model.LoginThrottleIPPrefix + ip
+}
+
+func (g *LoginGuard) keys(username, ip string) []string {
+	keys := []string{userThrottleKey(username)}
+	if ip != "" {
+		keys = append(keys, ipThrottleKey(ip))
+	}
+	return keys
+}
+
+// Check: dipanggil sebelum password dicek
+func (g *LoginGuard) Check(username, ip string, now time.Time) error {
+	for _, key := range g.keys(username, ip) {
+		t, err := g.store.Get(key)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			continue
+		}
+
+		if t.LockedUntil != nil {
+			if t.LockedUntil.After(now) {
+				return &LoginBlockedError{Err: ErrAccountLocked, RetryAfter: t.LockedUntil.Sub(now)}
+			}
+			// masa lock habis → mulai dari nol
+			if err := g.store.Reset(key); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if next := t.LastFailureAt.Add(g.policy.delay(t.Failures)); next.After(now) {
+			return &LoginBlockedError{Err: ErrTooManyAttempts, RetryAfter: next.Sub(now)}
+		}
+	}
+	return nil
+}
+
+// RegisterFailure: tambah counter akun + IP, lock kalau melewati batas
+func (g *LoginGuard) RegisterFailure(username, ip string, now time.Time) {
+	for _, key := range g.keys(username, ip) {
+		t, err := g.store.RegisterFailure(key, now, g.policy.Window)
+		if err != nil {
+			log.Printf("[LOGIN] failed to register failure for %s: %v", key, err)
+			continue
+		}
+
+		limit := g.policy.MaxAccountFailures
+		if strings.HasPrefix(key, model.LoginThrottleIPPrefix) {
+			limit = g.policy.MaxIPFailures
+		}
+		if limit > 0 && t.Failures >= limit {
+			if err := g.store.Lock(key, now.Add(g.policy.LockoutDuration)); err != nil {
+				log.Printf("[LOGIN] failed to lock %s: %v", key, err)
+				continue
+			}
+			log.Printf("[LOGIN] %s locked for %s after %d failed attempts", key, g.policy.LockoutDuration, t.Failures)
+		}
+	}
+}
+
+// RegisterSuccess: counter akun direset; counter IP tidak, supaya satu akun valid tidak bisa dipakai mereset IP
+func (g *LoginGuard) RegisterSuccess(username string) {
+	if err := g.store.Reset(userThrottleKey(username)); err != nil {
+		log.Printf("[LOGIN] failed to reset counter for %s: %v", username, err)
+	}
+}
+
+// Audit: gagal menulis audit tidak menggagalkan login
+func (g *LoginGuard) Audit(entry model.LoginAudit) {
+	if len(entry.UserAgent) > 255 {
+		entry.UserAgent = entry.UserAgent[:255]
+	}
+	if err := g.auditRepo.Create(&entry); err != nil {
+		log.Printf("[LOGIN] failed to write audit for %s: %v", entry.Username, err)
+	}
+}
+
+// ===== admin =====
+
+func (g *LoginGuard) GetLockouts(now time.Time) ([]model.LoginThrottle, error) {
+	return g.store.FindLocked(now)
+}
+
+// UnlockUser: buka lock akun berdasarkan user ID
+func (g *LoginGuard) UnlockUser(userID string) error {
+	user, err := g.userRepo.FindByID(userID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	return g.store.Reset(userThrottleKey(user.Username))
+}
+
+// Unlock: buka lock berdasarkan username dan/atau IP
+func (g *LoginGuard) Unlock(username, ip string) error {
+	username, ip = strings.TrimSpace(username), strings.TrimSpace(ip)
+	if username == "" && ip == "" {
+		return ErrUnlockTargetRequired
+	}
+	if username != "" {
+		if err := g.store.Reset(userThrottleKey(username)); err != nil {
+			return err
+		}
+	}
+	if ip != "" {
+		if err := g.store.Reset(ipThrottleKey(ip)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *LoginGuard) GetLoginAudit(filter model.LoginAuditFilter, page, limit int) ([]model.LoginAudit, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	return g.auditRepo.FindAll(filter, (page-1)*limit, limit)
+}
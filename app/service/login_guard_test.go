@@ -0,0 +1,143 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/nerhays/prestasi_uas/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLoginGuard_ProgressiveDelay(t *testing.T) {
+	guard := NewLoginGuard(repository.NewMemoryLoginAttemptStore(), nil, nil, DefaultLockoutPolicy())
+	start := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	// 2 kali gagal: belum ada jeda
+	guard.RegisterFailure("budi", "", start)
+	guard.RegisterFailure("budi", "", start)
+	assert.NoError(t, guard.Check("budi", "", start))
+
+	// gagal ke-3: jeda 1 detik
+	guard.RegisterFailure("budi", "", start)
+	err := guard.Check("budi", "", start.Add(500*time.Millisecond))
+	var blocked *LoginBlockedError
+	assert.True(t, errors.As(err, &blocked))
+	assert.ErrorIs(t, err, ErrTooManyAttempts)
+	assert.Equal(t, 500*time.Millisecond, blocked.RetryAfter)
+	assert.NoError(t, guard.Check("budi", "", start.Add(time.Second)))
+
+	// gagal ke-4: jeda 2 detik, akun lain tidak terpengaruh
+	guard.RegisterFailure("BUDI", "", start.Add(time.Second))
+	assert.ErrorIs(t, guard.Check("budi", "", start.Add(2*time.Second)), ErrTooManyAttempts)
+	assert.NoError(t, guard.Check("sari", "", start.Add(2*time.Second)))
+
+	assert.Equal(t, 30*time.Second, DefaultLockoutPolicy().delay(20))
+}
+
+func TestLoginGuard_LockoutExpiresAndFailureWindowResets(t *testing.T) {
+	policy := DefaultLockoutPolicy()
+	policy.DelayAfter = 0
+	policy.MaxAccountFailures = 3
+	store := repository.NewMemoryLoginAttemptStore()
+	guard := NewLoginGuard(store, nil, nil, policy)
+	start := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	// gagal 2x, lalu window 15 menit lewat → counter mulai ulang
+	guard.RegisterFailure("budi", "", start)
+	guard.RegisterFailure("budi", "", start)
+	guard.RegisterFailure("budi", "", start.Add(16*time.Minute))
+	th, _ := store.Get(userThrottleKey("budi"))
+	assert.Equal(t, 1, th.Failures)
+
+	now := start.Add(16 * time.Minute)
+	guard.RegisterFailure("budi", "", now)
+	guard.RegisterFailure("budi", "", now)
+	assert.ErrorIs(t, guard.Check("budi", "", now.Add(time.Minute)), ErrAccountLocked)
+
+	locks, _ := guard.GetLockouts(now)
+	assert.Len(t, locks, 1)
+
+	// lock habis → boleh coba lagi dengan counter kosong
+	assert.NoError(t, guard.Check("budi", "", now.Add(policy.LockoutDuration)))
+	th, _ = store.Get(userThrottleKey("budi"))
+	assert.Nil(t, th)
+}
+
+func TestLogin_LockedAccountRejectsCorrectPasswordUntilUnlocked(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	auditRepo := new(mocks.LoginAuditRepositoryMock)
+
+	var audits []model.LoginAudit
+	auditRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		audits = append(audits, *args.Get(0).(*model.LoginAudit))
+	}).Return(nil)
+
+	policy := DefaultLockoutPolicy()
+	policy.DelayAfter = 0
+	policy.MaxAccountFailures = 3
+	guard := NewLoginGuard(repository.NewMemoryLoginAttemptStore(), auditRepo, userRepo, policy)
+	svc := NewAuthService(userRepo, nil, guard, NewTwoFactorService(userRepo, new(mocks.TwoFactorRepositoryMock), "Prestasi", testKeys), nil, testKeys)
+
+	hashed, _ := utils.HashPassword("password123")
+	user := &model.User{ID: "user-1", Username: "budi", Email: "budi@mail.com", PasswordHash: hashed, IsActive: true}
+	userRepo.On("FindByUsernameOrEmail", "budi").Return(user, nil)
+	userRepo.On("FindByUsernameOrEmail", "budi@mail.com").Return(user, nil)
+	userRepo.On("FindByID", "user-1").Return(user, nil)
+	userRepo.On("GetPermissionsByUserID", "user-1").Return([]model.Permission{}, nil)
+
+	// login via email dan username dihitung ke akun yang sama
+	for _, login := range []string{"budi", "budi@mail.com", "budi"} {
+		_, err := svc.Login(LoginInput{Username: login, Password: "wrong", IP: "10.0.0.1", UserAgent: "test"})
+		assert.EqualError(t, err, "invalid_credentials")
+	}
+
+	_, err := svc.Login(LoginInput{Username: "budi", Password: "password123", IP: "10.0.0.1"})
+	assert.ErrorIs(t, err, ErrAccountLocked)
+
+	assert.NoError(t, guard.UnlockUser("user-1"))
+	res, err := svc.Login(LoginInput{Username: "budi", Password: "password123", IP: "10.0.0.1"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.Token)
+
+	assert.Len(t, audits, 5)
+	assert.Equal(t, "invalid_credentials", audits[0].Reason)
+	assert.Equal(t, "10.0.0.1", audits[0].IPAddress)
+	assert.Equal(t, "user-1", *audits[0].UserID)
+	assert.Equal(t, "account_locked", audits[3].Reason)
+	assert.True(t, audits[4].Success)
+}
+
+func TestLogin_IPLockoutAcrossAccounts(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	auditRepo := new(mocks.LoginAuditRepositoryMock)
+	auditRepo.On("Create", mock.Anything).Return(nil)
+
+	policy := DefaultLockoutPolicy()
+	policy.DelayAfter = 0
+	policy.MaxIPFailures = 3
+	guard := NewLoginGuard(repository.NewMemoryLoginAttemptStore(), auditRepo, userRepo, policy)
+	svc := NewAuthService(userRepo, nil, guard, NewTwoFactorService(userRepo, new(mocks.TwoFactorRepositoryMock), "Prestasi", testKeys), nil, testKeys)
+
+	userRepo.On("FindByUsernameOrEmail", mock.Anything).Return(nil, errors.New("not found"))
+
+	for _, name := range []string{"a", "b", "c"} {
+		_, err := svc.Login(LoginInput{Username: name, Password: "x", IP: "10.0.0.9"})
+		assert.EqualError(t, err, "invalid_credentials")
+	}
+
+	_, err := svc.Login(LoginInput{Username: "d", Password: "x", IP: "10.0.0.9"})
+	assert.ErrorIs(t, err, ErrAccountLocked)
+
+	// IP lain tetap boleh
+	_, err = svc.Login(LoginInput{Username: "d", Password: "x", IP: "10.0.0.10"})
+	assert.EqualError(t, err, "invalid_credentials")
+
+	assert.NoError(t, guard.Unlock("", "10.0.0.9"))
+	_, err = svc.Login(LoginInput{Username: "d", Password: "x", IP: "10.0.0.9"})
+	assert.EqualError(t, err, "invalid_credentials")
+}
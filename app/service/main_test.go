@@ -0,0 +1,25 @@
+package service
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+// testKeys: kunci JWT di memori yang dipakai semua test di package ini
+var testKeys *utils.KeyManager
+
+func TestMain(m *testing.M) {
+	keys, err := utils.NewKeyManager(utils.NewMemoryKeyStore(), utils.KeyManagerConfig{
+		Algorithm: utils.AlgEdDSA,
+		Issuer:    "prestasi-test",
+		Audience:  "prestasi",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	testKeys = keys
+	os.Exit(m.Run())
+}
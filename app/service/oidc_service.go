@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/utils"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrOIDCDisabled       = errors.New("single sign-on is not configured")
+	ErrOIDCStateInvalid   = errors.New("invalid or expired sso state")
+	ErrOIDCTokenInvalid   = errors.New("invalid id token from identity provider")
+	ErrOIDCUserNotLinked  = errors.New("sso_account_not_linked")
+	ErrOIDCProviderFailed = errors.New("identity provider unavailable")
+)
+
+type OIDCConfig struct {
+	// nama provider di tabel user_identities, mis. "campus"
+	Provider     string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// claim IdP yang berisi NIM mahasiswa, kosong = tidak dicocokkan lewat NIM
+	NIMClaim string
+	// buat user baru kalau akun IdP tidak cocok dengan user mana pun
+	AutoProvision bool
+	DefaultRole   string
+	StateTTL      time.Duration
+}
+
+type OIDCCallbackInput struct {
+	State     string
+	Code      string
+	IP        string
+	UserAgent string
+}
+
+// claim IdP yang dipakai untuk mencocokkan user
+type oidcClaims struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+	NIM               string
+}
+
+type OIDCService struct {
+	cfg          OIDCConfig
+	auth         *AuthService
+	userRepo     repository.UserRepository
+	roleRepo     repository.RoleRepository
+	studentRepo  repository.StudentRepository
+	identityRepo repository.UserIdentityRepository
+	stateRepo    repository.OIDCStateRepository
+
+	// discovery dilakukan saat login pertama, server tetap bisa start walau IdP sedang down
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+func NewOIDCService(
+	cfg OIDCConfig,
+	auth *AuthService,
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	studentRepo repository.StudentRepository,
+	identityRepo repository.UserIdentityRepository,
+	stateRepo repository.OIDCStateRepository,
+) *OIDCService {
+	if cfg.StateTTL <= 0 {
+		cfg.StateTTL = 10 * time.Minute
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"email", "profile"}
+	}
+	return &OIDCService{
+		cfg:          cfg,
+		auth:         auth,
+		userRepo:     userRepo,
+		roleRepo:     roleRepo,
+		studentRepo:  studentRepo,
+		identityRepo: identityRepo,
+		stateRepo:    stateRepo,
+	}
+}
+
+func (s *OIDCService) discover(ctx context.Context) (*oidc.Provider, error) {
+	if s.cfg.IssuerURL == "" {
+		return nil, ErrOIDCDisabled
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.provider != nil {
+		return s.provider, nil
+	}
+
+	p, err := oidc.NewProvider(ctx, s.cfg.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCProviderFailed, err)
+	}
+	s.provider = p
+	return p, nil
+}
+
+func (s *OIDCService) oauthConfig(p *oidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     s.cfg.ClientID,
+		ClientSecret: s.cfg.ClientSecret,
+		RedirectURL:  s.cfg.RedirectURL,
+		Endpoint:     p.Endpoint(),
+		Scopes:       append([]string{oidc.ScopeOpenID}, s.cfg.Scopes...),
+	}
+}
+
+// AuthURL: URL login IdP (authorization code + PKCE S256); state, nonce dan verifier disimpan sekali pakai
+func (s *OIDCService) AuthURL(ctx context.Context) (string, error) {
+	p, err := s.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	state, err := utils.RandomToken(16)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := utils.RandomToken(16)
+	if err != nil {
+		return "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	now := time.Now()
+	if err := s.stateRepo.DeleteExpired(now); err != nil {
+		log.Printf("[WARN] failed to clean expired sso states: %v", err)
+	}
+	if err := s.stateRepo.Create(&model.OIDCLoginState{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    now.Add(s.cfg.StateTTL),
+	}); err != nil {
+		return "", err
+	}
+
+	return s.oauthConfig(p).AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+// Callback: tukar code → id_token, verifikasi (JWKS, issuer, audience, nonce), cocokkan ke user lokal lalu login
+func (s *OIDCService) Callback(ctx context.Context, input OIDCCallbackInput) (*LoginOutput, error) {
+	p, err := s.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := s.stateRepo.Consume(input.State, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if st == nil {
+		return nil, ErrOIDCStateInvalid
+	}
+
+	token, err := s.oauthConfig(p).Exchange(ctx, input.Code, oauth2.VerifierOption(st.CodeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCTokenInvalid, err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("%w: id_token missing", ErrOIDCTokenInvalid)
+	}
+
+	idToken, err := p.Verifier(&oidc.Config{ClientID: s.cfg.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCTokenInvalid, err)
+	}
+	if idToken.Nonce != st.Nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrOIDCTokenInvalid)
+	}
+
+	claims, err := s.parseClaims(idToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, identity, err := s.resolveUser(claims)
+	if err != nil {
+		if errors.Is(err, ErrOIDCUserNotLinked) {
+			s.auth.guard.Audit(model.LoginAudit{
+				Username:  claims.Email,
+				IPAddress: input.IP,
+				UserAgent: input.UserAgent,
+				Reason:    err.Error(),
+			})
+		}
+		return nil, err
+	}
+
+	res, err := s.auth.LoginExternal(user, input.IP, input.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.identityRepo.TouchLastLogin(identity.ID, time.Now()); err != nil {
+		log.Printf("[WARN] failed to update sso last login: %v", err)
+	}
+	return res, nil
+}
+
+func (s *OIDCService) parseClaims(idToken *oidc.IDToken) (*oidcClaims, error) {
+	var raw map[string]any
+	if err := idToken.Claims(&raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCTokenInvalid, err)
+	}
+
+	claims := &oidcClaims{
+		Subject:           idToken.Subject,
+		Email:             strings.ToLower(strings.TrimSpace(claimString(raw["email"]))),
+		Name:              claimString(raw["name"]),
+		PreferredUsername: claimString(raw["preferred_username"]),
+	}
+	// beberapa IdP mengirim email_verified sebagai string "true"
+	switch v := raw["email_verified"].(type) {
+	case bool:
+		claims.EmailVerified = v
+	case string:
+		claims.EmailVerified, _ = strconv.ParseBool(v)
+	}
+	if s.cfg.NIMClaim != "" {
+		claims.NIM = strings.TrimSpace(claimString(raw[s.cfg.NIMClaim]))
+	}
+	return claims, nil
+}
+
+// resolveUser: urutan pencocokan: akun IdP yang sudah terhubung → email terverifikasi → NIM → JIT provisioning
+func (s *OIDCService) resolveUser(claims *oidcClaims) (*model.User, *model.UserIdentity, error) {
+	identity, err := s.identityRepo.FindByProviderSubject(s.cfg.Provider, claims.Subject)
+	if err != nil {
+		return nil, nil, err
+	}
+	if identity != nil {
+		user, err := s.userRepo.FindByID(identity.UserID)
+		if err != nil {
+			return nil, nil, ErrOIDCUserNotLinked
+		}
+		return user, identity, nil
+	}
+
+	user := s.matchExistingUser(claims)
+	if user == nil {
+		if !s.cfg.AutoProvision {
+			return nil, nil, ErrOIDCUserNotLinked
+		}
+		if user, err = s.provisionUser(claims); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	identity = &model.UserIdentity{
+		UserID:   user.ID,
+		Provider: s.cfg.Provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+	if err := s.identityRepo.Create(identity); err != nil {
+		return nil, nil, err
+	}
+	return user, identity, nil
+}
+
+func (s *OIDCService) matchExistingUser(claims *oidcClaims) *model.User {
+	// email yang belum diverifikasi IdP tidak boleh dipakai untuk mengambil alih akun
+	if claims.EmailVerified && strings.Contains(claims.Email, "@") {
+		user, err := s.userRepo.FindByUsernameOrEmail(claims.Email)
+		if err == nil && strings.EqualFold(user.Email, claims.Email) {
+			return user
+		}
+	}
+	if claims.NIM != "" {
+		if student, err := s.studentRepo.FindByNIM(claims.NIM); err == nil {
+			return &student.User
+		}
+	}
+	return nil
+}
+
+// provisionUser: user baru dengan role default; password acak (login lokal lewat reset password)
+func (s *OIDCService) provisionUser(claims *oidcClaims) (*model.User, error) {
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, ErrOIDCUserNotLinked
+	}
+	role, err := s.roleRepo.FindByName(s.cfg.DefaultRole)
+	if err != nil {
+		return nil, fmt.Errorf("default sso role %q not found", s.cfg.DefaultRole)
+	}
+
+	username := claims.NIM
+	if username == "" {
+		username = claims.PreferredUsername
+	}
+	if username == "" {
+		username = strings.SplitN(claims.Email, "@", 2)[0]
+	}
+	if _, err := s.userRepo.FindByUsernameOrEmail(username); err == nil {
+		username += "-" + utils.SHA256Hex(claims.Subject)[:6]
+	}
+
+	fullName := claims.Name
+	if fullName == "" {
+		fullName = username
+	}
+
+	secret, err := utils.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := utils.HashPassword(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &model.User{
+		Username:     username,
+		Email:        claims.Email,
+		PasswordHash: hash,
+		FullName:     fullName,
+		RoleID:       role.ID,
+		IsActive:     true,
+	}
+	if err := s.userRepo.Create(user); err != nil {
+		return nil, err
+	}
+	user.Role = *role
+	return user, nil
+}
+
+func claimString(v any) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	}
+	return ""
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testOIDCClientID = "prestasi-app"
+
+// fakeOIDCProvider: IdP minimal (discovery, JWKS, token endpoint dengan cek PKCE) supaya test jalan offline
+type fakeOIDCProvider struct {
+	srv *httptest.Server
+	key *rsa.PrivateKey
+	// signKey != key → id_token ditandatangani kunci yang tidak ada di JWKS
+	signKey *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]fakeAuthCode
+}
+
+type fakeAuthCode struct {
+	challenge string
+	nonce     string
+	claims    jwt.MapClaims
+}
+
+func newFakeOIDCProvider(t *testing.T) *fakeOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p := &fakeOIDCProvider{key: key, signKey: key, codes: map[string]fakeAuthCode{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, http.StatusOK, map[string]any{
+			"issuer":                                p.srv.URL,
+			"authorization_endpoint":                p.srv.URL + "/authorize",
+			"token_endpoint":                        p.srv.URL + "/token",
+			"jwks_uri":                              p.srv.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, http.StatusOK, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": "test-key",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", p.handleToken)
+
+	p.srv = httptest.NewServer(mux)
+	t.Cleanup(p.srv.Close)
+	return p
+}
+
+func (p *fakeOIDCProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+	p.mu.Lock()
+	ac, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != ac.challenge {
+		writeTestJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	claims := jwt.MapClaims{
+		"iss":   p.srv.URL,
+		"aud":   testOIDCClientID,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(5 * time.Minute).Unix(),
+		"nonce": ac.nonce,
+	}
+	for k, v := range ac.claims {
+		claims[k] = v
+	}
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	tok.Header["kid"] = "test-key"
+	idToken, _ := tok.SignedString(p.signKey)
+
+	writeTestJSON(w, http.StatusOK, map[string]any{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+// authorize: simulasi user login di halaman IdP, return state + code untuk callback
+func (p *fakeOIDCProvider) authorize(t *testing.T, authURL string, claims jwt.MapClaims) (string, string) {
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	q := u.Query()
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+	assert.Equal(t, testOIDCClientID, q.Get("client_id"))
+
+	code := "code-" + q.Get("state")
+	p.mu.Lock()
+	p.codes[code] = fakeAuthCode{challenge: q.Get("code_challenge"), nonce: q.Get("nonce"), claims: claims}
+	p.mu.Unlock()
+	return q.Get("state"), code
+}
+
+func writeTestJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+type memoryOIDCStateRepo struct {
+	mu     sync.Mutex
+	states map[string]model.OIDCLoginState
+}
+
+func (r *memoryOIDCStateRepo) Create(st *model.OIDCLoginState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states[st.State] = *st
+	return nil
+}
+
+func (r *memoryOIDCStateRepo) Consume(state string, now time.Time) (*model.OIDCLoginState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	st, ok := r.states[state]
+	delete(r.states, state)
+	if !ok || !st.ExpiresAt.After(now) {
+		return nil, nil
+	}
+	return &st, nil
+}
+
+func (r *memoryOIDCStateRepo) DeleteExpired(now time.Time) error { return nil }
+
+type oidcTestEnv struct {
+	idp          *fakeOIDCProvider
+	svc          *OIDCService
+	userRepo     *mocks.UserRepositoryMock
+	roleRepo     *mocks.RoleRepositoryMock
+	studentRepo  *mocks.StudentRepositoryMock
+	identityRepo *mocks.UserIdentityRepositoryMock
+}
+
+func newOIDCTestEnv(t *testing.T, autoProvision bool) *oidcTestEnv {
+	env := &oidcTestEnv{
+		idp:          newFakeOIDCProvider(t),
+		userRepo:     new(mocks.UserRepositoryMock),
+		roleRepo:     new(mocks.RoleRepositoryMock),
+		studentRepo:  new(mocks.StudentRepositoryMock),
+		identityRepo: new(mocks.UserIdentityRepositoryMock),
+	}
+
+	auditRepo := new(mocks.LoginAuditRepositoryMock)
+	auditRepo.On("Create", mock.Anything).Return(nil)
+	guard := NewLoginGuard(repository.NewMemoryLoginAttemptStore(), auditRepo, env.userRepo, DefaultLockoutPolicy())
+	auth := NewAuthService(env.userRepo, nil, guard, NewTwoFactorService(env.userRepo, new(mocks.TwoFactorRepositoryMock), "Prestasi", testKeys), nil, testKeys)
+
+	env.svc = NewOIDCService(
+		OIDCConfig{
+			Provider:      "campus",
+			IssuerURL:     env.idp.srv.URL,
+			ClientID:      testOIDCClientID,
+			ClientSecret:  "secret",
+			RedirectURL:   "http://localhost:8080/api/v1/auth/oidc/callback",
+			NIMClaim:      "nim",
+			AutoProvision: autoProvision,
+			DefaultRole:   "Mahasiswa",
+		},
+		auth,
+		env.userRepo,
+		env.roleRepo,
+		env.studentRepo,
+		env.identityRepo,
+		&memoryOIDCStateRepo{states: map[string]model.OIDCLoginState{}},
+	)
+
+	env.userRepo.On("GetPermissionsByUserID", mock.Anything).Return([]model.Permission{}, nil)
+	env.identityRepo.On("Create", mock.Anything).Return(nil)
+	env.identityRepo.On("TouchLastLogin", mock.Anything, mock.Anything).Return(nil)
+	return env
+}
+
+func (env *oidcTestEnv) login(t *testing.T, claims jwt.MapClaims) (*LoginOutput, error) {
+	ctx := context.Background()
+	authURL, err := env.svc.AuthURL(ctx)
+	require.NoError(t, err)
+	state, code := env.idp.authorize(t, authURL, claims)
+	return env.svc.Callback(ctx, OIDCCallbackInput{State: state, Code: code, IP: "10.0.0.1"})
+}
+
+func TestOIDCLogin_LinksExistingUserByVerifiedEmail(t *testing.T) {
+	env := newOIDCTestEnv(t, false)
+	user := &model.User{ID: "user-1", Username: "budi", Email: "budi@kampus.ac.id", IsActive: true}
+	env.identityRepo.On("FindByProviderSubject", "campus", "sub-123").Return(nil, nil)
+	env.userRepo.On("FindByUsernameOrEmail", "budi@kampus.ac.id").Return(user, nil)
+
+	res, err := env.login(t, jwt.MapClaims{"sub": "sub-123", "email": "Budi@Kampus.ac.id", "email_verified": true})
+	require.NoError(t, err)
+	assert.NotEmpty(t, res.Token)
+	assert.Equal(t, "user-1", res.User.ID)
+	env.identityRepo.AssertCalled(t, "Create", mock.MatchedBy(func(i *model.UserIdentity) bool {
+		return i.UserID == "user-1" && i.Provider == "campus" && i.Subject == "sub-123"
+	}))
+}
+
+func TestOIDCLogin_UnverifiedEmailFallsBackToNIM(t *testing.T) {
+	env := newOIDCTestEnv(t, false)
+	student := &model.Student{ID: "stu-1", StudentID: "2141720001", User: model.User{ID: "user-2", Username: "sari", IsActive: true}}
+	env.identityRepo.On("FindByProviderSubject", "campus", "sub-456").Return(nil, nil)
+	env.studentRepo.On("FindByNIM", "2141720001").Return(student, nil)
+
+	res, err := env.login(t, jwt.MapClaims{"sub": "sub-456", "email": "other@kampus.ac.id", "email_verified": false, "nim": "2141720001"})
+	require.NoError(t, err)
+	assert.Equal(t, "user-2", res.User.ID)
+	env.userRepo.AssertNotCalled(t, "FindByUsernameOrEmail", mock.Anything)
+}
+
+func TestOIDCLogin_UnknownAccountRejectedOrProvisioned(t *testing.T) {
+	claims := jwt.MapClaims{"sub": "sub-789", "email": "new@kampus.ac.id", "email_verified": true, "name": "Mahasiswa Baru", "nim": "2141720099"}
+
+	env := newOIDCTestEnv(t, false)
+	env.identityRepo.On("FindByProviderSubject", "campus", "sub-789").Return(nil, nil)
+	env.userRepo.On("FindByUsernameOrEmail", mock.Anything).Return(nil, errors.New("not found"))
+	env.studentRepo.On("FindByNIM", mock.Anything).Return(nil, errors.New("not found"))
+
+	_, err := env.login(t, claims)
+	assert.ErrorIs(t, err, ErrOIDCUserNotLinked)
+
+	// JIT provisioning dengan role default
+	env = newOIDCTestEnv(t, true)
+	env.identityRepo.On("FindByProviderSubject", "campus", "sub-789").Return(nil, nil)
+	env.userRepo.On("FindByUsernameOrEmail", mock.Anything).Return(nil, errors.New("not found"))
+	env.studentRepo.On("FindByNIM", mock.Anything).Return(nil, errors.New("not found"))
+	env.roleRepo.On("FindByName", "Mahasiswa").Return(&model.Role{ID: "role-mhs", Name: "Mahasiswa"}, nil)
+
+	res, err := env.login(t, claims)
+	require.NoError(t, err)
+	assert.Equal(t, "2141720099", res.User.Username)
+	assert.Equal(t, "new@kampus.ac.id", res.User.Email)
+	assert.Equal(t, "Mahasiswa Baru", res.User.FullName)
+	assert.Equal(t, "Mahasiswa", res.User.Role.Name)
+	assert.True(t, res.User.IsActive)
+}
+
+func TestOIDCCallback_RejectsReplayedStateAndForeignSignature(t *testing.T) {
+	env := newOIDCTestEnv(t, false)
+	user := &model.User{ID: "user-1", Username: "budi", Email: "budi@kampus.ac.id", IsActive: true}
+	env.identityRepo.On("FindByProviderSubject", "campus", "sub-123").Return(&model.UserIdentity{ID: "id-1", UserID: "user-1"}, nil)
+	env.userRepo.On("FindByID", "user-1").Return(user, nil)
+
+	ctx := context.Background()
+	authURL, err := env.svc.AuthURL(ctx)
+	require.NoError(t, err)
+	state, code := env.idp.authorize(t, authURL, jwt.MapClaims{"sub": "sub-123"})
+
+	_, err = env.svc.Callback(ctx, OIDCCallbackInput{State: state, Code: code})
+	require.NoError(t, err)
+
+	// state sekali pakai
+	_, err = env.svc.Callback(ctx, OIDCCallbackInput{State: state, Code: code})
+	assert.ErrorIs(t, err, ErrOIDCStateInvalid)
+
+	// id_token yang tidak ditandatangani kunci di JWKS IdP
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	env.idp.signKey = other
+	_, err = env.login(t, jwt.MapClaims{"sub": "sub-123"})
+	assert.ErrorIs(t, err, ErrOIDCTokenInvalid)
+}
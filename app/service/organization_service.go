@@ -0,0 +1,335 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+)
+
+var (
+	ErrUnitInvalidInput     = errors.New("code and name are required")
+	ErrFacultyNotFound      = errors.New("faculty not found")
+	ErrDepartmentNotFound   = errors.New("department not found")
+	ErrStudyProgramNotFound = errors.New("study program not found")
+	ErrUnitInUse            = errors.New("unit still has child units, students or lecturers")
+	ErrScopeRequiresAdmin   = errors.New("unit scope can only be assigned to Admin users")
+	ErrScopeMismatch        = errors.New("department does not belong to the given faculty")
+	ErrOutOfScope           = errors.New("resource is outside your unit scope")
+)
+
+const adminRoleName = "Admin"
+
+// UnitInput: input fakultas / jurusan / prodi (ParentID = faculty_id / department_id)
+type UnitInput struct {
+	ParentID string
+	Code     string
+	Name     string
+}
+
+func (in UnitInput) normalized() UnitInput {
+	return UnitInput{
+		ParentID: strings.TrimSpace(in.ParentID),
+		Code:     strings.ToUpper(strings.TrimSpace(in.Code)),
+		Name:     strings.TrimSpace(in.Name),
+	}
+}
+
+// OrganizationService: master fakultas -> jurusan -> prodi + scope admin unit
+type OrganizationService struct {
+	orgRepo  repository.OrganizationRepository
+	userRepo repository.UserRepository
+	// cache sesi instance ini dibuang setelah scope admin berubah; boleh nil
+	sessions *UserLifecycleService
+}
+
+func NewOrganizationService(
+	orgRepo repository.OrganizationRepository,
+	userRepo repository.UserRepository,
+	sessions *UserLifecycleService,
+) *OrganizationService {
+	return &OrganizationService{
+		orgRepo:  orgRepo,
+		userRepo: userRepo,
+		sessions: sessions,
+	}
+}
+
+// === FACULTIES ===
+
+func (s *OrganizationService) GetFaculties() ([]model.Faculty, error) {
+	return s.orgRepo.FindAllFaculties()
+}
+
+func (s *OrganizationService) CreateFaculty(input UnitInput) (*model.Faculty, error) {
+	input = input.normalized()
+	if input.Code == "" || input.Name == "" {
+		return nil, ErrUnitInvalidInput
+	}
+
+	f := &model.Faculty{Code: input.Code, Name: input.Name}
+	if err := s.orgRepo.CreateFaculty(f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *OrganizationService) UpdateFaculty(id string, input UnitInput) (*model.Faculty, error) {
+	input = input.normalized()
+	if input.Code == "" || input.Name == "" {
+		return nil, ErrUnitInvalidInput
+	}
+
+	f, err := s.orgRepo.FindFacultyByID(id)
+	if err != nil {
+		return nil, ErrFacultyNotFound
+	}
+	f.Code = input.Code
+	f.Name = input.Name
+
+	if err := s.orgRepo.SaveFaculty(f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *OrganizationService) DeleteFaculty(id string) error {
+	if _, err := s.orgRepo.FindFacultyByID(id); err != nil {
+		return ErrFacultyNotFound
+	}
+
+	departments, err := s.orgRepo.FindDepartments(id)
+	if err != nil {
+		return err
+	}
+	if len(departments) > 0 {
+		return ErrUnitInUse
+	}
+	return s.orgRepo.DeleteFaculty(id)
+}
+
+// === DEPARTMENTS ===
+
+func (s *OrganizationService) GetDepartments(facultyID string) ([]model.Department, error) {
+	return s.orgRepo.FindDepartments(facultyID)
+}
+
+func (s *OrganizationService) CreateDepartment(input UnitInput) (*model.Department, error) {
+	input = input.normalized()
+	if input.Code == "" || input.Name == "" {
+		return nil, ErrUnitInvalidInput
+	}
+	if _, err := s.orgRepo.FindFacultyByID(input.ParentID); err != nil {
+		return nil, ErrFacultyNotFound
+	}
+
+	d := &model.Department{FacultyID: input.ParentID, Code: input.Code, Name: input.Name}
+	if err := s.orgRepo.CreateDepartment(d); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.FindDepartmentByID(d.ID)
+}
+
+func (s *OrganizationService) UpdateDepartment(id string, input UnitInput) (*model.Department, error) {
+	input = input.normalized()
+	if input.Code == "" || input.Name == "" {
+		return nil, ErrUnitInvalidInput
+	}
+
+	d, err := s.orgRepo.FindDepartmentByID(id)
+	if err != nil {
+		return nil, ErrDepartmentNotFound
+	}
+	if input.ParentID != "" && input.ParentID != d.FacultyID {
+		if _, err := s.orgRepo.FindFacultyByID(input.ParentID); err != nil {
+			return nil, ErrFacultyNotFound
+		}
+		d.FacultyID = input.ParentID
+	}
+	d.Code = input.Code
+	d.Name = input.Name
+
+	if err := s.orgRepo.SaveDepartment(d); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.FindDepartmentByID(d.ID)
+}
+
+func (s *OrganizationService) DeleteDepartment(id string) error {
+	if _, err := s.orgRepo.FindDepartmentByID(id); err != nil {
+		return ErrDepartmentNotFound
+	}
+
+	programs, err := s.orgRepo.FindStudyPrograms(id)
+	if err != nil {
+		return err
+	}
+	lecturers, err := s.orgRepo.CountLecturersByDepartment(id)
+	if err != nil {
+		return err
+	}
+	if len(programs) > 0 || lecturers > 0 {
+		return ErrUnitInUse
+	}
+	return s.orgRepo.DeleteDepartment(id)
+}
+
+// === STUDY PROGRAMS ===
+
+func (s *OrganizationService) GetStudyPrograms(departmentID string) ([]model.StudyProgram, error) {
+	return s.orgRepo.FindStudyPrograms(departmentID)
+}
+
+func (s *OrganizationService) CreateStudyProgram(input UnitInput) (*model.StudyProgram, error) {
+	input = input.normalized()
+	if input.Code == "" || input.Name == "" {
+		return nil, ErrUnitInvalidInput
+	}
+	if _, err := s.orgRepo.FindDepartmentByID(input.ParentID); err != nil {
+		return nil, ErrDepartmentNotFound
+	}
+
+	p := &model.StudyProgram{DepartmentID: input.ParentID, Code: input.Code, Name: input.Name}
+	if err := s.orgRepo.CreateStudyProgram(p); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.FindStudyProgramByID(p.ID)
+}
+
+func (s *OrganizationService) UpdateStudyProgram(id string, input UnitInput) (*model.StudyProgram, error) {
+	input = input.normalized()
+	if input.Code == "" || input.Name == "" {
+		return nil, ErrUnitInvalidInput
+	}
+
+	p, err := s.orgRepo.FindStudyProgramByID(id)
+	if err != nil {
+		return nil, ErrStudyProgramNotFound
+	}
+	if input.ParentID != "" && input.ParentID != p.DepartmentID {
+		if _, err := s.orgRepo.FindDepartmentByID(input.ParentID); err != nil {
+			return nil, ErrDepartmentNotFound
+		}
+		p.DepartmentID = input.ParentID
+	}
+	p.Code = input.Code
+	p.Name = input.Name
+
+	if err := s.orgRepo.SaveStudyProgram(p); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.FindStudyProgramByID(p.ID)
+}
+
+func (s *OrganizationService) DeleteStudyProgram(id string) error {
+	if _, err := s.orgRepo.FindStudyProgramByID(id); err != nil {
+		return ErrStudyProgramNotFound
+	}
+
+	students, err := s.orgRepo.CountStudentsByProgram(id)
+	if err != nil {
+		return err
+	}
+	if students > 0 {
+		return ErrUnitInUse
+	}
+	return s.orgRepo.DeleteStudyProgram(id)
+}
+
+// === ADMIN SCOPE ===
+
+// SetAdminScope: batasi admin ke fakultas / jurusan; keduanya kosong = admin global.
+// Scope masuk ke JWT, jadi berlaku setelah user login ulang / refresh token.
+func (s *OrganizationService) SetAdminScope(userID string, scope model.UnitScope) (*model.User, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if user.Role.Name != adminRoleName {
+		return nil, ErrScopeRequiresAdmin
+	}
+
+	var facultyID, departmentID *string
+	if scope.DepartmentID != "" {
+		d, err := s.orgRepo.FindDepartmentByID(scope.DepartmentID)
+		if err != nil {
+			return nil, ErrDepartmentNotFound
+		}
+		if scope.FacultyID != "" && scope.FacultyID != d.FacultyID {
+			return nil, ErrScopeMismatch
+		}
+		facultyID, departmentID = &d.FacultyID, &d.ID
+	} else if scope.FacultyID != "" {
+		f, err := s.orgRepo.FindFacultyByID(scope.FacultyID)
+		if err != nil {
+			return nil, ErrFacultyNotFound
+		}
+		facultyID = &f.ID
+	}
+
+	// scope ada di claim JWT: token lama dicabut supaya scope baru langsung berlaku
+	if err := s.userRepo.UpdateScope(user.ID, facultyID, departmentID, time.Now()); err != nil {
+		return nil, err
+	}
+	if s.sessions != nil {
+		s.sessions.ForgetSession(user.ID)
+	}
+	user.ScopeFacultyID = facultyID
+	user.ScopeDepartmentID = departmentID
+	return user, nil
+}
+
+// === TEKS LAMA ===
+
+// UnmappedUnits: teks students.program_study / lecturers.department yang tidak terpetakan
+// migrasi 0004 (typo, unit belum dibuat). Diperbaiki lewat update profil dengan ID unit.
+func (s *OrganizationService) UnmappedUnits() (*model.OrganizationUnmappedReport, error) {
+	programs, err := s.orgRepo.UnmappedStudentPrograms()
+	if err != nil {
+		return nil, err
+	}
+	departments, err := s.orgRepo.UnmappedLecturerDepartments()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.OrganizationUnmappedReport{
+		Programs:    append([]model.UnitValueCount{}, programs...),
+		Departments: append([]model.UnitValueCount{}, departments...),
+	}
+	return report, nil
+}
+
+// studyProgramIndex: nama & kode prodi (dinormalisasi) -> prodi, untuk memetakan teks program_study
+func studyProgramIndex(orgRepo repository.OrganizationRepository) (map[string]model.StudyProgram, error) {
+	programs, err := orgRepo.FindStudyPrograms("")
+	if err != nil {
+		return nil, err
+	}
+	index := map[string]model.StudyProgram{}
+	for _, p := range programs {
+		index[normalizeUnitText(p.Name)] = p
+		index[normalizeUnitText(p.Code)] = p
+	}
+	return index, nil
+}
+
+// departmentIndex: nama & kode jurusan (dinormalisasi) -> jurusan, untuk memetakan teks department
+func departmentIndex(orgRepo repository.OrganizationRepository) (map[string]model.Department, error) {
+	departments, err := orgRepo.FindDepartments("")
+	if err != nil {
+		return nil, err
+	}
+	index := map[string]model.Department{}
+	for _, d := range departments {
+		index[normalizeUnitText(d.Name)] = d
+		index[normalizeUnitText(d.Code)] = d
+	}
+	return index, nil
+}
+
+func normalizeUnitText(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateDepartment_FacultyNotFound(t *testing.T) {
+	orgRepo := new(mocks.OrganizationRepositoryMock)
+	svc := NewOrganizationService(orgRepo, new(mocks.UserRepositoryMock), nil)
+
+	orgRepo.On("FindFacultyByID", "fac-x").Return(nil, errors.New("not found"))
+
+	_, err := svc.CreateDepartment(UnitInput{ParentID: "fac-x", Code: "TI", Name: "Teknik Informatika"})
+
+	assert.ErrorIs(t, err, ErrFacultyNotFound)
+	orgRepo.AssertNotCalled(t, "CreateDepartment", mock.Anything)
+}
+
+func TestDeleteFaculty_InUse(t *testing.T) {
+	orgRepo := new(mocks.OrganizationRepositoryMock)
+	svc := NewOrganizationService(orgRepo, new(mocks.UserRepositoryMock), nil)
+
+	orgRepo.On("FindFacultyByID", "fac-1").Return(&model.Faculty{ID: "fac-1"}, nil)
+	orgRepo.On("FindDepartments", "fac-1").Return([]model.Department{{ID: "dep-1"}}, nil)
+
+	err := svc.DeleteFaculty("fac-1")
+
+	assert.ErrorIs(t, err, ErrUnitInUse)
+	orgRepo.AssertNotCalled(t, "DeleteFaculty", "fac-1")
+}
+
+func TestSetAdminScope_DepartmentDerivesFaculty(t *testing.T) {
+	orgRepo := new(mocks.OrganizationRepositoryMock)
+	userRepo := new(mocks.UserRepositoryMock)
+	svc := NewOrganizationService(orgRepo, userRepo, nil)
+
+	userRepo.On("FindByID", "user-1").Return(&model.User{ID: "user-1", Role: model.Role{Name: "Admin"}}, nil)
+	orgRepo.On("FindDepartmentByID", "dep-1").Return(&model.Department{ID: "dep-1", FacultyID: "fac-1"}, nil)
+	userRepo.On("UpdateScope", "user-1", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	user, err := svc.SetAdminScope("user-1", model.UnitScope{DepartmentID: "dep-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "fac-1", *user.ScopeFacultyID)
+	assert.Equal(t, "dep-1", *user.ScopeDepartmentID)
+	assert.Equal(t, model.UnitScope{FacultyID: "fac-1", DepartmentID: "dep-1"}, user.Scope())
+}
+
+func TestSetAdminScope_NonAdminRejected(t *testing.T) {
+	orgRepo := new(mocks.OrganizationRepositoryMock)
+	userRepo := new(mocks.UserRepositoryMock)
+	svc := NewOrganizationService(orgRepo, userRepo, nil)
+
+	userRepo.On("FindByID", "user-2").Return(&model.User{ID: "user-2", Role: model.Role{Name: "Mahasiswa"}}, nil)
+
+	_, err := svc.SetAdminScope("user-2", model.UnitScope{FacultyID: "fac-1"})
+
+	assert.ErrorIs(t, err, ErrScopeRequiresAdmin)
+	userRepo.AssertNotCalled(t, "UpdateScope", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStudyProgramIndex_MatchesNameAndCode(t *testing.T) {
+	orgRepo := new(mocks.OrganizationRepositoryMock)
+
+	orgRepo.On("FindStudyPrograms", "").Return([]model.StudyProgram{
+		{ID: "prog-1", Code: "D4TI", Name: "D4 Teknik Informatika"},
+	}, nil)
+
+	index, err := studyProgramIndex(orgRepo)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "prog-1", index[normalizeUnitText("d4  teknik  informatika ")].ID)
+	assert.Equal(t, "prog-1", index[normalizeUnitText("d4ti")].ID)
+	_, ok := index[normalizeUnitText("Teknik Informatik")]
+	assert.False(t, ok)
+}
+
+func TestUnmappedUnits_ReportsLegacyText(t *testing.T) {
+	orgRepo := new(mocks.OrganizationRepositoryMock)
+	svc := NewOrganizationService(orgRepo, new(mocks.UserRepositoryMock), nil)
+
+	orgRepo.On("UnmappedStudentPrograms").Return([]model.UnitValueCount{
+		{Value: "Teknik Informatik", Count: 2},
+	}, nil)
+	orgRepo.On("UnmappedLecturerDepartments").Return([]model.UnitValueCount(nil), nil)
+
+	report, err := svc.UnmappedUnits()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []model.UnitValueCount{{Value: "Teknik Informatik", Count: 2}}, report.Programs)
+	assert.NotNil(t, report.Departments)
+}
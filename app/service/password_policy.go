@@ -0,0 +1,78 @@
+package service
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// batas input bcrypt
+const maxPasswordBytes = 72
+
+var (
+	ErrPasswordTooShort         = errors.New("password is too short")
+	ErrPasswordTooLong          = errors.New("password must be at most 72 bytes")
+	ErrPasswordBreached         = errors.New("password is too common or appears in a breached password list")
+	ErrPasswordContainsIdentity = errors.New("password must not contain the username or email")
+)
+
+// PasswordPolicy: aturan password baru (panjang minimum + daftar password bocor)
+type PasswordPolicy struct {
+	minLength int
+	breached  map[string]struct{}
+}
+
+func NewPasswordPolicy(minLength int, breached []string) *PasswordPolicy {
+	set := make(map[string]struct{}, len(breached))
+	for _, p := range breached {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			set[p] = struct{}{}
+		}
+	}
+	return &PasswordPolicy{minLength: minLength, breached: set}
+}
+
+// LoadBreachedPasswords: satu password per baris, baris kosong / diawali # diabaikan
+func LoadBreachedPasswords(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var list []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		list = append(list, line)
+	}
+	return list, sc.Err()
+}
+
+// Validate: identities = username / email pemilik akun, tidak boleh jadi bagian password
+func (p *PasswordPolicy) Validate(password string, identities ...string) error {
+	if len([]rune(password)) < p.minLength {
+		return fmt.Errorf("%w: minimum %d characters", ErrPasswordTooShort, p.minLength)
+	}
+	if len(password) > maxPasswordBytes {
+		return ErrPasswordTooLong
+	}
+
+	lower := strings.ToLower(password)
+	if _, ok := p.breached[lower]; ok {
+		return ErrPasswordBreached
+	}
+	for _, id := range identities {
+		// bagian lokal email juga dicek
+		id, _, _ = strings.Cut(strings.ToLower(strings.TrimSpace(id)), "@")
+		if len(id) >= 3 && strings.Contains(lower, id) {
+			return ErrPasswordContainsIdentity
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/mailer"
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+var (
+	ErrCurrentPasswordInvalid = errors.New("current password is incorrect")
+	ErrPasswordUnchanged      = errors.New("new password must differ from the current password")
+	ErrResetTokenInvalid      = errors.New("reset token is invalid or expired")
+)
+
+// panjang token reset (byte), dikirim ke user dalam bentuk hex
+const resetTokenBytes = 32
+
+type PasswordService struct {
+	userRepo  repository.UserRepository
+	resetRepo repository.PasswordResetRepository
+	policy    *PasswordPolicy
+	mailer    mailer.Mailer
+	resetTTL  time.Duration
+	// URL halaman reset di frontend; token ditambahkan sebagai query ?token=
+	resetURL string
+	now      utils.Clock
+	// cache sesi instance ini dibuang setelah password berubah; boleh nil
+	sessions *UserLifecycleService
+	keys     *utils.KeyManager
+}
+
+func NewPasswordService(
+	userRepo repository.UserRepository,
+	resetRepo repository.PasswordResetRepository,
+	policy *PasswordPolicy,
+	mailer mailer.Mailer,
+	resetTTL time.Duration,
+	resetURL string,
+	now utils.Clock,
+	sessions *UserLifecycleService,
+	keys *utils.KeyManager,
+) *PasswordService {
+	return &PasswordService{
+		userRepo:  userRepo,
+		resetRepo: resetRepo,
+		policy:    policy,
+		mailer:    mailer,
+		resetTTL:  resetTTL,
+		resetURL:  resetURL,
+		now:       now,
+		sessions:  sessions,
+		keys:      keys,
+	}
+}
+
+// ChangePassword: ganti password sendiri; sesi lain dicabut, return token baru
+// (satu-satunya sesi yang berlaku, flag wajib ganti password sudah hilang)
+func (s *PasswordService) ChangePassword(userID, currentPassword, newPassword string) (string, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil || !user.IsActive {
+		return "", errors.New("user not found or inactive")
+	}
+
+	if !utils.CheckPassword(user.PasswordHash, currentPassword) {
+		return "", ErrCurrentPasswordInvalid
+	}
+	if currentPassword == newPassword {
+		return "", ErrPasswordUnchanged
+	}
+
+	if err := s.setPassword(user, newPassword); err != nil {
+		return "", err
+	}
+
+	perms, err := s.userRepo.GetPermissionsByUserID(user.ID)
+	if err != nil {
+		return "", err
+	}
+	return s.keys.GenerateToken(user, perms)
+}
+
+// RequestReset: kirim token reset ke email user.
+// Selalu sukses untuk email yang tidak terdaftar supaya tidak bisa dipakai menebak akun.
+func (s *PasswordService) RequestReset(ctx context.Context, email string) error {
+	email = strings.TrimSpace(email)
+	user, err := s.userRepo.FindByUsernameOrEmail(email)
+	if err != nil || !user.IsActive || !strings.EqualFold(user.Email, email) {
+		return nil
+	}
+
+	now := s.now()
+	// hanya token terakhir yang berlaku
+	if err := s.resetRepo.InvalidateByUserID(user.ID, now); err != nil {
+		return err
+	}
+
+	token, err := utils.RandomToken(resetTokenBytes)
+	if err != nil {
+		return err
+	}
+	if err := s.resetRepo.Create(&model.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: utils.SHA256Hex(token),
+		ExpiresAt: now.Add(s.resetTTL),
+	}); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"Halo %s,\n\nKami menerima permintaan reset password untuk akun %s.\n",
+		user.FullName, user.Username,
+	)
+	if s.resetURL != "" {
+		body += fmt.Sprintf("Buka tautan berikut untuk membuat password baru:\n%s?token=%s\n", s.resetURL, token)
+	} else {
+		body += fmt.Sprintf("Token reset password: %s\n", token)
+	}
+	body += fmt.Sprintf(
+		"\nToken berlaku %d menit dan hanya bisa dipakai sekali. Abaikan email ini jika Anda tidak memintanya.\n",
+		int(s.resetTTL.Minutes()),
+	)
+
+	if err := s.mailer.Send(ctx, mailer.Message{
+		To:      []string{user.Email},
+		Subject: "Reset password Sistem Prestasi",
+		Body:    body,
+	}); err != nil {
+		// tidak dikembalikan ke client, respons tetap sama untuk semua email
+		log.Printf("[PASSWORD] failed to send reset mail to user %s: %v", user.ID, err)
+	}
+	return nil
+}
+
+// ResetPassword: tukar token reset (sekali pakai) dengan password baru; semua sesi dicabut
+func (s *PasswordService) ResetPassword(token, newPassword string) error {
+	now := s.now()
+	reset, err := s.resetRepo.FindValidByHash(utils.SHA256Hex(strings.TrimSpace(token)), now)
+	if err != nil {
+		return ErrResetTokenInvalid
+	}
+
+	user, err := s.userRepo.FindByID(reset.UserID)
+	if err != nil || !user.IsActive {
+		return ErrResetTokenInvalid
+	}
+	// dicek sebelum token dipakai supaya token tidak hangus karena password lemah
+	if err := s.policy.Validate(newPassword, user.Username, user.Email); err != nil {
+		return err
+	}
+
+	consumed, err := s.resetRepo.Consume(reset.ID, now)
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return ErrResetTokenInvalid
+	}
+
+	if err := s.setPassword(user, newPassword); err != nil {
+		return err
+	}
+	return s.resetRepo.InvalidateByUserID(user.ID, now)
+}
+
+// SetPassword: password ditentukan admin (CLI reset-password); user wajib menggantinya saat login berikutnya
+func (s *PasswordService) SetPassword(userID, newPassword string) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return ErrAccountNotFound
+	}
+	if user.AnonymizedAt != nil {
+		return ErrUserAnonymized
+	}
+	if err := s.policy.Validate(newPassword, user.Username, user.Email); err != nil {
+		return err
+	}
+
+	hash, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := s.userRepo.UpdatePassword(user.ID, hash, true, s.now()); err != nil {
+		return err
+	}
+	s.forgetSession(user.ID)
+	return nil
+}
+
+func (s *PasswordService) setPassword(user *model.User, password string) error {
+	if err := s.policy.Validate(password, user.Username, user.Email); err != nil {
+		return err
+	}
+
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	if err := s.userRepo.UpdatePassword(user.ID, hash, false, now); err != nil {
+		return err
+	}
+	s.forgetSession(user.ID)
+
+	user.PasswordHash = hash
+	user.MustChangePassword = false
+	user.PasswordChangedAt = &now
+	user.SessionsRevokedAt = &now
+	return nil
+}
+
+func (s *PasswordService) forgetSession(userID string) {
+	if s.sessions != nil {
+		s.sessions.ForgetSession(userID)
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/nerhays/prestasi_uas/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testNow: jam tetap untuk PasswordService di test
+var testNow = time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func newTestPasswordService() (*PasswordService, *mocks.UserRepositoryMock, *mocks.PasswordResetRepositoryMock, *fakeMailer) {
+	userRepo := new(mocks.UserRepositoryMock)
+	resetRepo := new(mocks.PasswordResetRepositoryMock)
+	m := &fakeMailer{}
+	policy := NewPasswordPolicy(8, []string{"Password123", "admin123"})
+	svc := NewPasswordService(userRepo, resetRepo, policy, m, 30*time.Minute, "https://prestasi.test/reset", utils.FixedClock(testNow), nil, testKeys)
+	return svc, userRepo, resetRepo, m
+}
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "breached.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("# komentar\n\nsunshine1\nQWERTY123\n"), 0o644))
+
+	list, err := LoadBreachedPasswords(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sunshine1", "QWERTY123"}, list)
+
+	policy := NewPasswordPolicy(10, list)
+
+	assert.ErrorIs(t, policy.Validate("short"), ErrPasswordTooShort)
+	assert.ErrorIs(t, policy.Validate(strings.Repeat("a", 73)), ErrPasswordTooLong)
+	assert.ErrorIs(t, NewPasswordPolicy(8, list).Validate("qwerty123"), ErrPasswordBreached)
+	assert.ErrorIs(t, policy.Validate("budisantoso-2024", "budisantoso", "budi@kampus.ac.id"), ErrPasswordContainsIdentity)
+	assert.NoError(t, policy.Validate("kuda-lari-pagi-7", "budisantoso", "budi@kampus.ac.id"))
+}
+
+func TestChangePassword_ClearsForcedRotation(t *testing.T) {
+	svc, userRepo, _, _ := newTestPasswordService()
+
+	hash, _ := utils.HashPassword("InitialPass#1")
+	user := &model.User{
+		ID:                 "user-1",
+		Username:           "admin",
+		Email:              "admin@example.com",
+		PasswordHash:       hash,
+		IsActive:           true,
+		MustChangePassword: true,
+	}
+	userRepo.On("FindByID", "user-1").Return(user, nil)
+	userRepo.On("UpdatePassword", "user-1", mock.Anything, false, testNow).Return(nil)
+	userRepo.On("GetPermissionsByUserID", "user-1").Return([]model.Permission{}, nil)
+
+	token, err := svc.ChangePassword("user-1", "InitialPass#1", "n3w-Secure-pass")
+
+	assert.NoError(t, err)
+	claims, err := testKeys.ParseToken(token)
+	assert.NoError(t, err)
+	assert.False(t, claims.PasswordChangeRequired)
+	assert.False(t, user.MustChangePassword)
+	assert.True(t, utils.CheckPassword(user.PasswordHash, "n3w-Secure-pass"))
+	// sesi lain dicabut, token baru tetap berlaku
+	assert.Equal(t, testNow, *user.SessionsRevokedAt)
+	assert.True(t, user.SessionValid(claims.IssuedAt.Time))
+}
+
+func TestChangePassword_WrongCurrentAndBreached(t *testing.T) {
+	svc, userRepo, _, _ := newTestPasswordService()
+
+	hash, _ := utils.HashPassword("InitialPass#1")
+	userRepo.On("FindByID", "user-1").Return(&model.User{
+		ID:           "user-1",
+		Username:     "admin",
+		PasswordHash: hash,
+		IsActive:     true,
+	}, nil)
+
+	_, err := svc.ChangePassword("user-1", "wrong", "n3w-Secure-pass")
+	assert.ErrorIs(t, err, ErrCurrentPasswordInvalid)
+
+	_, err = svc.ChangePassword("user-1", "InitialPass#1", "PASSWORD123")
+	assert.ErrorIs(t, err, ErrPasswordBreached)
+
+	userRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRequestReset_UnknownEmailSendsNothing(t *testing.T) {
+	svc, userRepo, resetRepo, m := newTestPasswordService()
+
+	userRepo.On("FindByUsernameOrEmail", "nobody@example.com").Return(nil, assert.AnError)
+
+	err := svc.RequestReset(context.Background(), "nobody@example.com")
+
+	assert.NoError(t, err)
+	assert.Empty(t, m.sent)
+	resetRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestRequestReset_StoresHashAndMailsToken(t *testing.T) {
+	svc, userRepo, resetRepo, m := newTestPasswordService()
+
+	userRepo.On("FindByUsernameOrEmail", "budi@example.com").Return(&model.User{
+		ID:       "user-1",
+		Username: "budi",
+		Email:    "budi@example.com",
+		IsActive: true,
+	}, nil)
+	resetRepo.On("InvalidateByUserID", "user-1", testNow).Return(nil)
+
+	var stored *model.PasswordResetToken
+	resetRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*model.PasswordResetToken)
+	}).Return(nil)
+
+	err := svc.RequestReset(context.Background(), "budi@example.com")
+
+	assert.NoError(t, err)
+	assert.Len(t, m.sent, 1)
+	assert.Equal(t, []string{"budi@example.com"}, m.sent[0].To)
+
+	_, token, found := strings.Cut(m.sent[0].Body, "https://prestasi.test/reset?token=")
+	assert.True(t, found)
+	token = strings.Fields(token)[0]
+	assert.Equal(t, utils.SHA256Hex(token), stored.TokenHash)
+	assert.NotContains(t, stored.TokenHash, token)
+	assert.Equal(t, testNow.Add(30*time.Minute), stored.ExpiresAt)
+}
+
+func TestResetPassword_SingleUse(t *testing.T) {
+	svc, userRepo, resetRepo, _ := newTestPasswordService()
+
+	reset := &model.PasswordResetToken{ID: "reset-1", UserID: "user-1"}
+	resetRepo.On("FindValidByHash", utils.SHA256Hex("tok"), mock.Anything).Return(reset, nil)
+	userRepo.On("FindByID", "user-1").Return(&model.User{ID: "user-1", Username: "budi", IsActive: true}, nil)
+	// permintaan lain sudah memakai token lebih dulu
+	resetRepo.On("Consume", "reset-1", mock.Anything).Return(false, nil)
+
+	err := svc.ResetPassword("tok", "n3w-Secure-pass")
+
+	assert.ErrorIs(t, err, ErrResetTokenInvalid)
+	userRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestResetPassword_Success(t *testing.T) {
+	svc, userRepo, resetRepo, _ := newTestPasswordService()
+
+	reset := &model.PasswordResetToken{ID: "reset-1", UserID: "user-1"}
+	resetRepo.On("FindValidByHash", utils.SHA256Hex("tok"), mock.Anything).Return(reset, nil)
+	userRepo.On("FindByID", "user-1").Return(&model.User{ID: "user-1", Username: "budi", IsActive: true, MustChangePassword: true}, nil)
+	resetRepo.On("Consume", "reset-1", mock.Anything).Return(true, nil)
+	userRepo.On("UpdatePassword", "user-1", mock.Anything, false, testNow).Return(nil)
+	resetRepo.On("InvalidateByUserID", "user-1", mock.Anything).Return(nil)
+
+	err := svc.ResetPassword(" tok ", "n3w-Secure-pass")
+
+	assert.NoError(t, err)
+	userRepo.AssertExpectations(t)
+	resetRepo.AssertExpectations(t)
+}
+
+func TestSetPassword_ForcesRotationAndAppliesPolicy(t *testing.T) {
+	svc, userRepo, _, _ := newTestPasswordService()
+
+	userRepo.On("FindByID", "user-1").Return(&model.User{ID: "user-1", Username: "budi", Email: "budi@example.com"}, nil)
+	userRepo.On("UpdatePassword", "user-1", mock.Anything, true, testNow).Return(nil)
+
+	assert.ErrorIs(t, svc.SetPassword("user-1", "admin123"), ErrPasswordBreached)
+	userRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	assert.NoError(t, svc.SetPassword("user-1", "kuda-lari-pagi-7"))
+	userRepo.AssertCalled(t, "UpdatePassword", "user-1", mock.Anything, true, testNow)
+}
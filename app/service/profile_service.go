@@ -0,0 +1,383 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+var (
+	ErrProfileInvalidInput = errors.New("username, email, full_name and profile number are required")
+	ErrInvalidEmail        = errors.New("invalid email address")
+	ErrPasswordRequired    = errors.New("password is required")
+	ErrRoleNotFound        = errors.New("role not found")
+	ErrRoleProfileMismatch = errors.New("role does not match profile type")
+	ErrUsernameTaken       = errors.New("username already used")
+	ErrEmailTaken          = errors.New("email already used")
+	ErrStudentIDTaken      = errors.New("student_id already used")
+	ErrLecturerIDTaken     = errors.New("lecturer_id already used")
+)
+
+// role yang wajib dipakai untuk tiap jenis profil
+const (
+	studentRoleName  = "Mahasiswa"
+	lecturerRoleName = "Dosen Wali"
+)
+
+type ProfileAccountInput struct {
+	Username string
+	Email    string
+	Password string
+	FullName string
+	RoleID   string // opsional, default sesuai jenis profil
+}
+
+type StudentProfileInput struct {
+	ProfileAccountInput
+	StudentID    string
+	ProgramStudy string
+	AcademicYear string
+	AdvisorID    string // lecturers.id
+	// StudyProgramID: jika diisi, ProgramStudy diambil dari nama prodi
+	StudyProgramID string
+}
+
+type LecturerProfileInput struct {
+	ProfileAccountInput
+	LecturerID string
+	Department string
+	// DepartmentID: jika diisi, Department diambil dari nama jurusan
+	DepartmentID string
+}
+
+// ProfileService: CRUD profil mahasiswa / dosen sekaligus akun user-nya
+type ProfileService struct {
+	userRepo     repository.UserRepository
+	roleRepo     repository.RoleRepository
+	studentRepo  repository.StudentRepository
+	lecturerRepo repository.LecturerRepository
+	profileRepo  repository.ProfileRepository
+	orgRepo      repository.OrganizationRepository
+	policy       *PasswordPolicy
+	lifecycle    *UserLifecycleService
+	advisorSvc   *AdvisorAssignmentService
+}
+
+func NewProfileService(
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	studentRepo repository.StudentRepository,
+	lecturerRepo repository.LecturerRepository,
+	profileRepo repository.ProfileRepository,
+	orgRepo repository.OrganizationRepository,
+	policy *PasswordPolicy,
+	lifecycle *UserLifecycleService,
+	advisorSvc *AdvisorAssignmentService,
+) *ProfileService {
+	return &ProfileService{
+		userRepo:     userRepo,
+		roleRepo:     roleRepo,
+		studentRepo:  studentRepo,
+		lecturerRepo: lecturerRepo,
+		profileRepo:  profileRepo,
+		orgRepo:      orgRepo,
+		policy:       policy,
+		lifecycle:    lifecycle,
+		advisorSvc:   advisorSvc,
+	}
+}
+
+func (s *ProfileService) CreateStudent(ctx context.Context, actorID string, input StudentProfileInput) (*model.Student, error) {
+	if input.StudentID == "" {
+		return nil, ErrProfileInvalidInput
+	}
+
+	user := &model.User{IsActive: true}
+	if err := s.fillAccount(user, input.ProfileAccountInput, studentRoleName); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.studentRepo.FindByNIM(input.StudentID); err == nil {
+		return nil, ErrStudentIDTaken
+	}
+
+	student := &model.Student{
+		StudentID:    input.StudentID,
+		ProgramStudy: input.ProgramStudy,
+		AcademicYear: input.AcademicYear,
+	}
+	if err := s.checkAdvisor(input.AdvisorID); err != nil {
+		return nil, err
+	}
+	if err := s.setStudyProgram(student, input.StudyProgramID); err != nil {
+		return nil, err
+	}
+
+	if err := s.profileRepo.SaveStudentAccount(user, student); err != nil {
+		return nil, err
+	}
+	student.User = *user
+	if err := s.assignAdvisor(ctx, actorID, student, input.AdvisorID, "penugasan awal"); err != nil {
+		return nil, err
+	}
+
+	return student, nil
+}
+
+func (s *ProfileService) UpdateStudent(ctx context.Context, actorID, id string, input StudentProfileInput) (*model.Student, error) {
+	student, err := s.studentRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrStudentProfileNotFound
+	}
+	if input.StudentID == "" {
+		return nil, ErrProfileInvalidInput
+	}
+
+	if err := s.fillAccount(&student.User, input.ProfileAccountInput, studentRoleName); err != nil {
+		return nil, err
+	}
+
+	if other, err := s.studentRepo.FindByNIM(input.StudentID); err == nil && other.ID != student.ID {
+		return nil, ErrStudentIDTaken
+	}
+
+	student.StudentID = input.StudentID
+	student.AcademicYear = input.AcademicYear
+	if input.AdvisorID != student.AdvisorID {
+		if err := s.checkAdvisor(input.AdvisorID); err != nil {
+			return nil, err
+		}
+	}
+	if input.StudyProgramID != "" {
+		if err := s.setStudyProgram(student, input.StudyProgramID); err != nil {
+			return nil, err
+		}
+	} else if input.ProgramStudy != student.ProgramStudy {
+		// teks prodi diganti manual -> FK lama tidak berlaku, dipetakan ulang dari nama / kode prodi
+		programs, err := studyProgramIndex(s.orgRepo)
+		if err != nil {
+			return nil, err
+		}
+		student.ProgramStudy = input.ProgramStudy
+		student.StudyProgramID = nil
+		student.StudyProgram = nil
+		if p, ok := programs[normalizeUnitText(input.ProgramStudy)]; ok {
+			student.StudyProgramID = &p.ID
+		}
+	}
+
+	if err := s.profileRepo.SaveStudentAccount(&student.User, student); err != nil {
+		return nil, err
+	}
+	if err := s.assignAdvisor(ctx, actorID, student, input.AdvisorID, "perubahan data mahasiswa"); err != nil {
+		return nil, err
+	}
+	return student, nil
+}
+
+// DeactivateStudent: data prestasi tetap ada, user tidak bisa login lagi (sama dengan nonaktif user)
+func (s *ProfileService) DeactivateStudent(ctx context.Context, actorID, id string) (*DeactivationResult, error) {
+	student, err := s.studentRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrStudentProfileNotFound
+	}
+	return s.lifecycle.Deactivate(ctx, actorID, student.UserID, "")
+}
+
+func (s *ProfileService) CreateLecturer(input LecturerProfileInput) (*model.Lecturer, error) {
+	if input.LecturerID == "" {
+		return nil, ErrProfileInvalidInput
+	}
+
+	user := &model.User{IsActive: true}
+	if err := s.fillAccount(user, input.ProfileAccountInput, lecturerRoleName); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.lecturerRepo.FindByNIDN(input.LecturerID); err == nil {
+		return nil, ErrLecturerIDTaken
+	}
+
+	lecturer := &model.Lecturer{
+		LecturerID: input.LecturerID,
+		Department: input.Department,
+	}
+	if err := s.setDepartment(lecturer, input.DepartmentID); err != nil {
+		return nil, err
+	}
+
+	if err := s.profileRepo.SaveLecturerAccount(user, lecturer); err != nil {
+		return nil, err
+	}
+	lecturer.User = *user
+
+	return lecturer, nil
+}
+
+func (s *ProfileService) UpdateLecturer(id string, input LecturerProfileInput) (*model.Lecturer, error) {
+	lecturer, err := s.lecturerRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrLecturerNotFound
+	}
+	if input.LecturerID == "" {
+		return nil, ErrProfileInvalidInput
+	}
+
+	if err := s.fillAccount(&lecturer.User, input.ProfileAccountInput, lecturerRoleName); err != nil {
+		return nil, err
+	}
+
+	if other, err := s.lecturerRepo.FindByNIDN(input.LecturerID); err == nil && other.ID != lecturer.ID {
+		return nil, ErrLecturerIDTaken
+	}
+
+	lecturer.LecturerID = input.LecturerID
+	if input.DepartmentID != "" {
+		if err := s.setDepartment(lecturer, input.DepartmentID); err != nil {
+			return nil, err
+		}
+	} else if input.Department != lecturer.Department {
+		lecturer.Department = input.Department
+		lecturer.DepartmentID = nil
+		lecturer.DepartmentUnit = nil
+	}
+
+	if err := s.profileRepo.SaveLecturerAccount(&lecturer.User, lecturer); err != nil {
+		return nil, err
+	}
+	return lecturer, nil
+}
+
+// DeactivateLecturer: sama dengan nonaktif user; mahasiswa bimbingan dipindah ke replacementLecturerID
+func (s *ProfileService) DeactivateLecturer(ctx context.Context, actorID, id, replacementLecturerID string) (*DeactivationResult, error) {
+	lecturer, err := s.lecturerRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrLecturerNotFound
+	}
+	return s.lifecycle.Deactivate(ctx, actorID, lecturer.UserID, replacementLecturerID)
+}
+
+// fillAccount: validasi + isi field user; user.ID kosong berarti akun baru
+func (s *ProfileService) fillAccount(user *model.User, input ProfileAccountInput, roleName string) error {
+	if input.Username == "" || input.Email == "" || input.FullName == "" {
+		return ErrProfileInvalidInput
+	}
+	if _, err := mail.ParseAddress(input.Email); err != nil {
+		return ErrInvalidEmail
+	}
+
+	// cek role sesuai jenis profil
+	var role *model.Role
+	var err error
+	switch {
+	case input.RoleID != "":
+		role, err = s.roleRepo.FindByID(input.RoleID)
+	case user.RoleID != "":
+		role, err = s.roleRepo.FindByID(user.RoleID)
+	default:
+		role, err = s.roleRepo.FindByName(roleName)
+	}
+	if err != nil {
+		return ErrRoleNotFound
+	}
+	if role.Name != roleName {
+		return ErrRoleProfileMismatch
+	}
+
+	if u, err := s.userRepo.FindByUsernameOrEmail(input.Username); err == nil && u.ID != user.ID {
+		return ErrUsernameTaken
+	}
+	if u, err := s.userRepo.FindByUsernameOrEmail(input.Email); err == nil && u.ID != user.ID {
+		return ErrEmailTaken
+	}
+
+	if user.ID == "" && input.Password == "" {
+		return ErrPasswordRequired
+	}
+	// password ditentukan admin → wajib diganti saat login berikutnya, sesi lama dicabut
+	if input.Password != "" {
+		if err := s.policy.Validate(input.Password, input.Username, input.Email); err != nil {
+			return err
+		}
+		hash, err := utils.HashPassword(input.Password)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		user.PasswordHash = hash
+		user.MustChangePassword = true
+		user.PasswordChangedAt = &now
+		if user.ID != "" {
+			user.SessionsRevokedAt = &now
+		}
+	}
+
+	user.Username = input.Username
+	user.Email = input.Email
+	user.FullName = input.FullName
+	user.RoleID = role.ID
+	user.Role = *role
+
+	return nil
+}
+
+// checkAdvisor: dicek sebelum akun disimpan supaya dosen wali yang tidak valid tidak meninggalkan profil setengah jadi
+func (s *ProfileService) checkAdvisor(lecturerID string) error {
+	if lecturerID == "" {
+		return nil
+	}
+	_, err := s.advisorSvc.activeAdvisor(lecturerID)
+	return err
+}
+
+// assignAdvisor: penetapan / pergantian dosen wali selalu lewat Reassign (riwayat, serah terima prestasi, notifikasi)
+func (s *ProfileService) assignAdvisor(ctx context.Context, actorID string, student *model.Student, lecturerID, reason string) error {
+	if lecturerID == "" || lecturerID == student.AdvisorID {
+		return nil
+	}
+	if _, err := s.advisorSvc.Reassign(ctx, ReassignAdvisorInput{
+		ToLecturerID: lecturerID,
+		StudentIDs:   []string{student.ID},
+		Reason:       reason,
+		ActorID:      actorID,
+	}); err != nil {
+		return err
+	}
+	student.AdvisorID = lecturerID
+	return nil
+}
+
+func (s *ProfileService) setStudyProgram(student *model.Student, programID string) error {
+	if programID == "" {
+		return nil
+	}
+
+	p, err := s.orgRepo.FindStudyProgramByID(programID)
+	if err != nil {
+		return ErrStudyProgramNotFound
+	}
+
+	student.StudyProgramID = &p.ID
+	student.ProgramStudy = p.Name
+	return nil
+}
+
+func (s *ProfileService) setDepartment(lecturer *model.Lecturer, departmentID string) error {
+	if departmentID == "" {
+		return nil
+	}
+
+	d, err := s.orgRepo.FindDepartmentByID(departmentID)
+	if err != nil {
+		return ErrDepartmentNotFound
+	}
+
+	lecturer.DepartmentID = &d.ID
+	lecturer.Department = d.Name
+	return nil
+}
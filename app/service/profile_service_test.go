@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateStudent_Success(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	roleRepo := new(mocks.RoleRepositoryMock)
+	studentRepo := new(mocks.StudentRepositoryMock)
+	lectRepo := new(mocks.LecturerRepositoryMock)
+	profileRepo := new(mocks.ProfileRepositoryMock)
+	assigns := new(mocks.AdvisorAssignmentRepositoryMock)
+	refRepo := new(mocks.AchievementReferenceRepositoryMock)
+
+	advisorSvc := NewAdvisorAssignmentService(assigns, new(mocks.VerificationDelegationRepositoryMock), studentRepo, lectRepo, refRepo, nil, nil)
+	svc := NewProfileService(userRepo, roleRepo, studentRepo, lectRepo, profileRepo, new(mocks.OrganizationRepositoryMock), NewPasswordPolicy(8, nil), nil, advisorSvc)
+
+	notFound := errors.New("not found")
+	roleRepo.On("FindByName", "Mahasiswa").Return(&model.Role{ID: "role-mhs", Name: "Mahasiswa"}, nil)
+	userRepo.On("FindByUsernameOrEmail", mock.Anything).Return(nil, notFound)
+	studentRepo.On("FindByNIM", "2201").Return(nil, notFound)
+	lectRepo.On("FindByID", "lect-1").Return(&model.Lecturer{
+		ID:   "lect-1",
+		User: model.User{IsActive: true, Role: model.Role{Name: "Dosen Wali"}},
+	}, nil)
+	profileRepo.On("SaveStudentAccount", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { args.Get(1).(*model.Student).ID = "student-1" }).
+		Return(nil)
+	studentRepo.On("FindByID", "student-1").Return(&model.Student{ID: "student-1"}, nil)
+	refRepo.On("FindByStudentID", "student-1").Return([]model.AchievementReference{}, nil)
+	// dosen wali awal tercatat di riwayat, bukan diisi langsung ke students.advisor_id
+	assigns.On("Reassign", mock.MatchedBy(func(rows []model.AdvisorAssignment) bool {
+		return len(rows) == 1 && rows[0].StudentID == "student-1" && rows[0].LecturerID == "lect-1" && *rows[0].AssignedBy == "admin-1"
+	})).Return(nil)
+
+	student, err := svc.CreateStudent(context.Background(), "admin-1", StudentProfileInput{
+		ProfileAccountInput: ProfileAccountInput{
+			Username: "budi",
+			Email:    "budi@mail.com",
+			Password: "secret123",
+			FullName: "Budi",
+		},
+		StudentID:    "2201",
+		ProgramStudy: "Informatika",
+		AdvisorID:    "lect-1",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2201", student.StudentID)
+	assert.Equal(t, "lect-1", student.AdvisorID)
+	assert.Equal(t, "role-mhs", student.User.RoleID)
+	assert.NotEmpty(t, student.User.PasswordHash)
+	assert.True(t, student.User.MustChangePassword, "password dari admin wajib diganti")
+	profileRepo.AssertExpectations(t)
+	assigns.AssertExpectations(t)
+}
+
+func TestUpdateStudent_InactiveAdvisorRejected(t *testing.T) {
+	studentRepo := new(mocks.StudentRepositoryMock)
+	lectRepo := new(mocks.LecturerRepositoryMock)
+	roleRepo := new(mocks.RoleRepositoryMock)
+	userRepo := new(mocks.UserRepositoryMock)
+	profileRepo := new(mocks.ProfileRepositoryMock)
+	assigns := new(mocks.AdvisorAssignmentRepositoryMock)
+
+	advisorSvc := NewAdvisorAssignmentService(assigns, new(mocks.VerificationDelegationRepositoryMock), studentRepo, lectRepo, new(mocks.AchievementReferenceRepositoryMock), nil, nil)
+	svc := NewProfileService(userRepo, roleRepo, studentRepo, lectRepo, profileRepo, new(mocks.OrganizationRepositoryMock), NewPasswordPolicy(8, nil), nil, advisorSvc)
+
+	studentRepo.On("FindByID", "student-1").Return(&model.Student{
+		ID: "student-1", StudentID: "2201", AdvisorID: "lect-1",
+		User: model.User{ID: "user-1", RoleID: "role-mhs"},
+	}, nil)
+	studentRepo.On("FindByNIM", "2201").Return(&model.Student{ID: "student-1"}, nil)
+	roleRepo.On("FindByID", "role-mhs").Return(&model.Role{ID: "role-mhs", Name: "Mahasiswa"}, nil)
+	userRepo.On("FindByUsernameOrEmail", mock.Anything).Return(&model.User{ID: "user-1"}, nil)
+	lectRepo.On("FindByID", "lect-2").Return(&model.Lecturer{
+		ID:   "lect-2",
+		User: model.User{IsActive: false, Role: model.Role{Name: "Dosen Wali"}},
+	}, nil)
+
+	_, err := svc.UpdateStudent(context.Background(), "admin-1", "student-1", StudentProfileInput{
+		ProfileAccountInput: ProfileAccountInput{Username: "budi", Email: "budi@mail.com", FullName: "Budi"},
+		StudentID:           "2201",
+		AdvisorID:           "lect-2",
+	})
+
+	assert.ErrorIs(t, err, ErrAdvisorInactive)
+	profileRepo.AssertNotCalled(t, "SaveStudentAccount", mock.Anything, mock.Anything)
+	assigns.AssertNotCalled(t, "Reassign", mock.Anything)
+}
+
+func TestCreateStudent_WeakPassword(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	roleRepo := new(mocks.RoleRepositoryMock)
+	profileRepo := new(mocks.ProfileRepositoryMock)
+
+	svc := NewProfileService(userRepo, roleRepo, new(mocks.StudentRepositoryMock), new(mocks.LecturerRepositoryMock), profileRepo, new(mocks.OrganizationRepositoryMock), NewPasswordPolicy(8, nil), nil, nil)
+
+	roleRepo.On("FindByName", "Mahasiswa").Return(&model.Role{ID: "role-mhs", Name: "Mahasiswa"}, nil)
+	userRepo.On("FindByUsernameOrEmail", mock.Anything).Return(nil, errors.New("not found"))
+
+	_, err := svc.CreateStudent(context.Background(), "admin-1", StudentProfileInput{
+		ProfileAccountInput: ProfileAccountInput{
+			Username: "budi",
+			Email:    "budi@mail.com",
+			Password: "budi1234",
+			FullName: "Budi",
+		},
+		StudentID: "2201",
+	})
+
+	assert.ErrorIs(t, err, ErrPasswordContainsIdentity)
+	profileRepo.AssertNotCalled(t, "SaveStudentAccount", mock.Anything, mock.Anything)
+}
+
+func TestCreateStudent_RoleMismatch(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	roleRepo := new(mocks.RoleRepositoryMock)
+	studentRepo := new(mocks.StudentRepositoryMock)
+	lectRepo := new(mocks.LecturerRepositoryMock)
+	profileRepo := new(mocks.ProfileRepositoryMock)
+
+	svc := NewProfileService(userRepo, roleRepo, studentRepo, lectRepo, profileRepo, new(mocks.OrganizationRepositoryMock), NewPasswordPolicy(8, nil), nil, nil)
+
+	roleRepo.On("FindByID", "role-dosen").Return(&model.Role{ID: "role-dosen", Name: "Dosen Wali"}, nil)
+
+	_, err := svc.CreateStudent(context.Background(), "admin-1", StudentProfileInput{
+		ProfileAccountInput: ProfileAccountInput{
+			Username: "budi",
+			Email:    "budi@mail.com",
+			Password: "secret123",
+			FullName: "Budi",
+			RoleID:   "role-dosen",
+		},
+		StudentID: "2201",
+	})
+
+	assert.ErrorIs(t, err, ErrRoleProfileMismatch)
+	profileRepo.AssertNotCalled(t, "SaveStudentAccount", mock.Anything, mock.Anything)
+}
+
+func TestUpdateLecturer_DuplicateNIDN(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	roleRepo := new(mocks.RoleRepositoryMock)
+	studentRepo := new(mocks.StudentRepositoryMock)
+	lectRepo := new(mocks.LecturerRepositoryMock)
+	profileRepo := new(mocks.ProfileRepositoryMock)
+
+	svc := NewProfileService(userRepo, roleRepo, studentRepo, lectRepo, profileRepo, new(mocks.OrganizationRepositoryMock), NewPasswordPolicy(8, nil), nil, nil)
+
+	lect := &model.Lecturer{
+		ID:     "lect-1",
+		UserID: "user-1",
+		User:   model.User{ID: "user-1", RoleID: "role-dosen"},
+	}
+	lectRepo.On("FindByID", "lect-1").Return(lect, nil)
+	lectRepo.On("FindByNIDN", "D02").Return(&model.Lecturer{ID: "lect-2"}, nil)
+	roleRepo.On("FindByID", "role-dosen").Return(&model.Role{ID: "role-dosen", Name: "Dosen Wali"}, nil)
+	userRepo.On("FindByUsernameOrEmail", mock.Anything).Return(&model.User{ID: "user-1"}, nil)
+
+	_, err := svc.UpdateLecturer("lect-1", LecturerProfileInput{
+		ProfileAccountInput: ProfileAccountInput{
+			Username: "sari",
+			Email:    "sari@mail.com",
+			FullName: "Sari",
+		},
+		LecturerID: "D02",
+	})
+
+	assert.ErrorIs(t, err, ErrLecturerIDTaken)
+}
+
+func TestDeactivateStudent_GoesThroughLifecycle(t *testing.T) {
+	lifecycle, m := newLifecycleTestService()
+	svc := NewProfileService(m.users, new(mocks.RoleRepositoryMock), m.students, m.lecturers, m.profiles, new(mocks.OrganizationRepositoryMock), NewPasswordPolicy(8, nil), lifecycle, nil)
+
+	m.students.On("FindByID", "student-1").Return(&model.Student{ID: "student-1", UserID: "user-1"}, nil)
+	m.users.On("FindByID", "user-1").Return(&model.User{ID: "user-1", IsActive: true}, nil)
+	m.lecturers.On("FindByUserID", "user-1").Return((*model.Lecturer)(nil), errors.New("not found"))
+	m.users.On("SetActive", "user-1", false, mock.Anything).Return(nil)
+	m.tokens.On("FindByUserID", "user-1").Return([]model.APIToken{{ID: "tok-1", ExpiresAt: time.Now().Add(time.Hour)}}, nil)
+	m.tokens.On("Revoke", "tok-1", mock.Anything).Return(true, nil)
+
+	result, err := svc.DeactivateStudent(context.Background(), "admin-1", "student-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.RevokedAPITokens)
+	m.users.AssertExpectations(t)
+	m.profiles.AssertNotCalled(t, "SaveStudentAccount", mock.Anything, mock.Anything)
+}
+
+func TestDeactivateLecturer_AdviseesNeedReplacement(t *testing.T) {
+	lifecycle, m := newLifecycleTestService()
+	svc := NewProfileService(m.users, new(mocks.RoleRepositoryMock), m.students, m.lecturers, m.profiles, new(mocks.OrganizationRepositoryMock), NewPasswordPolicy(8, nil), lifecycle, nil)
+
+	m.lecturers.On("FindByID", "lec-1").Return(&model.Lecturer{ID: "lec-1", UserID: "user-l1"}, nil)
+	m.users.On("FindByID", "user-l1").Return(&model.User{ID: "user-l1", IsActive: true}, nil)
+	m.lecturers.On("FindByUserID", "user-l1").Return(&model.Lecturer{ID: "lec-1", UserID: "user-l1"}, nil)
+	m.students.On("FindByAdvisorID", "lec-1").Return([]model.Student{{ID: "s1"}}, nil)
+
+	_, err := svc.DeactivateLecturer(context.Background(), "admin-1", "lec-1", "")
+
+	assert.ErrorIs(t, err, ErrAdvisorReplacementRequired)
+	m.users.AssertNotCalled(t, "SetActive", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"errors"
+
 	"github.com/nerhays/prestasi_uas/app/model"
 	"github.com/nerhays/prestasi_uas/app/repository"
 )
@@ -16,3 +18,16 @@ func NewRoleService(roleRepo repository.RoleRepository) *RoleService {
 func (s *RoleService) GetAllRoles() ([]model.Role, error) {
 	return s.roleRepo.FindAll()
 }
+
+// SetTwoFactorRequired: wajibkan / bebaskan 2FA untuk semua user dengan role ini
+func (s *RoleService) SetTwoFactorRequired(roleID string, required bool) (*model.Role, error) {
+	role, err := s.roleRepo.FindByID(roleID)
+	if err != nil {
+		return nil, errors.New("role not found")
+	}
+	if err := s.roleRepo.UpdateRequireTwoFactor(roleID, required); err != nil {
+		return nil, err
+	}
+	role.RequireTwoFactor = required
+	return role, nil
+}
@@ -1,9 +1,6 @@
 package service
 
 import (
-	"context"
-	"errors"
-
 	"github.com/nerhays/prestasi_uas/app/model"
 	"github.com/nerhays/prestasi_uas/app/repository"
 )
@@ -26,38 +23,21 @@ func NewStudentService(
 func (s *StudentService) GetProfileByUserID(userID string) (*model.Student, error) {
 	return s.studentRepo.FindByUserID(userID)
 }
-func (s *StudentService) SetAdvisor(
-	ctx context.Context,
-	studentID string,
-	lecturerID string,
-) error {
-
-	// 1. cek student
-	student, err := s.studentRepo.FindByID(studentID)
-	if err != nil {
-		return ErrStudentProfileNotFound
-	}
 
-	// 2. cek lecturer
-	lect, err := s.lecturerRepo.FindByID(lecturerID)
+// GetAllStudents: scope kosong = semua mahasiswa (admin global)
+func (s *StudentService) GetAllStudents(scope model.UnitScope) ([]model.Student, error) {
+	return s.studentRepo.FindAllInScope(scope)
+}
+
+func (s *StudentService) GetStudentByID(id string, scope model.UnitScope) (*model.Student, error) {
+	student, err := s.studentRepo.FindByID(id)
 	if err != nil {
-		return ErrLecturerNotFound
+		return nil, ErrStudentProfileNotFound
 	}
-
-	// 3. pastikan role = Dosen Wali
-	if lect.User.Role.Name != "Dosen Wali" {
-		return errors.New("selected user is not a dosen wali")
+	if !scope.CoversStudent(student) {
+		return nil, ErrOutOfScope
 	}
-
-	// 4. update advisor
-	return s.studentRepo.UpdateAdvisor(student.ID, lect.ID)
-}
-func (s *StudentService) GetAllStudents() ([]model.Student, error) {
-	return s.studentRepo.FindAll()
-}
-
-func (s *StudentService) GetStudentByID(id string) (*model.Student, error) {
-	return s.studentRepo.FindByID(id)
+	return student, nil
 }
 
 
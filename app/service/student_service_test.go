@@ -1,7 +1,6 @@
 package service
 
 import (
-	"context"
 	"testing"
 
 	"github.com/nerhays/prestasi_uas/app/model"
@@ -26,47 +25,25 @@ func TestGetProfileByUserID_Success(t *testing.T) {
 	assert.Equal(t, "student-1", res.ID)
 }
 
-func TestSetAdvisor_Success(t *testing.T) {
+func TestGetStudentByID_OutOfScope(t *testing.T) {
 	studentRepo := new(mocks.StudentRepositoryMock)
 	lectRepo := new(mocks.LecturerRepositoryMock)
 
 	svc := NewStudentService(studentRepo, lectRepo)
 
-	student := &model.Student{ID: "student-1"}
-	lecturer := &model.Lecturer{
-		ID: "lect-1",
-		User: model.User{
-			Role: model.Role{Name: "Dosen Wali"},
+	student := &model.Student{
+		ID: "student-1",
+		StudyProgram: &model.StudyProgram{
+			DepartmentID: "dep-2",
+			Department:   &model.Department{ID: "dep-2", FacultyID: "fac-2"},
 		},
 	}
-
 	studentRepo.On("FindByID", "student-1").Return(student, nil)
-	lectRepo.On("FindByID", "lect-1").Return(lecturer, nil)
-	studentRepo.On("UpdateAdvisor", "student-1", "lect-1").Return(nil)
 
-	err := svc.SetAdvisor(context.Background(), "student-1", "lect-1")
+	_, err := svc.GetStudentByID("student-1", model.UnitScope{FacultyID: "fac-1"})
+	assert.ErrorIs(t, err, ErrOutOfScope)
 
+	res, err := svc.GetStudentByID("student-1", model.UnitScope{FacultyID: "fac-2"})
 	assert.NoError(t, err)
-}
-
-func TestSetAdvisor_NotDosenWali(t *testing.T) {
-	studentRepo := new(mocks.StudentRepositoryMock)
-	lectRepo := new(mocks.LecturerRepositoryMock)
-
-	svc := NewStudentService(studentRepo, lectRepo)
-
-	student := &model.Student{ID: "student-1"}
-	lecturer := &model.Lecturer{
-		ID: "lect-1",
-		User: model.User{
-			Role: model.Role{Name: "Mahasiswa"},
-		},
-	}
-
-	studentRepo.On("FindByID", "student-1").Return(student, nil)
-	lectRepo.On("FindByID", "lect-1").Return(lecturer, nil)
-
-	err := svc.SetAdvisor(context.Background(), "student-1", "lect-1")
-
-	assert.Error(t, err)
+	assert.Equal(t, "student-1", res.ID)
 }
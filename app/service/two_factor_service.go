@@ -0,0 +1,309 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/utils"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorSetupMissing   = errors.New("start two-factor setup first")
+	ErrTwoFactorRequiredByRole = errors.New("two-factor authentication is required for this role")
+	ErrInvalidTwoFactorCode    = errors.New("invalid_2fa_code")
+	ErrInvalidChallenge        = errors.New("invalid or expired 2fa challenge")
+)
+
+const (
+	totpPeriod         = 30
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+	// alfabet tanpa karakter yang mirip (0/o, 1/l/i)
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	challengeTTL         = 5 * time.Minute
+)
+
+var totpOpts = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+type TwoFactorService struct {
+	userRepo repository.UserRepository
+	tfRepo   repository.TwoFactorRepository
+	issuer   string
+	keys     *utils.KeyManager
+}
+
+func NewTwoFactorService(
+	userRepo repository.UserRepository,
+	tfRepo repository.TwoFactorRepository,
+	issuer string,
+	keys *utils.KeyManager,
+) *TwoFactorService {
+	return &TwoFactorService{
+		userRepo: userRepo,
+		tfRepo:   tfRepo,
+		issuer:   issuer,
+		keys:     keys,
+	}
+}
+
+type TwoFactorSetup struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+func (s *TwoFactorService) Status(userID string) (*model.TwoFactorStatus, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	tf, err := s.tfRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &model.TwoFactorStatus{Required: user.Role.RequireTwoFactor}
+	if tf != nil {
+		status.Enabled = tf.Enabled
+		status.PendingEnrollment = !tf.Enabled
+	}
+	if status.Enabled {
+		if status.RecoveryCodesLeft, err = s.tfRepo.CountUnusedRecoveryCodes(userID); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// Setup: buat secret baru (belum aktif) + otpauth URI untuk di-scan aplikasi authenticator
+func (s *TwoFactorService) Setup(userID string) (*TwoFactorSetup, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	tf, err := s.tfRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf != nil && tf.Enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      s.issuer,
+		AccountName: user.Username,
+		Period:      totpPeriod,
+		Digits:      otp.DigitsSix,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tfRepo.Save(&model.UserTwoFactor{
+		UserID: userID,
+		Secret: key.Secret(),
+	}); err != nil {
+		return nil, err
+	}
+	return &TwoFactorSetup{Secret: key.Secret(), URI: key.URL()}, nil
+}
+
+// Enable: konfirmasi setup dengan kode TOTP pertama.
+// Return kode cadangan (hanya ditampilkan sekali) + token baru tanpa flag wajib daftar 2FA.
+func (s *TwoFactorService) Enable(userID, code string) ([]string, string, error) {
+	tf, err := s.tfRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if tf == nil {
+		return nil, "", ErrTwoFactorSetupMissing
+	}
+	if tf.Enabled {
+		return nil, "", ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := matchTOTP(tf.Secret, code, time.Now())
+	if !ok {
+		return nil, "", ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.tfRepo.Enable(userID, step, hashes); err != nil {
+		return nil, "", err
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, "", err
+	}
+	user.TwoFactorEnabled = true
+	perms, err := s.userRepo.GetPermissionsByUserID(userID)
+	if err != nil {
+		return nil, "", err
+	}
+	token, err := s.keys.GenerateToken(user, perms)
+	if err != nil {
+		return nil, "", err
+	}
+	return codes, token, nil
+}
+
+// Disable: butuh password + kode (TOTP / cadangan); ditolak kalau role mewajibkan 2FA
+func (s *TwoFactorService) Disable(userID, password, code string) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	if user.Role.RequireTwoFactor {
+		return ErrTwoFactorRequiredByRole
+	}
+	if !utils.CheckPassword(user.PasswordHash, password) {
+		return ErrCurrentPasswordInvalid
+	}
+	if err := s.Verify(userID, code); err != nil {
+		return err
+	}
+	return s.tfRepo.Delete(userID)
+}
+
+// RegenerateRecoveryCodes: semua kode cadangan lama hangus
+func (s *TwoFactorService) RegenerateRecoveryCodes(userID, code string) ([]string, error) {
+	tf, err := s.tfRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf == nil || !tf.Enabled {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if err := s.verifyTOTP(tf, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tfRepo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Verify: kode TOTP 6 digit atau kode cadangan (sekali pakai)
+func (s *TwoFactorService) Verify(userID, code string) error {
+	tf, err := s.tfRepo.FindByUserID(userID)
+	if err != nil {
+		return err
+	}
+	if tf == nil || !tf.Enabled {
+		return ErrTwoFactorNotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) == int(otp.DigitsSix) && isDigits(code) {
+		return s.verifyTOTP(tf, code)
+	}
+
+	ok, err := s.tfRepo.ConsumeRecoveryCode(userID, utils.SHA256Hex(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// AdminReset: hapus 2FA user (mis. HP hilang dan kode cadangan habis)
+func (s *TwoFactorService) AdminReset(userID string) error {
+	if _, err := s.userRepo.FindByID(userID); err != nil {
+		return errors.New("user not found")
+	}
+	return s.tfRepo.Delete(userID)
+}
+
+func (s *TwoFactorService) verifyTOTP(tf *model.UserTwoFactor, code string) error {
+	step, ok := matchTOTP(tf.Secret, code, time.Now())
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+	// kode yang sama (atau kode lebih lama) tidak boleh dipakai ulang
+	used, err := s.tfRepo.UseStep(tf.UserID, step)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// matchTOTP: cocokkan kode dengan toleransi satu step (±30 detik), return step yang cocok
+func matchTOTP(secret, code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	for _, skew := range []int64{0, -1, 1} {
+		t := now.Add(time.Duration(skew*totpPeriod) * time.Second)
+		expected, err := totp.GenerateCodeCustom(secret, t, totpOpts)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return t.Unix() / totpPeriod, true
+		}
+	}
+	return 0, false
+}
+
+// newRecoveryCodes: kode untuk user (format xxxxx-xxxxx) + hash untuk disimpan
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	// byte di atas kelipatan panjang alfabet dibuang supaya distribusi huruf rata
+	limit := byte(256 / len(recoveryCodeAlphabet) * len(recoveryCodeAlphabet))
+	one := make([]byte, 1)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, 0, recoveryCodeLength)
+		for len(raw) < recoveryCodeLength {
+			if _, err := rand.Read(one); err != nil {
+				return nil, nil, err
+			}
+			if one[0] < limit {
+				raw = append(raw, recoveryCodeAlphabet[int(one[0])%len(recoveryCodeAlphabet)])
+			}
+		}
+		code := string(raw[:recoveryCodeLength/2]) + "-" + string(raw[recoveryCodeLength/2:])
+		codes = append(codes, code)
+		hashes = append(hashes, utils.SHA256Hex(normalizeRecoveryCode(code)))
+	}
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/nerhays/prestasi_uas/utils"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXP"
+
+func TestTwoFactorEnable_ReturnsRecoveryCodesAndClearsSetupFlag(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tfRepo := new(mocks.TwoFactorRepositoryMock)
+	svc := NewTwoFactorService(userRepo, tfRepo, "Prestasi", testKeys)
+
+	user := &model.User{ID: "user-1", Username: "admin", Role: model.Role{Name: "Admin", RequireTwoFactor: true}}
+	userRepo.On("FindByID", "user-1").Return(user, nil)
+	userRepo.On("GetPermissionsByUserID", "user-1").Return([]model.Permission{}, nil)
+	tfRepo.On("FindByUserID", "user-1").Return(&model.UserTwoFactor{UserID: "user-1", Secret: testTOTPSecret}, nil)
+
+	var hashes []string
+	tfRepo.On("Enable", "user-1", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		hashes = args.Get(2).([]string)
+	}).Return(nil)
+
+	// kode salah → belum aktif
+	_, _, err := svc.Enable("user-1", "000000")
+	assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+
+	code, _ := totp.GenerateCode(testTOTPSecret, time.Now())
+	codes, token, err := svc.Enable("user-1", code)
+	assert.NoError(t, err)
+	assert.Len(t, codes, recoveryCodeCount)
+	assert.Regexp(t, `^[a-z2-9]{5}-[a-z2-9]{5}$`, codes[0])
+	assert.Equal(t, utils.SHA256Hex(normalizeRecoveryCode(codes[0])), hashes[0])
+
+	claims, err := testKeys.ParseToken(token)
+	assert.NoError(t, err)
+	assert.False(t, claims.TwoFactorSetupRequired)
+}
+
+func TestTwoFactorVerify_ReplayedCodeRejected(t *testing.T) {
+	tfRepo := new(mocks.TwoFactorRepositoryMock)
+	svc := NewTwoFactorService(new(mocks.UserRepositoryMock), tfRepo, "Prestasi", testKeys)
+
+	tfRepo.On("FindByUserID", "user-1").Return(&model.UserTwoFactor{UserID: "user-1", Secret: testTOTPSecret, Enabled: true}, nil)
+	tfRepo.On("UseStep", "user-1", mock.Anything).Return(true, nil).Once()
+	tfRepo.On("UseStep", "user-1", mock.Anything).Return(false, nil).Once()
+
+	code, _ := totp.GenerateCode(testTOTPSecret, time.Now())
+	assert.NoError(t, svc.Verify("user-1", code))
+	assert.ErrorIs(t, svc.Verify("user-1", code), ErrInvalidTwoFactorCode)
+}
+
+func TestLogin_TwoFactorChallengeCompletedWithRecoveryCode(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tfRepo := new(mocks.TwoFactorRepositoryMock)
+	auditRepo := new(mocks.LoginAuditRepositoryMock)
+	auditRepo.On("Create", mock.Anything).Return(nil)
+
+	guard := NewLoginGuard(repository.NewMemoryLoginAttemptStore(), auditRepo, userRepo, DefaultLockoutPolicy())
+	svc := NewAuthService(userRepo, nil, guard, NewTwoFactorService(userRepo, tfRepo, "Prestasi", testKeys), nil, testKeys)
+
+	hashed, _ := utils.HashPassword("password123")
+	user := &model.User{ID: "user-1", Username: "budi", PasswordHash: hashed, IsActive: true, TwoFactorEnabled: true}
+	userRepo.On("FindByUsernameOrEmail", "budi").Return(user, nil)
+	userRepo.On("FindByID", "user-1").Return(user, nil)
+	userRepo.On("GetPermissionsByUserID", "user-1").Return([]model.Permission{}, nil)
+	tfRepo.On("FindByUserID", "user-1").Return(&model.UserTwoFactor{UserID: "user-1", Secret: testTOTPSecret, Enabled: true}, nil)
+	tfRepo.On("ConsumeRecoveryCode", "user-1", utils.SHA256Hex("abcdefghjk")).Return(true, nil).Once()
+	tfRepo.On("ConsumeRecoveryCode", "user-1", mock.Anything).Return(false, nil)
+
+	res, err := svc.Login(LoginInput{Username: "budi", Password: "password123", IP: "10.0.0.1"})
+	assert.NoError(t, err)
+	assert.True(t, res.TwoFactorRequired)
+	assert.Empty(t, res.Token)
+
+	// token tantangan tidak bisa dipakai sebagai access token
+	_, err = testKeys.ParseToken(res.ChallengeToken)
+	assert.Error(t, err)
+
+	_, err = svc.VerifyTwoFactor(TwoFactorLoginInput{ChallengeToken: res.ChallengeToken, Code: "wrong-code", IP: "10.0.0.1"})
+	assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+
+	out, err := svc.VerifyTwoFactor(TwoFactorLoginInput{ChallengeToken: res.ChallengeToken, Code: "ABCDE-FGHJK", IP: "10.0.0.1"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, out.Token)
+
+	// kode cadangan sekali pakai
+	_, err = svc.VerifyTwoFactor(TwoFactorLoginInput{ChallengeToken: res.ChallengeToken, Code: "ABCDE-FGHJK", IP: "10.0.0.1"})
+	assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+
+	_, err = svc.VerifyTwoFactor(TwoFactorLoginInput{ChallengeToken: "garbage", Code: "123456"})
+	assert.ErrorIs(t, err, ErrInvalidChallenge)
+}
+
+func TestTwoFactorDisable_BlockedWhenRoleRequiresIt(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tfRepo := new(mocks.TwoFactorRepositoryMock)
+	svc := NewTwoFactorService(userRepo, tfRepo, "Prestasi", testKeys)
+
+	user := &model.User{ID: "user-1", Role: model.Role{Name: "Admin", RequireTwoFactor: true}}
+	userRepo.On("FindByID", "user-1").Return(user, nil)
+
+	assert.ErrorIs(t, svc.Disable("user-1", "password123", "123456"), ErrTwoFactorRequiredByRole)
+	tfRepo.AssertNotCalled(t, "Delete", mock.Anything)
+
+	// user belum daftar 2FA → token menandai wajib setup
+	token, err := testKeys.GenerateToken(user, nil)
+	assert.NoError(t, err)
+	claims, err := testKeys.ParseToken(token)
+	assert.NoError(t, err)
+	assert.True(t, claims.TwoFactorSetupRequired)
+}
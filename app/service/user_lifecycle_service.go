@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+const (
+	// status aktif / sessions_revoked_at di-cache per instance; instance lain paling lambat menyusul setelah TTL ini
+	sessionCacheTTL = 30 * time.Second
+
+	anonymizedFullName    = "Anonymized User"
+	anonymizedEmailDomain = "anonymized.invalid"
+	// bukan hash bcrypt yang valid → login password selalu gagal
+	anonymizedPasswordHash = "!"
+)
+
+var (
+	ErrAccountNotFound            = errors.New("user not found")
+	ErrCannotDeactivateSelf       = errors.New("cannot deactivate your own account")
+	ErrAdvisorReplacementRequired = errors.New("lecturer still has advisees, replacement_advisor_id is required")
+	ErrInvalidReplacementAdvisor  = errors.New("replacement advisor must be another active lecturer")
+	ErrUserAnonymized             = errors.New("user has been anonymized")
+	ErrSessionRevoked             = errors.New("session revoked")
+)
+
+// DeactivationResult: ringkasan efek samping nonaktif / anonimisasi
+type DeactivationResult struct {
+	UserID             string `json:"user_id"`
+	ReassignedAdvisees int    `json:"reassigned_advisees"`
+	RevokedAPITokens   int    `json:"revoked_api_tokens"`
+}
+
+type sessionCacheEntry struct {
+	user     model.User
+	loadedAt time.Time
+}
+
+// UserLifecycleService: nonaktif / aktifkan kembali / anonimkan user (pengganti hard delete).
+// Baris users tetap ada, jadi FK dari students, lecturers dan achievement_references.verified_by aman.
+type UserLifecycleService struct {
+	userRepo     repository.UserRepository
+	studentRepo  repository.StudentRepository
+	lecturerRepo repository.LecturerRepository
+	profileRepo  repository.ProfileRepository
+	tokenRepo    repository.APITokenRepository
+	advisorSvc   *AdvisorAssignmentService
+
+	mu        sync.Mutex
+	sessions  map[string]sessionCacheEntry
+	lastSweep time.Time
+}
+
+func NewUserLifecycleService(
+	userRepo repository.UserRepository,
+	studentRepo repository.StudentRepository,
+	lecturerRepo repository.LecturerRepository,
+	profileRepo repository.ProfileRepository,
+	tokenRepo repository.APITokenRepository,
+	advisorSvc *AdvisorAssignmentService,
+) *UserLifecycleService {
+	return &UserLifecycleService{
+		userRepo:     userRepo,
+		studentRepo:  studentRepo,
+		lecturerRepo: lecturerRepo,
+		profileRepo:  profileRepo,
+		tokenRepo:    tokenRepo,
+		advisorSvc:   advisorSvc,
+		sessions:     make(map[string]sessionCacheEntry),
+	}
+}
+
+// Deactivate: user tidak bisa login, semua access token & API token dicabut.
+// Dosen wali yang masih punya mahasiswa bimbingan wajib menyebut penggantinya.
+func (s *UserLifecycleService) Deactivate(ctx context.Context, actorID, userID, replacementLecturerID string) (*DeactivationResult, error) {
+	if actorID == userID {
+		return nil, ErrCannotDeactivateSelf
+	}
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, ErrAccountNotFound
+	}
+
+	reassigned, err := s.handOverAdvisees(ctx, actorID, user.ID, replacementLecturerID, "dosen wali dinonaktifkan")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.userRepo.SetActive(user.ID, false, now); err != nil {
+		return nil, err
+	}
+	s.ForgetSession(user.ID)
+
+	return &DeactivationResult{
+		UserID:             user.ID,
+		ReassignedAdvisees: reassigned,
+		RevokedAPITokens:   s.revokeAPITokens(user.ID, now),
+	}, nil
+}
+
+// Reactivate: user bisa login lagi; token yang dicabut saat nonaktif tetap tidak berlaku
+func (s *UserLifecycleService) Reactivate(userID string) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return ErrAccountNotFound
+	}
+	if user.AnonymizedAt != nil {
+		return ErrUserAnonymized
+	}
+	if user.IsActive {
+		return nil
+	}
+
+	if err := s.userRepo.SetActive(user.ID, true, time.Now()); err != nil {
+		return err
+	}
+	s.ForgetSession(user.ID)
+	return nil
+}
+
+// Anonymize: permintaan penghapusan data pribadi. Nama, username, email dan NIM/NIDN diganti nilai anonim,
+// identitas SSO / 2FA dihapus; prestasi, prodi, angkatan dan status verifikasi tetap untuk statistik.
+// Tidak bisa dibatalkan.
+func (s *UserLifecycleService) Anonymize(ctx context.Context, actorID, userID, replacementLecturerID string) (*DeactivationResult, error) {
+	if actorID == userID {
+		return nil, ErrCannotDeactivateSelf
+	}
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, ErrAccountNotFound
+	}
+	if user.AnonymizedAt != nil {
+		return nil, ErrUserAnonymized
+	}
+
+	reassigned, err := s.handOverAdvisees(ctx, actorID, user.ID, replacementLecturerID, "data dosen wali dianonimkan")
+	if err != nil {
+		return nil, err
+	}
+
+	anon, err := newAnonymizedAccount(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.profileRepo.AnonymizeAccount(user.ID, anon); err != nil {
+		return nil, err
+	}
+	s.ForgetSession(user.ID)
+
+	return &DeactivationResult{
+		UserID:             user.ID,
+		ReassignedAdvisees: reassigned,
+		RevokedAPITokens:   s.revokeAPITokens(user.ID, anon.At),
+	}, nil
+}
+
+// CheckSession: dipakai middleware.Auth; tolak token user nonaktif atau yang terbit sebelum sesi dicabut
+func (s *UserLifecycleService) CheckSession(userID string, issuedAt time.Time) error {
+	user, err := s.cachedUser(userID)
+	if err != nil {
+		return ErrSessionRevoked
+	}
+	if !user.SessionValid(issuedAt) {
+		return ErrSessionRevoked
+	}
+	return nil
+}
+
+// handOverAdvisees: pindahkan mahasiswa bimbingan (dengan riwayat + serah terima) kalau user adalah dosen wali
+func (s *UserLifecycleService) handOverAdvisees(ctx context.Context, actorID, userID, replacementLecturerID, reason string) (int, error) {
+	lecturer, err := s.lecturerRepo.FindByUserID(userID)
+	if err != nil {
+		// bukan dosen
+		return 0, nil
+	}
+
+	advisees, err := s.studentRepo.FindByAdvisorID(lecturer.ID)
+	if err != nil {
+		return 0, err
+	}
+	if len(advisees) == 0 {
+		return 0, nil
+	}
+	if replacementLecturerID == "" {
+		return 0, ErrAdvisorReplacementRequired
+	}
+	if replacementLecturerID == lecturer.ID {
+		return 0, ErrInvalidReplacementAdvisor
+	}
+
+	result, err := s.advisorSvc.Reassign(ctx, ReassignAdvisorInput{
+		ToLecturerID:   replacementLecturerID,
+		FromLecturerID: lecturer.ID,
+		Reason:         reason,
+		ActorID:        actorID,
+	})
+	if errors.Is(err, ErrLecturerNotFound) || errors.Is(err, ErrNotDosenWali) || errors.Is(err, ErrAdvisorInactive) {
+		return 0, ErrInvalidReplacementAdvisor
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result.Reassigned, nil
+}
+
+// revokeAPITokens: gagal mencabut tidak membatalkan nonaktif, VerifyAPIToken juga menolak pemilik nonaktif
+func (s *UserLifecycleService) revokeAPITokens(userID string, at time.Time) int {
+	tokens, err := s.tokenRepo.FindByUserID(userID)
+	if err != nil {
+		log.Printf("[USER] failed to list api tokens of %s: %v", userID, err)
+		return 0
+	}
+
+	revoked := 0
+	for _, t := range tokens {
+		if !t.Active(at) {
+			continue
+		}
+		ok, err := s.tokenRepo.Revoke(t.ID, at)
+		if err != nil {
+			log.Printf("[USER] failed to revoke api token %s: %v", t.ID, err)
+			continue
+		}
+		if ok {
+			revoked++
+		}
+	}
+	return revoked
+}
+
+func (s *UserLifecycleService) cachedUser(userID string) (model.User, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	entry, ok := s.sessions[userID]
+	s.mu.Unlock()
+	if ok && now.Sub(entry.loadedAt) < sessionCacheTTL {
+		return entry.user, nil
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// entry kedaluwarsa dibuang paling sering sekali per TTL, supaya cache tidak tumbuh terus
+	// oleh user yang sudah tidak aktif memakai token
+	if now.Sub(s.lastSweep) >= sessionCacheTTL {
+		for id, e := range s.sessions {
+			if now.Sub(e.loadedAt) >= sessionCacheTTL {
+				delete(s.sessions, id)
+			}
+		}
+		s.lastSweep = now
+	}
+	if err != nil {
+		delete(s.sessions, userID)
+		return model.User{}, err
+	}
+	s.sessions[userID] = sessionCacheEntry{user: *user, loadedAt: now}
+	return *user, nil
+}
+
+// ForgetSession: buang cache sesi user di instance ini setelah status / sessions_revoked_at berubah
+func (s *UserLifecycleService) ForgetSession(userID string) {
+	s.mu.Lock()
+	delete(s.sessions, userID)
+	s.mu.Unlock()
+}
+
+// newAnonymizedAccount: nilai acak supaya tetap lolos constraint UNIQUE username / email / NIM
+func newAnonymizedAccount(at time.Time) (model.AnonymizedAccount, error) {
+	suffix, err := utils.RandomToken(6)
+	if err != nil {
+		return model.AnonymizedAccount{}, err
+	}
+	return model.AnonymizedAccount{
+		Username:      "anon-" + suffix,
+		Email:         "anon-" + suffix + "@" + anonymizedEmailDomain,
+		FullName:      anonymizedFullName,
+		PasswordHash:  anonymizedPasswordHash,
+		ProfileNumber: "ANON-" + strings.ToUpper(suffix),
+		At:            at,
+	}, nil
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type lifecycleMocks struct {
+	users     *mocks.UserRepositoryMock
+	students  *mocks.StudentRepositoryMock
+	lecturers *mocks.LecturerRepositoryMock
+	profiles  *mocks.ProfileRepositoryMock
+	tokens    *mocks.APITokenRepositoryMock
+	assigns   *mocks.AdvisorAssignmentRepositoryMock
+	refs      *mocks.AchievementReferenceRepositoryMock
+}
+
+func newLifecycleTestService() (*UserLifecycleService, lifecycleMocks) {
+	m := lifecycleMocks{
+		users:     new(mocks.UserRepositoryMock),
+		students:  new(mocks.StudentRepositoryMock),
+		lecturers: new(mocks.LecturerRepositoryMock),
+		profiles:  new(mocks.ProfileRepositoryMock),
+		tokens:    new(mocks.APITokenRepositoryMock),
+		assigns:   new(mocks.AdvisorAssignmentRepositoryMock),
+		refs:      new(mocks.AchievementReferenceRepositoryMock),
+	}
+	advisorSvc := NewAdvisorAssignmentService(
+		m.assigns,
+		new(mocks.VerificationDelegationRepositoryMock),
+		m.students,
+		m.lecturers,
+		m.refs,
+		nil,
+		nil,
+	)
+	svc := NewUserLifecycleService(m.users, m.students, m.lecturers, m.profiles, m.tokens, advisorSvc)
+	return svc, m
+}
+
+func TestDeactivate_LecturerWithAdviseesNeedsReplacement(t *testing.T) {
+	svc, m := newLifecycleTestService()
+
+	m.users.On("FindByID", "user-l1").Return(&model.User{ID: "user-l1", IsActive: true}, nil)
+	m.lecturers.On("FindByUserID", "user-l1").Return(&model.Lecturer{ID: "lec-1", UserID: "user-l1"}, nil)
+	m.students.On("FindByAdvisorID", "lec-1").Return([]model.Student{{ID: "s1"}, {ID: "s2"}}, nil)
+
+	_, err := svc.Deactivate(context.Background(), "admin-1", "user-l1", "")
+	assert.ErrorIs(t, err, ErrAdvisorReplacementRequired)
+
+	m.users.AssertNotCalled(t, "SetActive", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeactivate_ReassignsAdviseesAndRevokesTokens(t *testing.T) {
+	svc, m := newLifecycleTestService()
+
+	m.users.On("FindByID", "user-l1").Return(&model.User{ID: "user-l1", IsActive: true}, nil)
+	m.lecturers.On("FindByUserID", "user-l1").Return(&model.Lecturer{ID: "lec-1", UserID: "user-l1"}, nil)
+	m.students.On("FindByAdvisorID", "lec-1").Return([]model.Student{
+		{ID: "s1", AdvisorID: "lec-1"},
+		{ID: "s2", AdvisorID: "lec-1"},
+	}, nil)
+	m.lecturers.On("FindByID", "lec-1").Return(&model.Lecturer{ID: "lec-1", UserID: "user-l1"}, nil)
+	m.lecturers.On("FindByID", "lec-2").Return(&model.Lecturer{
+		ID:   "lec-2",
+		User: model.User{ID: "user-l2", IsActive: true, Role: model.Role{Name: "Dosen Wali"}},
+	}, nil)
+	m.refs.On("FindByStudentID", mock.Anything).Return([]model.AchievementReference{}, nil)
+	m.assigns.On("Reassign", mock.MatchedBy(func(rows []model.AdvisorAssignment) bool {
+		return len(rows) == 2 && rows[0].LecturerID == "lec-2" && *rows[0].AssignedBy == "admin-1"
+	})).Return(nil)
+	m.users.On("SetActive", "user-l1", false, mock.Anything).Return(nil)
+
+	revoked := time.Now().Add(-time.Hour)
+	m.tokens.On("FindByUserID", "user-l1").Return([]model.APIToken{
+		{ID: "tok-1", ExpiresAt: time.Now().Add(time.Hour)},
+		{ID: "tok-2", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revoked},
+	}, nil)
+	m.tokens.On("Revoke", "tok-1", mock.Anything).Return(true, nil)
+
+	result, err := svc.Deactivate(context.Background(), "admin-1", "user-l1", "lec-2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.ReassignedAdvisees)
+	assert.Equal(t, 1, result.RevokedAPITokens)
+	m.tokens.AssertNotCalled(t, "Revoke", "tok-2", mock.Anything)
+	m.assigns.AssertExpectations(t)
+	m.users.AssertExpectations(t)
+}
+
+func TestDeactivate_RejectsInactiveReplacement(t *testing.T) {
+	svc, m := newLifecycleTestService()
+
+	m.users.On("FindByID", "user-l1").Return(&model.User{ID: "user-l1", IsActive: true}, nil)
+	m.lecturers.On("FindByUserID", "user-l1").Return(&model.Lecturer{ID: "lec-1"}, nil)
+	m.students.On("FindByAdvisorID", "lec-1").Return([]model.Student{{ID: "s1"}}, nil)
+	m.lecturers.On("FindByID", "lec-2").Return(&model.Lecturer{
+		ID:   "lec-2",
+		User: model.User{IsActive: false, Role: model.Role{Name: "Dosen Wali"}},
+	}, nil)
+
+	_, err := svc.Deactivate(context.Background(), "admin-1", "user-l1", "lec-2")
+	assert.ErrorIs(t, err, ErrInvalidReplacementAdvisor)
+}
+
+func TestDeactivate_CannotDeactivateSelf(t *testing.T) {
+	svc, _ := newLifecycleTestService()
+
+	_, err := svc.Deactivate(context.Background(), "admin-1", "admin-1", "")
+	assert.ErrorIs(t, err, ErrCannotDeactivateSelf)
+}
+
+func TestReactivate_AnonymizedUserRejected(t *testing.T) {
+	svc, m := newLifecycleTestService()
+
+	at := time.Now()
+	m.users.On("FindByID", "user-1").Return(&model.User{ID: "user-1", AnonymizedAt: &at}, nil)
+
+	err := svc.Reactivate("user-1")
+	assert.ErrorIs(t, err, ErrUserAnonymized)
+}
+
+func TestAnonymize_StudentKeepsProfileForStatistics(t *testing.T) {
+	svc, m := newLifecycleTestService()
+
+	m.users.On("FindByID", "user-s1").Return(&model.User{ID: "user-s1", Username: "budi", IsActive: true}, nil)
+	m.lecturers.On("FindByUserID", "user-s1").Return((*model.Lecturer)(nil), errors.New("not found"))
+	m.tokens.On("FindByUserID", "user-s1").Return([]model.APIToken{}, nil)
+
+	var anon model.AnonymizedAccount
+	m.profiles.On("AnonymizeAccount", "user-s1", mock.Anything).
+		Run(func(args mock.Arguments) { anon = args.Get(1).(model.AnonymizedAccount) }).
+		Return(nil)
+
+	_, err := svc.Anonymize(context.Background(), "admin-1", "user-s1", "")
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(anon.Username, "anon-"))
+	assert.True(t, strings.HasSuffix(anon.Email, "@"+anonymizedEmailDomain))
+	assert.Equal(t, anonymizedFullName, anon.FullName)
+	assert.LessOrEqual(t, len(anon.ProfileNumber), 20)
+	assert.False(t, anon.At.IsZero())
+}
+
+func TestCheckSession(t *testing.T) {
+	svc, m := newLifecycleTestService()
+
+	revokedAt := time.Now().Add(-time.Hour)
+	m.users.On("FindByID", "user-1").Return(&model.User{ID: "user-1", IsActive: true, SessionsRevokedAt: &revokedAt}, nil)
+	m.users.On("FindByID", "user-2").Return(&model.User{ID: "user-2", IsActive: false}, nil)
+
+	assert.ErrorIs(t, svc.CheckSession("user-1", revokedAt.Add(-time.Minute)), ErrSessionRevoked)
+	assert.NoError(t, svc.CheckSession("user-1", time.Now()))
+	assert.ErrorIs(t, svc.CheckSession("user-2", time.Now()), ErrSessionRevoked)
+
+	// status user di-cache, repository tidak dipanggil ulang
+	m.users.AssertNumberOfCalls(t, "FindByID", 2)
+}
+
+func TestCheckSession_SweepsExpiredCacheEntries(t *testing.T) {
+	svc, m := newLifecycleTestService()
+
+	m.users.On("FindByID", "user-1").Return(&model.User{ID: "user-1", IsActive: true}, nil)
+	m.users.On("FindByID", "gone").Return(nil, errors.New("not found"))
+
+	stale := time.Now().Add(-2 * sessionCacheTTL)
+	svc.sessions["old-1"] = sessionCacheEntry{loadedAt: stale}
+	svc.sessions["old-2"] = sessionCacheEntry{loadedAt: stale}
+	svc.sessions["gone"] = sessionCacheEntry{loadedAt: stale}
+
+	assert.NoError(t, svc.CheckSession("user-1", time.Now()))
+	assert.Len(t, svc.sessions, 1)
+	assert.Contains(t, svc.sessions, "user-1")
+
+	// sweep baru saja jalan; user yang sudah tidak ada tetap tidak meninggalkan entry
+	svc.sessions["gone"] = sessionCacheEntry{loadedAt: stale}
+	assert.Error(t, svc.CheckSession("gone", time.Now()))
+	assert.NotContains(t, svc.sessions, "gone")
+}
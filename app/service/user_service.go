@@ -2,21 +2,27 @@ package service
 
 import (
 	"errors"
+	"time"
 
 	"github.com/nerhays/prestasi_uas/app/model"
 	"github.com/nerhays/prestasi_uas/app/repository"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/nerhays/prestasi_uas/utils"
 )
 type UserService struct {
 	userRepo repository.UserRepository
 	roleRepo repository.RoleRepository
+	policy   *PasswordPolicy
+	// cache sesi instance ini dibuang setelah role berubah; boleh nil
+	sessions *UserLifecycleService
 }
 
 func NewUserService(
 	userRepo repository.UserRepository,
 	roleRepo repository.RoleRepository,
+	policy *PasswordPolicy,
+	sessions *UserLifecycleService,
 ) *UserService {
-	return &UserService{userRepo, roleRepo}
+	return &UserService{userRepo, roleRepo, policy, sessions}
 }
 func (s *UserService) GetAllUsers() ([]model.User, error) {
 	return s.userRepo.FindAll()
@@ -33,15 +39,24 @@ func (s *UserService) CreateUser(
 		return errors.New("role not found")
 	}
 
-	hash, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err := s.policy.Validate(password, username, email); err != nil {
+		return err
+	}
+
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return err
+	}
 
+	// password ditentukan admin → user wajib ganti saat login pertama
 	user := &model.User{
-		Username:     username,
-		Email:        email,
-		PasswordHash: string(hash),
-		FullName:     fullName,
-		RoleID:       roleID,
-		IsActive:     true,
+		Username:           username,
+		Email:              email,
+		PasswordHash:       hash,
+		FullName:           fullName,
+		RoleID:             roleID,
+		IsActive:           true,
+		MustChangePassword: true,
 	}
 
 	return s.userRepo.Create(user)
@@ -60,13 +75,32 @@ func (s *UserService) UpdateUser(
 
 	return s.userRepo.Update(user)
 }
-func (s *UserService) DeleteUser(id string) error {
-	return s.userRepo.Delete(id)
-}
 func (s *UserService) UpdateUserRole(userID, roleID string) error {
 	if _, err := s.roleRepo.FindByID(roleID); err != nil {
 		return errors.New("role not found")
 	}
 
-	return s.userRepo.UpdateRole(userID, roleID)
+	// role ada di claim JWT: token lama dicabut supaya role baru langsung berlaku
+	if err := s.userRepo.UpdateRole(userID, roleID, time.Now()); err != nil {
+		return err
+	}
+	if s.sessions != nil {
+		s.sessions.ForgetSession(userID)
+	}
+	return nil
+}
+
+var ErrInvalidAuthBackend = errors.New("auth backend must be local, ldap or empty")
+
+// SetAuthBackend: backend login khusus user ini; kosong = ikut AUTH_BACKEND global
+func (s *UserService) SetAuthBackend(userID, backend string) error {
+	switch backend {
+	case "", model.AuthBackendLocal, model.AuthBackendLDAP:
+	default:
+		return ErrInvalidAuthBackend
+	}
+	if _, err := s.userRepo.FindByID(userID); err != nil {
+		return errors.New("user not found")
+	}
+	return s.userRepo.UpdateAuthBackend(userID, backend)
 }
@@ -13,7 +13,7 @@ func TestGetAllUsers_Success(t *testing.T) {
 	userRepo := new(mocks.UserRepositoryMock)
 	roleRepo := new(mocks.RoleRepositoryMock)
 
-	svc := NewUserService(userRepo, roleRepo)
+	svc := NewUserService(userRepo, roleRepo, NewPasswordPolicy(8, nil), nil)
 
 	expectedUsers := []model.User{
 		{ID: "u1", Username: "user1"},
@@ -36,7 +36,7 @@ func TestCreateUser_Success(t *testing.T) {
 	userRepo := new(mocks.UserRepositoryMock)
 	roleRepo := new(mocks.RoleRepositoryMock)
 
-	svc := NewUserService(userRepo, roleRepo)
+	svc := NewUserService(userRepo, roleRepo, NewPasswordPolicy(8, nil), nil)
 
 	roleRepo.On("FindByID", "role-1").Return(&model.Role{ID: "role-1"}, nil)
 	userRepo.On("Create", mock.Anything).Return(nil)
@@ -52,11 +52,26 @@ func TestCreateUser_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCreateUser_WeakPasswordRejected(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	roleRepo := new(mocks.RoleRepositoryMock)
+
+	svc := NewUserService(userRepo, roleRepo, NewPasswordPolicy(8, []string{"admin123"}), nil)
+
+	roleRepo.On("FindByID", "role-1").Return(&model.Role{ID: "role-1"}, nil)
+
+	err := svc.CreateUser("john", "john@mail.com", "123", "John Doe", "role-1")
+	assert.ErrorIs(t, err, ErrPasswordTooShort)
+
+	err = svc.CreateUser("john", "john@mail.com", "Admin123", "John Doe", "role-1")
+	assert.ErrorIs(t, err, ErrPasswordBreached)
+}
+
 func TestUpdateUserRole_Success(t *testing.T) {
 	userRepo := new(mocks.UserRepositoryMock)
 	roleRepo := new(mocks.RoleRepositoryMock)
 
-	svc := NewUserService(userRepo, roleRepo)
+	svc := NewUserService(userRepo, roleRepo, NewPasswordPolicy(8, nil), nil)
 
 	roleRepo.On("FindByID", "role-2").Return(&model.Role{}, nil)
 	userRepo.On("UpdateRole", "user-1", "role-2").Return(nil)
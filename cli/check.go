@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// runCheck: check consistency; exit code 1 kalau ada temuan (bisa dijadwalkan di cron / CI)
+func runCheck(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 || args[0] != "consistency" {
+		return newUsageError("check needs consistency")
+	}
+
+	fs := newFlagSet("check consistency")
+	asJSON := fs.Bool("json", false, "output JSON")
+	if err := fs.Parse(args[1:]); err != nil {
+		return newUsageError("%v", err)
+	}
+
+	issues, err := a.c.Consistency.Check(ctx)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(issues); err != nil {
+			return err
+		}
+	} else if len(issues) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "KIND\tID\tMESSAGE")
+		for _, issue := range issues {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", issue.Kind, issue.ID, issue.Message)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("%d consistency issues found", len(issues))
+	}
+	if !*asJSON {
+		fmt.Println("no consistency issues found")
+	}
+	return nil
+}
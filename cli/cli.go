@@ -0,0 +1,129 @@
+// Package cli: perintah `prestasi` (server + operasi admin) yang memakai config dan service yang sama dengan HTTP API
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/nerhays/prestasi_uas/app/container"
+	"github.com/nerhays/prestasi_uas/config"
+	"github.com/nerhays/prestasi_uas/database"
+)
+
+const usage = `usage: prestasi <command> [arguments]
+
+commands:
+  serve
+  migrate up | down <postgres|mongo> [steps] | status
+  seed roles | admin | demo [-seed n] [-students n] [-lecturers n] [-achievements n] [-years n]
+  user create -username u -email e -full-name n -role r [-password p]
+  user reset-password [-password p] <username|email>
+  user set-role <username|email> <role>
+  student import [-dry-run] [-as username] <file.csv|file.xlsx>
+  export achievements [-format csv|json] [-status s] [-period id] [-out file]
+  check consistency [-json]
+`
+
+// command: satu subcommand; koneksi database sudah dibuka dan skema sudah dicek (kecuali migrate)
+type command struct {
+	run func(ctx context.Context, a *app, args []string) error
+	// migrate boleh jalan di atas skema yang tertinggal; serve mengecek sendiri (MIGRATE_ON_START)
+	skipSchemaCheck bool
+}
+
+var commands = map[string]command{
+	"serve":   {run: runServe, skipSchemaCheck: true},
+	"migrate": {run: runMigrateCommand, skipSchemaCheck: true},
+	"seed":    {run: runSeed},
+	"user":    {run: runUser},
+	"student": {run: runStudent},
+	"export":  {run: runExport},
+	"check":   {run: runCheck},
+}
+
+// app: config + container (koneksi, repository, service) yang dibagi semua subcommand
+type app struct {
+	cfg      *config.Config
+	c        *container.Container
+	migrator *database.Migrator
+}
+
+// usageError: argumen salah, keluar dengan kode 2 + teks usage
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string { return e.msg }
+
+func newUsageError(format string, args ...any) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// Run: jalankan `prestasi <args...>`; return exit code (0 sukses, 1 gagal, 2 salah pakai)
+func Run(args []string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(os.Stderr, usage)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	a, err := newApp(ctx, config.LoadConfig(), !cmd.skipSchemaCheck)
+	if err != nil {
+		log.Printf("[CLI] %v", err)
+		return 1
+	}
+	defer a.close()
+
+	if err := cmd.run(ctx, a, args[1:]); err != nil {
+		var uerr *usageError
+		if errors.As(err, &uerr) {
+			fmt.Fprintf(os.Stderr, "%s\n\n%s", uerr.msg, usage)
+			return 2
+		}
+		log.Printf("[CLI] %s: %v", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+func newApp(ctx context.Context, cfg *config.Config, checkSchema bool) (*app, error) {
+	a := &app{
+		cfg: cfg,
+		c:   container.Open(cfg, container.Options{}),
+	}
+
+	migrator, err := database.NewMigrator(a.c.DB, a.c.Mongo.DB)
+	if err != nil {
+		a.close()
+		return nil, fmt.Errorf("migrations: %w", err)
+	}
+	a.migrator = migrator
+
+	if checkSchema {
+		if err := migrator.Check(ctx); err != nil {
+			a.close()
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+func (a *app) close() {
+	a.c.Close()
+}
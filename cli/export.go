@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/app/service"
+)
+
+// runExport: export achievements ke stdout atau file
+func runExport(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 || args[0] != "achievements" {
+		return newUsageError("export needs achievements")
+	}
+
+	fs := newFlagSet("export achievements")
+	format := fs.String("format", service.ExportFormatCSV, "csv | json")
+	status := fs.String("status", "", "filter status (draft, submitted, verified, rejected, deleted)")
+	periodID := fs.String("period", "", "filter id periode akademik")
+	out := fs.String("out", "", "file tujuan; kosong = stdout")
+	if err := fs.Parse(args[1:]); err != nil {
+		return newUsageError("%v", err)
+	}
+	if fs.NArg() > 0 {
+		return newUsageError("export achievements takes no positional arguments")
+	}
+
+	exportFormat, err := service.ParseExportFormat(*format)
+	if err != nil {
+		return newUsageError("%v", err)
+	}
+	var filter repository.AchievementReferenceFilter
+	if *status != "" {
+		filter.Status = status
+	}
+	if *periodID != "" {
+		filter.PeriodID = periodID
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	n, err := a.c.Export.Export(ctx, w, exportFormat, filter)
+	if err != nil {
+		return err
+	}
+	// log ke stderr supaya tidak tercampur dengan data di stdout
+	if *out != "" {
+		log.Printf("[EXPORT] %d achievements written to %s", n, *out)
+	} else {
+		log.Printf("[EXPORT] %d achievements written", n)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/nerhays/prestasi_uas/database"
+)
+
+const migrateUsage = "migrate needs up | down <postgres|mongo> [steps] | status"
+
+func runMigrateCommand(ctx context.Context, a *app, args []string) error {
+	return runMigrate(ctx, a.migrator, args)
+}
+
+// runMigrate: subcommand migrate; down default 1 langkah supaya tidak ada rollback massal tanpa sengaja
+func runMigrate(ctx context.Context, m *database.Migrator, args []string) error {
+	if len(args) == 0 {
+		return newUsageError(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		done, err := m.Up(ctx)
+		for _, st := range done {
+			log.Printf("[MIGRATE] applied %s %04d_%s", st.Store, st.Version, st.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(done) == 0 {
+			log.Println("[MIGRATE] schema is up to date")
+		}
+		return nil
+
+	case "down":
+		if len(args) < 2 {
+			return newUsageError(migrateUsage)
+		}
+		steps := 1
+		if len(args) > 2 {
+			n, err := strconv.Atoi(args[2])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid steps %q", args[2])
+			}
+			steps = n
+		}
+		done, err := m.Down(ctx, args[1], steps)
+		for _, st := range done {
+			log.Printf("[MIGRATE] reverted %s %04d_%s", st.Store, st.Version, st.Name)
+		}
+		return err
+
+	case "status":
+		list, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "STORE\tVERSION\tNAME\tAPPLIED AT")
+		for _, st := range list {
+			applied := "pending"
+			if st.AppliedAt != nil {
+				applied = st.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%s\t%04d\t%s\t%s\n", st.Store, st.Version, st.Name, applied)
+		}
+		return w.Flush()
+	}
+	return newUsageError(migrateUsage)
+}
+
+// checkSchema: server tidak boleh jalan di atas skema yang tertinggal dari kode
+func checkSchema(ctx context.Context, m *database.Migrator, migrateOnStart bool) error {
+	if migrateOnStart {
+		done, err := m.Up(ctx)
+		for _, st := range done {
+			log.Printf("[MIGRATE] applied %s %04d_%s", st.Store, st.Version, st.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return m.Check(ctx)
+}
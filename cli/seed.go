@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/service"
+	"github.com/nerhays/prestasi_uas/database"
+)
+
+// runSeed: seed roles | admin | demo; roles/admin idempotent, demo menolak seed yang sudah pernah dipakai
+func runSeed(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 {
+		return newUsageError("seed needs roles | admin | demo")
+	}
+	switch args[0] {
+	case "roles":
+		if err := database.SeedRolesAndPermissions(a.c.DB); err != nil {
+			return err
+		}
+		log.Println("[SEED] roles and permissions are up to date")
+		return nil
+	case "admin":
+		database.SeedAdminUser(a.c.DB)
+		return nil
+	case "demo":
+		return runSeedDemo(ctx, a, args[1:])
+	}
+	return newUsageError("unknown seed command %q", args[0])
+}
+
+// runSeedDemo: data demo deterministik (seed RNG), bisa diulang dengan -seed lain untuk menambah volume
+func runSeedDemo(ctx context.Context, a *app, args []string) error {
+	def := service.DefaultDemoDataConfig()
+
+	fs := newFlagSet("seed demo")
+	seed := fs.Int64("seed", def.Seed, "seed RNG; seed sama = data sama")
+	faculties := fs.Int("faculties", def.Faculties, "jumlah fakultas (maks 4)")
+	lecturers := fs.Int("lecturers", def.Lecturers, "jumlah dosen wali")
+	students := fs.Int("students", def.Students, "jumlah mahasiswa")
+	achievements := fs.Int("achievements", def.AchievementsPerStudent, "rata-rata prestasi per mahasiswa")
+	years := fs.Int("years", def.Years, "jumlah tahun akademik ke belakang")
+	until := fs.String("until", def.Until.Format("2006-01-02"), "tanggal akhir data (YYYY-MM-DD)")
+	password := fs.String("password", def.Password, "password semua akun demo")
+	if err := fs.Parse(args); err != nil {
+		return newUsageError("%v", err)
+	}
+	untilDate, err := time.Parse("2006-01-02", *until)
+	if err != nil {
+		return newUsageError("invalid -until %q, use YYYY-MM-DD", *until)
+	}
+
+	if err := database.SeedRolesAndPermissions(a.c.DB); err != nil {
+		return err
+	}
+
+	summary, err := a.c.DemoData.Generate(ctx, service.DemoDataConfig{
+		Seed:                   *seed,
+		Faculties:              *faculties,
+		Lecturers:              *lecturers,
+		Students:               *students,
+		AchievementsPerStudent: *achievements,
+		Years:                  *years,
+		Until:                  untilDate,
+		Password:               *password,
+	})
+	if errors.Is(err, service.ErrDemoConfig) {
+		return newUsageError("%v", err)
+	}
+	if summary != nil {
+		log.Printf("[SEED] demo data: %d faculties, %d departments, %d study programs, %d periods, %d lecturers, %d students, %d achievements (password %q)",
+			summary.Faculties, summary.Departments, summary.StudyPrograms, summary.Periods,
+			summary.Lecturers, summary.Students, summary.Achievements, *password)
+		log.Printf("[SEED] achievements by type %v, by status %v", summary.ByType, summary.ByStatus)
+	}
+	return err
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+
+	"github.com/nerhays/prestasi_uas/route"
+
+	_ "github.com/nerhays/prestasi_uas/docs"
+)
+
+// shutdownTimeout: batas waktu request yang sedang berjalan diselesaikan setelah SIGINT/SIGTERM
+const shutdownTimeout = 30 * time.Second
+
+// runServe: HTTP API + job background; skema dicek (atau dimigrasi kalau MIGRATE_ON_START) sebelum listen.
+// Saat ctx dibatalkan (SIGINT/SIGTERM) server berhenti menerima koneksi, request berjalan diselesaikan,
+// lalu job background ditunggu sampai selesai
+func runServe(ctx context.Context, a *app, args []string) error {
+	if len(args) > 0 {
+		return newUsageError("serve takes no arguments")
+	}
+	if err := checkSchema(ctx, a.migrator, a.cfg.MigrateOnStart); err != nil {
+		return err
+	}
+
+	if err := a.c.LoadKeys(); err != nil {
+		return fmt.Errorf("jwt keys: %w", err)
+	}
+
+	r := route.SetupRouter(a.c)
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	srv := &http.Server{Addr: ":" + a.cfg.AppPort, Handler: r}
+
+	jobCtx, stopJobs := context.WithCancel(ctx)
+	jobs := route.StartBackgroundJobs(jobCtx, a.c)
+	defer func() {
+		stopJobs()
+		jobs.Wait()
+		log.Printf("[APP] background jobs stopped")
+	}()
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.ListenAndServe() }()
+	log.Printf("[APP] Server running on :%s\n", a.cfg.AppPort)
+
+	select {
+	case err := <-serveErr:
+		// gagal listen (mis. port dipakai) sebelum ada sinyal berhenti
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("[APP] shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+)
+
+// runStudent: student import — format file sama dengan POST /admin/imports
+func runStudent(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 || args[0] != "import" {
+		return newUsageError("student needs import")
+	}
+
+	fs := newFlagSet("student import")
+	dryRun := fs.Bool("dry-run", false, "validasi saja, tidak menulis ke database")
+	as := fs.String("as", "admin", "username yang dicatat sebagai pembuat import job")
+	if err := fs.Parse(args[1:]); err != nil {
+		return newUsageError("%v", err)
+	}
+	if fs.NArg() != 1 {
+		return newUsageError("student import needs <file.csv|file.xlsx>")
+	}
+
+	path := fs.Arg(0)
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	importSvc := a.c.Imports
+	rows, err := importSvc.ParseImportFile(filepath.Base(path), f)
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		report, err := importSvc.DryRun(rows)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("dry run: %d rows, %d valid, %d failed\n", report.TotalRows, report.ValidRows, report.FailedRows)
+		printImportErrors(report.Errors)
+		return importFailure(report.FailedRows)
+	}
+
+	actor, err := findUser(a, *as)
+	if err != nil {
+		return err
+	}
+	job, err := importSvc.RunImport(ctx, actor.ID, filepath.Base(path), rows)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("import %s %s: %d rows, %d created, %d updated, %d failed\n",
+		job.ID, job.Status, job.TotalRows, job.CreatedRows, job.UpdatedRows, job.FailedRows)
+	printImportErrors(job.Errors)
+	if job.Status == model.ImportJobFailed {
+		return fmt.Errorf("import job %s failed", job.ID)
+	}
+	return importFailure(job.FailedRows)
+}
+
+func printImportErrors(errs []model.ImportRowError) {
+	for _, e := range errs {
+		if e.Field != "" {
+			fmt.Printf("  row %d (%s): %s\n", e.Row, e.Field, e.Message)
+			continue
+		}
+		fmt.Printf("  row %d: %s\n", e.Row, e.Message)
+	}
+}
+
+// importFailure: baris gagal → exit code 1 supaya bisa dipakai di script
+func importFailure(failed int) error {
+	if failed > 0 {
+		return fmt.Errorf("%d rows failed", failed)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+// runUser: user create | reset-password | set-role
+func runUser(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 {
+		return newUsageError("user needs create | reset-password | set-role")
+	}
+	switch args[0] {
+	case "create":
+		return runUserCreate(a, args[1:])
+	case "reset-password":
+		return runUserResetPassword(a, args[1:])
+	case "set-role":
+		return runUserSetRole(a, args[1:])
+	}
+	return newUsageError("unknown user command %q", args[0])
+}
+
+func runUserCreate(a *app, args []string) error {
+	fs := newFlagSet("user create")
+	username := fs.String("username", "", "username (wajib)")
+	email := fs.String("email", "", "email (wajib)")
+	fullName := fs.String("full-name", "", "nama lengkap (wajib)")
+	roleName := fs.String("role", "", "nama role, mis. Admin (wajib)")
+	password := fs.String("password", "", "password awal; kosong = dibuat acak")
+	if err := fs.Parse(args); err != nil {
+		return newUsageError("%v", err)
+	}
+	if *username == "" || *email == "" || *fullName == "" || *roleName == "" {
+		return newUsageError("user create needs -username, -email, -full-name and -role")
+	}
+
+	role, err := a.c.Repos.Roles.FindByName(*roleName)
+	if err != nil {
+		return fmt.Errorf("role %q not found", *roleName)
+	}
+	initial, generated, err := initialPassword(*password)
+	if err != nil {
+		return err
+	}
+
+	if err := a.c.Users.CreateUser(*username, *email, initial, *fullName, role.ID); err != nil {
+		return err
+	}
+	user, err := findUser(a, *username)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("user created: id=%s username=%s role=%s\n", user.ID, user.Username, role.Name)
+	printInitialPassword(initial, generated)
+	return nil
+}
+
+func runUserResetPassword(a *app, args []string) error {
+	fs := newFlagSet("user reset-password")
+	password := fs.String("password", "", "password baru; kosong = dibuat acak")
+	if err := fs.Parse(args); err != nil {
+		return newUsageError("%v", err)
+	}
+	if fs.NArg() != 1 {
+		return newUsageError("user reset-password needs <username|email>")
+	}
+
+	user, err := findUser(a, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	newPassword, generated, err := initialPassword(*password)
+	if err != nil {
+		return err
+	}
+	if err := a.c.Password.SetPassword(user.ID, newPassword); err != nil {
+		return err
+	}
+	fmt.Printf("password reset: username=%s\n", user.Username)
+	printInitialPassword(newPassword, generated)
+	return nil
+}
+
+func runUserSetRole(a *app, args []string) error {
+	if len(args) != 2 {
+		return newUsageError("user set-role needs <username|email> <role>")
+	}
+
+	user, err := findUser(a, args[0])
+	if err != nil {
+		return err
+	}
+	role, err := a.c.Repos.Roles.FindByName(args[1])
+	if err != nil {
+		return fmt.Errorf("role %q not found", args[1])
+	}
+	if err := a.c.Users.UpdateUserRole(user.ID, role.ID); err != nil {
+		return err
+	}
+	fmt.Printf("role updated: username=%s role=%s\n", user.Username, role.Name)
+	return nil
+}
+
+func findUser(a *app, usernameOrEmail string) (*model.User, error) {
+	user, err := a.c.Repos.Users.FindByUsernameOrEmail(strings.TrimSpace(usernameOrEmail))
+	if err != nil {
+		return nil, fmt.Errorf("user %q not found", usernameOrEmail)
+	}
+	return user, nil
+}
+
+// initialPassword: password dari flag, atau acak; user tetap wajib ganti saat login pertama
+func initialPassword(password string) (string, bool, error) {
+	if password != "" {
+		return password, false, nil
+	}
+	token, err := utils.RandomToken(9)
+	return token, true, err
+}
+
+func printInitialPassword(password string, generated bool) {
+	if generated {
+		fmt.Printf("initial password: %s (must be changed on first login)\n", password)
+	}
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
@@ -0,0 +1,12 @@
+// Command prestasi: server dan operasi admin (migrate, seed, user, import, export, check)
+package main
+
+import (
+	"os"
+
+	"github.com/nerhays/prestasi_uas/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:]))
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
@@ -12,7 +13,78 @@ type Config struct {
 	PostgresDSN string
 	MongoURI   string
 	MongoDB    string
-	JWTSecret  string
+
+	// jalankan migration yang belum saat startup; false = server menolak start kalau skema tertinggal
+	MigrateOnStart bool
+
+	// JWT: ditandatangani kunci asimetris yang dirotasi, kunci publik di /.well-known/jwks.json
+	JWTAlgorithm        string // RS256 | EdDSA
+	JWTIssuer           string
+	JWTAudience         string
+	JWTKeyStore         string // postgres | memory (memory: token hangus saat restart)
+	JWTKeyRotationHours int
+
+	// SLA verifikasi prestasi oleh dosen wali
+	VerificationSLAHours    int
+	ReminderIntervalMinutes int // 0 = reminder otomatis mati
+
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	MailFrom     string
+
+	// aturan default bagi poin prestasi tim: equal | full | leader_weighted
+	TeamPointSplit string
+
+	// prestasi di tempat sampah dihapus permanen setelah N hari, 0 = tidak pernah
+	TrashRetentionDays int
+
+	// password policy + reset password
+	PasswordMinLength       int
+	BreachedPasswordsFile   string // kosong = tanpa daftar password bocor
+	PasswordResetTTLMinutes int
+	PasswordResetURL        string // halaman reset di frontend, kosong = token dikirim apa adanya
+
+	// brute-force protection login
+	LoginAttemptStore         string // memory | postgres (wajib postgres kalau lebih dari satu instance)
+	LoginMaxFailures          int
+	LoginIPMaxFailures        int
+	LoginFailureWindowMinutes int
+	LoginLockoutMinutes       int
+	LoginDelayAfter           int // jeda progresif mulai setelah N kali gagal
+
+	// nama layanan yang tampil di aplikasi authenticator (TOTP)
+	TOTPIssuer string
+
+	// SSO via OpenID Connect, OIDCIssuerURL kosong = SSO mati
+	OIDCProvider      string
+	OIDCIssuerURL     string
+	OIDCClientID      string
+	OIDCClientSecret  string
+	OIDCRedirectURL   string
+	OIDCScopes        string // dipisah spasi, "openid" selalu ditambahkan
+	OIDCNIMClaim      string
+	OIDCAutoProvision bool
+	OIDCDefaultRole   string
+
+	// backend login global: local (bcrypt) | ldap; bisa di-override per user (users.auth_backend)
+	AuthBackend string
+
+	// direktori LDAP, LDAPURL kosong = backend ldap tidak tersedia
+	LDAPURL                string
+	LDAPStartTLS           bool
+	LDAPInsecureSkipVerify bool
+	LDAPBindDN             string
+	LDAPBindPassword       string
+	LDAPBaseDN             string
+	LDAPUserFilter         string // %s = username
+	LDAPUsernameAttr       string
+	LDAPEmailAttr          string
+	LDAPNameAttr           string
+	LDAPGroupAttr          string
+	LDAPGroupRoles         string // "<group DN>:<role>;..." user baru dari direktori wajib punya grup terpetakan
+	LDAPTimeoutSeconds     int
 }
 
 func LoadConfig() *Config {
@@ -23,7 +95,67 @@ func LoadConfig() *Config {
 		PostgresDSN: getEnv("POSTGRES_DSN", ""),
 		MongoURI:   getEnv("MONGO_URI", "mongodb://localhost:27017"),
 		MongoDB:    getEnv("MONGO_DB", "prestasi_db"),
-		JWTSecret:  getEnv("JWT_SECRET", "changeme"),
+
+		MigrateOnStart: getEnvBool("MIGRATE_ON_START", false),
+
+		JWTAlgorithm:        getEnv("JWT_ALGORITHM", "RS256"),
+		JWTIssuer:           getEnv("JWT_ISSUER", "prestasi-api"),
+		JWTAudience:         getEnv("JWT_AUDIENCE", "prestasi"),
+		JWTKeyStore:         getEnv("JWT_KEY_STORE", "postgres"),
+		JWTKeyRotationHours: getEnvInt("JWT_KEY_ROTATION_HOURS", 24*30),
+
+		VerificationSLAHours:    getEnvInt("VERIFICATION_SLA_HOURS", 72),
+		ReminderIntervalMinutes: getEnvInt("REMINDER_INTERVAL_MINUTES", 60),
+
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		MailFrom:     getEnv("MAIL_FROM", "noreply@prestasi.ac.id"),
+
+		TeamPointSplit: getEnv("TEAM_POINT_SPLIT", "equal"),
+
+		TrashRetentionDays: getEnvInt("TRASH_RETENTION_DAYS", 30),
+
+		PasswordMinLength:       getEnvInt("PASSWORD_MIN_LENGTH", 8),
+		BreachedPasswordsFile:   getEnv("BREACHED_PASSWORDS_FILE", "config/breached_passwords.txt"),
+		PasswordResetTTLMinutes: getEnvInt("PASSWORD_RESET_TTL_MINUTES", 30),
+		PasswordResetURL:        getEnv("PASSWORD_RESET_URL", ""),
+
+		LoginAttemptStore:         getEnv("LOGIN_ATTEMPT_STORE", "memory"),
+		LoginMaxFailures:          getEnvInt("LOGIN_MAX_FAILURES", 5),
+		LoginIPMaxFailures:        getEnvInt("LOGIN_IP_MAX_FAILURES", 50),
+		LoginFailureWindowMinutes: getEnvInt("LOGIN_FAILURE_WINDOW_MINUTES", 15),
+		LoginLockoutMinutes:       getEnvInt("LOGIN_LOCKOUT_MINUTES", 15),
+		LoginDelayAfter:           getEnvInt("LOGIN_DELAY_AFTER", 3),
+
+		TOTPIssuer: getEnv("TOTP_ISSUER", "Prestasi Mahasiswa"),
+
+		OIDCProvider:      getEnv("OIDC_PROVIDER", "campus"),
+		OIDCIssuerURL:     getEnv("OIDC_ISSUER_URL", ""),
+		OIDCClientID:      getEnv("OIDC_CLIENT_ID", ""),
+		OIDCClientSecret:  getEnv("OIDC_CLIENT_SECRET", ""),
+		OIDCRedirectURL:   getEnv("OIDC_REDIRECT_URL", "http://localhost:8080/api/v1/auth/oidc/callback"),
+		OIDCScopes:        getEnv("OIDC_SCOPES", "email profile"),
+		OIDCNIMClaim:      getEnv("OIDC_NIM_CLAIM", "nim"),
+		OIDCAutoProvision: getEnvBool("OIDC_AUTO_PROVISION", false),
+		OIDCDefaultRole:   getEnv("OIDC_DEFAULT_ROLE", "Mahasiswa"),
+
+		AuthBackend: getEnv("AUTH_BACKEND", "local"),
+
+		LDAPURL:                getEnv("LDAP_URL", ""),
+		LDAPStartTLS:           getEnvBool("LDAP_START_TLS", false),
+		LDAPInsecureSkipVerify: getEnvBool("LDAP_INSECURE_SKIP_VERIFY", false),
+		LDAPBindDN:             getEnv("LDAP_BIND_DN", ""),
+		LDAPBindPassword:       getEnv("LDAP_BIND_PASSWORD", ""),
+		LDAPBaseDN:             getEnv("LDAP_BASE_DN", ""),
+		LDAPUserFilter:         getEnv("LDAP_USER_FILTER", "(uid=%s)"),
+		LDAPUsernameAttr:       getEnv("LDAP_USERNAME_ATTR", "uid"),
+		LDAPEmailAttr:          getEnv("LDAP_EMAIL_ATTR", "mail"),
+		LDAPNameAttr:           getEnv("LDAP_NAME_ATTR", "cn"),
+		LDAPGroupAttr:          getEnv("LDAP_GROUP_ATTR", "memberOf"),
+		LDAPGroupRoles:         getEnv("LDAP_GROUP_ROLES", ""),
+		LDAPTimeoutSeconds:     getEnvInt("LDAP_TIMEOUT_SECONDS", 5),
 	}
 
 	if cfg.PostgresDSN == "" {
@@ -39,3 +171,31 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvInt(key string, fallback int) int {
+	v := getEnv(key, "")
+	if v == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("[WARN] %s is not a number, using %d", key, fallback)
+		return fallback
+	}
+	return n
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	v := getEnv(key, "")
+	if v == "" {
+		return fallback
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Printf("[WARN] %s is not a boolean, using %t", key, fallback)
+		return fallback
+	}
+	return b
+}
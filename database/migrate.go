@@ -0,0 +1,238 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+)
+
+// migrations/<store>/<versi>_<nama>.up.<ext> + .down.<ext>; versi bertambah, tidak boleh diubah setelah dirilis
+//
+//go:embed migrations/postgres/*.sql migrations/mongo/*.json
+var migrationFiles embed.FS
+
+var migrationFileRe = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.(sql|json)$`)
+
+var ErrSchemaOutdated = errors.New("database schema is outdated, run `migrate up` first")
+
+// Migration: satu langkah skema; Up/Down berisi SQL (Postgres) atau array perintah JSON (Mongo)
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus: status satu migration di satu store (postgres | mongo)
+type MigrationStatus struct {
+	Store     string
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// migrationTarget: tempat migration dijalankan + tabel/collection versi skema
+type migrationTarget interface {
+	name() string
+	ensureVersionTable(ctx context.Context) error
+	applied(ctx context.Context) (map[int]time.Time, error)
+	// apply menjalankan script dan mencatat / menghapus versi secara atomik (sejauh store mendukung);
+	// false = versi sudah dijalankan proses lain
+	apply(ctx context.Context, m Migration, up bool) (bool, error)
+}
+
+type Migrator struct {
+	targets    []migrationTarget
+	migrations map[string][]Migration
+}
+
+// NewMigrator: migration Postgres + Mongo yang di-embed ke binary
+func NewMigrator(pg *gorm.DB, mongoDB *mongo.Database) (*Migrator, error) {
+	m := &Migrator{migrations: map[string][]Migration{}}
+	for _, t := range []migrationTarget{newPostgresTarget(pg), newMongoTarget(mongoDB)} {
+		list, err := loadMigrations(migrationFiles, path.Join("migrations", t.name()))
+		if err != nil {
+			return nil, err
+		}
+		m.targets = append(m.targets, t)
+		m.migrations[t.name()] = list
+	}
+	return m, nil
+}
+
+// Up: jalankan semua migration yang belum, Postgres dulu lalu Mongo
+func (m *Migrator) Up(ctx context.Context) ([]MigrationStatus, error) {
+	var done []MigrationStatus
+	for _, t := range m.targets {
+		pending, err := m.pending(ctx, t)
+		if err != nil {
+			return done, err
+		}
+		for _, mig := range pending {
+			ok, err := t.apply(ctx, mig, true)
+			if err != nil {
+				return done, fmt.Errorf("%s migration %04d_%s: %w", t.name(), mig.Version, mig.Name, err)
+			}
+			if ok {
+				now := time.Now()
+				done = append(done, MigrationStatus{Store: t.name(), Version: mig.Version, Name: mig.Name, AppliedAt: &now})
+			}
+		}
+	}
+	return done, nil
+}
+
+// Down: batalkan steps migration terakhir di satu store
+func (m *Migrator) Down(ctx context.Context, store string, steps int) ([]MigrationStatus, error) {
+	t := m.target(store)
+	if t == nil {
+		return nil, fmt.Errorf("unknown migration store %q (postgres | mongo)", store)
+	}
+	if err := t.ensureVersionTable(ctx); err != nil {
+		return nil, err
+	}
+	applied, err := t.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := m.migrations[store]
+	var done []MigrationStatus
+	for i := len(list) - 1; i >= 0 && len(done) < steps; i-- {
+		mig := list[i]
+		if _, ok := applied[mig.Version]; !ok {
+			continue
+		}
+		ok, err := t.apply(ctx, mig, false)
+		if err != nil {
+			return done, fmt.Errorf("%s migration %04d_%s (down): %w", store, mig.Version, mig.Name, err)
+		}
+		if ok {
+			done = append(done, MigrationStatus{Store: store, Version: mig.Version, Name: mig.Name})
+		}
+	}
+	return done, nil
+}
+
+// Status: semua migration yang dikenal binary ini; AppliedAt nil = belum dijalankan
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var out []MigrationStatus
+	for _, t := range m.targets {
+		if err := t.ensureVersionTable(ctx); err != nil {
+			return nil, err
+		}
+		applied, err := t.applied(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, mig := range m.migrations[t.name()] {
+			st := MigrationStatus{Store: t.name(), Version: mig.Version, Name: mig.Name}
+			if at, ok := applied[mig.Version]; ok {
+				st.AppliedAt = &at
+			}
+			out = append(out, st)
+		}
+	}
+	return out, nil
+}
+
+// Check: dipanggil saat startup; error kalau masih ada migration yang belum dijalankan.
+// Versi di database yang lebih baru dari binary (rollback aplikasi) tidak dianggap error.
+func (m *Migrator) Check(ctx context.Context) error {
+	var missing []string
+	for _, t := range m.targets {
+		pending, err := m.pending(ctx, t)
+		if err != nil {
+			return err
+		}
+		for _, mig := range pending {
+			missing = append(missing, fmt.Sprintf("%s %04d_%s", t.name(), mig.Version, mig.Name))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w (pending: %s)", ErrSchemaOutdated, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func (m *Migrator) pending(ctx context.Context, t migrationTarget) ([]Migration, error) {
+	if err := t.ensureVersionTable(ctx); err != nil {
+		return nil, fmt.Errorf("%s schema version table: %w", t.name(), err)
+	}
+	applied, err := t.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, mig := range m.migrations[t.name()] {
+		if _, ok := applied[mig.Version]; !ok {
+			pending = append(pending, mig)
+		}
+	}
+	return pending, nil
+}
+
+func (m *Migrator) target(store string) migrationTarget {
+	for _, t := range m.targets {
+		if t.name() == store {
+			return t
+		}
+	}
+	return nil
+}
+
+// loadMigrations: pasangan up/down per versi, urut naik; versi ganda / tanpa pasangan = error
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, e := range entries {
+		match := migrationFileRe.FindStringSubmatch(e.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %s/%s", dir, e.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		body, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		mig := byVersion[version]
+		if mig == nil {
+			mig = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != match[2] {
+			return nil, fmt.Errorf("migration %s/%04d has two names: %s and %s", dir, version, mig.Name, match[2])
+		}
+		if match[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if strings.TrimSpace(mig.Up) == "" || strings.TrimSpace(mig.Down) == "" {
+			return nil, fmt.Errorf("migration %s/%04d_%s needs both up and down files", dir, mig.Version, mig.Name)
+		}
+		list = append(list, *mig)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	mongoNamespaceNotFound = 26
+	mongoNamespaceExists   = 48
+)
+
+// mongoTarget: migration Mongo = array perintah database (Extended JSON) yang dijalankan berurutan,
+// mis. create (dengan validator), collMod, createIndexes, dropIndexes, drop
+type mongoTarget struct {
+	db *mongo.Database
+}
+
+func newMongoTarget(db *mongo.Database) *mongoTarget {
+	return &mongoTarget{db: db}
+}
+
+func (t *mongoTarget) name() string { return "mongo" }
+
+func (t *mongoTarget) versions() *mongo.Collection {
+	return t.db.Collection("schema_migrations")
+}
+
+// ensureVersionTable: collection dibuat otomatis saat insert pertama
+func (t *mongoTarget) ensureVersionTable(ctx context.Context) error {
+	return nil
+}
+
+func (t *mongoTarget) applied(ctx context.Context) (map[int]time.Time, error) {
+	cur, err := t.versions().Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		Version   int       `bson:"_id"`
+		AppliedAt time.Time `bson:"appliedAt"`
+	}
+	if err := cur.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]time.Time, len(rows))
+	for _, r := range rows {
+		applied[r.Version] = r.AppliedAt
+	}
+	return applied, nil
+}
+
+// apply: Mongo tidak punya DDL transaksional, jadi versi dicatat dulu sebagai kunci
+// (insert _id unik) dan dikembalikan kalau salah satu perintah gagal
+func (t *mongoTarget) apply(ctx context.Context, m Migration, up bool) (bool, error) {
+	script := m.Down
+	if up {
+		script = m.Up
+	}
+	commands, err := parseMongoCommands(script)
+	if err != nil {
+		return false, err
+	}
+
+	if up {
+		_, err := t.versions().InsertOne(ctx, bson.M{"_id": m.Version, "name": m.Name, "appliedAt": time.Now()})
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	} else {
+		res, err := t.versions().DeleteOne(ctx, bson.M{"_id": m.Version})
+		if err != nil {
+			return false, err
+		}
+		if res.DeletedCount == 0 {
+			return false, nil
+		}
+	}
+
+	if err := t.run(ctx, commands); err != nil {
+		// kembalikan catatan versi supaya migration bisa diulang setelah diperbaiki
+		if up {
+			_, _ = t.versions().DeleteOne(ctx, bson.M{"_id": m.Version})
+		} else {
+			_, _ = t.versions().InsertOne(ctx, bson.M{"_id": m.Version, "name": m.Name, "appliedAt": time.Now()})
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (t *mongoTarget) run(ctx context.Context, commands []bson.D) error {
+	for _, cmd := range commands {
+		err := t.db.RunCommand(ctx, cmd).Err()
+		if err == nil {
+			continue
+		}
+
+		var cmdErr mongo.CommandError
+		if !errors.As(err, &cmdErr) {
+			return err
+		}
+		switch {
+		case cmd[0].Key == "create" && cmdErr.Code == mongoNamespaceExists:
+			// collection lama (dibuat mongo-init.js): validator tetap diperbarui
+			if mod := collModFromCreate(cmd); mod != nil {
+				if err := t.db.RunCommand(ctx, mod).Err(); err != nil {
+					return fmt.Errorf("collMod %v: %w", cmd[0].Value, err)
+				}
+			}
+		case cmd[0].Key == "drop" && cmdErr.Code == mongoNamespaceNotFound:
+			// sudah tidak ada
+		default:
+			return fmt.Errorf("%s %v: %w", cmd[0].Key, cmd[0].Value, err)
+		}
+	}
+	return nil
+}
+
+func parseMongoCommands(script string) ([]bson.D, error) {
+	var wrapper struct {
+		Commands []bson.D `bson:"commands"`
+	}
+	if err := bson.UnmarshalExtJSON([]byte(`{"commands":`+script+`}`), false, &wrapper); err != nil {
+		return nil, fmt.Errorf("invalid mongo migration: %w", err)
+	}
+	for i, cmd := range wrapper.Commands {
+		if len(cmd) == 0 {
+			return nil, fmt.Errorf("invalid mongo migration: command #%d is empty", i+1)
+		}
+	}
+	return wrapper.Commands, nil
+}
+
+func collModFromCreate(create bson.D) bson.D {
+	mod := bson.D{{Key: "collMod", Value: create[0].Value}}
+	for _, e := range create[1:] {
+		switch e.Key {
+		case "validator", "validationLevel", "validationAction":
+			mod = append(mod, e)
+		}
+	}
+	if len(mod) == 1 {
+		return nil
+	}
+	return mod
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// postgresMigrationLock: kunci advisory supaya dua instance tidak menjalankan migration bersamaan
+const postgresMigrationLock = 4604601
+
+var errMigrationSkipped = errors.New("migration already handled by another process")
+
+type postgresTarget struct {
+	db *gorm.DB
+}
+
+func newPostgresTarget(db *gorm.DB) *postgresTarget {
+	return &postgresTarget{db: db}
+}
+
+func (t *postgresTarget) name() string { return "postgres" }
+
+func (t *postgresTarget) ensureVersionTable(ctx context.Context) error {
+	return t.db.WithContext(ctx).Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`).Error
+}
+
+func (t *postgresTarget) applied(ctx context.Context) (map[int]time.Time, error) {
+	var rows []struct {
+		Version   int
+		AppliedAt time.Time
+	}
+	if err := t.db.WithContext(ctx).
+		Raw("SELECT version, applied_at FROM schema_migrations").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]time.Time, len(rows))
+	for _, r := range rows {
+		applied[r.Version] = r.AppliedAt
+	}
+	return applied, nil
+}
+
+// apply: script + catatan versi dalam satu transaksi (DDL Postgres transaksional)
+func (t *postgresTarget) apply(ctx context.Context, m Migration, up bool) (bool, error) {
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", postgresMigrationLock).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Raw("SELECT COUNT(*) FROM schema_migrations WHERE version = ?", m.Version).Scan(&count).Error; err != nil {
+			return err
+		}
+		if (count > 0) == up {
+			return errMigrationSkipped
+		}
+
+		script := m.Down
+		if up {
+			script = m.Up
+		}
+		// lewat ConnPool langsung: script bisa berisi banyak statement, "?" dan blok $$ ... $$
+		if _, err := tx.Statement.ConnPool.ExecContext(ctx, script); err != nil {
+			return err
+		}
+
+		if up {
+			return tx.Exec("INSERT INTO schema_migrations (version, name) VALUES (?, ?)", m.Version, m.Name).Error
+		}
+		return tx.Exec("DELETE FROM schema_migrations WHERE version = ?", m.Version).Error
+	})
+	if errors.Is(err, errMigrationSkipped) {
+		return false, nil
+	}
+	return err == nil, err
+}
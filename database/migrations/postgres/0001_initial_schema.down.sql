@@ -0,0 +1,31 @@
+-- menghapus SELURUH skema beserta datanya; urutan kebalikan dari up (tabel anak dulu)
+
+DROP TABLE IF EXISTS import_jobs;
+DROP TABLE IF EXISTS achievement_duplicate_flags;
+DROP TABLE IF EXISTS verification_delegations;
+DROP TABLE IF EXISTS advisor_assignments;
+DROP TABLE IF EXISTS achievement_team_members;
+DROP TABLE IF EXISTS achievement_references;
+DROP TABLE IF EXISTS academic_periods;
+DROP TYPE IF EXISTS achievement_status;
+DROP TABLE IF EXISTS students;
+DROP TABLE IF EXISTS lecturers;
+DROP TABLE IF EXISTS api_token_permissions;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS login_audits;
+DROP TABLE IF EXISTS login_throttles;
+DROP TABLE IF EXISTS audit_logs;
+DROP FUNCTION IF EXISTS audit_logs_append_only();
+DROP TABLE IF EXISTS api_tokens;
+DROP TABLE IF EXISTS jwt_signing_keys;
+DROP TABLE IF EXISTS oidc_login_states;
+DROP TABLE IF EXISTS user_identities;
+DROP TABLE IF EXISTS two_factor_recovery_codes;
+DROP TABLE IF EXISTS user_two_factors;
+DROP TABLE IF EXISTS password_reset_tokens;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS study_programs;
+DROP TABLE IF EXISTS departments;
+DROP TABLE IF EXISTS faculties;
+DROP TABLE IF EXISTS roles;
@@ -0,0 +1,463 @@
+-- skema awal (dulu database/schema.sql yang dijalankan manual).
+-- Semua DDL idempotent supaya database lama yang dibuat dari schema.sql bisa langsung diadopsi;
+-- kolom yang tidak ada di schema.sql ditambahkan lewat ALTER TABLE ... ADD COLUMN IF NOT EXISTS.
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+-- roles
+CREATE TABLE IF NOT EXISTS roles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(50) UNIQUE NOT NULL,
+    description TEXT,
+    -- user dengan role ini wajib mengaktifkan 2FA
+    require_two_factor BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+ALTER TABLE roles ADD COLUMN IF NOT EXISTS require_two_factor BOOLEAN DEFAULT FALSE;
+
+-- faculties -> departments -> study_programs
+CREATE TABLE IF NOT EXISTS faculties (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(20) UNIQUE NOT NULL,
+    name VARCHAR(150) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS departments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    faculty_id UUID NOT NULL REFERENCES faculties(id) ON DELETE RESTRICT,
+    code VARCHAR(20) UNIQUE NOT NULL,
+    name VARCHAR(150) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS study_programs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    department_id UUID NOT NULL REFERENCES departments(id) ON DELETE RESTRICT,
+    code VARCHAR(20) UNIQUE NOT NULL,
+    name VARCHAR(150) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- users
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    username VARCHAR(50) UNIQUE NOT NULL,
+    email VARCHAR(100) UNIQUE NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    full_name VARCHAR(100) NOT NULL,
+    role_id UUID REFERENCES roles(id),
+    is_active BOOLEAN DEFAULT TRUE,
+    -- scope admin fakultas/jurusan (NULL = admin global)
+    scope_faculty_id UUID REFERENCES faculties(id) ON DELETE SET NULL,
+    scope_department_id UUID REFERENCES departments(id) ON DELETE SET NULL,
+    -- akun seed / dibuat admin wajib ganti password saat login pertama
+    must_change_password BOOLEAN DEFAULT FALSE,
+    password_changed_at TIMESTAMP,
+    two_factor_enabled BOOLEAN DEFAULT FALSE,
+    -- local | ldap, NULL = ikut AUTH_BACKEND
+    auth_backend VARCHAR(20),
+    -- akun layanan: tanpa login password, akses hanya lewat api_tokens
+    is_service_account BOOLEAN DEFAULT FALSE,
+    -- nonaktif oleh admin (pengganti hard delete)
+    deactivated_at TIMESTAMP,
+    -- access token dengan iat sebelum waktu ini ditolak
+    sessions_revoked_at TIMESTAMP,
+    -- data pribadi sudah dianonimkan
+    anonymized_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS scope_faculty_id UUID REFERENCES faculties(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS scope_department_id UUID REFERENCES departments(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS must_change_password BOOLEAN DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS two_factor_enabled BOOLEAN DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS auth_backend VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS is_service_account BOOLEAN DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS sessions_revoked_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS anonymized_at TIMESTAMP;
+
+-- password_reset_tokens (lupa password, token disimpan sebagai hash SHA-256)
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_reset_user ON password_reset_tokens(user_id) WHERE used_at IS NULL;
+
+-- user_two_factors (secret TOTP; enabled setelah kode pertama diverifikasi)
+CREATE TABLE IF NOT EXISTS user_two_factors (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    enabled BOOLEAN DEFAULT FALSE,
+    confirmed_at TIMESTAMP,
+    last_used_step BIGINT DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- two_factor_recovery_codes (kode cadangan sekali pakai, disimpan sebagai hash SHA-256)
+CREATE TABLE IF NOT EXISTS two_factor_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_two_factor_recovery_user ON two_factor_recovery_codes(user_id) WHERE used_at IS NULL;
+
+-- user_identities (akun SSO / OIDC yang terhubung ke user lokal)
+CREATE TABLE IF NOT EXISTS user_identities (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(100),
+    last_login_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_identities_user ON user_identities(user_id);
+
+-- oidc_login_states (state + nonce + PKCE verifier login SSO, sekali pakai)
+CREATE TABLE IF NOT EXISTS oidc_login_states (
+    state VARCHAR(64) PRIMARY KEY,
+    nonce VARCHAR(64) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- jwt_signing_keys (kunci tanda tangan JWT; pensiun = hanya verifikasi sampai expires_at)
+CREATE TABLE IF NOT EXISTS jwt_signing_keys (
+    kid VARCHAR(64) PRIMARY KEY,
+    algorithm VARCHAR(10) NOT NULL,
+    private_key TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    retired_at TIMESTAMP,
+    expires_at TIMESTAMP
+);
+
+-- api_tokens (personal access token / token akun layanan, disimpan sebagai hash SHA-256)
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    token_prefix VARCHAR(16) NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP,
+    last_used_ip VARCHAR(45),
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_api_tokens_user ON api_tokens(user_id);
+
+-- audit_logs (jejak aksi yang mengubah data; append-only, hash = sha256(prev_hash + isi entri))
+CREATE TABLE IF NOT EXISTS audit_logs (
+    seq BIGINT PRIMARY KEY,
+    actor_id UUID,
+    actor_username VARCHAR(50),
+    actor_role VARCHAR(50),
+    api_token_id UUID,
+    action VARCHAR(150) NOT NULL,
+    target_type VARCHAR(50),
+    target_id VARCHAR(100),
+    before_state JSONB,
+    after_state JSONB,
+    changes JSONB,
+    status_code INT,
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(255),
+    request_id VARCHAR(64),
+    created_at TIMESTAMP NOT NULL,
+    prev_hash VARCHAR(64),
+    hash VARCHAR(64) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created ON audit_logs(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor ON audit_logs(actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_target ON audit_logs(target_type, target_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_request ON audit_logs(request_id);
+
+-- satu-satunya UPDATE yang diizinkan: pseudonimisasi actor_username (tidak masuk hash) saat user dianonimkan
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+DECLARE
+    probe audit_logs%ROWTYPE;
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        probe := NEW;
+        probe.actor_username := OLD.actor_username;
+        IF probe IS NOT DISTINCT FROM OLD THEN
+            RETURN NEW;
+        END IF;
+    END IF;
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_audit_logs_append_only ON audit_logs;
+CREATE TRIGGER trg_audit_logs_append_only
+    BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
+
+-- login_throttles (counter login gagal, dipakai kalau LOGIN_ATTEMPT_STORE=postgres)
+CREATE TABLE IF NOT EXISTS login_throttles (
+    throttle_key VARCHAR(255) PRIMARY KEY, -- user:<username> | ip:<alamat>
+    failures INT NOT NULL DEFAULT 0,
+    window_start TIMESTAMP NOT NULL,
+    last_failure_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP
+);
+
+-- login_audits (jejak percobaan login)
+CREATE TABLE IF NOT EXISTS login_audits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    username VARCHAR(100),
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(255),
+    success BOOLEAN NOT NULL,
+    reason VARCHAR(50),
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_audits_created ON login_audits(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_login_audits_username ON login_audits(LOWER(username));
+
+-- permissions
+CREATE TABLE IF NOT EXISTS permissions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) UNIQUE NOT NULL,
+    resource VARCHAR(50) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    description TEXT
+);
+
+-- pivot
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id UUID REFERENCES roles(id),
+    permission_id UUID REFERENCES permissions(id),
+    PRIMARY KEY (role_id, permission_id)
+);
+
+-- api_token_permissions (scope token, subset permission pemilik token)
+CREATE TABLE IF NOT EXISTS api_token_permissions (
+    token_id UUID REFERENCES api_tokens(id) ON DELETE CASCADE,
+    permission_id UUID REFERENCES permissions(id) ON DELETE CASCADE,
+    PRIMARY KEY (token_id, permission_id)
+);
+
+-- lecturers
+CREATE TABLE IF NOT EXISTS lecturers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id),
+    lecturer_id VARCHAR(20) UNIQUE NOT NULL,
+    department VARCHAR(100),
+    department_id UUID REFERENCES departments(id),
+    created_at TIMESTAMP DEFAULT NOW()
+);
+ALTER TABLE lecturers ADD COLUMN IF NOT EXISTS department_id UUID REFERENCES departments(id);
+
+-- students
+CREATE TABLE IF NOT EXISTS students (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id),
+    student_id VARCHAR(20) UNIQUE NOT NULL,
+    program_study VARCHAR(100),
+    study_program_id UUID REFERENCES study_programs(id),
+    academic_year VARCHAR(10),
+    advisor_id UUID REFERENCES lecturers(id),
+    created_at TIMESTAMP DEFAULT NOW()
+);
+ALTER TABLE students ADD COLUMN IF NOT EXISTS study_program_id UUID REFERENCES study_programs(id);
+
+-- enum for achievement status
+DO $$ BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'achievement_status') THEN
+        CREATE TYPE achievement_status AS ENUM ('draft','submitted','verified','rejected');
+    END IF;
+END$$;
+
+-- academic_periods (semester + jendela pengajuan prestasi)
+CREATE TABLE IF NOT EXISTS academic_periods (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    academic_year VARCHAR(9) NOT NULL,
+    semester VARCHAR(10) NOT NULL CHECK (semester IN ('ganjil','genap')),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    submission_opens_at TIMESTAMP,
+    submission_closes_at TIMESTAMP,
+    is_active BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (academic_year, semester),
+    CHECK (start_date <= end_date)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_academic_periods_active ON academic_periods(is_active) WHERE is_active;
+
+-- achievement_references
+CREATE TABLE IF NOT EXISTS achievement_references (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    student_id UUID REFERENCES students(id),
+    mongo_achievement_id VARCHAR(24) NOT NULL,
+    period_id UUID REFERENCES academic_periods(id) ON DELETE SET NULL,
+    status achievement_status,
+    submitted_at TIMESTAMP,
+    verified_at TIMESTAMP,
+    verified_by UUID REFERENCES users(id),
+    verified_version INT, -- versi achievement_versions (Mongo) yang diverifikasi
+    rejection_note TEXT,
+    last_reminded_at TIMESTAMP,
+    team_role VARCHAR(10), -- NULL = prestasi individu
+    point_share NUMERIC(10,2),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS period_id UUID REFERENCES academic_periods(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS verified_version INT,
+    ADD COLUMN IF NOT EXISTS last_reminded_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS team_role VARCHAR(10),
+    ADD COLUMN IF NOT EXISTS point_share NUMERIC(10,2);
+
+-- Optional: index untuk query paling umum
+CREATE INDEX IF NOT EXISTS idx_achievement_ref_student ON achievement_references(student_id);
+CREATE INDEX IF NOT EXISTS idx_achievement_ref_status ON achievement_references(status);
+CREATE INDEX IF NOT EXISTS idx_students_study_program ON students(study_program_id);
+CREATE INDEX IF NOT EXISTS idx_achievement_ref_period ON achievement_references(period_id);
+CREATE INDEX IF NOT EXISTS idx_achievement_ref_mongo ON achievement_references(mongo_achievement_id);
+
+-- achievement_team_members (anggota + undangan prestasi tim)
+CREATE TABLE IF NOT EXISTS achievement_team_members (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    mongo_achievement_id VARCHAR(24) NOT NULL,
+    student_id UUID NOT NULL REFERENCES students(id),
+    role VARCHAR(10) NOT NULL CHECK (role IN ('leader', 'member')),
+    status VARCHAR(10) NOT NULL CHECK (status IN ('pending', 'accepted', 'declined')),
+    reference_id UUID REFERENCES achievement_references(id) ON DELETE SET NULL,
+    invited_by UUID NOT NULL REFERENCES students(id),
+    responded_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (mongo_achievement_id, student_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_members_student ON achievement_team_members(student_id, status);
+
+-- advisor_assignments (riwayat dosen wali; effective_to NULL = penugasan saat ini)
+CREATE TABLE IF NOT EXISTS advisor_assignments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    student_id UUID NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    lecturer_id UUID NOT NULL REFERENCES lecturers(id),
+    effective_from TIMESTAMP NOT NULL,
+    effective_to TIMESTAMP,
+    reason VARCHAR(255),
+    -- prestasi submitted yang ikut pindah saat penugasan dimulai
+    pending_handed_over INT DEFAULT 0,
+    assigned_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_advisor_assignments_student ON advisor_assignments(student_id, effective_from);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_advisor_assignments_current ON advisor_assignments(student_id) WHERE effective_to IS NULL;
+
+-- verification_delegations (hak verifikasi dosen wali dialihkan sementara ke dosen lain)
+CREATE TABLE IF NOT EXISTS verification_delegations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    from_lecturer_id UUID NOT NULL REFERENCES lecturers(id),
+    to_lecturer_id UUID NOT NULL REFERENCES lecturers(id),
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    reason VARCHAR(255),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    CHECK (ends_at > starts_at),
+    CHECK (from_lecturer_id <> to_lecturer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_verification_delegations_to ON verification_delegations(to_lecturer_id, ends_at) WHERE revoked_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_verification_delegations_from ON verification_delegations(from_lecturer_id, ends_at) WHERE revoked_at IS NULL;
+
+-- achievement_duplicate_flags (deteksi duplikat saat submit)
+CREATE TABLE IF NOT EXISTS achievement_duplicate_flags (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    reference_id UUID NOT NULL REFERENCES achievement_references(id) ON DELETE CASCADE,
+    mongo_achievement_id VARCHAR(24) NOT NULL,
+    matched_mongo_achievement_id VARCHAR(24) NOT NULL,
+    matched_student_id UUID,
+    matched_title VARCHAR(255),
+    reasons JSONB,
+    score NUMERIC(4,2) NOT NULL DEFAULT 0,
+    status VARCHAR(10) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'dismissed', 'confirmed')),
+    reviewed_by UUID REFERENCES users(id),
+    reviewed_at TIMESTAMP,
+    review_note TEXT,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (reference_id, matched_mongo_achievement_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_duplicate_flags_status ON achievement_duplicate_flags(status, score DESC);
+
+-- import_jobs (bulk import user + profil)
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    file_name VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    total_rows INT DEFAULT 0,
+    processed_rows INT DEFAULT 0,
+    created_rows INT DEFAULT 0,
+    updated_rows INT DEFAULT 0,
+    failed_rows INT DEFAULT 0,
+    errors JSONB,
+    created_by UUID REFERENCES users(id),
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Seed roles
+INSERT INTO roles (name, description)
+VALUES 
+ ('Admin','Pengelola sistem'),
+ ('Mahasiswa','Mahasiswa pelapor prestasi'),
+ ('Dosen Wali','Dosen pembimbing akademik')
+ON CONFLICT (name) DO NOTHING;
+
+-- Seed permissions
+INSERT INTO permissions (name, resource, action, description) VALUES
+ ('achievement:create','achievement','create','Buat prestasi'),
+ ('achievement:read','achievement','read','Lihat prestasi'),
+ ('achievement:update','achievement','update','Update prestasi'),
+ ('achievement:delete','achievement','delete','Hapus prestasi'),
+ ('achievement:verify','achievement','verify','Verifikasi prestasi'),
+ ('user:manage','user','manage','Kelola user'),
+ ('student:read','student','read','Lihat data mahasiswa'),
+ ('lecturer:read','lecturer','read','Lihat data dosen'),
+ ('report:read','report','read','Lihat laporan & statistik')
+ON CONFLICT (name) DO NOTHING;
+
+-- (Optional) map some basic permissions to roles (example)
+-- You can adjust role-permission mapping later via seeder code or admin UI
+-- Example: Admin gets all permissions
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'Admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,8 @@
+-- nilai enum tidak bisa dihapus langsung: tipe dibuat ulang tanpa 'deleted'.
+-- Prestasi yang masih di tempat sampah dikembalikan ke draft.
+ALTER TABLE achievement_references ALTER COLUMN status TYPE VARCHAR(20);
+UPDATE achievement_references SET status = 'draft' WHERE status = 'deleted';
+DROP TYPE achievement_status;
+CREATE TYPE achievement_status AS ENUM ('draft','submitted','verified','rejected');
+ALTER TABLE achievement_references
+    ALTER COLUMN status TYPE achievement_status USING status::achievement_status;
@@ -0,0 +1,3 @@
+-- status 'deleted' (tempat sampah prestasi) sudah dipakai aplikasi tapi belum ada di enum.
+-- ADD VALUE di dalam transaksi butuh PostgreSQL 12+.
+ALTER TYPE achievement_status ADD VALUE IF NOT EXISTS 'deleted';
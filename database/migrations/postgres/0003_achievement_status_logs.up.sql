@@ -0,0 +1,13 @@
+-- achievement_status_logs (riwayat perubahan status prestasi; dipakai timeline, restore dan analitik)
+CREATE TABLE IF NOT EXISTS achievement_status_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    achievement_reference_id UUID NOT NULL REFERENCES achievement_references(id) ON DELETE CASCADE,
+    old_status VARCHAR(20),
+    new_status VARCHAR(20) NOT NULL,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    note TEXT,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_status_logs_reference ON achievement_status_logs(achievement_reference_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_status_logs_new_status ON achievement_status_logs(new_status, created_at);
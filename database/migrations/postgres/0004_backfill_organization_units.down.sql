@@ -0,0 +1,2 @@
+-- migrasi data: FK hasil pemetaan tidak bisa dibedakan dari yang diisi manual, jadi tidak dibatalkan
+SELECT 1;
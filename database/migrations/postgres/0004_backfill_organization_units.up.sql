@@ -0,0 +1,16 @@
+-- petakan teks lama students.program_study / lecturers.department ke FK prodi / jurusan
+-- (cocok dengan nama atau kode, tanpa beda huruf besar & spasi). Nilai yang tidak cocok
+-- dibiarkan NULL dan bisa dilihat di GET /admin/organization/unmapped.
+UPDATE students st
+SET study_program_id = sp.id
+FROM study_programs sp
+WHERE st.study_program_id IS NULL
+  AND LOWER(REGEXP_REPLACE(TRIM(st.program_study), '\s+', ' ', 'g'))
+      IN (LOWER(REGEXP_REPLACE(TRIM(sp.name), '\s+', ' ', 'g')), LOWER(REGEXP_REPLACE(TRIM(sp.code), '\s+', ' ', 'g')));
+
+UPDATE lecturers l
+SET department_id = d.id
+FROM departments d
+WHERE l.department_id IS NULL
+  AND LOWER(REGEXP_REPLACE(TRIM(l.department), '\s+', ' ', 'g'))
+      IN (LOWER(REGEXP_REPLACE(TRIM(d.name), '\s+', ' ', 'g')), LOWER(REGEXP_REPLACE(TRIM(d.code), '\s+', ' ', 'g')));
@@ -2,17 +2,102 @@ package database
 
 import (
 	"log"
+	"os"
 
 	"github.com/nerhays/prestasi_uas/app/model"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/nerhays/prestasi_uas/utils"
 	"gorm.io/gorm"
 )
 
+// RoleSeed: role bawaan + nama permission-nya
+type RoleSeed struct {
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// role bawaan; Admin selalu mendapat semua permission
+var seedRoles = []RoleSeed{
+	{"Admin", "Pengelola sistem", nil},
+	{"Mahasiswa", "Mahasiswa pelapor prestasi", []string{
+		"achievement:create", "achievement:read", "achievement:update", "achievement:delete",
+	}},
+	{"Dosen Wali", "Dosen pembimbing akademik", []string{
+		"achievement:read", "achievement:verify", "student:read", "report:read",
+	}},
+}
+
+var seedPermissions = []model.Permission{
+	{Name: "achievement:create", Resource: "achievement", Action: "create", Description: "Buat prestasi"},
+	{Name: "achievement:read", Resource: "achievement", Action: "read", Description: "Lihat prestasi"},
+	{Name: "achievement:update", Resource: "achievement", Action: "update", Description: "Update prestasi"},
+	{Name: "achievement:delete", Resource: "achievement", Action: "delete", Description: "Hapus prestasi"},
+	{Name: "achievement:verify", Resource: "achievement", Action: "verify", Description: "Verifikasi prestasi"},
+	{Name: "user:manage", Resource: "user", Action: "manage", Description: "Kelola user"},
+	{Name: "student:read", Resource: "student", Action: "read", Description: "Lihat data mahasiswa"},
+	{Name: "lecturer:read", Resource: "lecturer", Action: "read", Description: "Lihat data dosen"},
+	{Name: "report:read", Resource: "report", Action: "read", Description: "Lihat laporan & statistik"},
+}
+
+// DefaultPermissions: permission bawaan
+func DefaultPermissions() []model.Permission {
+	return append([]model.Permission(nil), seedPermissions...)
+}
+
+// DefaultRoles: role bawaan, Permissions Admin sudah diisi semua permission (dipakai juga store in-memory)
+func DefaultRoles() []RoleSeed {
+	roles := make([]RoleSeed, 0, len(seedRoles))
+	for _, r := range seedRoles {
+		if r.Name == "Admin" {
+			r.Permissions = nil
+			for _, p := range seedPermissions {
+				r.Permissions = append(r.Permissions, p.Name)
+			}
+		}
+		roles = append(roles, r)
+	}
+	return roles
+}
+
 func Seed(db *gorm.DB) {
-	seedAdminUser(db)
+	if err := SeedRolesAndPermissions(db); err != nil {
+		log.Printf("[SEED] failed to seed roles and permissions: %v", err)
+		return
+	}
+	SeedAdminUser(db)
+}
+
+// SeedRolesAndPermissions: idempotent; role / permission yang sudah ada tidak ditimpa, mapping yang hilang ditambahkan lagi
+func SeedRolesAndPermissions(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		permIDs := map[string]string{}
+		for _, p := range seedPermissions {
+			perm := p
+			if err := tx.Where(model.Permission{Name: perm.Name}).FirstOrCreate(&perm).Error; err != nil {
+				return err
+			}
+			permIDs[perm.Name] = perm.ID
+		}
+
+		for _, r := range DefaultRoles() {
+			role := model.Role{Name: r.Name, Description: r.Description}
+			if err := tx.Where(model.Role{Name: r.Name}).FirstOrCreate(&role).Error; err != nil {
+				return err
+			}
+
+			for _, name := range r.Permissions {
+				rp := model.RolePermission{RoleID: role.ID, PermissionID: permIDs[name]}
+				if err := tx.Where(rp).FirstOrCreate(&rp).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
 }
 
-func seedAdminUser(db *gorm.DB) {
+// SeedAdminUser: akun admin awal kalau belum ada (password dari SEED_ADMIN_PASSWORD atau acak)
+func SeedAdminUser(db *gorm.DB) {
 	// cek user admin
 	var count int64
 	if err := db.Model(&model.User{}).
@@ -34,8 +119,20 @@ func seedAdminUser(db *gorm.DB) {
 		return
 	}
 
+	// password awal dari SEED_ADMIN_PASSWORD, kalau kosong dibuat acak
+	password := os.Getenv("SEED_ADMIN_PASSWORD")
+	generated := password == ""
+	if generated {
+		token, err := utils.RandomToken(9)
+		if err != nil {
+			log.Printf("[SEED] failed to generate admin password: %v", err)
+			return
+		}
+		password = token
+	}
+
 	// hash password
-	hashed, err := bcrypt.GenerateFromPassword([]byte("admin123"), bcrypt.DefaultCost)
+	hashed, err := utils.HashPassword(password)
 	if err != nil {
 		log.Printf("[SEED] failed to hash password: %v", err)
 		return
@@ -45,9 +142,11 @@ func seedAdminUser(db *gorm.DB) {
 		Username:     "admin",
 		Email:        "admin@example.com",
 		FullName:     "Administrator",
-		PasswordHash: string(hashed),
+		PasswordHash: hashed,
 		RoleID:       role.ID,
 		IsActive:     true,
+		// wajib ganti password saat login pertama
+		MustChangePassword: true,
 	}
 
 	if err := db.Create(&admin).Error; err != nil {
@@ -55,5 +154,10 @@ func seedAdminUser(db *gorm.DB) {
 		return
 	}
 
-	log.Println("[SEED] admin user created: username=admin, password=admin123")
+	if generated {
+		// satu-satunya tempat password awal muncul; langsung diganti saat login pertama
+		log.Printf("[SEED] admin user created: username=admin, initial password=%s (must be changed on first login)", password)
+		return
+	}
+	log.Println("[SEED] admin user created: username=admin, password from SEED_ADMIN_PASSWORD (must be changed on first login)")
 }
@@ -23,6 +23,138 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Kunci publik untuk memverifikasi access token (header kid). Dipakai layanan kampus lain",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "JSON Web Key Set",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JWKSet"
+                        }
+                    }
+                }
+            }
+        },
+        "/academic-periods": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daftar periode akademik (semester) beserta jendela pengajuan prestasi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Periods"
+                ],
+                "summary": "Get academic periods",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AcademicPeriod"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/academic-periods/active": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Periods"
+                ],
+                "summary": "Get active academic period",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AcademicPeriod"
+                        }
+                    },
+                    "404": {
+                        "description": "No active period",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/academic-periods/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Periods"
+                ],
+                "summary": "Get academic period by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Period ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AcademicPeriod"
+                        }
+                    },
+                    "404": {
+                        "description": "Period not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/achievements": {
             "get": {
                 "security": [
@@ -162,61 +294,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/achievements/deleted": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Mahasiswa melihat prestasi yang dihapus",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Achievements"
-                ],
-                "summary": "Get deleted achievements",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Achievement"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/achievements/me": {
+        "/achievements/bimbingan/dashboard": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mahasiswa melihat semua prestasi miliknya",
+                "description": "Dosen wali melihat prestasi pending per mahasiswa bimbingan, umur tiap item, item overdue terhadap SLA, dan throughput 30 hari",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Get my achievements",
+                "summary": "Advisor dashboard",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Achievement"
-                            }
+                            "$ref": "#/definitions/model.AdvisorDashboard"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Lecturer not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -227,40 +328,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/achievements/{id}": {
+        "/achievements/bimbingan/delegations": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Detail prestasi (RBAC: Mahasiswa, Dosen Wali, Admin)",
+                "description": "Delegasi verifikasi yang diberikan atau diterima dosen wali yang login",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Get achievement detail",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Achievement Reference ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "summary": "My verification delegations",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.VerificationDelegation"
+                            }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Lecturer profile not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -270,13 +364,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mahasiswa mengubah prestasi berstatus draft",
+                "description": "Dosen wali yang login mendelegasikan verifikasi prestasi mahasiswa bimbingannya ke dosen lain selama rentang tanggal (mis. cuti / dinas)",
                 "consumes": [
                     "application/json"
                 ],
@@ -286,34 +380,36 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Update draft achievement",
+                "summary": "Delegate my verification rights",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Achievement Reference ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated payload",
+                        "description": "Delegation",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Achievement"
+                            "$ref": "#/definitions/route.DelegationRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.Achievement"
+                            "$ref": "#/definitions/model.VerificationDelegation"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Lecturer not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -322,25 +418,26 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/achievements/bimbingan/delegations/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mahasiswa menghapus prestasi berstatus draft",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Delete draft achievement",
+                "summary": "Revoke my verification delegation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement Reference ID",
+                        "description": "Delegation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -348,7 +445,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Delegation revoked",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -356,8 +453,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Delegation not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -365,8 +462,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "409": {
+                        "description": "Already revoked",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -377,146 +474,89 @@ const docTemplate = `{
                 }
             }
         },
-        "/achievements/{id}/attachments": {
-            "post": {
+        "/achievements/deleted": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mahasiswa upload file bukti prestasi",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Mahasiswa melihat prestasi yang dihapus",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Upload achievement attachment",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Achievement Reference ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Attachment file",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    }
-                ],
+                "summary": "Get deleted achievements",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Attachment"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Achievement"
                             }
                         }
                     }
                 }
             }
         },
-        "/achievements/{id}/history": {
+        "/achievements/invitations": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Melihat riwayat perubahan status prestasi",
+                "description": "Mahasiswa melihat undangan prestasi tim yang belum dijawab",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
-                ],
-                "summary": "Get achievement history",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Achievement Reference ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Achievements - Team"
                 ],
+                "summary": "Get my team invitations",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.AchievementStatusLog"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/achievements/{id}/reject": {
+        "/achievements/invitations/{id}/accept": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Dosen wali menolak prestasi dengan catatan",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Anggota menerima undangan; draft prestasi anggota dibuat untuk diverifikasi dosen walinya",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Achievements - Team"
                 ],
-                "summary": "Reject achievement",
+                "summary": "Accept team invitation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement Reference ID",
+                        "description": "Invitation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Rejection note",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/route.rejectRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.AchievementReference"
+                            "$ref": "#/definitions/model.AchievementTeamMember"
                         }
                     },
                     "400": {
@@ -528,15 +568,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -549,25 +580,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/achievements/{id}/submit": {
+        "/achievements/invitations/{id}/decline": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mahasiswa submit prestasi draft untuk diverifikasi dosen wali",
+                "description": "Anggota menolak undangan dan dikeluarkan dari tim",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Achievements - Team"
                 ],
-                "summary": "Submit achievement for verification",
+                "summary": "Decline team invitation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement Reference ID",
+                        "description": "Invitation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -577,7 +608,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.AchievementReference"
+                            "$ref": "#/definitions/model.AchievementTeamMember"
                         }
                     },
                     "400": {
@@ -589,15 +620,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -610,57 +632,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/achievements/{id}/verify": {
-            "post": {
+        "/achievements/me": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Dosen wali atau admin memverifikasi prestasi mahasiswa",
+                "description": "Mahasiswa melihat semua prestasi miliknya",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Verify achievement",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Achievement Reference ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "summary": "Get my achievements",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.AchievementReference"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Achievement"
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -671,86 +669,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/achievements": {
-            "get": {
+        "/achievements/team": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin can view all achievements with pagination and filter",
+                "description": "Ketua tim membuat prestasi tim dan mengundang anggota berdasarkan NIM. pointSplit: equal | full | leader_weighted",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Achievements"
+                    "Achievements - Team"
                 ],
-                "summary": "Get all achievements",
+                "summary": "Create team achievement (draft)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Achievement status",
-                        "name": "status",
-                        "in": "query"
+                        "description": "Team achievement payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.TeamAchievementRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "List of achievements",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/admin/lecturers": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve list of all lecturers",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Admin - Lecturers"
-                ],
-                "summary": "Get all lecturers",
-                "responses": {
-                    "200": {
-                        "description": "List of lecturers",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Member NIM not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -761,25 +727,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/lecturers/{id}/advisees": {
+        "/achievements/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get students supervised by a lecturer",
+                "description": "Detail prestasi (RBAC: Mahasiswa, Dosen Wali, Admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Lecturers"
+                    "Achievements"
                 ],
-                "summary": "Get lecturer advisees",
+                "summary": "Get achievement detail",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Lecturer ID",
+                        "description": "Achievement Reference ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -787,14 +753,14 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of advisees",
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Lecturer not found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -803,33 +769,51 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/reports/statistics": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get statistics of achievements by type and status",
+                "description": "Mahasiswa mengubah prestasi berstatus draft",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Reports"
+                    "Achievements"
+                ],
+                "summary": "Update draft achievement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement Reference ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Achievement"
+                        }
+                    }
                 ],
-                "summary": "Get achievement statistics",
                 "responses": {
                     "200": {
-                        "description": "Statistics data",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Achievement"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -838,27 +822,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/reports/student/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get achievement summary report for a student",
+                "description": "Mahasiswa menghapus prestasi berstatus draft",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Reports"
+                    "Achievements"
                 ],
-                "summary": "Get student achievement report",
+                "summary": "Delete draft achievement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID",
+                        "description": "Achievement Reference ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -866,49 +848,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Student report",
+                        "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Student not found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/admin/students": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Admin can view all students",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Admin - Students"
-                ],
-                "summary": "Get all students",
-                "responses": {
-                    "200": {
-                        "description": "List of students",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -919,40 +877,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/students/{id}": {
-            "get": {
+        "/achievements/{id}/attachments": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detail student by ID",
+                "description": "Mahasiswa upload file bukti prestasi",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Students"
+                    "Achievements"
                 ],
-                "summary": "Get student by ID",
+                "summary": "Upload achievement attachment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID",
+                        "description": "Achievement Reference ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Attachment file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Student detail",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Attachment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Student not found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -963,25 +939,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/students/{id}/achievements": {
+        "/achievements/{id}/history": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all achievements for a student",
+                "description": "Melihat riwayat perubahan status prestasi",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Students"
+                    "Achievements"
                 ],
-                "summary": "Get student achievements",
+                "summary": "Get achievement history",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID",
+                        "description": "Achievement Reference ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -989,32 +965,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Student achievements",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "404": {
-                        "description": "Student not found",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AchievementStatusLog"
                             }
                         }
                     }
                 }
             }
         },
-        "/admin/students/{id}/advisor": {
-            "put": {
+        "/achievements/{id}/reject": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Assign dosen wali to a student (Admin only)",
+                "description": "Dosen wali menolak prestasi dengan catatan",
                 "consumes": [
                     "application/json"
                 ],
@@ -1022,30 +991,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Students"
+                    "Achievements"
                 ],
-                "summary": "Assign advisor to student",
+                "summary": "Reject achievement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID",
+                        "description": "Achievement Reference ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Advisor payload",
+                        "description": "Rejection note",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/route.SetAdvisorRequest"
+                            "$ref": "#/definitions/route.rejectRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Advisor assigned",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementReference"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1053,8 +1028,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid input",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1062,8 +1037,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1074,31 +1049,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users": {
-            "get": {
+        "/achievements/{id}/restore": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin can retrieve all users",
+                "description": "Prestasi yang dihapus kembali menjadi draft (pemilik atau admin). Draft anggota tim ikut dipulihkan",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Users"
+                    "Achievements"
+                ],
+                "summary": "Restore deleted achievement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement Reference ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all users",
                 "responses": {
                     "200": {
-                        "description": "List of users",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementReference"
+                        }
+                    },
+                    "400": {
+                        "description": "Not deleted / team member reference",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1107,38 +1108,41 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/achievements/{id}/submit": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin creates a new user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mahasiswa submit prestasi draft untuk diverifikasi dosen wali",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Users"
+                    "Achievements"
                 ],
-                "summary": "Create new user",
+                "summary": "Submit achievement for verification",
                 "parameters": [
                     {
-                        "description": "Create user payload",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/route.CreateUserRequest"
-                        }
+                        "type": "string",
+                        "description": "Achievement Reference ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User created",
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementReference"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1146,8 +1150,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid input",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1155,8 +1159,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1167,25 +1171,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users/{id}": {
+        "/achievements/{id}/team": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin can retrieve user detail by ID",
+                "description": "Anggota tim + status undangan (RBAC sama dengan detail prestasi)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Users"
+                    "Achievements - Team"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Get team members",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Achievement Reference ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1193,23 +1197,16 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "User detail",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AchievementTeamMember"
                             }
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1218,45 +1215,41 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/achievements/{id}/verify": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin updates user information",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Dosen wali atau admin memverifikasi prestasi mahasiswa",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Users"
+                    "Achievements"
                 ],
-                "summary": "Update user",
+                "summary": "Verify achievement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Achievement Reference ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update user payload",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/route.UpdateUserRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User updated",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementReference"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1264,8 +1257,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid input",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1273,8 +1266,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1283,25 +1276,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/achievements/{id}/versions": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin deletes a user",
+                "description": "Riwayat isi prestasi: setiap penyimpanan membuat versi baru. verified_version = versi yang diverifikasi dosen wali",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Users"
+                    "Achievements"
                 ],
-                "summary": "Delete user",
+                "summary": "List achievement versions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Achievement Reference ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1309,16 +1304,14 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted",
+                        "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Delete failed",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1326,8 +1319,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1338,45 +1331,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users/{id}/role": {
-            "put": {
+        "/achievements/{id}/versions/diff": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin updates role of a user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Perubahan per field antara dua versi isi prestasi; field details dibandingkan per key (mis. details.rank)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Users"
+                    "Achievements"
                 ],
-                "summary": "Update user role",
+                "summary": "Diff two achievement versions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Achievement Reference ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update role payload",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/route.UpdateRoleRequest"
-                        }
+                        "type": "integer",
+                        "description": "Versi awal",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Versi akhir",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Role updated",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementVersionDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1384,8 +1385,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid input",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1393,8 +1394,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1405,9 +1406,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
+        "/admin/academic-periods": {
             "post": {
-                "description": "Login menggunakan username dan password",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin membuat periode akademik; rentang tanggal tidak boleh tumpang tindih dengan periode lain",
                 "consumes": [
                     "application/json"
                 ],
@@ -1415,26 +1421,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin - Academic Periods"
                 ],
-                "summary": "Login user",
+                "summary": "Create academic period",
                 "parameters": [
                     {
-                        "description": "Login payload",
+                        "description": "Period payload",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/route.loginRequest"
+                            "$ref": "#/definitions/route.AcademicPeriodRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Login success",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.AcademicPeriod"
                         }
                     },
                     "400": {
@@ -1446,8 +1451,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Overlapping period",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1458,24 +1463,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/logout": {
-            "post": {
+        "/admin/academic-periods/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Logout user (JWT stateless, client-side logout)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin - Academic Periods"
+                ],
+                "summary": "Update academic period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Period ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Period payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.AcademicPeriodRequest"
+                        }
+                    }
                 ],
-                "summary": "Logout user",
                 "responses": {
                     "200": {
-                        "description": "Logout success",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AcademicPeriod"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Period not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Overlapping period",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1484,33 +1533,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/auth/profile": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get profile user yang sedang login",
+                "description": "Prestasi yang tercatat di periode ini menjadi tanpa periode",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin - Academic Periods"
+                ],
+                "summary": "Delete academic period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Period ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user profile",
                 "responses": {
                     "200": {
-                        "description": "User profile",
+                        "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Period not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1521,31 +1579,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh": {
+        "/admin/academic-periods/{id}/activate": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Generate token baru dari token lama",
+                "description": "Jadikan periode ini periode aktif (periode lain otomatis nonaktif)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin - Academic Periods"
+                ],
+                "summary": "Activate academic period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Period ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Refresh JWT token",
                 "responses": {
                     "200": {
-                        "description": "Token refreshed",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.AcademicPeriod"
                         }
                     },
-                    "401": {
-                        "description": "Invalid or expired token",
+                    "404": {
+                        "description": "Period not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1556,31 +1622,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/roles": {
+        "/admin/achievements": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve list of available roles",
+                "description": "Admin can view all achievements with pagination and filter",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Admin - Achievements"
+                ],
+                "summary": "Get all achievements",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Academic period ID",
+                        "name": "period_id",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all roles",
                 "responses": {
                     "200": {
-                        "description": "List of roles",
+                        "description": "List of achievements",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Failed to fetch roles",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1591,205 +1683,5690 @@ const docTemplate = `{
                 }
             }
         },
-        "/students/me": {
+        "/admin/achievements/trash": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get logged-in student's profile",
+                "description": "Prestasi yang dihapus (semua mahasiswa dalam scope admin). purge_at = jadwal hapus permanen (TRASH_RETENTION_DAYS), null = tidak pernah",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Admin - Achievements"
+                ],
+                "summary": "Achievement trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get my student profile",
                 "responses": {
                     "200": {
-                        "description": "Student profile",
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
+                    }
+                }
+            }
+        },
+        "/admin/advisors/reassign": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pindahkan banyak mahasiswa sekaligus ke satu dosen wali: berdasarkan daftar student_ids, semua mahasiswa bimbingan from_lecturer_id, mahasiswa prodi study_program_id, atau kombinasi from_lecturer_id + study_program_id. effective_from boleh mundur untuk pencatatan, tidak boleh di masa depan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Advisors"
+                ],
+                "summary": "Bulk reassign advisor",
+                "parameters": [
+                    {
+                        "description": "Reassignment",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.ReassignAdvisorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ReassignAdvisorResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
-                        "description": "Student not found",
+                        "description": "Student / lecturer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/advisors/reminders": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kirim reminder sekarang ke dosen wali yang punya prestasi melewati SLA (selain jadwal otomatis)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Lecturers"
+                ],
+                "summary": "Send overdue reminders",
+                "responses": {
+                    "200": {
+                        "description": "Number of reminders sent",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/advisors/workload": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin melihat backlog verifikasi semua dosen wali (pending, overdue, throughput)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Lecturers"
+                ],
+                "summary": "All advisors workload",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AdvisorWorkload"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/advisors/{id}/dashboard": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin melihat dashboard dosen wali tertentu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Lecturers"
+                ],
+                "summary": "Advisor dashboard (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lecturer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AdvisorDashboard"
+                        }
+                    },
+                    "404": {
+                        "description": "Lecturer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/advisors/{id}/delegations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delegasi verifikasi yang diberikan atau diterima seorang dosen",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Advisors"
+                ],
+                "summary": "Lecturer verification delegations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lecturer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.VerificationDelegation"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Lecturer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Selama rentang tanggal, dosen to_lecturer_id ikut bisa melihat dan memverifikasi prestasi mahasiswa bimbingan dosen {id}. Dosen wali asli tetap bisa memverifikasi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Advisors"
+                ],
+                "summary": "Delegate verification rights",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lecturer ID (pemberi delegasi)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delegation",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.DelegationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.VerificationDelegation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    },
+                    "404": {
+                        "description": "Lecturer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/api-tokens/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cabut API token milik user / akun layanan mana pun",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Service Accounts"
+                ],
+                "summary": "Revoke any API token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Token not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/audit-logs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Jejak semua aksi yang mengubah data (aktor, aksi, target, before/after, IP, request ID), terbaru di depan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Audit"
+                ],
+                "summary": "Audit log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter actor user ID",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter action (substring, mis. /admin/users)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter target type (user, student, achievement, ...)",
+                        "name": "target_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter target ID",
+                        "name": "target_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter request ID",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From (YYYY-MM-DD atau RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To, eksklusif (YYYY-MM-DD atau RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/audit-logs/verify": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hitung ulang rantai hash audit log dari awal; entri yang diubah / dihapus langsung di tabel terdeteksi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Audit"
+                ],
+                "summary": "Verify audit log hash chain",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/delegations/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Advisors"
+                ],
+                "summary": "Revoke verification delegation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delegation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delegation revoked",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Delegation not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Already revoked",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/departments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Organization"
+                ],
+                "summary": "Get departments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by faculty",
+                        "name": "faculty_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Department"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Organization"
+                ],
+                "summary": "Create department",
+                "parameters": [
+                    {
+                        "description": "Department payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.DepartmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Department"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Faculty not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/departments/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Organization"
+                ],
+                "summary": "Update department",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Department ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Department payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.DepartmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Department"
+                        }
+                    },
+                    "404": {
+                        "description": "Department not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hanya bisa dihapus jika tidak punya prodi dan dosen",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Organization"
+                ],
+                "summary": "Delete department",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Department ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Department not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Department still in use",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daftar prestasi yang terdeteksi kemungkinan duplikat (judul mirip, event sama, hash lampiran sama, nomor sertifikat sama)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Duplicates"
+                ],
+                "summary": "Get duplicate review queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "open",
+                        "description": "open | dismissed | confirmed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/duplicates/{id}/review": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tandai flag sebagai bukan duplikat (dismissed) atau duplikat (confirmed)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Duplicates"
+                ],
+                "summary": "Review duplicate flag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Duplicate flag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review decision",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.DuplicateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementDuplicateFlag"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid decision or already reviewed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Flag not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/faculties": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Organization"
+                ],
+                "summary": "Get faculties",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Faculty"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Organization"
+                ],
+                "summary": "Create faculty",
+                "parameters": [
+                    {
+                        "description": "Faculty payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.FacultyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Faculty"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/faculties/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Organization"
+                ],
+                "summary": "Update faculty",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Faculty ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Faculty payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.FacultyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Faculty"
+                        }
+                    },
+                    "404": {
+                        "description": "Faculty not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hanya bisa dihapus jika tidak punya jurusan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Organization"
+                ],
+                "summary": "Delete faculty",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Faculty ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Faculty not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Faculty still in use",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/imports": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List semua job import (tanpa detail error per baris)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Imports"
+                ],
+                "summary": "List import jobs",
+                "responses": {
+                    "200": {
+                        "description": "List of import jobs",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import user + profil mahasiswa/dosen + dosen wali dari CSV/XLSX. Kolom: username, email, full_name, password, role, student_id, program_study, academic_year, advisor_id (NIDN), lecturer_id, department. Upsert berdasarkan student_id / lecturer_id.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Imports"
+                ],
+                "summary": "Import students and lecturers",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV / XLSX file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate only, nothing is written",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dry-run report",
+                        "schema": {
+                            "$ref": "#/definitions/model.ImportReport"
+                        }
+                    },
+                    "202": {
+                        "description": "Import job started",
+                        "schema": {
+                            "$ref": "#/definitions/model.ImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Server is shutting down",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/imports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Progress dan error per baris dari job import",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Imports"
+                ],
+                "summary": "Get import job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Import job",
+                        "schema": {
+                            "$ref": "#/definitions/model.ImportJob"
+                        }
+                    },
+                    "404": {
+                        "description": "Import job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/lecturers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve list of all lecturers",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Lecturers"
+                ],
+                "summary": "Get all lecturers",
+                "responses": {
+                    "200": {
+                        "description": "List of lecturers",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Buat akun user + profil dosen dalam satu transaksi (role harus Dosen Wali)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Lecturers"
+                ],
+                "summary": "Create lecturer",
+                "parameters": [
+                    {
+                        "description": "Lecturer payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.LecturerProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Lecturer created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Lecturer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Duplicate username, email or NIDN",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/lecturers/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ubah data akun + profil dosen (password opsional)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Lecturers"
+                ],
+                "summary": "Update lecturer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lecturer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Lecturer payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.LecturerProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lecturer updated",
+                        "schema": {
+                            "$ref": "#/definitions/model.Lecturer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Lecturer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Duplicate username, email or NIDN",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nonaktifkan akun dosen; efeknya sama dengan POST /admin/users/{id}/deactivate. Dosen wali yang masih punya mahasiswa bimbingan wajib menyebut replacement_advisor_id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Lecturers"
+                ],
+                "summary": "Deactivate lecturer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lecturer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Replacement advisor",
+                        "name": "body",
+                        "in": "body",
+                        "required": false,
+                        "schema": {
+                            "$ref": "#/definitions/route.DeactivateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lecturer deactivated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid replacement / own account",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Lecturer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Replacement advisor required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/lecturers/{id}/advisees": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get students supervised by a lecturer",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Lecturers"
+                ],
+                "summary": "Get lecturer advisees",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lecturer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of advisees",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Lecturer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/organization/unmapped": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nilai students.program_study dan lecturers.department yang tidak terpetakan migrasi 0004 (typo / unit belum dibuat).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Organization"
+                ],
+                "summary": "List legacy program/department text without a unit",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrganizationUnmappedReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reports/analytics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tren prestasi terverifikasi, rata-rata waktu verifikasi, dan leaderboard mahasiswa/prodi berdasarkan poin",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Reports"
+                ],
+                "summary": "Get achievement analytics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD), default 1 year ago",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date inclusive (YYYY-MM-DD), default today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "month",
+                        "description": "month | semester | program_study | academic_year | competition_level",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Leaderboard size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Academic period ID (overrides from/to)",
+                        "name": "period_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Analytics data",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementAnalytics"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reports/statistics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get statistics of achievements by type and status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Reports"
+                ],
+                "summary": "Get achievement statistics",
+                "responses": {
+                    "200": {
+                        "description": "Statistics data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reports/student/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get achievement summary report for a student",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Reports"
+                ],
+                "summary": "Get student achievement report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Student report",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/roles/{id}/two-factor": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Wajibkan / bebaskan 2FA untuk semua user dengan role ini. User yang belum daftar 2FA hanya bisa akses endpoint setup 2FA.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Roles"
+                ],
+                "summary": "Require 2FA for a role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Required flag",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.TwoFactorRequirementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/security/lockouts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Akun / IP yang sedang dikunci karena terlalu banyak login gagal",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Security"
+                ],
+                "summary": "List active login lockouts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/security/login-audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Riwayat percobaan login (berhasil / gagal, IP, user agent)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Security"
+                ],
+                "summary": "Login audit trail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter username",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter result",
+                        "name": "success",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/security/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hapus lock + counter login gagal untuk username dan/atau IP",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Security"
+                ],
+                "summary": "Unlock username or IP",
+                "parameters": [
+                    {
+                        "description": "Username and/or IP",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.UnlockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Username or IP required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/service-accounts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Service Accounts"
+                ],
+                "summary": "List service accounts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Akun non-manusia untuk script / integrasi. Tidak bisa login dengan password, akses hanya lewat API token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Service Accounts"
+                ],
+                "summary": "Create service account",
+                "parameters": [
+                    {
+                        "description": "Service account",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.CreateServiceAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input / role not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/service-accounts/{id}/tokens": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Service Accounts"
+                ],
+                "summary": "List service account tokens",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account user ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Service account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Scope harus permission yang dimiliki role akun layanan. Token hanya ditampilkan sekali.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Service Accounts"
+                ],
+                "summary": "Create service account token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account user ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Token name, scopes and expiry",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.CreateAPITokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "token (plaintext, sekali) + metadata",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input / scope not allowed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Service account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/students": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin can view all students",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Students"
+                ],
+                "summary": "Get all students",
+                "responses": {
+                    "200": {
+                        "description": "List of students",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Buat akun user + profil mahasiswa dalam satu transaksi (role harus Mahasiswa)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Students"
+                ],
+                "summary": "Create student",
+                "parameters": [
+                    {
+                        "description": "Student payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.StudentProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Student created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Student"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Duplicate username, email or NIM",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/students/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detail student by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Students"
+                ],
+                "summary": "Get student by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Student detail",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ubah data akun + profil mahasiswa (password opsional)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Students"
+                ],
+                "summary": "Update student",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Student payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.StudentProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Student updated",
+                        "schema": {
+                            "$ref": "#/definitions/model.Student"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Duplicate username, email or NIM",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nonaktifkan akun mahasiswa (data prestasi tetap disimpan); efeknya sama dengan POST /admin/users/{id}/deactivate",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Students"
+                ],
+                "summary": "Deactivate student",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Student deactivated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Own account",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/students/{id}/achievements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all achievements for a student",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Students"
+                ],
+                "summary": "Get student achievements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Student achievements",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/students/{id}/advisor": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pindahkan satu mahasiswa ke dosen wali lain. Riwayat penugasan dicatat dan prestasi yang masih menunggu verifikasi ikut diserahkan ke dosen wali baru; dosen lama, dosen baru dan mahasiswa diberi tahu lewat email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Students"
+                ],
+                "summary": "Assign advisor to student",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Advisor payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.SetAdvisorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ReassignAdvisorResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Student / lecturer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/students/{id}/advisor-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Riwayat dosen wali satu mahasiswa beserta tanggal berlaku, terbaru dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Students"
+                ],
+                "summary": "Advisor assignment history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AdvisorAssignment"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/study-programs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Organization"
+                ],
+                "summary": "Get study programs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by department",
+                        "name": "department_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StudyProgram"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Organization"
+                ],
+                "summary": "Create study program",
+                "parameters": [
+                    {
+                        "description": "Study program payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.StudyProgramRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.StudyProgram"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Department not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/study-programs/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Organization"
+                ],
+                "summary": "Update study program",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Study program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Study program payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.StudyProgramRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StudyProgram"
+                        }
+                    },
+                    "404": {
+                        "description": "Study program not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hanya bisa dihapus jika tidak ada mahasiswa di prodi ini",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Organization"
+                ],
+                "summary": "Delete study program",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Study program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Study program not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Study program still in use",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin can retrieve all users",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Get all users",
+                "responses": {
+                    "200": {
+                        "description": "List of users",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin creates a new user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Create new user",
+                "parameters": [
+                    {
+                        "description": "Create user payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin can retrieve user detail by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User detail",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin updates user information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update user payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nonaktifkan user (pengganti hapus permanen): login ditolak, access token & API token dicabut. Dosen wali yang masih punya mahasiswa bimbingan wajib menyebut replacement_advisor_id. DELETE /admin/users/{id} sama dengan endpoint ini tanpa pengganti.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Deactivate user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Replacement advisor",
+                        "name": "body",
+                        "in": "body",
+                        "required": false,
+                        "schema": {
+                            "$ref": "#/definitions/route.DeactivateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deactivated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid replacement / own account",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Replacement advisor required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/2fa": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hapus secret + kode cadangan 2FA user (mis. HP hilang). Kalau role mewajibkan 2FA, user harus daftar ulang setelah login.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Reset user 2FA",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/anonymize": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permintaan penghapusan data pribadi: nama, username, email dan NIM/NIDN diganti nilai anonim, identitas SSO dan 2FA dihapus, user dinonaktifkan. Prestasi, prodi, angkatan dan status verifikasi tetap untuk statistik. Tidak bisa dibatalkan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Anonymize user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Replacement advisor",
+                        "name": "body",
+                        "in": "body",
+                        "required": false,
+                        "schema": {
+                            "$ref": "#/definitions/route.DeactivateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User anonymized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid replacement / own account",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Replacement advisor required / already anonymized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/auth-backend": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pilih backend verifikasi password user (local / ldap); kosong = ikut konfigurasi global",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Set user login backend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Backend",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.UpdateAuthBackendRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Backend updated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid backend",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/deactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nonaktifkan user (pengganti hapus permanen): login ditolak, access token & API token dicabut. Dosen wali yang masih punya mahasiswa bimbingan wajib menyebut replacement_advisor_id. DELETE /admin/users/{id} sama dengan endpoint ini tanpa pengganti.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Deactivate user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Replacement advisor",
+                        "name": "body",
+                        "in": "body",
+                        "required": false,
+                        "schema": {
+                            "$ref": "#/definitions/route.DeactivateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deactivated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid replacement / own account",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Replacement advisor required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/reactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Aktifkan kembali user nonaktif. Token yang dicabut saat nonaktif tetap tidak berlaku; user yang sudah dianonimkan tidak bisa diaktifkan.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Reactivate user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User reactivated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "User anonymized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin updates role of a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Update user role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update role payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.UpdateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role updated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/scope": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Batasi admin ke fakultas/jurusan (admin fakultas). Kosongkan keduanya untuk admin global. Token lama user ini langsung dicabut.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Organization"
+                ],
+                "summary": "Set admin unit scope",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Scope payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.AdminScopeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid scope",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User or unit not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hapus lock + counter login gagal akun user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Unlock user account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/2fa": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Status 2FA user login (aktif, wajib dari role, sisa kode cadangan)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth - 2FA"
+                ],
+                "summary": "Two-factor status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/2fa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nonaktifkan 2FA dengan password + kode. Ditolak kalau role mewajibkan 2FA",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth - 2FA"
+                ],
+                "summary": "Disable two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "Password and code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.DisableTwoFactorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid password or code",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Required by role",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/2fa/enable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Aktifkan 2FA dengan kode TOTP pertama. Kode cadangan hanya ditampilkan sekali; token baru dikembalikan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth - 2FA"
+                ],
+                "summary": "Confirm two-factor enrollment",
+                "parameters": [
+                    {
+                        "description": "TOTP code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Setup not started",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid code",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Already enabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/2fa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Buat kode cadangan baru (kode lama hangus). Butuh kode TOTP",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth - 2FA"
+                ],
+                "summary": "Regenerate recovery codes",
+                "parameters": [
+                    {
+                        "description": "TOTP code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "2FA not enabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid code",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/2fa/setup": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Buat secret TOTP baru + otpauth URI (untuk QR code). 2FA baru aktif setelah /auth/2fa/enable",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth - 2FA"
+                ],
+                "summary": "Start two-factor enrollment",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Already enabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/2fa/verify": {
+            "post": {
+                "description": "Tukar challengeToken dari /auth/login dengan kode TOTP atau kode cadangan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login step 2: verify 2FA code",
+                "parameters": [
+                    {
+                        "description": "Challenge token and code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.twoFactorLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid code or challenge",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Account / IP temporarily locked (see Retry-After)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Login menggunakan username dan password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.loginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login success, or status 2fa_required with challengeToken",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Account / IP temporarily locked (see Retry-After)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Logout user (JWT stateless, client-side logout)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Logout user",
+                "responses": {
+                    "200": {
+                        "description": "Logout success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/callback": {
+            "get": {
+                "description": "Redirect URI yang didaftarkan di IdP. Tukar authorization code dengan token aplikasi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth - SSO"
+                ],
+                "summary": "SSO callback (OpenID Connect)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "State from /auth/oidc/login",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid state or IdP error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Token invalid or account not linked",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Account / IP temporarily locked (see Retry-After)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Identity provider unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/login": {
+            "get": {
+                "description": "Redirect ke halaman login IdP kampus. Pakai format=json untuk mendapat URL-nya saja (SPA)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth - SSO"
+                ],
+                "summary": "Start SSO login (OpenID Connect)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "json = return the URL instead of redirecting",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Authorization URL (format=json)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "302": {
+                        "description": "Redirect to identity provider"
+                    },
+                    "503": {
+                        "description": "Identity provider unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ganti password user login. Token baru dikembalikan (akun dengan wajib ganti password bisa akses API lagi)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Change own password",
+                "parameters": [
+                    {
+                        "description": "Current and new password",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password changed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Password does not meet the policy",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Current password incorrect",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password/forgot": {
+            "post": {
+                "description": "Kirim token reset password ke email. Respons selalu sama walaupun email tidak terdaftar",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Request password reset",
+                "parameters": [
+                    {
+                        "description": "Account email",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reset mail sent if the account exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password/reset": {
+            "post": {
+                "description": "Set password baru memakai token dari email (sekali pakai, ada masa berlaku)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Reset password with token",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password reset",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid token or password does not meet the policy",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get profile user yang sedang login",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "User profile",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate token baru dari token lama",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh JWT token",
+                "responses": {
+                    "200": {
+                        "description": "Token refreshed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/tokens": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daftar API token milik user login (tanpa nilai token)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth - API Tokens"
+                ],
+                "summary": "List own API tokens",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Buat API token milik user login. Scope harus permission yang dimiliki role user. Token hanya ditampilkan sekali.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth - API Tokens"
+                ],
+                "summary": "Create personal access token",
+                "parameters": [
+                    {
+                        "description": "Token name, scopes and expiry",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/route.CreateAPITokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "token (plaintext, sekali) + metadata",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input / scope not allowed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/tokens/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth - API Tokens"
+                ],
+                "summary": "Revoke own API token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Token not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve list of available roles",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Get all roles",
+                "responses": {
+                    "200": {
+                        "description": "List of roles",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch roles",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/students/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get logged-in student's profile",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get my student profile",
+                "responses": {
+                    "200": {
+                        "description": "Student profile",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.AcademicPeriod": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "description": "contoh: 2024/2025",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "semester": {
+                    "$ref": "#/definitions/model.Semester"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "submission_closes_at": {
+                    "type": "string"
+                },
+                "submission_opens_at": {
+                    "description": "jendela submit prestasi; kosong = tidak dibatasi (SubmissionClosesAt biasanya cut-off SKPI)",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Achievement": {
+            "type": "object",
+            "properties": {
+                "achievementType": {
+                    "type": "string"
+                },
+                "attachments": {
+                    "type": "array",
+                    "items": {}
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "description": "DeletedAt: diisi saat soft delete, dasar hitung retensi tempat sampah",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "id": {
+                    "type": "string"
+                },
+                "pointSplit": {
+                    "$ref": "#/definitions/model.TeamPointSplit"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "studentId": {
+                    "type": "string"
+                },
+                "team": {
+                    "description": "Team: kosong untuk prestasi individu; StudentID tetap ketua tim",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TeamMember"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AchievementAnalytics": {
+            "type": "object",
+            "properties": {
+                "avg_turnaround_hours": {
+                    "type": "number"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "group_by": {
+                    "type": "string"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AnalyticsBucket"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "top_programs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LeaderboardEntry"
+                    }
+                },
+                "top_students": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LeaderboardEntry"
+                    }
+                },
+                "total_points": {
+                    "type": "number"
+                },
+                "total_verified": {
+                    "type": "integer"
+                },
+                "turnaround_samples": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AchievementDuplicateFlag": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "matched_mongo_achievement_id": {
+                    "type": "string"
+                },
+                "matched_student_id": {
+                    "type": "string"
+                },
+                "matched_title": {
+                    "type": "string"
+                },
+                "mongo_achievement_id": {
+                    "type": "string"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reference": {
+                    "$ref": "#/definitions/model.AchievementReference"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "review_note": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.DuplicateFlagStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AchievementReference": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_reminded_at": {
+                    "type": "string"
+                },
+                "mongo_achievement_id": {
+                    "type": "string"
+                },
+                "period": {
+                    "$ref": "#/definitions/model.AcademicPeriod"
+                },
+                "period_id": {
+                    "type": "string"
+                },
+                "point_share": {
+                    "description": "PointShare: bagian poin anggota tim, dihitung saat submit",
+                    "type": "number"
+                },
+                "rejection_note": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.AchievementStatus"
+                },
+                "student": {
+                    "$ref": "#/definitions/model.Student"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "team_role": {
+                    "description": "TeamRole: nil untuk prestasi individu",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.TeamRole"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "string"
+                },
+                "verified_version": {
+                    "description": "VerifiedVersion: versi isi prestasi (achievement_versions) yang diverifikasi",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AchievementStatus": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "submitted",
+                "verified",
+                "rejected",
+                "deleted"
+            ],
+            "x-enum-varnames": [
+                "AchievementStatusDraft",
+                "AchievementStatusSubmitted",
+                "AchievementStatusVerified",
+                "AchievementStatusRejected",
+                "AchievementStatusDeleted"
+            ]
+        },
+        "model.AchievementStatusLog": {
+            "type": "object",
+            "properties": {
+                "achievementReferenceID": {
+                    "type": "string"
+                },
+                "changedBy": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "newStatus": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "oldStatus": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AchievementTeamMember": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invited_by": {
+                    "type": "string"
+                },
+                "mongo_achievement_id": {
+                    "type": "string"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "responded_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/model.TeamRole"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.TeamInvitationStatus"
+                },
+                "student": {
+                    "$ref": "#/definitions/model.Student"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AchievementVersionDiff": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.AuditChange"
+                    }
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AdviseeWorkload": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PendingVerification"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "overdue": {
+                    "type": "integer"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "student_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AdvisorAssignment": {
+            "type": "object",
+            "properties": {
+                "assigned_by": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "effective_from": {
+                    "type": "string"
+                },
+                "effective_to": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lecturer": {
+                    "$ref": "#/definitions/model.Lecturer"
+                },
+                "lecturer_id": {
+                    "type": "string"
+                },
+                "pending_handed_over": {
+                    "description": "prestasi berstatus submitted yang ikut pindah ke dosen wali baru saat penugasan ini dimulai",
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "student_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AdvisorDashboard": {
+            "type": "object",
+            "properties": {
+                "advisee_details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AdviseeWorkload"
+                    }
+                },
+                "advisees": {
+                    "type": "integer"
+                },
+                "lecturer_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "oldest_pending_hours": {
+                    "type": "number"
+                },
+                "overdue": {
+                    "type": "integer"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "sla_hours": {
+                    "type": "number"
+                },
+                "throughput_days": {
+                    "type": "integer"
+                },
+                "verified": {
+                    "description": "dalam periode throughput",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AdvisorWorkload": {
+            "type": "object",
+            "properties": {
+                "advisees": {
+                    "type": "integer"
+                },
+                "lecturer_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "oldest_pending_hours": {
+                    "type": "number"
+                },
+                "overdue": {
+                    "type": "integer"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "verified": {
+                    "description": "dalam periode throughput",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AnalyticsBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Attachment": {
+            "type": "object",
+            "properties": {
+                "fileName": {
+                    "type": "string"
+                },
+                "fileType": {
+                    "type": "string"
+                },
+                "fileUrl": {
+                    "type": "string"
+                },
+                "hash": {
+                    "description": "Hash: sha256 isi file, dipakai deteksi lampiran yang sama antar mahasiswa",
+                    "type": "string"
+                },
+                "uploadedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuditChange": {
+            "type": "object",
+            "properties": {
+                "new": {},
+                "old": {}
+            }
+        },
+        "model.Department": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "faculty": {
+                    "$ref": "#/definitions/model.Faculty"
+                },
+                "faculty_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DuplicateFlagStatus": {
+            "type": "string",
+            "enum": [
+                "open",
+                "dismissed",
+                "confirmed"
+            ],
+            "x-enum-varnames": [
+                "DuplicateFlagOpen",
+                "DuplicateFlagDismissed",
+                "DuplicateFlagConfirmed"
+            ]
+        },
+        "model.Faculty": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ImportJob": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "created_rows": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ImportRowError"
+                    }
+                },
+                "failed_rows": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "processed_rows": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.ImportJobStatus"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ImportJobStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ImportJobPending",
+                "ImportJobRunning",
+                "ImportJobCompleted",
+                "ImportJobFailed"
+            ]
+        },
+        "model.ImportReport": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ImportRowError"
+                    }
+                },
+                "failed_rows": {
+                    "type": "integer"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "valid_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.LeaderboardEntry": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Lecturer": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "department": {
+                    "type": "string"
+                },
+                "department_id": {
+                    "description": "DepartmentID: FK ke departments; Department tetap diisi nama jurusan untuk kompatibilitas",
+                    "type": "string"
+                },
+                "department_unit": {
+                    "$ref": "#/definitions/model.Department"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lecturer_id": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/model.User"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrganizationUnmappedReport": {
+            "type": "object",
+            "properties": {
+                "departments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UnitValueCount"
+                    }
+                },
+                "programs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UnitValueCount"
+                    }
+                }
+            }
+        },
+        "model.PendingVerification": {
+            "type": "object",
+            "properties": {
+                "age_hours": {
+                    "type": "number"
+                },
+                "overdue": {
+                    "type": "boolean"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Role": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "require_two_factor": {
+                    "description": "true = semua user dengan role ini wajib mengaktifkan 2FA (TOTP)",
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.Semester": {
+            "type": "string",
+            "enum": [
+                "ganjil",
+                "genap"
+            ],
+            "x-enum-varnames": [
+                "SemesterGanjil",
+                "SemesterGenap"
+            ]
+        },
+        "model.Student": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string"
+                },
+                "advisor_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "program_study": {
+                    "type": "string"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "study_program": {
+                    "$ref": "#/definitions/model.StudyProgram"
+                },
+                "study_program_id": {
+                    "description": "StudyProgramID: FK ke study_programs; ProgramStudy tetap diisi nama prodi untuk kompatibilitas",
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/model.User"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.Achievement": {
+        },
+        "model.StudyProgram": {
             "type": "object",
             "properties": {
-                "achievementType": {
+                "code": {
                     "type": "string"
                 },
-                "attachments": {
-                    "type": "array",
-                    "items": {}
+                "created_at": {
+                    "type": "string"
                 },
-                "createdAt": {
+                "department": {
+                    "$ref": "#/definitions/model.Department"
+                },
+                "department_id": {
                     "type": "string"
                 },
-                "description": {
+                "id": {
                     "type": "string"
                 },
-                "details": {
-                    "type": "object",
-                    "additionalProperties": {}
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TeamInvitationStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "accepted",
+                "declined"
+            ],
+            "x-enum-varnames": [
+                "TeamInvitationPending",
+                "TeamInvitationAccepted",
+                "TeamInvitationDeclined"
+            ]
+        },
+        "model.TeamMember": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "$ref": "#/definitions/model.TeamRole"
+                },
+                "studentId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TeamPointSplit": {
+            "type": "string",
+            "enum": [
+                "equal",
+                "full",
+                "leader_weighted"
+            ],
+            "x-enum-varnames": [
+                "TeamPointSplitEqual",
+                "TeamPointSplitFull",
+                "TeamPointSplitLeaderWeighted"
+            ]
+        },
+        "model.TeamRole": {
+            "type": "string",
+            "enum": [
+                "leader",
+                "member"
+            ],
+            "x-enum-varnames": [
+                "TeamRoleLeader",
+                "TeamRoleMember"
+            ]
+        },
+        "model.UnitValueCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.User": {
+            "type": "object",
+            "properties": {
+                "anonymized_at": {
+                    "description": "data pribadi sudah diganti nilai anonim (permintaan penghapusan data)",
+                    "type": "string"
+                },
+                "auth_backend": {
+                    "description": "backend verifikasi password: local | ldap; kosong = ikut AUTH_BACKEND global",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deactivated_at": {
+                    "description": "diisi saat dinonaktifkan admin; kosong lagi setelah diaktifkan kembali",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "points": {
-                    "type": "number"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "studentId": {
+                "is_service_account": {
+                    "description": "akun non-manusia (script / portal); tidak bisa login password, hanya lewat API token",
+                    "type": "boolean"
+                },
+                "must_change_password": {
+                    "description": "akun seed / dibuat admin wajib ganti password saat login pertama",
+                    "type": "boolean"
+                },
+                "password_changed_at": {
                     "type": "string"
                 },
-                "title": {
+                "role": {
+                    "$ref": "#/definitions/model.Role"
+                },
+                "role_id": {
+                    "type": "string"
+                },
+                "scope_department_id": {
+                    "type": "string"
+                },
+                "scope_faculty_id": {
+                    "description": "scope admin fakultas/jurusan; keduanya kosong = admin global",
+                    "type": "string"
+                },
+                "sessions_revoked_at": {
+                    "description": "access token yang terbit sebelum waktu ini ditolak walaupun belum expired",
+                    "type": "string"
+                },
+                "two_factor_enabled": {
+                    "description": "salinan status user_two_factors.enabled, dipakai saat membuat token",
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.VerificationDelegation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "from_lecturer": {
+                    "$ref": "#/definitions/model.Lecturer"
+                },
+                "from_lecturer_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "to_lecturer": {
+                    "$ref": "#/definitions/model.Lecturer"
+                },
+                "to_lecturer_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "route.AcademicPeriodRequest": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string",
+                    "example": "2024/2025"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-01-31"
+                },
+                "semester": {
+                    "type": "string",
+                    "example": "ganjil"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2024-08-01"
+                },
+                "submission_closes_at": {
+                    "type": "string",
+                    "example": "2025-01-15"
+                },
+                "submission_opens_at": {
+                    "description": "RFC3339 atau YYYY-MM-DD (closes_at tanggal saja = sampai akhir hari)",
+                    "type": "string",
+                    "example": "2024-08-01"
+                }
+            }
+        },
+        "route.AdminScopeRequest": {
+            "type": "object",
+            "properties": {
+                "department_id": {
+                    "type": "string"
+                },
+                "faculty_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "route.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "route.CreateAPITokenRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_in_days": {
+                    "description": "0 / kosong = 90 hari, maksimal 365",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "route.CreateServiceAccountRequest": {
+            "type": "object",
+            "required": [
+                "role_id",
+                "username"
+            ],
+            "properties": {
+                "full_name": {
+                    "type": "string"
+                },
+                "role_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "route.CreateUserRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "route.DeactivateUserRequest": {
+            "type": "object",
+            "properties": {
+                "replacement_advisor_id": {
+                    "description": "lecturers.id pengganti dosen wali; wajib kalau user masih punya mahasiswa bimbingan",
+                    "type": "string"
+                }
+            }
+        },
+        "route.DelegationRequest": {
+            "type": "object",
+            "required": [
+                "ends_at",
+                "starts_at",
+                "to_lecturer_id"
+            ],
+            "properties": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-03-31"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "dinas luar negeri"
+                },
+                "starts_at": {
+                    "description": "YYYY-MM-DD / RFC3339; ends_at tanggal saja = sampai akhir hari",
+                    "type": "string",
+                    "example": "2025-03-01"
+                },
+                "to_lecturer_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "route.DepartmentRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "TI"
+                },
+                "faculty_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Teknologi Informasi"
+                }
+            }
+        },
+        "route.DisableTwoFactorRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "description": "kode TOTP atau kode cadangan",
                     "type": "string"
                 },
-                "updatedAt": {
+                "password": {
                     "type": "string"
                 }
             }
         },
-        "model.AchievementReference": {
+        "route.DuplicateReviewRequest": {
             "type": "object",
+            "required": [
+                "decision"
+            ],
             "properties": {
-                "created_at": {
+                "decision": {
+                    "description": "Decision: dismissed (bukan duplikat) | confirmed (duplikat)",
                     "type": "string"
                 },
-                "id": {
+                "note": {
                     "type": "string"
+                }
+            }
+        },
+        "route.FacultyRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "FT"
                 },
-                "mongo_achievement_id": {
+                "name": {
+                    "type": "string",
+                    "example": "Fakultas Teknik"
+                }
+            }
+        },
+        "route.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
                     "type": "string"
-                },
-                "rejection_note": {
+                }
+            }
+        },
+        "route.LecturerProfileRequest": {
+            "type": "object",
+            "properties": {
+                "department": {
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/model.AchievementStatus"
+                "department_id": {
+                    "description": "DepartmentID: jika diisi, department mengikuti nama jurusan",
+                    "type": "string"
                 },
-                "student": {
-                    "$ref": "#/definitions/model.Student"
+                "email": {
+                    "type": "string"
                 },
-                "student_id": {
+                "full_name": {
                     "type": "string"
                 },
-                "submitted_at": {
+                "lecturer_id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "password": {
                     "type": "string"
                 },
-                "verified_at": {
+                "role_id": {
                     "type": "string"
                 },
-                "verified_by": {
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "model.AchievementStatus": {
-            "type": "string",
-            "enum": [
-                "draft",
-                "submitted",
-                "verified",
-                "rejected",
-                "deleted"
-            ],
-            "x-enum-varnames": [
-                "AchievementStatusDraft",
-                "AchievementStatusSubmitted",
-                "AchievementStatusVerified",
-                "AchievementStatusRejected",
-                "AchievementStatusDeleted"
-            ]
-        },
-        "model.AchievementStatusLog": {
+        "route.ReassignAdvisorRequest": {
             "type": "object",
+            "required": [
+                "to_lecturer_id"
+            ],
             "properties": {
-                "achievementReferenceID": {
-                    "type": "string"
-                },
-                "changedBy": {
-                    "type": "string"
+                "effective_from": {
+                    "type": "string",
+                    "example": "2025-02-01"
                 },
-                "createdAt": {
+                "from_lecturer_id": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "reason": {
+                    "type": "string",
+                    "example": "dosen wali cuti studi"
                 },
-                "newStatus": {
-                    "type": "string"
+                "student_ids": {
+                    "description": "pilih salah satu: student_ids, atau from_lecturer_id dan/atau study_program_id",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "note": {
+                "study_program_id": {
                     "type": "string"
                 },
-                "oldStatus": {
+                "to_lecturer_id": {
                     "type": "string"
                 }
             }
         },
-        "model.Attachment": {
+        "route.ResetPasswordRequest": {
             "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
             "properties": {
-                "fileName": {
-                    "type": "string"
-                },
-                "fileType": {
+                "new_password": {
                     "type": "string"
                 },
-                "fileUrl": {
-                    "type": "string"
-                },
-                "uploadedAt": {
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "model.Role": {
+        "route.SetAdvisorRequest": {
             "type": "object",
+            "required": [
+                "advisor_id"
+            ],
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "description": {
+                "advisor_id": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "effective_from": {
+                    "description": "YYYY-MM-DD / RFC3339; kosong = sekarang",
+                    "type": "string",
+                    "example": "2025-02-01"
                 },
-                "name": {
+                "reason": {
                     "type": "string"
                 }
             }
         },
-        "model.Student": {
+        "route.StudentProfileRequest": {
             "type": "object",
             "properties": {
                 "academic_year": {
@@ -1798,71 +7375,129 @@ const docTemplate = `{
                 "advisor_id": {
                     "type": "string"
                 },
-                "created_at": {
+                "email": {
                     "type": "string"
                 },
-                "id": {
+                "full_name": {
+                    "type": "string"
+                },
+                "password": {
                     "type": "string"
                 },
                 "program_study": {
                     "type": "string"
                 },
+                "role_id": {
+                    "type": "string"
+                },
                 "student_id": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/model.User"
+                "study_program_id": {
+                    "description": "StudyProgramID: jika diisi, program_study mengikuti nama prodi",
+                    "type": "string"
                 },
-                "user_id": {
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "model.User": {
+        "route.StudyProgramRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "code": {
+                    "type": "string",
+                    "example": "D4TI"
+                },
+                "department_id": {
                     "type": "string"
                 },
-                "email": {
+                "name": {
+                    "type": "string",
+                    "example": "D4 Teknik Informatika"
+                }
+            }
+        },
+        "route.TeamAchievementRequest": {
+            "type": "object",
+            "properties": {
+                "achievementType": {
                     "type": "string"
                 },
-                "full_name": {
+                "attachments": {
+                    "type": "array",
+                    "items": {}
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "description": "DeletedAt: diisi saat soft delete, dasar hitung retensi tempat sampah",
                     "type": "string"
                 },
+                "description": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
                 "id": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "role": {
-                    "$ref": "#/definitions/model.Role"
+                "pointSplit": {
+                    "$ref": "#/definitions/model.TeamPointSplit"
                 },
-                "role_id": {
+                "points": {
+                    "type": "number"
+                },
+                "studentId": {
                     "type": "string"
                 },
-                "updated_at": {
+                "team": {
+                    "description": "Team: kosong untuk prestasi individu; StudentID tetap ketua tim",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TeamMember"
+                    }
+                },
+                "title": {
                     "type": "string"
                 },
-                "username": {
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "route.CreateUserRequest": {
+        "route.TwoFactorCodeRequest": {
             "type": "object",
+            "required": [
+                "code"
+            ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "full_name": {
+                "code": {
                     "type": "string"
-                },
-                "password": {
-                    "type": "string"
-                },
-                "role_id": {
+                }
+            }
+        },
+        "route.TwoFactorRequirementRequest": {
+            "type": "object",
+            "properties": {
+                "required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "route.UnlockRequest": {
+            "type": "object",
+            "properties": {
+                "ip": {
                     "type": "string"
                 },
                 "username": {
@@ -1870,13 +7505,11 @@ const docTemplate = `{
                 }
             }
         },
-        "route.SetAdvisorRequest": {
+        "route.UpdateAuthBackendRequest": {
             "type": "object",
-            "required": [
-                "advisor_id"
-            ],
             "properties": {
-                "advisor_id": {
+                "backend": {
+                    "description": "local | ldap | \"\" (ikut AUTH_BACKEND global)",
                     "type": "string"
                 }
             }
@@ -1928,6 +7561,103 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "route.twoFactorLoginRequest": {
+            "type": "object",
+            "required": [
+                "challenge_token",
+                "code"
+            ],
+            "properties": {
+                "challenge_token": {
+                    "type": "string"
+                },
+                "code": {
+                    "description": "kode TOTP 6 digit atau kode cadangan",
+                    "type": "string"
+                }
+            }
+        },
+        "service.ReassignAdvisorResult": {
+            "type": "object",
+            "properties": {
+                "pending_handed_over": {
+                    "type": "integer"
+                },
+                "reassigned": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "description": "mahasiswa yang sudah dibimbing dosen tujuan",
+                    "type": "integer"
+                },
+                "students": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ReassignedStudent"
+                    }
+                },
+                "to_lecturer_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.ReassignedStudent": {
+            "type": "object",
+            "properties": {
+                "from_lecturer_id": {
+                    "type": "string"
+                },
+                "pending_handed_over": {
+                    "description": "prestasi submitted yang sekarang menunggu verifikasi dosen wali baru",
+                    "type": "integer"
+                },
+                "student_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "description": "Ed25519",
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "description": "RSA",
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.JWKSet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/utils.JWK"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,68 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/smtp"
+	"strings"
+)
+
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Mailer: abstraksi pengiriman email (SMTP / log saat development)
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type Config struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// New: tanpa SMTP host, email hanya ditulis ke log
+func New(cfg Config) Mailer {
+	if cfg.Host == "" {
+		return &LogMailer{}
+	}
+	return &SMTPMailer{cfg: cfg}
+}
+
+type LogMailer struct{}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	log.Printf("[MAIL] to=%s subject=%q\n%s", strings.Join(msg.To, ","), msg.Subject, msg.Body)
+	return nil
+}
+
+type SMTPMailer struct {
+	cfg Config
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if len(msg.To) == 0 {
+		return nil
+	}
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+
+	body := fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		m.cfg.From,
+		strings.Join(msg.To, ", "),
+		msg.Subject,
+		msg.Body,
+	)
+
+	return smtp.SendMail(m.cfg.Host+":"+m.cfg.Port, auth, m.cfg.From, msg.To, []byte(body))
+}
@@ -1,15 +1,9 @@
 package main
 
 import (
-	"log"
+	"os"
 
-	"github.com/nerhays/prestasi_uas/config"
-	"github.com/nerhays/prestasi_uas/database"
-	"github.com/nerhays/prestasi_uas/route"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
-
-	_ "github.com/nerhays/prestasi_uas/docs"
+	"github.com/nerhays/prestasi_uas/cli"
 )
 
 // @title Prestasi Mahasiswa API
@@ -31,16 +25,11 @@ import (
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
+// go run . = serve; argumen lain diteruskan ke CLI (go run . migrate up, sama dengan cmd/prestasi)
 func main() {
-	cfg := config.LoadConfig()
-
-	pgDB := database.NewPostgres(cfg.PostgresDSN)
-	mongo := database.NewMongo(cfg.MongoURI, cfg.MongoDB)
-	
-	r := route.SetupRouter(pgDB, mongo.DB)
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-	log.Printf("[APP] Server running on :%s\n", cfg.AppPort)
-	if err := r.Run(":" + cfg.AppPort); err != nil {
-		log.Fatal(err)
+	args := os.Args[1:]
+	if len(args) == 0 {
+		args = []string{"serve"}
 	}
+	os.Exit(cli.Run(args))
 }
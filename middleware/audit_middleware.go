@@ -0,0 +1,214 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+const (
+	ContextRequestIDKey = "requestID"
+	RequestIDHeader     = "X-Request-ID"
+
+	// body request lebih besar dari ini tidak disalin ke audit log
+	auditMaxBody = 64 << 10
+)
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// field body request yang tidak boleh masuk audit log
+var auditSecretFields = []string{"password", "token", "secret"}
+
+// RequestID: pakai X-Request-ID dari client / proxy kalau formatnya wajar, selain itu buat baru
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			id, _ = utils.RandomToken(16)
+		}
+		c.Set(ContextRequestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// AuditRecorder: diimplementasikan service.AuditService
+type AuditRecorder interface {
+	Record(entry *model.AuditLog) error
+}
+
+// AuditLoader: ambil entitas target (id dari path) untuk snapshot sebelum / sesudah request
+type AuditLoader func(ctx context.Context, id string) (interface{}, error)
+
+type auditTarget struct {
+	targetType string
+	load       AuditLoader
+}
+
+// Audit: audit log request yang mengubah data; dibuat sekali per router dari dependensi di container
+type Audit struct {
+	recorder AuditRecorder
+
+	mu      sync.RWMutex
+	targets map[string]auditTarget
+}
+
+func NewAudit(recorder AuditRecorder) *Audit {
+	return &Audit{recorder: recorder, targets: map[string]auditTarget{}}
+}
+
+// Target: request POST/PUT/PATCH/DELETE ke fullPath mengubah entitas targetType dengan ID :id
+func (a *Audit) Target(fullPath, targetType string, load AuditLoader) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.targets[fullPath] = auditTarget{targetType, load}
+}
+
+// Trail: catat setiap request yang mengubah data dan berhasil (2xx/3xx) oleh user terautentikasi.
+// Request tanpa user (login, reset password) sudah tercatat di login_audits.
+func (a *Audit) Trail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			c.Next()
+			return
+		}
+
+		a.mu.RLock()
+		target, known := a.targets[c.FullPath()]
+		a.mu.RUnlock()
+
+		targetID := c.Param("id")
+		var before json.RawMessage
+		if known && target.load != nil && targetID != "" {
+			before = auditSnapshot(c.Request.Context(), target.load, targetID)
+		}
+		body := auditRequestBody(c)
+
+		c.Next()
+
+		actorID := c.GetString(ContextUserIDKey)
+		status := c.Writer.Status()
+		if actorID == "" || status >= http.StatusBadRequest {
+			return
+		}
+
+		entry := &model.AuditLog{
+			ActorID:       &actorID,
+			ActorUsername: c.GetString(ContextUsernameKey),
+			ActorRole:     c.GetString(ContextRoleKey),
+			Action:        c.Request.Method + " " + strings.TrimPrefix(c.FullPath(), "/api/v1"),
+			TargetType:    auditTargetType(c.FullPath()),
+			TargetID:      targetID,
+			Before:        before,
+			StatusCode:    status,
+			IPAddress:     c.ClientIP(),
+			UserAgent:     truncate(c.Request.UserAgent(), 255),
+			RequestID:     c.GetString(ContextRequestIDKey),
+		}
+		if tokenID := c.GetString(ContextAPITokenIDKey); tokenID != "" {
+			entry.APITokenID = &tokenID
+		}
+		if known {
+			entry.TargetType = target.targetType
+		}
+		if known && target.load != nil && targetID != "" {
+			entry.After = auditSnapshot(c.Request.Context(), target.load, targetID)
+		} else {
+			entry.After = body
+		}
+
+		if err := a.recorder.Record(entry); err != nil {
+			log.Printf("[AUDIT] failed to record %s by %s: %v", entry.Action, actorID, err)
+		}
+	}
+}
+
+func auditSnapshot(ctx context.Context, load AuditLoader, id string) json.RawMessage {
+	v, err := load(ctx, id)
+	if err != nil || v == nil {
+		return nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return raw
+}
+
+// auditRequestBody: salinan body JSON dengan field rahasia disensor; body tetap bisa dibaca handler.
+// Body endpoint /auth (password, kode 2FA, dll) tidak pernah disalin.
+func auditRequestBody(c *gin.Context) json.RawMessage {
+	if c.Request.Body == nil || !strings.HasPrefix(c.ContentType(), "application/json") ||
+		strings.HasPrefix(c.FullPath(), "/api/v1/auth/") {
+		return nil
+	}
+	raw, err := io.ReadAll(io.LimitReader(c.Request.Body, auditMaxBody+1))
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(raw), c.Request.Body))
+	if err != nil || len(raw) > auditMaxBody {
+		return nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil
+	}
+	redacted, _ := json.Marshal(redactSecrets(v))
+	return redacted
+}
+
+func redactSecrets(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, x := range t {
+			if isSecretField(k) {
+				t[k] = "[REDACTED]"
+				continue
+			}
+			t[k] = redactSecrets(x)
+		}
+	case []interface{}:
+		for i, x := range t {
+			t[i] = redactSecrets(x)
+		}
+	}
+	return v
+}
+
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range auditSecretFields {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// auditTargetType: "/api/v1/admin/users/:id/role" → "users"
+func auditTargetType(fullPath string) string {
+	for _, seg := range strings.Split(strings.TrimPrefix(fullPath, "/api/v1"), "/") {
+		if seg != "" && seg != "admin" && !strings.HasPrefix(seg, ":") {
+			return seg
+		}
+	}
+	return ""
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -3,8 +3,11 @@ package middleware
 import (
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/model"
 	"github.com/nerhays/prestasi_uas/utils"
 )
 
@@ -13,10 +16,58 @@ const (
 	ContextUsernameKey    = "username"
 	ContextRoleKey        = "role"
 	ContextPermissionsKey = "permissions"
+	ContextScopeKey       = "scope"
+	// ID API token; kosong = request memakai JWT
+	ContextAPITokenIDKey = "apiTokenID"
 )
 
-// AuthMiddleware: cek header Authorization: Bearer <token>
-func AuthMiddleware() gin.HandlerFunc {
+// TokenParser: diimplementasikan utils.KeyManager
+type TokenParser interface {
+	ParseToken(tokenStr string) (*utils.JWTCustomClaims, error)
+}
+
+// APITokenVerifier: diimplementasikan service.APITokenService
+type APITokenVerifier interface {
+	VerifyAPIToken(token, ip string) (*model.APITokenPrincipal, error)
+}
+
+// SessionChecker: diimplementasikan service.UserLifecycleService
+type SessionChecker interface {
+	CheckSession(userID string, issuedAt time.Time) error
+}
+
+// Auth: autentikasi JWT / API token; dibuat sekali per router dari dependensi di container
+type Auth struct {
+	tokens TokenParser
+	// nil = JWT cukup diverifikasi tanda tangan + exp (tanpa cek user nonaktif)
+	sessions SessionChecker
+	// nil = API token selalu ditolak
+	apiTokens APITokenVerifier
+
+	mu sync.RWMutex
+	// "METHOD /full/path" → permission yang wajib ada di scope token
+	apiTokenRoutes map[string]string
+}
+
+func NewAuth(tokens TokenParser, sessions SessionChecker, apiTokens APITokenVerifier) *Auth {
+	return &Auth{
+		tokens:         tokens,
+		sessions:       sessions,
+		apiTokens:      apiTokens,
+		apiTokenRoutes: map[string]string{},
+	}
+}
+
+// AllowAPIToken: endpoint ini boleh diakses API token yang punya scope perm.
+// Endpoint yang tidak didaftarkan hanya bisa diakses dengan JWT.
+func (a *Auth) AllowAPIToken(method, fullPath, perm string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.apiTokenRoutes[method+" "+fullPath] = perm
+}
+
+// Middleware: cek header Authorization: Bearer <token>
+func (a *Auth) Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if !strings.HasPrefix(authHeader, "Bearer ") {
@@ -32,23 +83,119 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		claims, err := utils.ParseToken(tokenStr)
+		if strings.HasPrefix(tokenStr, model.APITokenPrefix) {
+			a.authenticateAPIToken(c, tokenStr)
+			return
+		}
+
+		// tanda tangan (kid), exp, iss dan aud diverifikasi di sini
+		claims, err := a.tokens.ParseToken(tokenStr)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"message": "invalid or expired token"})
 			c.Abort()
 			return
 		}
 
+		if a.sessions != nil {
+			var issuedAt time.Time
+			if claims.IssuedAt != nil {
+				issuedAt = claims.IssuedAt.Time
+			}
+			if err := a.sessions.CheckSession(claims.UserID, issuedAt); err != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{"message": "session revoked"})
+				c.Abort()
+				return
+			}
+		}
+
+		// akun dengan langkah wajib (ganti password / daftar 2FA) hanya boleh endpoint terkait
+		if msg := pendingStepBlocked(claims, c.FullPath()); msg != "" {
+			c.JSON(http.StatusForbidden, gin.H{"message": msg})
+			c.Abort()
+			return
+		}
+
 		// simpan info user ke context
 		c.Set(ContextUserIDKey, claims.UserID)
 		c.Set(ContextUsernameKey, claims.Username)
 		c.Set(ContextRoleKey, claims.Role)
 		c.Set(ContextPermissionsKey, claims.Permissions)
+		c.Set(ContextScopeKey, model.UnitScope{
+			FacultyID:    claims.FacultyID,
+			DepartmentID: claims.DepartmentID,
+		})
 
 		c.Next()
 	}
 }
 
+func (a *Auth) authenticateAPIToken(c *gin.Context, tokenStr string) {
+	a.mu.RLock()
+	perm, allowed := a.apiTokenRoutes[c.Request.Method+" "+c.FullPath()]
+	a.mu.RUnlock()
+
+	if a.apiTokens == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "invalid or expired token"})
+		c.Abort()
+		return
+	}
+
+	principal, err := a.apiTokens.VerifyAPIToken(tokenStr, c.ClientIP())
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "invalid or expired token"})
+		c.Abort()
+		return
+	}
+
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"message": "endpoint not available for API tokens"})
+		c.Abort()
+		return
+	}
+	if !hasPermission(principal.Permissions, perm) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "token scope does not include " + perm})
+		c.Abort()
+		return
+	}
+
+	c.Set(ContextUserIDKey, principal.UserID)
+	c.Set(ContextUsernameKey, principal.Username)
+	c.Set(ContextRoleKey, principal.Role)
+	c.Set(ContextPermissionsKey, principal.Permissions)
+	c.Set(ContextScopeKey, principal.Scope)
+	c.Set(ContextAPITokenIDKey, principal.TokenID)
+
+	c.Next()
+}
+
+func hasPermission(perms []string, perm string) bool {
+	for _, p := range perms {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+func pendingStepBlocked(claims *utils.JWTCustomClaims, path string) string {
+	allowed := func(suffixes ...string) bool {
+		for _, s := range append(suffixes, "/auth/profile", "/auth/logout") {
+			if strings.HasSuffix(path, s) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if claims.PasswordChangeRequired && !allowed("/auth/password") {
+		return "password change required"
+	}
+	if claims.TwoFactorSetupRequired && !allowed("/auth/password", "/auth/2fa", "/auth/2fa/setup", "/auth/2fa/enable") {
+		return "two-factor enrollment required"
+	}
+	return ""
+}
+
 // RequireRole: cek role ("Admin", "Mahasiswa", "Dosen Wali")
 func RequireRole(roles ...string) gin.HandlerFunc {
 	roleSet := make(map[string]struct{}, len(roles))
@@ -107,3 +254,22 @@ func RoleOnly(allowedRoles ...string) gin.HandlerFunc {
 		c.Abort()
 	}
 }
+
+// ScopeFrom: scope unit user login (kosong = global)
+func ScopeFrom(c *gin.Context) model.UnitScope {
+	scope, _ := c.Get(ContextScopeKey)
+	s, _ := scope.(model.UnitScope)
+	return s
+}
+
+// GlobalAdminOnly: tolak admin fakultas/jurusan untuk endpoint lintas unit
+func GlobalAdminOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !ScopeFrom(c).IsGlobal() {
+			c.JSON(http.StatusForbidden, gin.H{"message": "forbidden for unit-scoped admin"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,256 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nerhays/prestasi_uas/app/container"
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/service"
+)
+
+type AcademicPeriodHandler struct {
+	periodSvc *service.AcademicPeriodService
+}
+
+func NewAcademicPeriodHandler(periodSvc *service.AcademicPeriodService) *AcademicPeriodHandler {
+	return &AcademicPeriodHandler{periodSvc}
+}
+
+type AcademicPeriodRequest struct {
+	AcademicYear string `json:"academic_year" example:"2024/2025"`
+	Semester     string `json:"semester" example:"ganjil"`
+	StartDate    string `json:"start_date" example:"2024-08-01"`
+	EndDate      string `json:"end_date" example:"2025-01-31"`
+	// RFC3339 atau YYYY-MM-DD (closes_at tanggal saja = sampai akhir hari)
+	SubmissionOpensAt  string `json:"submission_opens_at,omitempty" example:"2024-08-01"`
+	SubmissionClosesAt string `json:"submission_closes_at,omitempty" example:"2025-01-15"`
+}
+
+func (r AcademicPeriodRequest) toInput() (service.AcademicPeriodInput, error) {
+	in := service.AcademicPeriodInput{
+		AcademicYear: r.AcademicYear,
+		Semester:     model.Semester(r.Semester),
+	}
+
+	var err error
+	if in.StartDate, err = time.Parse("2006-01-02", r.StartDate); err != nil {
+		return in, errors.New("invalid start_date, use YYYY-MM-DD")
+	}
+	if in.EndDate, err = time.Parse("2006-01-02", r.EndDate); err != nil {
+		return in, errors.New("invalid end_date, use YYYY-MM-DD")
+	}
+	if in.SubmissionOpensAt, err = parseWindowTime(r.SubmissionOpensAt, false); err != nil {
+		return in, errors.New("invalid submission_opens_at")
+	}
+	if in.SubmissionClosesAt, err = parseWindowTime(r.SubmissionClosesAt, true); err != nil {
+		return in, errors.New("invalid submission_closes_at")
+	}
+	return in, nil
+}
+
+func parseWindowTime(v string, endOfDay bool) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Second)
+	}
+	return &t, nil
+}
+
+// GetAllPeriods godoc
+// @Summary Get academic periods
+// @Description Daftar periode akademik (semester) beserta jendela pengajuan prestasi
+// @Tags Academic Periods
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} model.AcademicPeriod
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /academic-periods [get]
+func (h *AcademicPeriodHandler) GetAll(c *gin.Context) {
+	data, err := h.periodSvc.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// GetActivePeriod godoc
+// @Summary Get active academic period
+// @Tags Academic Periods
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} model.AcademicPeriod
+// @Failure 404 {object} map[string]string "No active period"
+// @Router /academic-periods/active [get]
+func (h *AcademicPeriodHandler) GetActive(c *gin.Context) {
+	data, err := h.periodSvc.GetActive()
+	if err != nil {
+		writePeriodError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// GetPeriodByID godoc
+// @Summary Get academic period by ID
+// @Tags Academic Periods
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Period ID"
+// @Success 200 {object} model.AcademicPeriod
+// @Failure 404 {object} map[string]string "Period not found"
+// @Router /academic-periods/{id} [get]
+func (h *AcademicPeriodHandler) GetByID(c *gin.Context) {
+	data, err := h.periodSvc.GetByID(c.Param("id"))
+	if err != nil {
+		writePeriodError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// CreatePeriod godoc
+// @Summary Create academic period
+// @Description Admin membuat periode akademik; rentang tanggal tidak boleh tumpang tindih dengan periode lain
+// @Tags Admin - Academic Periods
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body AcademicPeriodRequest true "Period payload"
+// @Success 201 {object} model.AcademicPeriod
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 409 {object} map[string]string "Overlapping period"
+// @Router /admin/academic-periods [post]
+func (h *AcademicPeriodHandler) Create(c *gin.Context) {
+	var req AcademicPeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+	in, err := req.toInput()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	data, err := h.periodSvc.Create(in)
+	if err != nil {
+		writePeriodError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": data})
+}
+
+// UpdatePeriod godoc
+// @Summary Update academic period
+// @Tags Admin - Academic Periods
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Period ID"
+// @Param body body AcademicPeriodRequest true "Period payload"
+// @Success 200 {object} model.AcademicPeriod
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 404 {object} map[string]string "Period not found"
+// @Failure 409 {object} map[string]string "Overlapping period"
+// @Router /admin/academic-periods/{id} [put]
+func (h *AcademicPeriodHandler) Update(c *gin.Context) {
+	var req AcademicPeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+	in, err := req.toInput()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	data, err := h.periodSvc.Update(c.Param("id"), in)
+	if err != nil {
+		writePeriodError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// DeletePeriod godoc
+// @Summary Delete academic period
+// @Description Prestasi yang tercatat di periode ini menjadi tanpa periode
+// @Tags Admin - Academic Periods
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Period ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string "Period not found"
+// @Router /admin/academic-periods/{id} [delete]
+func (h *AcademicPeriodHandler) Delete(c *gin.Context) {
+	if err := h.periodSvc.Delete(c.Param("id")); err != nil {
+		writePeriodError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "academic period deleted"})
+}
+
+// ActivatePeriod godoc
+// @Summary Activate academic period
+// @Description Jadikan periode ini periode aktif (periode lain otomatis nonaktif)
+// @Tags Admin - Academic Periods
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Period ID"
+// @Success 200 {object} model.AcademicPeriod
+// @Failure 404 {object} map[string]string "Period not found"
+// @Router /admin/academic-periods/{id}/activate [post]
+func (h *AcademicPeriodHandler) Activate(c *gin.Context) {
+	data, err := h.periodSvc.Activate(c.Param("id"))
+	if err != nil {
+		writePeriodError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+func writePeriodError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrPeriodNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+	case errors.Is(err, service.ErrPeriodOverlap):
+		c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+	case errors.Is(err, service.ErrInvalidAcademicYear),
+		errors.Is(err, service.ErrInvalidSemester),
+		errors.Is(err, service.ErrInvalidPeriodRange),
+		errors.Is(err, service.ErrInvalidSubmissionWindow):
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+	}
+}
+
+func SetupAcademicPeriodRoutes(rg *gin.RouterGroup, c *container.Container) {
+	handler := NewAcademicPeriodHandler(c.AcademicPeriods)
+
+	rg.GET("/academic-periods", handler.GetAll)
+	rg.GET("/academic-periods/active", handler.GetActive)
+	rg.GET("/academic-periods/:id", handler.GetByID)
+}
@@ -2,30 +2,34 @@ package route
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"log"
+	"io"
 	"net/http"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/nerhays/prestasi_uas/app/container"
 	"github.com/nerhays/prestasi_uas/app/model"
 	"github.com/nerhays/prestasi_uas/app/repository"
 	"github.com/nerhays/prestasi_uas/app/service"
 	"github.com/nerhays/prestasi_uas/middleware"
-	"go.mongodb.org/mongo-driver/mongo"
-	"gorm.io/gorm"
+	"github.com/nerhays/prestasi_uas/utils"
 )
 
 type AchievementHandler struct {
-	svc *service.AchievementService
+	svc     *service.AchievementService
+	storage utils.Storage
 }
 
-func NewAchievementHandler(svc *service.AchievementService) *AchievementHandler {
-	return &AchievementHandler{svc: svc}
+func NewAchievementHandler(svc *service.AchievementService, storage utils.Storage) *AchievementHandler {
+	return &AchievementHandler{svc: svc, storage: storage}
 }
 
 // CreateAchievement godoc
@@ -110,8 +114,11 @@ func (h *AchievementHandler) Submit(c *gin.Context) {
 		switch err {
 		case service.ErrStudentProfileNotFound, service.ErrRefNotFound:
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
-		case service.ErrInvalidStatus, service.ErrNotOwner:
+		case service.ErrInvalidStatus, service.ErrNotOwner, service.ErrSubmissionClosed,
+			service.ErrNoOpenPeriod, service.ErrTeamInvitationPending:
 			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		case service.ErrNotTeamLeader:
+			c.JSON(http.StatusForbidden, gin.H{"message": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"message": "internal error"})
 		}
@@ -231,6 +238,8 @@ func (h *AchievementHandler) Delete(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"message": "only draft can be deleted"})
 		case service.ErrNotOwner:
 			c.JSON(http.StatusForbidden, gin.H{"message": "unauthorized"})
+		case service.ErrNotTeamLeader:
+			c.JSON(http.StatusForbidden, gin.H{"message": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
 		}
@@ -263,6 +272,42 @@ func (h *AchievementHandler) GetDeleted(c *gin.Context) {
     })
 }
 
+// RestoreAchievement godoc
+// @Summary Restore deleted achievement
+// @Description Prestasi yang dihapus kembali menjadi draft (pemilik atau admin). Draft anggota tim ikut dipulihkan
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Success 200 {object} model.AchievementReference
+// @Failure 400 {object} map[string]string "Not deleted / team member reference"
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /achievements/{id}/restore [post]
+func (h *AchievementHandler) Restore(c *gin.Context) {
+	ref, err := h.svc.RestoreAchievement(
+		c.Request.Context(),
+		c.GetString(middleware.ContextUserIDKey),
+		c.GetString(middleware.ContextRoleKey),
+		c.Param("id"),
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrRefNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		case errors.Is(err, service.ErrNotOwner):
+			c.JSON(http.StatusForbidden, gin.H{"message": err.Error()})
+		case errors.Is(err, service.ErrInvalidStatus), errors.Is(err, service.ErrNotTeamLeader):
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": ref})
+}
+
 // GetBimbinganAchievements godoc
 // @Summary Get achievements under supervision
 // @Description Dosen wali melihat prestasi mahasiswa bimbingannya
@@ -337,19 +382,24 @@ func (h *AchievementHandler) UploadAttachment(c *gin.Context) {
 		return
 	}
 
-	// buat folder jika belum ada
-	uploadDir := "uploads/achievements"
-	_ = os.MkdirAll(uploadDir, 0755)
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "file required"})
+		return
+	}
+	defer src.Close()
 
 	// generate nama file aman
 	filename := uuid.New().String() + ext
-	filePath := filepath.Join(uploadDir, filename)
 
-	// simpan file ke disk
-	if err := c.SaveUploadedFile(file, filePath); err != nil {
+	// simpan file; isi di-hash sekalian untuk deteksi lampiran duplikat
+	hasher := sha256.New()
+	fileURL, err := h.storage.Save("achievements", filename, io.TeeReader(src, hasher))
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
 		return
 	}
+	fileHash := hex.EncodeToString(hasher.Sum(nil))
 
 	// panggil service
 	attachment, err := h.svc.UploadAttachment(
@@ -357,11 +407,12 @@ func (h *AchievementHandler) UploadAttachment(c *gin.Context) {
 		userID,
 		refID,
 		filename,
-		"/"+filePath,
+		fileURL,
 		file.Header.Get("Content-Type"),
+		fileHash,
 	)
 	if err != nil {
-		_ = os.Remove(filePath) // rollback file
+		_ = h.storage.Remove(fileURL) // rollback file
 		c.JSON(http.StatusForbidden, gin.H{"message": err.Error()})
 		return
 	}
@@ -396,6 +447,87 @@ func (h *AchievementHandler) GetHistory(c *gin.Context) {
 	})
 }
 
+// GetVersions godoc
+// @Summary List achievement versions
+// @Description Riwayat isi prestasi: setiap penyimpanan membuat versi baru. verified_version = versi yang diverifikasi dosen wali
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /achievements/{id}/versions [get]
+func (h *AchievementHandler) GetVersions(c *gin.Context) {
+	ref, versions, err := h.svc.GetAchievementVersions(
+		c.Request.Context(),
+		c.Param("id"),
+		c.GetString(middleware.ContextUserIDKey),
+		c.GetString(middleware.ContextRoleKey),
+	)
+	if err != nil {
+		writeVersionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"verified_version": ref.VerifiedVersion,
+			"versions":         versions,
+		},
+	})
+}
+
+// DiffVersions godoc
+// @Summary Diff two achievement versions
+// @Description Perubahan per field antara dua versi isi prestasi; field details dibandingkan per key (mis. details.rank)
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Param from query int true "Versi awal"
+// @Param to query int true "Versi akhir"
+// @Success 200 {object} model.AchievementVersionDiff
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /achievements/{id}/versions/diff [get]
+func (h *AchievementHandler) DiffVersions(c *gin.Context) {
+	from, errFrom := strconv.Atoi(c.Query("from"))
+	to, errTo := strconv.Atoi(c.Query("to"))
+	if errFrom != nil || errTo != nil || from < 1 || to < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "from and to must be version numbers"})
+		return
+	}
+
+	diff, err := h.svc.DiffAchievementVersions(
+		c.Request.Context(),
+		c.Param("id"),
+		c.GetString(middleware.ContextUserIDKey),
+		c.GetString(middleware.ContextRoleKey),
+		from, to,
+	)
+	if err != nil {
+		writeVersionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": diff})
+}
+
+func writeVersionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrRefNotFound), errors.Is(err, service.ErrVersionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+	case errors.Is(err, service.ErrNotOwner), errors.Is(err, service.ErrNotAdvisor),
+		errors.Is(err, service.ErrStudentProfileNotFound), errors.Is(err, service.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"message": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+	}
+}
+
 // GetAchievementDetail godoc
 // @Summary Get achievement detail
 // @Description Detail prestasi (RBAC: Mahasiswa, Dosen Wali, Admin)
@@ -490,19 +622,201 @@ func (h *AchievementHandler) GetListByRole(c *gin.Context) {
 	c.JSON(200, gin.H{"status": "success", "data": data})
 }
 
+// TeamAchievementRequest: payload prestasi + NIM anggota tim (ketua = user login)
+type TeamAchievementRequest struct {
+	model.Achievement
+	Members []string `json:"members"`
+}
+
+// CreateTeamAchievement godoc
+// @Summary Create team achievement (draft)
+// @Description Ketua tim membuat prestasi tim dan mengundang anggota berdasarkan NIM. pointSplit: equal | full | leader_weighted
+// @Tags Achievements - Team
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body TeamAchievementRequest true "Team achievement payload"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string "Member NIM not found"
+// @Router /achievements/team [post]
+func (h *AchievementHandler) CreateTeam(c *gin.Context) {
+	userID := c.GetString(middleware.ContextUserIDKey)
 
-func SetupAchievementRoutes(rg *gin.RouterGroup, db *gorm.DB, mongoDB *mongo.Database) {
-	achievementRepo := repository.NewAchievementRepository(mongoDB)
-	studentRepo := repository.NewStudentRepository(db)
-	refRepo := repository.NewAchievementReferenceRepository(db)
-	userRepo := repository.NewUserRepository(db)
-	lecturerRepo := repository.NewLecturerRepository(db)
-	logRepo := repository.NewAchievementStatusLogRepository(db)
-	achievementSvc := service.NewAchievementService(achievementRepo, studentRepo, refRepo, userRepo, lecturerRepo, logRepo)
-	handler := NewAchievementHandler(achievementSvc)
+	var req TeamAchievementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	ac, ref, team, err := h.svc.CreateTeamAchievement(c.Request.Context(), userID, &req.Achievement, req.Members)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrTeamMemberNotFound), errors.Is(err, service.ErrStudentProfileNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		case errors.Is(err, service.ErrTeamMembersRequired), errors.Is(err, service.ErrInvalidPointSplit):
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"achievement": ac,
+			"reference":   ref,
+			"team":        team,
+		},
+	})
+}
+
+// GetTeamInvitations godoc
+// @Summary Get my team invitations
+// @Description Mahasiswa melihat undangan prestasi tim yang belum dijawab
+// @Tags Achievements - Team
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /achievements/invitations [get]
+func (h *AchievementHandler) GetInvitations(c *gin.Context) {
+	userID := c.GetString(middleware.ContextUserIDKey)
+
+	data, err := h.svc.GetMyTeamInvitations(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// AcceptTeamInvitation godoc
+// @Summary Accept team invitation
+// @Description Anggota menerima undangan; draft prestasi anggota dibuat untuk diverifikasi dosen walinya
+// @Tags Achievements - Team
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} model.AchievementTeamMember
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /achievements/invitations/{id}/accept [post]
+func (h *AchievementHandler) AcceptInvitation(c *gin.Context) {
+	h.respondInvitation(c, true)
+}
+
+// DeclineTeamInvitation godoc
+// @Summary Decline team invitation
+// @Description Anggota menolak undangan dan dikeluarkan dari tim
+// @Tags Achievements - Team
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} model.AchievementTeamMember
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /achievements/invitations/{id}/decline [post]
+func (h *AchievementHandler) DeclineInvitation(c *gin.Context) {
+	h.respondInvitation(c, false)
+}
+
+func (h *AchievementHandler) respondInvitation(c *gin.Context, accept bool) {
+	userID := c.GetString(middleware.ContextUserIDKey)
+
+	inv, err := h.svc.RespondTeamInvitation(c.Request.Context(), userID, c.Param("id"), accept)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvitationNotFound),
+			errors.Is(err, service.ErrStudentProfileNotFound),
+			errors.Is(err, service.ErrRefNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		case errors.Is(err, service.ErrInvitationAnswered), errors.Is(err, service.ErrInvalidStatus):
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": inv})
+}
+
+// GetTeamMembers godoc
+// @Summary Get team members
+// @Description Anggota tim + status undangan (RBAC sama dengan detail prestasi)
+// @Tags Achievements - Team
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Success 200 {array} model.AchievementTeamMember
+// @Failure 403 {object} map[string]string
+// @Router /achievements/{id}/team [get]
+func (h *AchievementHandler) GetTeam(c *gin.Context) {
+	userID := c.GetString(middleware.ContextUserIDKey)
+	role := c.GetString(middleware.ContextRoleKey)
+
+	members, err := h.svc.GetTeamMembers(c.Request.Context(), c.Param("id"), userID, role)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": members})
+}
+
+// achievementAuditSnapshot: isi prestasi (Mongo) + status dari reference dalam satu objek datar,
+// supaya diff audit log per field
+func achievementAuditSnapshot(
+	ctx context.Context,
+	refRepo repository.AchievementReferenceRepository,
+	achievementRepo repository.AchievementRepository,
+	refID string,
+) (interface{}, error) {
+	ref, err := refRepo.GetByID(refID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := map[string]interface{}{}
+	if ac, err := achievementRepo.FindByID(ctx, ref.MongoAchievementID); err == nil {
+		raw, _ := json.Marshal(ac)
+		_ = json.Unmarshal(raw, &snapshot)
+	}
+	snapshot["status"] = ref.Status
+	snapshot["period_id"] = ref.PeriodID
+	snapshot["submitted_at"] = ref.SubmittedAt
+	snapshot["verified_at"] = ref.VerifiedAt
+	snapshot["verified_by"] = ref.VerifiedBy
+	snapshot["rejection_note"] = ref.RejectionNote
+	snapshot["point_share"] = ref.PointShare
+	snapshot["verified_version"] = ref.VerifiedVersion
+	return snapshot, nil
+}
+
+func SetupAchievementRoutes(rg *gin.RouterGroup, c *container.Container, authn *middleware.Auth, audit *middleware.Audit) {
+	refRepo := c.Repos.AchievementReferences
+	achievementRepo := c.Repos.Achievements
+	teamRepo := c.Repos.AchievementTeams
+
+	handler := NewAchievementHandler(c.Achievements, c.Storage)
+	dashboardHandler := NewAdvisorDashboardHandler(c.AdvisorDashboard)
+	advisorHandler := NewAdvisorAssignmentHandler(c.AdvisorAssignments)
 
 	ach := rg.Group("/achievements")
-	ach.Use(middleware.AuthMiddleware())
+	ach.Use(authn.Middleware())
+
+	loadAchievement := func(ctx context.Context, id string) (interface{}, error) {
+		return achievementAuditSnapshot(ctx, refRepo, achievementRepo, id)
+	}
+	for _, p := range []string{"/:id", "/:id/submit", "/:id/attachments", "/:id/verify", "/:id/reject", "/:id/restore"} {
+		audit.Target(ach.BasePath()+p, "achievement", loadAchievement)
+	}
+	for _, p := range []string{"/invitations/:id/accept", "/invitations/:id/decline"} {
+		audit.Target(ach.BasePath()+p, "achievement_team_member", func(_ context.Context, id string) (interface{}, error) {
+			return teamRepo.FindByID(id)
+		})
+	}
 
 	// mahasiswa
 	ach.POST("/", handler.Create)
@@ -510,12 +824,22 @@ func SetupAchievementRoutes(rg *gin.RouterGroup, db *gorm.DB, mongoDB *mongo.Dat
 	ach.POST("/:id/submit", handler.Submit)
 	ach.DELETE("/:id", handler.Delete)
 	ach.GET("/deleted", handler.GetDeleted)
+	ach.POST("/:id/restore", handler.Restore)
 	ach.POST("/:id/attachments", handler.UploadAttachment)
 	ach.GET("/:id/history", handler.GetHistory)
+	ach.GET("/:id/versions", handler.GetVersions)
+	ach.GET("/:id/versions/diff", handler.DiffVersions)
 	ach.GET("/:id", handler.GetDetail)
 	ach.PUT("/:id", handler.Update)
 	ach.GET("/", handler.GetListByRole)
 
+	// prestasi tim
+	ach.POST("/team", handler.CreateTeam)
+	ach.GET("/invitations", handler.GetInvitations)
+	ach.POST("/invitations/:id/accept", handler.AcceptInvitation)
+	ach.POST("/invitations/:id/decline", handler.DeclineInvitation)
+	ach.GET("/:id/team", handler.GetTeam)
+
 
 
 
@@ -525,4 +849,8 @@ func SetupAchievementRoutes(rg *gin.RouterGroup, db *gorm.DB, mongoDB *mongo.Dat
 	dosen.POST("/:id/verify", handler.Verify)
 	dosen.POST("/:id/reject", handler.Reject)
 	dosen.GET("/bimbingan", handler.GetBimbingan)
+	dosen.GET("/bimbingan/dashboard", dashboardHandler.GetMyDashboard)
+	dosen.GET("/bimbingan/delegations", advisorHandler.GetMyDelegations)
+	dosen.POST("/bimbingan/delegations", advisorHandler.CreateMyDelegation)
+	dosen.DELETE("/bimbingan/delegations/:id", advisorHandler.RevokeMyDelegation)
 }
@@ -0,0 +1,89 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/service"
+	"github.com/nerhays/prestasi_uas/middleware"
+)
+
+type AdminAnalyticsHandler struct {
+	analyticsSvc *service.AnalyticsService
+}
+
+func NewAdminAnalyticsHandler(analyticsSvc *service.AnalyticsService) *AdminAnalyticsHandler {
+	return &AdminAnalyticsHandler{analyticsSvc}
+}
+
+// GetAnalytics godoc
+// @Summary Get achievement analytics
+// @Description Tren prestasi terverifikasi, rata-rata waktu verifikasi, dan leaderboard mahasiswa/prodi berdasarkan poin
+// @Tags Admin - Reports
+// @Security BearerAuth
+// @Produce json
+// @Param from query string false "Start date (YYYY-MM-DD), default 1 year ago"
+// @Param to query string false "End date inclusive (YYYY-MM-DD), default today"
+// @Param group_by query string false "month | semester | program_study | academic_year | competition_level" default(month)
+// @Param limit query int false "Leaderboard size" default(10)
+// @Param period_id query string false "Academic period ID (overrides from/to)"
+// @Success 200 {object} model.AchievementAnalytics "Analytics data"
+// @Failure 400 {object} map[string]string "Invalid parameter"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /admin/reports/analytics [get]
+func (h *AdminAnalyticsHandler) GetAnalytics(c *gin.Context) {
+	today := time.Now().Truncate(24 * time.Hour)
+
+	to := today.AddDate(0, 0, 1)
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "invalid to date, use YYYY-MM-DD"})
+			return
+		}
+		to = t.AddDate(0, 0, 1) // inklusif
+	}
+
+	from := to.AddDate(-1, 0, 0)
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "invalid from date, use YYYY-MM-DD"})
+			return
+		}
+		from = t
+	}
+
+	if !from.Before(to) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "from must be before to"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	data, err := h.analyticsSvc.GetAnalytics(c.Request.Context(), service.AnalyticsQuery{
+		From:     from,
+		To:       to,
+		GroupBy:  c.Query("group_by"),
+		Limit:    limit,
+		PeriodID: c.Query("period_id"),
+		Scope:    middleware.ScopeFrom(c),
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidGroupBy) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrPeriodNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
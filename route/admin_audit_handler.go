@@ -0,0 +1,101 @@
+package route
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/service"
+)
+
+type AdminAuditHandler struct {
+	auditSvc *service.AuditService
+}
+
+func NewAdminAuditHandler(auditSvc *service.AuditService) *AdminAuditHandler {
+	return &AdminAuditHandler{auditSvc}
+}
+
+// GetLogs godoc
+// @Summary Audit log
+// @Description Jejak semua aksi yang mengubah data (aktor, aksi, target, before/after, IP, request ID), terbaru di depan
+// @Tags Admin - Audit
+// @Security BearerAuth
+// @Produce json
+// @Param actor_id query string false "Filter actor user ID"
+// @Param action query string false "Filter action (substring, mis. /admin/users)"
+// @Param target_type query string false "Filter target type (user, student, achievement, ...)"
+// @Param target_id query string false "Filter target ID"
+// @Param request_id query string false "Filter request ID"
+// @Param from query string false "From (YYYY-MM-DD atau RFC3339)"
+// @Param to query string false "To, eksklusif (YYYY-MM-DD atau RFC3339)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Invalid date"
+// @Router /admin/audit-logs [get]
+func (h *AdminAuditHandler) GetLogs(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	filter := model.AuditLogFilter{
+		ActorID:    c.Query("actor_id"),
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+		RequestID:  c.Query("request_id"),
+	}
+	var err error
+	if filter.From, err = parseAuditTime(c.Query("from")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid from"})
+		return
+	}
+	if filter.To, err = parseAuditTime(c.Query("to")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid to"})
+		return
+	}
+
+	entries, total, err := h.auditSvc.GetLogs(filter, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"meta":   gin.H{"page": page, "limit": limit, "total": total},
+		"data":   entries,
+	})
+}
+
+// VerifyChain godoc
+// @Summary Verify audit log hash chain
+// @Description Hitung ulang rantai hash audit log dari awal; entri yang diubah / dihapus langsung di tabel terdeteksi
+// @Tags Admin - Audit
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /admin/audit-logs/verify [get]
+func (h *AdminAuditHandler) VerifyChain(c *gin.Context) {
+	report, err := h.auditSvc.VerifyChain()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": report})
+}
+
+func parseAuditTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		if t, err = time.Parse("2006-01-02", s); err != nil {
+			return nil, err
+		}
+	}
+	return &t, nil
+}
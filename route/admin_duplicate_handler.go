@@ -0,0 +1,95 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/service"
+	"github.com/nerhays/prestasi_uas/middleware"
+)
+
+type AdminDuplicateHandler struct {
+	duplicateSvc *service.DuplicateService
+}
+
+func NewAdminDuplicateHandler(duplicateSvc *service.DuplicateService) *AdminDuplicateHandler {
+	return &AdminDuplicateHandler{duplicateSvc}
+}
+
+type DuplicateReviewRequest struct {
+	// Decision: dismissed (bukan duplikat) | confirmed (duplikat)
+	Decision string `json:"decision" binding:"required"`
+	Note     string `json:"note"`
+}
+
+// GetDuplicateQueue godoc
+// @Summary Get duplicate review queue
+// @Description Daftar prestasi yang terdeteksi kemungkinan duplikat (judul mirip, event sama, hash lampiran sama, nomor sertifikat sama)
+// @Tags Admin - Duplicates
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "open | dismissed | confirmed" default(open)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Invalid status"
+// @Router /admin/duplicates [get]
+func (h *AdminDuplicateHandler) GetQueue(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	flags, total, err := h.duplicateSvc.GetReviewQueue(c.DefaultQuery("status", "open"), page, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidDuplicateStatus) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"meta":   gin.H{"page": page, "limit": limit, "total": total},
+		"data":   flags,
+	})
+}
+
+// ReviewDuplicate godoc
+// @Summary Review duplicate flag
+// @Description Tandai flag sebagai bukan duplikat (dismissed) atau duplikat (confirmed)
+// @Tags Admin - Duplicates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Duplicate flag ID"
+// @Param body body DuplicateReviewRequest true "Review decision"
+// @Success 200 {object} model.AchievementDuplicateFlag
+// @Failure 400 {object} map[string]string "Invalid decision or already reviewed"
+// @Failure 404 {object} map[string]string "Flag not found"
+// @Router /admin/duplicates/{id}/review [post]
+func (h *AdminDuplicateHandler) Review(c *gin.Context) {
+	var req DuplicateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	userID := c.GetString(middleware.ContextUserIDKey)
+	flag, err := h.duplicateSvc.Review(c.Param("id"), userID, req.Decision, req.Note)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrDuplicateFlagNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		case errors.Is(err, service.ErrInvalidDuplicateDecision), errors.Is(err, service.ErrDuplicateFlagReviewed):
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": flag})
+}
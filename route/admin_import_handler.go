@@ -0,0 +1,125 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/service"
+	"github.com/nerhays/prestasi_uas/middleware"
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+type AdminImportHandler struct {
+	importSvc *service.ImportService
+}
+
+func NewAdminImportHandler(importSvc *service.ImportService) *AdminImportHandler {
+	return &AdminImportHandler{importSvc}
+}
+
+// ImportUsers godoc
+// @Summary Import students and lecturers
+// @Description Import user + profil mahasiswa/dosen + dosen wali dari CSV/XLSX. Kolom: username, email, full_name, password, role, student_id, program_study, academic_year, advisor_id (NIDN), lecturer_id, department. Upsert berdasarkan student_id / lecturer_id.
+// @Tags Admin - Imports
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV / XLSX file"
+// @Param dry_run formData bool false "Validate only, nothing is written"
+// @Success 200 {object} model.ImportReport "Dry-run report"
+// @Success 202 {object} model.ImportJob "Import job started"
+// @Failure 400 {object} map[string]string "Invalid file"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 503 {object} map[string]string "Server is shutting down"
+// @Router /admin/imports [post]
+func (h *AdminImportHandler) Import(c *gin.Context) {
+	userID := c.GetString(middleware.ContextUserIDKey)
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "file required"})
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "cannot read file"})
+		return
+	}
+	defer f.Close()
+
+	rows, err := h.importSvc.ParseImportFile(file.Filename, f)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrUnsupportedSpreadsheet),
+			errors.Is(err, service.ErrImportEmptyFile),
+			errors.Is(err, service.ErrImportMissingColumn):
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"message": "invalid file: " + err.Error()})
+		}
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(c.DefaultPostForm("dry_run", "false"))
+	if dryRun {
+		report, err := h.importSvc.DryRun(rows)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "success", "data": report})
+		return
+	}
+
+	job, err := h.importSvc.StartImport(userID, file.Filename, rows)
+	if errors.Is(err, service.ErrImportShuttingDown) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"status": "success", "data": job})
+}
+
+// GetAllImports godoc
+// @Summary List import jobs
+// @Description List semua job import (tanpa detail error per baris)
+// @Tags Admin - Imports
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{} "List of import jobs"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /admin/imports [get]
+func (h *AdminImportHandler) GetAll(c *gin.Context) {
+	jobs, err := h.importSvc.GetAllJobs()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": jobs})
+}
+
+// GetImport godoc
+// @Summary Get import job status
+// @Description Progress dan error per baris dari job import
+// @Tags Admin - Imports
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Import job ID"
+// @Success 200 {object} model.ImportJob "Import job"
+// @Failure 404 {object} map[string]string "Import job not found"
+// @Router /admin/imports/{id} [get]
+func (h *AdminImportHandler) GetByID(c *gin.Context) {
+	job, err := h.importSvc.GetJob(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": job})
+}
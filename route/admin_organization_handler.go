@@ -0,0 +1,368 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/service"
+)
+
+type AdminOrganizationHandler struct {
+	orgSvc *service.OrganizationService
+}
+
+func NewAdminOrganizationHandler(orgSvc *service.OrganizationService) *AdminOrganizationHandler {
+	return &AdminOrganizationHandler{orgSvc}
+}
+
+type FacultyRequest struct {
+	Code string `json:"code" example:"FT"`
+	Name string `json:"name" example:"Fakultas Teknik"`
+}
+
+type DepartmentRequest struct {
+	FacultyID string `json:"faculty_id"`
+	Code      string `json:"code" example:"TI"`
+	Name      string `json:"name" example:"Teknologi Informasi"`
+}
+
+type StudyProgramRequest struct {
+	DepartmentID string `json:"department_id"`
+	Code         string `json:"code" example:"D4TI"`
+	Name         string `json:"name" example:"D4 Teknik Informatika"`
+}
+
+type AdminScopeRequest struct {
+	FacultyID    string `json:"faculty_id"`
+	DepartmentID string `json:"department_id"`
+}
+
+// GetFaculties godoc
+// @Summary Get faculties
+// @Tags Admin - Organization
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} model.Faculty
+// @Router /admin/faculties [get]
+func (h *AdminOrganizationHandler) GetFaculties(c *gin.Context) {
+	data, err := h.orgSvc.GetFaculties()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// CreateFaculty godoc
+// @Summary Create faculty
+// @Tags Admin - Organization
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body FacultyRequest true "Faculty payload"
+// @Success 201 {object} model.Faculty
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Router /admin/faculties [post]
+func (h *AdminOrganizationHandler) CreateFaculty(c *gin.Context) {
+	var req FacultyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	data, err := h.orgSvc.CreateFaculty(service.UnitInput{Code: req.Code, Name: req.Name})
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": data})
+}
+
+// UpdateFaculty godoc
+// @Summary Update faculty
+// @Tags Admin - Organization
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Faculty ID"
+// @Param body body FacultyRequest true "Faculty payload"
+// @Success 200 {object} model.Faculty
+// @Failure 404 {object} map[string]string "Faculty not found"
+// @Router /admin/faculties/{id} [put]
+func (h *AdminOrganizationHandler) UpdateFaculty(c *gin.Context) {
+	var req FacultyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	data, err := h.orgSvc.UpdateFaculty(c.Param("id"), service.UnitInput{Code: req.Code, Name: req.Name})
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// DeleteFaculty godoc
+// @Summary Delete faculty
+// @Description Hanya bisa dihapus jika tidak punya jurusan
+// @Tags Admin - Organization
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Faculty ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string "Faculty not found"
+// @Failure 409 {object} map[string]string "Faculty still in use"
+// @Router /admin/faculties/{id} [delete]
+func (h *AdminOrganizationHandler) DeleteFaculty(c *gin.Context) {
+	if err := h.orgSvc.DeleteFaculty(c.Param("id")); err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "faculty deleted"})
+}
+
+// GetDepartments godoc
+// @Summary Get departments
+// @Tags Admin - Organization
+// @Security BearerAuth
+// @Produce json
+// @Param faculty_id query string false "Filter by faculty"
+// @Success 200 {array} model.Department
+// @Router /admin/departments [get]
+func (h *AdminOrganizationHandler) GetDepartments(c *gin.Context) {
+	data, err := h.orgSvc.GetDepartments(c.Query("faculty_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// CreateDepartment godoc
+// @Summary Create department
+// @Tags Admin - Organization
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body DepartmentRequest true "Department payload"
+// @Success 201 {object} model.Department
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 404 {object} map[string]string "Faculty not found"
+// @Router /admin/departments [post]
+func (h *AdminOrganizationHandler) CreateDepartment(c *gin.Context) {
+	var req DepartmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	data, err := h.orgSvc.CreateDepartment(service.UnitInput{ParentID: req.FacultyID, Code: req.Code, Name: req.Name})
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": data})
+}
+
+// UpdateDepartment godoc
+// @Summary Update department
+// @Tags Admin - Organization
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Department ID"
+// @Param body body DepartmentRequest true "Department payload"
+// @Success 200 {object} model.Department
+// @Failure 404 {object} map[string]string "Department not found"
+// @Router /admin/departments/{id} [put]
+func (h *AdminOrganizationHandler) UpdateDepartment(c *gin.Context) {
+	var req DepartmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	data, err := h.orgSvc.UpdateDepartment(c.Param("id"), service.UnitInput{ParentID: req.FacultyID, Code: req.Code, Name: req.Name})
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// DeleteDepartment godoc
+// @Summary Delete department
+// @Description Hanya bisa dihapus jika tidak punya prodi dan dosen
+// @Tags Admin - Organization
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Department ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string "Department not found"
+// @Failure 409 {object} map[string]string "Department still in use"
+// @Router /admin/departments/{id} [delete]
+func (h *AdminOrganizationHandler) DeleteDepartment(c *gin.Context) {
+	if err := h.orgSvc.DeleteDepartment(c.Param("id")); err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "department deleted"})
+}
+
+// GetStudyPrograms godoc
+// @Summary Get study programs
+// @Tags Admin - Organization
+// @Security BearerAuth
+// @Produce json
+// @Param department_id query string false "Filter by department"
+// @Success 200 {array} model.StudyProgram
+// @Router /admin/study-programs [get]
+func (h *AdminOrganizationHandler) GetStudyPrograms(c *gin.Context) {
+	data, err := h.orgSvc.GetStudyPrograms(c.Query("department_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// CreateStudyProgram godoc
+// @Summary Create study program
+// @Tags Admin - Organization
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body StudyProgramRequest true "Study program payload"
+// @Success 201 {object} model.StudyProgram
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 404 {object} map[string]string "Department not found"
+// @Router /admin/study-programs [post]
+func (h *AdminOrganizationHandler) CreateStudyProgram(c *gin.Context) {
+	var req StudyProgramRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	data, err := h.orgSvc.CreateStudyProgram(service.UnitInput{ParentID: req.DepartmentID, Code: req.Code, Name: req.Name})
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": data})
+}
+
+// UpdateStudyProgram godoc
+// @Summary Update study program
+// @Tags Admin - Organization
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Study program ID"
+// @Param body body StudyProgramRequest true "Study program payload"
+// @Success 200 {object} model.StudyProgram
+// @Failure 404 {object} map[string]string "Study program not found"
+// @Router /admin/study-programs/{id} [put]
+func (h *AdminOrganizationHandler) UpdateStudyProgram(c *gin.Context) {
+	var req StudyProgramRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	data, err := h.orgSvc.UpdateStudyProgram(c.Param("id"), service.UnitInput{ParentID: req.DepartmentID, Code: req.Code, Name: req.Name})
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// DeleteStudyProgram godoc
+// @Summary Delete study program
+// @Description Hanya bisa dihapus jika tidak ada mahasiswa di prodi ini
+// @Tags Admin - Organization
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Study program ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string "Study program not found"
+// @Failure 409 {object} map[string]string "Study program still in use"
+// @Router /admin/study-programs/{id} [delete]
+func (h *AdminOrganizationHandler) DeleteStudyProgram(c *gin.Context) {
+	if err := h.orgSvc.DeleteStudyProgram(c.Param("id")); err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "study program deleted"})
+}
+
+// SetAdminScope godoc
+// @Summary Set admin unit scope
+// @Description Batasi admin ke fakultas/jurusan (admin fakultas). Kosongkan keduanya untuk admin global. Token lama user ini langsung dicabut.
+// @Tags Admin - Organization
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param body body AdminScopeRequest true "Scope payload"
+// @Success 200 {object} model.User
+// @Failure 400 {object} map[string]string "Invalid scope"
+// @Failure 404 {object} map[string]string "User or unit not found"
+// @Router /admin/users/{id}/scope [put]
+func (h *AdminOrganizationHandler) SetAdminScope(c *gin.Context) {
+	var req AdminScopeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	data, err := h.orgSvc.SetAdminScope(c.Param("id"), model.UnitScope{
+		FacultyID:    req.FacultyID,
+		DepartmentID: req.DepartmentID,
+	})
+	if err != nil {
+		writeOrganizationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// UnmappedOrganization godoc
+// @Summary List legacy program/department text without a unit
+// @Description Nilai students.program_study dan lecturers.department yang tidak terpetakan migrasi 0004 (typo / unit belum dibuat).
+// @Tags Admin - Organization
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} model.OrganizationUnmappedReport
+// @Router /admin/organization/unmapped [get]
+func (h *AdminOrganizationHandler) Unmapped(c *gin.Context) {
+	data, err := h.orgSvc.UnmappedUnits()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+func writeOrganizationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrFacultyNotFound),
+		errors.Is(err, service.ErrDepartmentNotFound),
+		errors.Is(err, service.ErrStudyProgramNotFound),
+		errors.Is(err, service.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+	case errors.Is(err, service.ErrUnitInUse):
+		c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+	case errors.Is(err, service.ErrUnitInvalidInput),
+		errors.Is(err, service.ErrScopeRequiresAdmin),
+		errors.Is(err, service.ErrScopeMismatch):
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+	}
+}
@@ -0,0 +1,287 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/service"
+	"github.com/nerhays/prestasi_uas/middleware"
+)
+
+type AdminProfileHandler struct {
+	profileSvc *service.ProfileService
+}
+
+func NewAdminProfileHandler(profileSvc *service.ProfileService) *AdminProfileHandler {
+	return &AdminProfileHandler{profileSvc}
+}
+
+type StudentProfileRequest struct {
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	FullName     string `json:"full_name"`
+	RoleID       string `json:"role_id"`
+	StudentID    string `json:"student_id"`
+	ProgramStudy string `json:"program_study"`
+	AcademicYear string `json:"academic_year"`
+	AdvisorID    string `json:"advisor_id"`
+	// StudyProgramID: jika diisi, program_study mengikuti nama prodi
+	StudyProgramID string `json:"study_program_id"`
+}
+
+func (r StudentProfileRequest) toInput() service.StudentProfileInput {
+	return service.StudentProfileInput{
+		ProfileAccountInput: service.ProfileAccountInput{
+			Username: r.Username,
+			Email:    r.Email,
+			Password: r.Password,
+			FullName: r.FullName,
+			RoleID:   r.RoleID,
+		},
+		StudentID:      r.StudentID,
+		ProgramStudy:   r.ProgramStudy,
+		AcademicYear:   r.AcademicYear,
+		AdvisorID:      r.AdvisorID,
+		StudyProgramID: r.StudyProgramID,
+	}
+}
+
+type LecturerProfileRequest struct {
+	Username   string `json:"username"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	FullName   string `json:"full_name"`
+	RoleID     string `json:"role_id"`
+	LecturerID string `json:"lecturer_id"`
+	Department string `json:"department"`
+	// DepartmentID: jika diisi, department mengikuti nama jurusan
+	DepartmentID string `json:"department_id"`
+}
+
+func (r LecturerProfileRequest) toInput() service.LecturerProfileInput {
+	return service.LecturerProfileInput{
+		ProfileAccountInput: service.ProfileAccountInput{
+			Username: r.Username,
+			Email:    r.Email,
+			Password: r.Password,
+			FullName: r.FullName,
+			RoleID:   r.RoleID,
+		},
+		LecturerID:   r.LecturerID,
+		Department:   r.Department,
+		DepartmentID: r.DepartmentID,
+	}
+}
+
+// CreateStudent godoc
+// @Summary Create student
+// @Description Buat akun user + profil mahasiswa dalam satu transaksi (role harus Mahasiswa)
+// @Tags Admin - Students
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body StudentProfileRequest true "Student payload"
+// @Success 201 {object} model.Student "Student created"
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 409 {object} map[string]string "Duplicate username, email or NIM"
+// @Router /admin/students [post]
+func (h *AdminProfileHandler) CreateStudent(c *gin.Context) {
+	var req StudentProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	actorID := c.GetString(middleware.ContextUserIDKey)
+	student, err := h.profileSvc.CreateStudent(c.Request.Context(), actorID, req.toInput())
+	if err != nil {
+		writeProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": student})
+}
+
+// UpdateStudent godoc
+// @Summary Update student
+// @Description Ubah data akun + profil mahasiswa (password opsional)
+// @Tags Admin - Students
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Student ID"
+// @Param body body StudentProfileRequest true "Student payload"
+// @Success 200 {object} model.Student "Student updated"
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 404 {object} map[string]string "Student not found"
+// @Failure 409 {object} map[string]string "Duplicate username, email or NIM"
+// @Router /admin/students/{id} [put]
+func (h *AdminProfileHandler) UpdateStudent(c *gin.Context) {
+	var req StudentProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	actorID := c.GetString(middleware.ContextUserIDKey)
+	student, err := h.profileSvc.UpdateStudent(c.Request.Context(), actorID, c.Param("id"), req.toInput())
+	if err != nil {
+		writeProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": student})
+}
+
+// DeactivateStudent godoc
+// @Summary Deactivate student
+// @Description Nonaktifkan akun mahasiswa (data prestasi tetap disimpan); efeknya sama dengan POST /admin/users/{id}/deactivate
+// @Tags Admin - Students
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Student ID"
+// @Success 200 {object} map[string]interface{} "Student deactivated"
+// @Failure 400 {object} map[string]string "Own account"
+// @Failure 404 {object} map[string]string "Student not found"
+// @Router /admin/students/{id} [delete]
+func (h *AdminProfileHandler) DeactivateStudent(c *gin.Context) {
+	actorID := c.GetString(middleware.ContextUserIDKey)
+	result, err := h.profileSvc.DeactivateStudent(c.Request.Context(), actorID, c.Param("id"))
+	if err != nil {
+		writeProfileDeactivateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "student deactivated", "data": result})
+}
+
+// CreateLecturer godoc
+// @Summary Create lecturer
+// @Description Buat akun user + profil dosen dalam satu transaksi (role harus Dosen Wali)
+// @Tags Admin - Lecturers
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body LecturerProfileRequest true "Lecturer payload"
+// @Success 201 {object} model.Lecturer "Lecturer created"
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 409 {object} map[string]string "Duplicate username, email or NIDN"
+// @Router /admin/lecturers [post]
+func (h *AdminProfileHandler) CreateLecturer(c *gin.Context) {
+	var req LecturerProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	lecturer, err := h.profileSvc.CreateLecturer(req.toInput())
+	if err != nil {
+		writeProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": lecturer})
+}
+
+// UpdateLecturer godoc
+// @Summary Update lecturer
+// @Description Ubah data akun + profil dosen (password opsional)
+// @Tags Admin - Lecturers
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Lecturer ID"
+// @Param body body LecturerProfileRequest true "Lecturer payload"
+// @Success 200 {object} model.Lecturer "Lecturer updated"
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 404 {object} map[string]string "Lecturer not found"
+// @Failure 409 {object} map[string]string "Duplicate username, email or NIDN"
+// @Router /admin/lecturers/{id} [put]
+func (h *AdminProfileHandler) UpdateLecturer(c *gin.Context) {
+	var req LecturerProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	lecturer, err := h.profileSvc.UpdateLecturer(c.Param("id"), req.toInput())
+	if err != nil {
+		writeProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": lecturer})
+}
+
+// DeactivateLecturer godoc
+// @Summary Deactivate lecturer
+// @Description Nonaktifkan akun dosen; efeknya sama dengan POST /admin/users/{id}/deactivate. Dosen wali yang masih punya mahasiswa bimbingan wajib menyebut replacement_advisor_id.
+// @Tags Admin - Lecturers
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Lecturer ID"
+// @Param body body DeactivateUserRequest false "Replacement advisor"
+// @Success 200 {object} map[string]interface{} "Lecturer deactivated"
+// @Failure 400 {object} map[string]string "Invalid replacement / own account"
+// @Failure 404 {object} map[string]string "Lecturer not found"
+// @Failure 409 {object} map[string]string "Replacement advisor required"
+// @Router /admin/lecturers/{id} [delete]
+func (h *AdminProfileHandler) DeactivateLecturer(c *gin.Context) {
+	var req DeactivateUserRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+			return
+		}
+	}
+
+	actorID := c.GetString(middleware.ContextUserIDKey)
+	result, err := h.profileSvc.DeactivateLecturer(c.Request.Context(), actorID, c.Param("id"), req.ReplacementAdvisorID)
+	if err != nil {
+		writeProfileDeactivateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "lecturer deactivated", "data": result})
+}
+
+// writeProfileDeactivateError: profil tidak ada → 404, sisanya error UserLifecycleService
+func writeProfileDeactivateError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrStudentProfileNotFound) || errors.Is(err, service.ErrLecturerNotFound) {
+		writeProfileError(c, err)
+		return
+	}
+	c.JSON(lifecycleErrorStatus(err), gin.H{"message": err.Error()})
+}
+
+func writeProfileError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrStudentProfileNotFound),
+		errors.Is(err, service.ErrLecturerNotFound),
+		errors.Is(err, service.ErrStudyProgramNotFound),
+		errors.Is(err, service.ErrDepartmentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+	case errors.Is(err, service.ErrUsernameTaken),
+		errors.Is(err, service.ErrEmailTaken),
+		errors.Is(err, service.ErrStudentIDTaken),
+		errors.Is(err, service.ErrLecturerIDTaken):
+		c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+	case errors.Is(err, service.ErrProfileInvalidInput),
+		errors.Is(err, service.ErrInvalidEmail),
+		errors.Is(err, service.ErrPasswordRequired),
+		errors.Is(err, service.ErrPasswordTooShort),
+		errors.Is(err, service.ErrPasswordTooLong),
+		errors.Is(err, service.ErrPasswordBreached),
+		errors.Is(err, service.ErrPasswordContainsIdentity),
+		errors.Is(err, service.ErrRoleNotFound),
+		errors.Is(err, service.ErrRoleProfileMismatch),
+		errors.Is(err, service.ErrNotDosenWali),
+		errors.Is(err, service.ErrAdvisorInactive):
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+	}
+}
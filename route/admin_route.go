@@ -1,12 +1,12 @@
 package route
 
 import (
+	"context"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/mongo"
-	"gorm.io/gorm"
 
-	"github.com/nerhays/prestasi_uas/app/repository"
-	"github.com/nerhays/prestasi_uas/app/service"
+	"github.com/nerhays/prestasi_uas/app/container"
 	"github.com/nerhays/prestasi_uas/middleware"
 )
 
@@ -16,70 +16,202 @@ Base path: /api/v1/admin
 Middleware: Auth + RoleOnly(Admin)
 */
 
-func SetupAdminRoutes(
-	rg *gin.RouterGroup,
-	db *gorm.DB,
-	mongoDB *mongo.Database,
-) {
-	// === repositories ===
-	studentRepo := repository.NewStudentRepository(db)
-	lecturerRepo := repository.NewLecturerRepository(db)
-	userRepo := repository.NewUserRepository(db)
-	roleRepo := repository.NewRoleRepository(db)
-	refRepo := repository.NewAchievementReferenceRepository(db)
-	logRepo := repository.NewAchievementStatusLogRepository(db)
-	achievementRepo := repository.NewAchievementRepository(mongoDB)
-
-	// === services ===
-	studentSvc := service.NewStudentService(studentRepo, lecturerRepo)
-	userSvc := service.NewUserService(userRepo, roleRepo)
-	lecturerSvc := service.NewLecturerService(lecturerRepo, studentRepo)
-	achievementSvc := service.NewAchievementService(
-		achievementRepo,
-		studentRepo,
-		refRepo,
-		userRepo,
-		lecturerRepo,
-		logRepo,
-	)
+func SetupAdminRoutes(rg *gin.RouterGroup, c *container.Container, authn *middleware.Auth, audit *middleware.Audit) {
+	// === repositories (snapshot audit) ===
+	studentRepo := c.Repos.Students
+	lecturerRepo := c.Repos.Lecturers
+	userRepo := c.Repos.Users
+	roleRepo := c.Repos.Roles
+	periodRepo := c.Repos.AcademicPeriods
+	orgRepo := c.Repos.Organizations
+	flagRepo := c.Repos.DuplicateFlags
+	delegationRepo := c.Repos.VerificationDelegations
 
 	// === handlers ===
-	studentHandler := NewAdminStudentHandler(studentSvc)
-	studentQueryHandler := NewAdminStudentQueryHandler(studentSvc, achievementSvc)
-	lecturerHandler := NewAdminLecturerHandler(lecturerSvc)
-	userHandler := NewAdminUserHandler(userSvc)
-	achievementHandler := NewAdminAchievementHandler(achievementSvc)
-
-	
+	advisorHandler := NewAdvisorAssignmentHandler(c.AdvisorAssignments)
+	studentQueryHandler := NewAdminStudentQueryHandler(c.Students, c.Achievements)
+	lecturerHandler := NewAdminLecturerHandler(c.Lecturers)
+	userHandler := NewAdminUserHandler(c.Users)
+	lifecycleHandler := NewAdminUserLifecycleHandler(c.UserLifecycle)
+	achievementHandler := NewAdminAchievementHandler(c.Achievements)
+	trashHandler := NewAdminTrashHandler(c.Trash)
+	importHandler := NewAdminImportHandler(c.Imports)
+	profileHandler := NewAdminProfileHandler(c.Profiles)
+	analyticsHandler := NewAdminAnalyticsHandler(c.Analytics)
+	dashboardHandler := NewAdvisorDashboardHandler(c.AdvisorDashboard)
+	periodHandler := NewAcademicPeriodHandler(c.AcademicPeriods)
+	orgHandler := NewAdminOrganizationHandler(c.Organizations)
+	duplicateHandler := NewAdminDuplicateHandler(c.Duplicates)
+	securityHandler := NewAdminSecurityHandler(c.LoginGuard, c.TwoFactor)
+	roleHandler := NewRoleHandler(c.Roles)
+	tokenHandler := NewAPITokenHandler(c.APITokens)
+	auditHandler := NewAdminAuditHandler(c.Audit)
 
 	admin := rg.Group("/admin")
 	admin.Use(
-		middleware.AuthMiddleware(),
+		authn.Middleware(),
 		middleware.RoleOnly("Admin"),
 	)
 
+	// admin fakultas/jurusan hanya boleh endpoint baca yang sudah dibatasi scope;
+	// endpoint lain (kelola data master, user, import, dll) khusus admin global
+	global := admin.Group("")
+	global.Use(middleware.GlobalAdminOnly())
+
+	// endpoint baca yang juga boleh diakses API token dengan scope tertentu
+	// (script laporan, portal fakultas); endpoint lain hanya dengan JWT
+	allowToken := func(path, perm string) {
+		authn.AllowAPIToken(http.MethodGet, admin.BasePath()+path, perm)
+	}
+
+	// snapshot entitas sebelum / sesudah request, untuk diff di audit log.
+	// /users/:id/anonymize sengaja tidak: snapshot-nya akan menyimpan data pribadi yang justru dihapus
+	auditTarget := func(targetType string, load middleware.AuditLoader, paths ...string) {
+		for _, p := range paths {
+			audit.Target(admin.BasePath()+p, targetType, load)
+		}
+	}
+	auditTarget("user", func(_ context.Context, id string) (interface{}, error) {
+		return userRepo.FindByID(id)
+	}, "/users/:id", "/users/:id/deactivate", "/users/:id/reactivate", "/users/:id/role", "/users/:id/scope", "/users/:id/auth-backend", "/users/:id/unlock", "/users/:id/2fa")
+	auditTarget("service_account", func(_ context.Context, id string) (interface{}, error) {
+		return userRepo.FindByID(id)
+	}, "/service-accounts/:id/tokens")
+	auditTarget("api_token", func(_ context.Context, id string) (interface{}, error) {
+		return c.Repos.APITokens.FindByID(id)
+	}, "/api-tokens/:id")
+	auditTarget("role", func(_ context.Context, id string) (interface{}, error) {
+		return roleRepo.FindByID(id)
+	}, "/roles/:id/two-factor")
+	auditTarget("student", func(_ context.Context, id string) (interface{}, error) {
+		return studentRepo.FindByID(id)
+	}, "/students/:id", "/students/:id/advisor")
+	auditTarget("lecturer", func(_ context.Context, id string) (interface{}, error) {
+		return lecturerRepo.FindByID(id)
+	}, "/lecturers/:id", "/advisors/:id/delegations")
+	auditTarget("verification_delegation", func(_ context.Context, id string) (interface{}, error) {
+		return delegationRepo.FindByID(id)
+	}, "/delegations/:id")
+	auditTarget("duplicate_flag", func(_ context.Context, id string) (interface{}, error) {
+		return flagRepo.FindByID(id)
+	}, "/duplicates/:id/review")
+	auditTarget("academic_period", func(_ context.Context, id string) (interface{}, error) {
+		return periodRepo.FindByID(id)
+	}, "/academic-periods/:id", "/academic-periods/:id/activate")
+	auditTarget("faculty", func(_ context.Context, id string) (interface{}, error) {
+		return orgRepo.FindFacultyByID(id)
+	}, "/faculties/:id")
+	auditTarget("department", func(_ context.Context, id string) (interface{}, error) {
+		return orgRepo.FindDepartmentByID(id)
+	}, "/departments/:id")
+	auditTarget("study_program", func(_ context.Context, id string) (interface{}, error) {
+		return orgRepo.FindStudyProgramByID(id)
+	}, "/study-programs/:id")
+
 	// === USERS ===
-	admin.GET("/users", userHandler.GetAll)
-	admin.GET("/users/:id", userHandler.GetByID)
-	admin.POST("/users", userHandler.Create)
-	admin.PUT("/users/:id", userHandler.Update)
-	admin.DELETE("/users/:id", userHandler.Delete)
-	admin.PUT("/users/:id/role", userHandler.UpdateRole)
+	global.GET("/users", userHandler.GetAll)
+	global.GET("/users/:id", userHandler.GetByID)
+	global.POST("/users", userHandler.Create)
+	global.PUT("/users/:id", userHandler.Update)
+	global.DELETE("/users/:id", lifecycleHandler.Deactivate)
+	global.POST("/users/:id/deactivate", lifecycleHandler.Deactivate)
+	global.POST("/users/:id/reactivate", lifecycleHandler.Reactivate)
+	global.POST("/users/:id/anonymize", lifecycleHandler.Anonymize)
+	global.PUT("/users/:id/role", userHandler.UpdateRole)
+	global.PUT("/users/:id/scope", orgHandler.SetAdminScope)
+	global.PUT("/users/:id/auth-backend", userHandler.UpdateAuthBackend)
+	global.POST("/users/:id/unlock", securityHandler.UnlockUser)
+	global.DELETE("/users/:id/2fa", securityHandler.ResetTwoFactor)
+
+	// === SERVICE ACCOUNTS & API TOKENS ===
+	global.POST("/service-accounts", tokenHandler.CreateServiceAccount)
+	global.GET("/service-accounts", tokenHandler.ListServiceAccounts)
+	global.POST("/service-accounts/:id/tokens", tokenHandler.CreateServiceAccountToken)
+	global.GET("/service-accounts/:id/tokens", tokenHandler.ListServiceAccountTokens)
+	global.DELETE("/api-tokens/:id", tokenHandler.RevokeToken)
+
+	// === ROLES ===
+	global.PUT("/roles/:id/two-factor", roleHandler.SetTwoFactorRequired)
+
+	// === AUDIT LOG ===
+	global.GET("/audit-logs", auditHandler.GetLogs)
+	global.GET("/audit-logs/verify", auditHandler.VerifyChain)
+
+	// === LOGIN SECURITY ===
+	global.GET("/security/lockouts", securityHandler.GetLockouts)
+	global.POST("/security/unlock", securityHandler.Unlock)
+	global.GET("/security/login-audit", securityHandler.GetLoginAudit)
 
 	// === STUDENTS ===
-	admin.PUT("/students/:id/advisor", studentHandler.SetAdvisor)
+	global.POST("/students", profileHandler.CreateStudent)
+	global.PUT("/students/:id", profileHandler.UpdateStudent)
+	global.DELETE("/students/:id", profileHandler.DeactivateStudent)
+	global.PUT("/students/:id/advisor", advisorHandler.SetAdvisor)
+	global.GET("/students/:id/advisor-history", advisorHandler.GetHistory)
 	admin.GET("/students", studentQueryHandler.GetAll)
 	admin.GET("/students/:id", studentQueryHandler.GetByID)
 	admin.GET("/students/:id/achievements", studentQueryHandler.GetAchievements)
 	admin.GET("/reports/student/:id", achievementHandler.GetStudentReport)
+	allowToken("/students", "student:read")
+	allowToken("/students/:id", "student:read")
+	allowToken("/students/:id/achievements", "student:read")
+	allowToken("/reports/student/:id", "report:read")
 
 	// === ACHIEVEMENTS ===
 	admin.GET("/achievements", achievementHandler.GetAllAchievements)
+	admin.GET("/achievements/trash", trashHandler.GetTrash)
+	allowToken("/achievements", "achievement:read")
+
+	// === DUPLICATE REVIEW ===
+	global.GET("/duplicates", duplicateHandler.GetQueue)
+	global.POST("/duplicates/:id/review", duplicateHandler.Review)
 
 	// === REPORTS ===
-	admin.GET("/reports/statistics", achievementHandler.GetStatistics)
+	global.GET("/reports/statistics", achievementHandler.GetStatistics)
+	admin.GET("/reports/analytics", analyticsHandler.GetAnalytics)
+	allowToken("/reports/statistics", "report:read")
+	allowToken("/reports/analytics", "report:read")
 	admin.GET("/lecturers", lecturerHandler.GetAll)
-	admin.GET("/lecturers/:id/advisees", lecturerHandler.GetAdvisees)
-}
+	allowToken("/lecturers", "lecturer:read")
+	global.POST("/lecturers", profileHandler.CreateLecturer)
+	global.PUT("/lecturers/:id", profileHandler.UpdateLecturer)
+	global.DELETE("/lecturers/:id", profileHandler.DeactivateLecturer)
+	global.GET("/lecturers/:id/advisees", lecturerHandler.GetAdvisees)
 
+	// === ADVISOR WORKLOAD ===
+	global.GET("/advisors/workload", dashboardHandler.GetAllWorkloads)
+	global.GET("/advisors/:id/dashboard", dashboardHandler.GetLecturerDashboard)
+	global.POST("/advisors/reminders", dashboardHandler.SendReminders)
 
+	// === ADVISOR REASSIGNMENT & DELEGATION ===
+	global.POST("/advisors/reassign", advisorHandler.Reassign)
+	global.GET("/advisors/:id/delegations", advisorHandler.GetDelegations)
+	global.POST("/advisors/:id/delegations", advisorHandler.CreateDelegation)
+	global.DELETE("/delegations/:id", advisorHandler.RevokeDelegation)
+
+	// === ACADEMIC PERIODS ===
+	global.POST("/academic-periods", periodHandler.Create)
+	global.PUT("/academic-periods/:id", periodHandler.Update)
+	global.DELETE("/academic-periods/:id", periodHandler.Delete)
+	global.POST("/academic-periods/:id/activate", periodHandler.Activate)
+
+	// === ORGANIZATION ===
+	global.GET("/faculties", orgHandler.GetFaculties)
+	global.POST("/faculties", orgHandler.CreateFaculty)
+	global.PUT("/faculties/:id", orgHandler.UpdateFaculty)
+	global.DELETE("/faculties/:id", orgHandler.DeleteFaculty)
+	global.GET("/departments", orgHandler.GetDepartments)
+	global.POST("/departments", orgHandler.CreateDepartment)
+	global.PUT("/departments/:id", orgHandler.UpdateDepartment)
+	global.DELETE("/departments/:id", orgHandler.DeleteDepartment)
+	global.GET("/study-programs", orgHandler.GetStudyPrograms)
+	global.POST("/study-programs", orgHandler.CreateStudyProgram)
+	global.PUT("/study-programs/:id", orgHandler.UpdateStudyProgram)
+	global.DELETE("/study-programs/:id", orgHandler.DeleteStudyProgram)
+	global.GET("/organization/unmapped", orgHandler.Unmapped)
+
+	// === IMPORTS ===
+	global.POST("/imports", importHandler.Import)
+	global.GET("/imports", importHandler.GetAll)
+	global.GET("/imports/:id", importHandler.GetByID)
+}
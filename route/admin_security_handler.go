@@ -0,0 +1,142 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/service"
+)
+
+type AdminSecurityHandler struct {
+	loginGuard   *service.LoginGuard
+	twoFactorSvc *service.TwoFactorService
+}
+
+func NewAdminSecurityHandler(loginGuard *service.LoginGuard, twoFactorSvc *service.TwoFactorService) *AdminSecurityHandler {
+	return &AdminSecurityHandler{loginGuard, twoFactorSvc}
+}
+
+type UnlockRequest struct {
+	Username string `json:"username"`
+	IP       string `json:"ip"`
+}
+
+// GetLockouts godoc
+// @Summary List active login lockouts
+// @Description Akun / IP yang sedang dikunci karena terlalu banyak login gagal
+// @Tags Admin - Security
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /admin/security/lockouts [get]
+func (h *AdminSecurityHandler) GetLockouts(c *gin.Context) {
+	locks, err := h.loginGuard.GetLockouts(time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": locks})
+}
+
+// Unlock godoc
+// @Summary Unlock username or IP
+// @Description Hapus lock + counter login gagal untuk username dan/atau IP
+// @Tags Admin - Security
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body UnlockRequest true "Username and/or IP"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string "Username or IP required"
+// @Router /admin/security/unlock [post]
+func (h *AdminSecurityHandler) Unlock(c *gin.Context) {
+	var req UnlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	if err := h.loginGuard.Unlock(req.Username, req.IP); err != nil {
+		if errors.Is(err, service.ErrUnlockTargetRequired) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "unlocked"})
+}
+
+// UnlockUser godoc
+// @Summary Unlock user account
+// @Description Hapus lock + counter login gagal akun user
+// @Tags Admin - Users
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string "User not found"
+// @Router /admin/users/{id}/unlock [post]
+func (h *AdminSecurityHandler) UnlockUser(c *gin.Context) {
+	if err := h.loginGuard.UnlockUser(c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "unlocked"})
+}
+
+// ResetTwoFactor godoc
+// @Summary Reset user 2FA
+// @Description Hapus secret + kode cadangan 2FA user (mis. HP hilang). Kalau role mewajibkan 2FA, user harus daftar ulang setelah login.
+// @Tags Admin - Users
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string "User not found"
+// @Router /admin/users/{id}/2fa [delete]
+func (h *AdminSecurityHandler) ResetTwoFactor(c *gin.Context) {
+	if err := h.twoFactorSvc.AdminReset(c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "two-factor authentication reset"})
+}
+
+// GetLoginAudit godoc
+// @Summary Login audit trail
+// @Description Riwayat percobaan login (berhasil / gagal, IP, user agent)
+// @Tags Admin - Security
+// @Security BearerAuth
+// @Produce json
+// @Param username query string false "Filter username"
+// @Param success query bool false "Filter result"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} map[string]interface{}
+// @Router /admin/security/login-audit [get]
+func (h *AdminSecurityHandler) GetLoginAudit(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	filter := model.LoginAuditFilter{Username: c.Query("username")}
+	if v, err := strconv.ParseBool(c.Query("success")); err == nil {
+		filter.Success = &v
+	}
+
+	entries, total, err := h.loginGuard.GetLoginAudit(filter, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"meta":   gin.H{"page": page, "limit": limit, "total": total},
+		"data":   entries,
+	})
+}
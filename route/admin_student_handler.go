@@ -1,61 +1,16 @@
 package route
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/repository"
 	"github.com/nerhays/prestasi_uas/app/service"
+	"github.com/nerhays/prestasi_uas/middleware"
 )
 
-type AdminStudentHandler struct {
-	studentSvc *service.StudentService
-}
-
-func NewAdminStudentHandler(studentSvc *service.StudentService) *AdminStudentHandler {
-	return &AdminStudentHandler{studentSvc}
-}
-
-type SetAdvisorRequest struct {
-	AdvisorID string `json:"advisor_id" binding:"required"`
-}
-
-// SetAdvisor godoc
-// @Summary Assign advisor to student
-// @Description Assign dosen wali to a student (Admin only)
-// @Tags Admin - Students
-// @Security BearerAuth
-// @Accept json
-// @Produce json
-// @Param id path string true "Student ID"
-// @Param body body SetAdvisorRequest true "Advisor payload"
-// @Success 200 {object} map[string]string "Advisor assigned"
-// @Failure 400 {object} map[string]string "Invalid input"
-// @Failure 401 {object} map[string]string "Unauthorized"
-// @Router /admin/students/{id}/advisor [put]
-func (h *AdminStudentHandler) SetAdvisor(c *gin.Context) {
-	studentID := c.Param("id")
-
-	var req SetAdvisorRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
-		return
-	}
-
-	if err := h.studentSvc.SetAdvisor(
-		c.Request.Context(),
-		studentID,
-		req.AdvisorID,
-	); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "success",
-		"message": "advisor assigned successfully",
-	})
-}
 type AdminAchievementHandler struct {
 	achievementSvc *service.AchievementService
 }
@@ -75,6 +30,7 @@ func NewAdminAchievementHandler(
 // @Param page query int false "Page number"
 // @Param limit query int false "Items per page"
 // @Param status query string false "Achievement status"
+// @Param period_id query string false "Academic period ID"
 // @Success 200 {object} map[string]interface{} "List of achievements"
 // @Failure 401 {object} map[string]string "Unauthorized"
 // @Router /admin/achievements [get]
@@ -92,11 +48,19 @@ func (h *AdminAchievementHandler) GetAllAchievements(c *gin.Context) {
 		statusPtr = &status
 	}
 
+	filter := repository.AchievementReferenceFilter{
+		Status: statusPtr,
+		Scope:  middleware.ScopeFrom(c),
+	}
+	if periodID := c.Query("period_id"); periodID != "" {
+		filter.PeriodID = &periodID
+	}
+
 	data, total, err := h.achievementSvc.GetAllAchievements(
 		c.Request.Context(),
 		page,
 		limit,
-		statusPtr,
+		filter,
 	)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
@@ -158,7 +122,7 @@ func NewAdminStudentQueryHandler(
 // @Failure 401 {object} map[string]string "Unauthorized"
 // @Router /admin/students [get]
 func (h *AdminStudentQueryHandler) GetAll(c *gin.Context) {
-	data, err := h.studentSvc.GetAllStudents()
+	data, err := h.studentSvc.GetAllStudents(middleware.ScopeFrom(c))
 	if err != nil {
 		c.JSON(500, gin.H{"message": err.Error()})
 		return
@@ -178,8 +142,12 @@ func (h *AdminStudentQueryHandler) GetAll(c *gin.Context) {
 // @Router /admin/students/{id} [get]
 func (h *AdminStudentQueryHandler) GetByID(c *gin.Context) {
 	id := c.Param("id")
-	data, err := h.studentSvc.GetStudentByID(id)
+	data, err := h.studentSvc.GetStudentByID(id, middleware.ScopeFrom(c))
 	if err != nil {
+		if errors.Is(err, service.ErrOutOfScope) {
+			c.JSON(403, gin.H{"message": err.Error()})
+			return
+		}
 		c.JSON(404, gin.H{"message": "student not found"})
 		return
 	}
@@ -202,9 +170,17 @@ func (h *AdminStudentQueryHandler) GetAchievements(c *gin.Context) {
 	data, err := h.achievementSvc.GetAchievementsByStudentID(
 		c.Request.Context(),
 		studentID,
+		middleware.ScopeFrom(c),
 	)
 	if err != nil {
-		c.JSON(500, gin.H{"message": err.Error()})
+		switch {
+		case errors.Is(err, service.ErrOutOfScope):
+			c.JSON(403, gin.H{"message": err.Error()})
+		case errors.Is(err, service.ErrStudentProfileNotFound):
+			c.JSON(404, gin.H{"message": err.Error()})
+		default:
+			c.JSON(500, gin.H{"message": err.Error()})
+		}
 		return
 	}
 
@@ -275,8 +251,13 @@ func (h *AdminAchievementHandler) GetStudentReport(c *gin.Context) {
 	data, err := h.achievementSvc.GetStudentReport(
 		c.Request.Context(),
 		studentID,
+		middleware.ScopeFrom(c),
 	)
 	if err != nil {
+		if errors.Is(err, service.ErrOutOfScope) {
+			c.JSON(403, gin.H{"message": err.Error()})
+			return
+		}
 		c.JSON(404, gin.H{"message": err.Error()})
 		return
 	}
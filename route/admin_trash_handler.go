@@ -0,0 +1,45 @@
+package route
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/service"
+	"github.com/nerhays/prestasi_uas/middleware"
+)
+
+type AdminTrashHandler struct {
+	trashSvc *service.AchievementTrashService
+}
+
+func NewAdminTrashHandler(trashSvc *service.AchievementTrashService) *AdminTrashHandler {
+	return &AdminTrashHandler{trashSvc}
+}
+
+// GetTrash godoc
+// @Summary Achievement trash
+// @Description Prestasi yang dihapus (semua mahasiswa dalam scope admin). purge_at = jadwal hapus permanen (TRASH_RETENTION_DAYS), null = tidak pernah
+// @Tags Admin - Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} map[string]interface{}
+// @Router /admin/achievements/trash [get]
+func (h *AdminTrashHandler) GetTrash(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	data, total, err := h.trashSvc.GetTrash(c.Request.Context(), page, limit, middleware.ScopeFrom(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   data,
+		"meta":   gin.H{"page": page, "limit": limit, "total": total},
+	})
+}
@@ -120,27 +120,6 @@ func (h *AdminUserHandler) Update(c *gin.Context) {
 	c.JSON(200, gin.H{"status": "success"})
 }
 
-// DeleteUser godoc
-// @Summary Delete user
-// @Description Admin deletes a user
-// @Tags Admin - Users
-// @Security BearerAuth
-// @Produce json
-// @Param id path string true "User ID"
-// @Success 200 {object} map[string]string "User deleted"
-// @Failure 400 {object} map[string]string "Delete failed"
-// @Failure 401 {object} map[string]string "Unauthorized"
-// @Router /admin/users/{id} [delete]
-func (h *AdminUserHandler) Delete(c *gin.Context) {
-	id := c.Param("id")
-
-	if err := h.userSvc.DeleteUser(id); err != nil {
-		c.JSON(400, gin.H{"message": err.Error()})
-		return
-	}
-
-	c.JSON(200, gin.H{"status": "success"})
-}
 type UpdateRoleRequest struct {
 	RoleID string `json:"role_id"`
 }
@@ -171,3 +150,39 @@ func (h *AdminUserHandler) UpdateRole(c *gin.Context) {
 
 	c.JSON(200, gin.H{"status": "success"})
 }
+
+type UpdateAuthBackendRequest struct {
+	// local | ldap | "" (ikut AUTH_BACKEND global)
+	Backend string `json:"backend"`
+}
+
+// UpdateAuthBackend godoc
+// @Summary Set user login backend
+// @Description Pilih backend verifikasi password user (local / ldap); kosong = ikut konfigurasi global
+// @Tags Admin - Users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param body body UpdateAuthBackendRequest true "Backend"
+// @Success 200 {object} map[string]string "Backend updated"
+// @Failure 400 {object} map[string]string "Invalid backend"
+// @Failure 404 {object} map[string]string "User not found"
+// @Router /admin/users/{id}/auth-backend [put]
+func (h *AdminUserHandler) UpdateAuthBackend(c *gin.Context) {
+	var req UpdateAuthBackendRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"message": "invalid input"})
+		return
+	}
+
+	if err := h.userSvc.SetAuthBackend(c.Param("id"), req.Backend); err != nil {
+		if err == service.ErrInvalidAuthBackend {
+			c.JSON(400, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(404, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(200, gin.H{"status": "success"})
+}
@@ -0,0 +1,118 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/service"
+	"github.com/nerhays/prestasi_uas/middleware"
+)
+
+type AdminUserLifecycleHandler struct {
+	lifecycleSvc *service.UserLifecycleService
+}
+
+func NewAdminUserLifecycleHandler(lifecycleSvc *service.UserLifecycleService) *AdminUserLifecycleHandler {
+	return &AdminUserLifecycleHandler{lifecycleSvc}
+}
+
+type DeactivateUserRequest struct {
+	// lecturers.id pengganti dosen wali; wajib kalau user masih punya mahasiswa bimbingan
+	ReplacementAdvisorID string `json:"replacement_advisor_id"`
+}
+
+// Deactivate godoc
+// @Summary Deactivate user
+// @Description Nonaktifkan user (pengganti hapus permanen): login ditolak, access token & API token dicabut. Dosen wali yang masih punya mahasiswa bimbingan wajib menyebut replacement_advisor_id. DELETE /admin/users/{id} sama dengan endpoint ini tanpa pengganti.
+// @Tags Admin - Users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param body body DeactivateUserRequest false "Replacement advisor"
+// @Success 200 {object} map[string]interface{} "User deactivated"
+// @Failure 400 {object} map[string]string "Invalid replacement / own account"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 409 {object} map[string]string "Replacement advisor required"
+// @Router /admin/users/{id}/deactivate [post]
+func (h *AdminUserLifecycleHandler) Deactivate(c *gin.Context) {
+	var req DeactivateUserRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+			return
+		}
+	}
+
+	actorID := c.GetString(middleware.ContextUserIDKey)
+	result, err := h.lifecycleSvc.Deactivate(c.Request.Context(), actorID, c.Param("id"), req.ReplacementAdvisorID)
+	if err != nil {
+		c.JSON(lifecycleErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": result})
+}
+
+// Reactivate godoc
+// @Summary Reactivate user
+// @Description Aktifkan kembali user nonaktif. Token yang dicabut saat nonaktif tetap tidak berlaku; user yang sudah dianonimkan tidak bisa diaktifkan.
+// @Tags Admin - Users
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} map[string]string "User reactivated"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 409 {object} map[string]string "User anonymized"
+// @Router /admin/users/{id}/reactivate [post]
+func (h *AdminUserLifecycleHandler) Reactivate(c *gin.Context) {
+	if err := h.lifecycleSvc.Reactivate(c.Param("id")); err != nil {
+		c.JSON(lifecycleErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+// Anonymize godoc
+// @Summary Anonymize user
+// @Description Permintaan penghapusan data pribadi: nama, username, email dan NIM/NIDN diganti nilai anonim, identitas SSO dan 2FA dihapus, user dinonaktifkan. Prestasi, prodi, angkatan dan status verifikasi tetap untuk statistik. Tidak bisa dibatalkan.
+// @Tags Admin - Users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param body body DeactivateUserRequest false "Replacement advisor"
+// @Success 200 {object} map[string]interface{} "User anonymized"
+// @Failure 400 {object} map[string]string "Invalid replacement / own account"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 409 {object} map[string]string "Replacement advisor required / already anonymized"
+// @Router /admin/users/{id}/anonymize [post]
+func (h *AdminUserLifecycleHandler) Anonymize(c *gin.Context) {
+	var req DeactivateUserRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+			return
+		}
+	}
+
+	actorID := c.GetString(middleware.ContextUserIDKey)
+	result, err := h.lifecycleSvc.Anonymize(c.Request.Context(), actorID, c.Param("id"), req.ReplacementAdvisorID)
+	if err != nil {
+		c.JSON(lifecycleErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": result})
+}
+
+func lifecycleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrAdvisorReplacementRequired), errors.Is(err, service.ErrUserAnonymized):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrCannotDeactivateSelf), errors.Is(err, service.ErrInvalidReplacementAdvisor):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,336 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/service"
+	"github.com/nerhays/prestasi_uas/middleware"
+)
+
+// AdvisorAssignmentHandler: pindah dosen wali + delegasi verifikasi (admin dan dosen wali sendiri)
+type AdvisorAssignmentHandler struct {
+	advisorSvc *service.AdvisorAssignmentService
+}
+
+func NewAdvisorAssignmentHandler(advisorSvc *service.AdvisorAssignmentService) *AdvisorAssignmentHandler {
+	return &AdvisorAssignmentHandler{advisorSvc}
+}
+
+type SetAdvisorRequest struct {
+	AdvisorID string `json:"advisor_id" binding:"required"`
+	// YYYY-MM-DD / RFC3339; kosong = sekarang
+	EffectiveFrom string `json:"effective_from,omitempty" example:"2025-02-01"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+type ReassignAdvisorRequest struct {
+	ToLecturerID string `json:"to_lecturer_id" binding:"required"`
+	// pilih salah satu: student_ids, atau from_lecturer_id dan/atau study_program_id
+	StudentIDs     []string `json:"student_ids,omitempty"`
+	FromLecturerID string   `json:"from_lecturer_id,omitempty"`
+	StudyProgramID string   `json:"study_program_id,omitempty"`
+	EffectiveFrom  string   `json:"effective_from,omitempty" example:"2025-02-01"`
+	Reason         string   `json:"reason,omitempty" example:"dosen wali cuti studi"`
+}
+
+type DelegationRequest struct {
+	ToLecturerID string `json:"to_lecturer_id" binding:"required"`
+	// YYYY-MM-DD / RFC3339; ends_at tanggal saja = sampai akhir hari
+	StartsAt string `json:"starts_at" binding:"required" example:"2025-03-01"`
+	EndsAt   string `json:"ends_at" binding:"required" example:"2025-03-31"`
+	Reason   string `json:"reason,omitempty" example:"dinas luar negeri"`
+}
+
+func (r DelegationRequest) toInput() (service.DelegationInput, error) {
+	in := service.DelegationInput{ToLecturerID: r.ToLecturerID, Reason: r.Reason}
+	starts, err := parseWindowTime(r.StartsAt, false)
+	if err != nil || starts == nil {
+		return in, errors.New("invalid starts_at")
+	}
+	ends, err := parseWindowTime(r.EndsAt, true)
+	if err != nil || ends == nil {
+		return in, errors.New("invalid ends_at")
+	}
+	in.StartsAt, in.EndsAt = *starts, *ends
+	return in, nil
+}
+
+func parseEffectiveFrom(v string) (time.Time, error) {
+	t, err := parseWindowTime(v, false)
+	if err != nil {
+		return time.Time{}, errors.New("invalid effective_from, use YYYY-MM-DD")
+	}
+	if t == nil {
+		return time.Time{}, nil
+	}
+	return *t, nil
+}
+
+// SetAdvisor godoc
+// @Summary Assign advisor to student
+// @Description Pindahkan satu mahasiswa ke dosen wali lain. Riwayat penugasan dicatat dan prestasi yang masih menunggu verifikasi ikut diserahkan ke dosen wali baru; dosen lama, dosen baru dan mahasiswa diberi tahu lewat email.
+// @Tags Admin - Students
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Student ID"
+// @Param body body SetAdvisorRequest true "Advisor payload"
+// @Success 200 {object} service.ReassignAdvisorResult
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Student / lecturer not found"
+// @Router /admin/students/{id}/advisor [put]
+func (h *AdvisorAssignmentHandler) SetAdvisor(c *gin.Context) {
+	var req SetAdvisorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+	effective, err := parseEffectiveFrom(req.EffectiveFrom)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	result, err := h.advisorSvc.Reassign(c.Request.Context(), service.ReassignAdvisorInput{
+		ToLecturerID:  req.AdvisorID,
+		StudentIDs:    []string{c.Param("id")},
+		EffectiveFrom: effective,
+		Reason:        req.Reason,
+		ActorID:       c.GetString(middleware.ContextUserIDKey),
+	})
+	if err != nil {
+		c.JSON(advisorErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "advisor assigned successfully",
+		"data":    result,
+	})
+}
+
+// Reassign godoc
+// @Summary Bulk reassign advisor
+// @Description Pindahkan banyak mahasiswa sekaligus ke satu dosen wali: berdasarkan daftar student_ids, semua mahasiswa bimbingan from_lecturer_id, mahasiswa prodi study_program_id, atau kombinasi from_lecturer_id + study_program_id. effective_from boleh mundur untuk pencatatan, tidak boleh di masa depan.
+// @Tags Admin - Advisors
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body ReassignAdvisorRequest true "Reassignment"
+// @Success 200 {object} service.ReassignAdvisorResult
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 404 {object} map[string]string "Student / lecturer not found"
+// @Router /admin/advisors/reassign [post]
+func (h *AdvisorAssignmentHandler) Reassign(c *gin.Context) {
+	var req ReassignAdvisorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+	effective, err := parseEffectiveFrom(req.EffectiveFrom)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	result, err := h.advisorSvc.Reassign(c.Request.Context(), service.ReassignAdvisorInput{
+		ToLecturerID:   req.ToLecturerID,
+		StudentIDs:     req.StudentIDs,
+		FromLecturerID: req.FromLecturerID,
+		StudyProgramID: req.StudyProgramID,
+		EffectiveFrom:  effective,
+		Reason:         req.Reason,
+		ActorID:        c.GetString(middleware.ContextUserIDKey),
+	})
+	if err != nil {
+		c.JSON(advisorErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": result})
+}
+
+// GetHistory godoc
+// @Summary Advisor assignment history
+// @Description Riwayat dosen wali satu mahasiswa beserta tanggal berlaku, terbaru dulu
+// @Tags Admin - Students
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Student ID"
+// @Success 200 {array} model.AdvisorAssignment
+// @Failure 404 {object} map[string]string "Student not found"
+// @Router /admin/students/{id}/advisor-history [get]
+func (h *AdvisorAssignmentHandler) GetHistory(c *gin.Context) {
+	history, err := h.advisorSvc.GetHistory(c.Param("id"))
+	if err != nil {
+		c.JSON(advisorErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": history})
+}
+
+// GetDelegations godoc
+// @Summary Lecturer verification delegations
+// @Description Delegasi verifikasi yang diberikan atau diterima seorang dosen
+// @Tags Admin - Advisors
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Lecturer ID"
+// @Success 200 {array} model.VerificationDelegation
+// @Failure 404 {object} map[string]string "Lecturer not found"
+// @Router /admin/advisors/{id}/delegations [get]
+func (h *AdvisorAssignmentHandler) GetDelegations(c *gin.Context) {
+	delegations, err := h.advisorSvc.GetDelegations(c.Param("id"))
+	if err != nil {
+		c.JSON(advisorErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": delegations})
+}
+
+// CreateDelegation godoc
+// @Summary Delegate verification rights
+// @Description Selama rentang tanggal, dosen to_lecturer_id ikut bisa melihat dan memverifikasi prestasi mahasiswa bimbingan dosen {id}. Dosen wali asli tetap bisa memverifikasi.
+// @Tags Admin - Advisors
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Lecturer ID (pemberi delegasi)"
+// @Param body body DelegationRequest true "Delegation"
+// @Success 201 {object} model.VerificationDelegation
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 404 {object} map[string]string "Lecturer not found"
+// @Router /admin/advisors/{id}/delegations [post]
+func (h *AdvisorAssignmentHandler) CreateDelegation(c *gin.Context) {
+	var req DelegationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+	in, err := req.toInput()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	in.FromLecturerID = c.Param("id")
+	in.ActorID = c.GetString(middleware.ContextUserIDKey)
+
+	d, err := h.advisorSvc.CreateDelegation(c.Request.Context(), in)
+	if err != nil {
+		c.JSON(advisorErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": d})
+}
+
+// RevokeDelegation godoc
+// @Summary Revoke verification delegation
+// @Tags Admin - Advisors
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Delegation ID"
+// @Success 200 {object} map[string]string "Delegation revoked"
+// @Failure 404 {object} map[string]string "Delegation not found"
+// @Failure 409 {object} map[string]string "Already revoked"
+// @Router /admin/delegations/{id} [delete]
+func (h *AdvisorAssignmentHandler) RevokeDelegation(c *gin.Context) {
+	if err := h.advisorSvc.RevokeDelegation(c.Request.Context(), c.Param("id"), ""); err != nil {
+		c.JSON(advisorErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+// GetMyDelegations godoc
+// @Summary My verification delegations
+// @Description Delegasi verifikasi yang diberikan atau diterima dosen wali yang login
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} model.VerificationDelegation
+// @Failure 404 {object} map[string]string "Lecturer profile not found"
+// @Router /achievements/bimbingan/delegations [get]
+func (h *AdvisorAssignmentHandler) GetMyDelegations(c *gin.Context) {
+	delegations, err := h.advisorSvc.GetMyDelegations(c.GetString(middleware.ContextUserIDKey))
+	if err != nil {
+		c.JSON(advisorErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": delegations})
+}
+
+// CreateMyDelegation godoc
+// @Summary Delegate my verification rights
+// @Description Dosen wali yang login mendelegasikan verifikasi prestasi mahasiswa bimbingannya ke dosen lain selama rentang tanggal (mis. cuti / dinas)
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body DelegationRequest true "Delegation"
+// @Success 201 {object} model.VerificationDelegation
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 404 {object} map[string]string "Lecturer not found"
+// @Router /achievements/bimbingan/delegations [post]
+func (h *AdvisorAssignmentHandler) CreateMyDelegation(c *gin.Context) {
+	var req DelegationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+	in, err := req.toInput()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	d, err := h.advisorSvc.CreateMyDelegation(c.Request.Context(), c.GetString(middleware.ContextUserIDKey), in)
+	if err != nil {
+		c.JSON(advisorErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": d})
+}
+
+// RevokeMyDelegation godoc
+// @Summary Revoke my verification delegation
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Delegation ID"
+// @Success 200 {object} map[string]string "Delegation revoked"
+// @Failure 404 {object} map[string]string "Delegation not found"
+// @Failure 409 {object} map[string]string "Already revoked"
+// @Router /achievements/bimbingan/delegations/{id} [delete]
+func (h *AdvisorAssignmentHandler) RevokeMyDelegation(c *gin.Context) {
+	userID := c.GetString(middleware.ContextUserIDKey)
+	if err := h.advisorSvc.RevokeDelegation(c.Request.Context(), c.Param("id"), userID); err != nil {
+		c.JSON(advisorErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+func advisorErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrStudentProfileNotFound),
+		errors.Is(err, service.ErrLecturerNotFound),
+		errors.Is(err, service.ErrDelegationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrDelegationNotRevocable):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrReassignTarget),
+		errors.Is(err, service.ErrReassignSelector),
+		errors.Is(err, service.ErrNotDosenWali),
+		errors.Is(err, service.ErrAdvisorInactive),
+		errors.Is(err, service.ErrEffectiveDateInFuture),
+		errors.Is(err, service.ErrDelegationInput),
+		errors.Is(err, service.ErrDelegationRange),
+		errors.Is(err, service.ErrDelegationSelf):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,104 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/service"
+	"github.com/nerhays/prestasi_uas/middleware"
+)
+
+type AdvisorDashboardHandler struct {
+	dashboardSvc *service.AdvisorDashboardService
+}
+
+func NewAdvisorDashboardHandler(dashboardSvc *service.AdvisorDashboardService) *AdvisorDashboardHandler {
+	return &AdvisorDashboardHandler{dashboardSvc}
+}
+
+// GetMyDashboard godoc
+// @Summary Advisor dashboard
+// @Description Dosen wali melihat prestasi pending per mahasiswa bimbingan, umur tiap item, item overdue terhadap SLA, dan throughput 30 hari
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} model.AdvisorDashboard
+// @Failure 404 {object} map[string]string "Lecturer not found"
+// @Router /achievements/bimbingan/dashboard [get]
+func (h *AdvisorDashboardHandler) GetMyDashboard(c *gin.Context) {
+	userID := c.GetString(middleware.ContextUserIDKey)
+
+	data, err := h.dashboardSvc.GetMyDashboard(c.Request.Context(), userID)
+	if err != nil {
+		writeDashboardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// GetAllWorkloads godoc
+// @Summary All advisors workload
+// @Description Admin melihat backlog verifikasi semua dosen wali (pending, overdue, throughput)
+// @Tags Admin - Lecturers
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} model.AdvisorWorkload
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /admin/advisors/workload [get]
+func (h *AdvisorDashboardHandler) GetAllWorkloads(c *gin.Context) {
+	data, err := h.dashboardSvc.GetAllWorkloads(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// GetLecturerDashboard godoc
+// @Summary Advisor dashboard (admin)
+// @Description Admin melihat dashboard dosen wali tertentu
+// @Tags Admin - Lecturers
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Lecturer ID"
+// @Success 200 {object} model.AdvisorDashboard
+// @Failure 404 {object} map[string]string "Lecturer not found"
+// @Router /admin/advisors/{id}/dashboard [get]
+func (h *AdvisorDashboardHandler) GetLecturerDashboard(c *gin.Context) {
+	data, err := h.dashboardSvc.GetLecturerDashboard(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeDashboardError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+}
+
+// SendReminders godoc
+// @Summary Send overdue reminders
+// @Description Kirim reminder sekarang ke dosen wali yang punya prestasi melewati SLA (selain jadwal otomatis)
+// @Tags Admin - Lecturers
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Number of reminders sent"
+// @Router /admin/advisors/reminders [post]
+func (h *AdvisorDashboardHandler) SendReminders(c *gin.Context) {
+	sent, err := h.dashboardSvc.SendOverdueReminders(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"sent": sent}})
+}
+
+func writeDashboardError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrLecturerNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+}
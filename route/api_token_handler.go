@@ -0,0 +1,236 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/service"
+	"github.com/nerhays/prestasi_uas/middleware"
+)
+
+type APITokenHandler struct {
+	tokenSvc *service.APITokenService
+}
+
+func NewAPITokenHandler(tokenSvc *service.APITokenService) *APITokenHandler {
+	return &APITokenHandler{tokenSvc}
+}
+
+type CreateAPITokenRequest struct {
+	Name   string   `json:"name" binding:"required"`
+	Scopes []string `json:"scopes" binding:"required"`
+	// 0 / kosong = 90 hari, maksimal 365
+	ExpiresInDays int `json:"expires_in_days"`
+}
+
+type CreateServiceAccountRequest struct {
+	Username string `json:"username" binding:"required"`
+	FullName string `json:"full_name"`
+	RoleID   string `json:"role_id" binding:"required"`
+}
+
+// CreatePersonalToken godoc
+// @Summary Create personal access token
+// @Description Buat API token milik user login. Scope harus permission yang dimiliki role user. Token hanya ditampilkan sekali.
+// @Tags Auth - API Tokens
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body CreateAPITokenRequest true "Token name, scopes and expiry"
+// @Success 201 {object} map[string]interface{} "token (plaintext, sekali) + metadata"
+// @Failure 400 {object} map[string]string "Invalid input / scope not allowed"
+// @Router /auth/tokens [post]
+func (h *APITokenHandler) CreatePersonalToken(c *gin.Context) {
+	var req CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	userID := c.GetString(middleware.ContextUserIDKey)
+	token, plain, err := h.tokenSvc.CreatePersonalToken(userID, createAPITokenInput(req))
+	if err != nil {
+		c.JSON(apiTokenErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data":   gin.H{"token": plain, "api_token": token},
+	})
+}
+
+// ListPersonalTokens godoc
+// @Summary List own API tokens
+// @Description Daftar API token milik user login (tanpa nilai token)
+// @Tags Auth - API Tokens
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /auth/tokens [get]
+func (h *APITokenHandler) ListPersonalTokens(c *gin.Context) {
+	tokens, err := h.tokenSvc.ListTokens(c.GetString(middleware.ContextUserIDKey))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": tokens})
+}
+
+// RevokePersonalToken godoc
+// @Summary Revoke own API token
+// @Tags Auth - API Tokens
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Token ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string "Token not found"
+// @Router /auth/tokens/{id} [delete]
+func (h *APITokenHandler) RevokePersonalToken(c *gin.Context) {
+	userID := c.GetString(middleware.ContextUserIDKey)
+	if err := h.tokenSvc.RevokePersonalToken(userID, c.Param("id")); err != nil {
+		c.JSON(apiTokenErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "token revoked"})
+}
+
+// CreateServiceAccount godoc
+// @Summary Create service account
+// @Description Akun non-manusia untuk script / integrasi. Tidak bisa login dengan password, akses hanya lewat API token.
+// @Tags Admin - Service Accounts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body CreateServiceAccountRequest true "Service account"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Invalid input / role not found"
+// @Router /admin/service-accounts [post]
+func (h *APITokenHandler) CreateServiceAccount(c *gin.Context) {
+	var req CreateServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	account, err := h.tokenSvc.CreateServiceAccount(service.CreateServiceAccountInput{
+		Username: req.Username,
+		FullName: req.FullName,
+		RoleID:   req.RoleID,
+	})
+	if err != nil {
+		c.JSON(apiTokenErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "data": account})
+}
+
+// ListServiceAccounts godoc
+// @Summary List service accounts
+// @Tags Admin - Service Accounts
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /admin/service-accounts [get]
+func (h *APITokenHandler) ListServiceAccounts(c *gin.Context) {
+	accounts, err := h.tokenSvc.ListServiceAccounts()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": accounts})
+}
+
+// CreateServiceAccountToken godoc
+// @Summary Create service account token
+// @Description Scope harus permission yang dimiliki role akun layanan. Token hanya ditampilkan sekali.
+// @Tags Admin - Service Accounts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Service account user ID"
+// @Param body body CreateAPITokenRequest true "Token name, scopes and expiry"
+// @Success 201 {object} map[string]interface{} "token (plaintext, sekali) + metadata"
+// @Failure 400 {object} map[string]string "Invalid input / scope not allowed"
+// @Failure 404 {object} map[string]string "Service account not found"
+// @Router /admin/service-accounts/{id}/tokens [post]
+func (h *APITokenHandler) CreateServiceAccountToken(c *gin.Context) {
+	var req CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	adminID := c.GetString(middleware.ContextUserIDKey)
+	token, plain, err := h.tokenSvc.CreateServiceAccountToken(adminID, c.Param("id"), createAPITokenInput(req))
+	if err != nil {
+		c.JSON(apiTokenErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data":   gin.H{"token": plain, "api_token": token},
+	})
+}
+
+// ListServiceAccountTokens godoc
+// @Summary List service account tokens
+// @Tags Admin - Service Accounts
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Service account user ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string "Service account not found"
+// @Router /admin/service-accounts/{id}/tokens [get]
+func (h *APITokenHandler) ListServiceAccountTokens(c *gin.Context) {
+	tokens, err := h.tokenSvc.ListServiceAccountTokens(c.Param("id"))
+	if err != nil {
+		c.JSON(apiTokenErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": tokens})
+}
+
+// RevokeToken godoc
+// @Summary Revoke any API token
+// @Description Cabut API token milik user / akun layanan mana pun
+// @Tags Admin - Service Accounts
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Token ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string "Token not found"
+// @Router /admin/api-tokens/{id} [delete]
+func (h *APITokenHandler) RevokeToken(c *gin.Context) {
+	if err := h.tokenSvc.RevokeToken(c.Param("id")); err != nil {
+		c.JSON(apiTokenErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "token revoked"})
+}
+
+func createAPITokenInput(req CreateAPITokenRequest) service.CreateAPITokenInput {
+	return service.CreateAPITokenInput{
+		Name:          req.Name,
+		Scopes:        req.Scopes,
+		ExpiresInDays: req.ExpiresInDays,
+	}
+}
+
+func apiTokenErrorStatus(err error) int {
+	var scopeErr *service.ErrScopeNotAllowed
+	switch {
+	case errors.As(err, &scopeErr),
+		errors.Is(err, service.ErrAPITokenName),
+		errors.Is(err, service.ErrAPITokenScopes),
+		errors.Is(err, service.ErrAPITokenTTL),
+		errors.Is(err, service.ErrServiceAccountInput),
+		errors.Is(err, service.ErrServiceAccountRole):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrAPITokenNotFound),
+		errors.Is(err, service.ErrServiceAccountNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
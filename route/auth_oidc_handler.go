@@ -0,0 +1,78 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/service"
+)
+
+type OIDCHandler struct {
+	oidcSvc *service.OIDCService
+}
+
+func NewOIDCHandler(oidcSvc *service.OIDCService) *OIDCHandler {
+	return &OIDCHandler{oidcSvc}
+}
+
+// Login godoc
+// @Summary Start SSO login (OpenID Connect)
+// @Description Redirect ke halaman login IdP kampus. Pakai format=json untuk mendapat URL-nya saja (SPA)
+// @Tags Auth - SSO
+// @Produce json
+// @Param format query string false "json = return the URL instead of redirecting"
+// @Success 302 "Redirect to identity provider"
+// @Success 200 {object} map[string]interface{} "Authorization URL (format=json)"
+// @Failure 503 {object} map[string]string "Identity provider unavailable"
+// @Router /auth/oidc/login [get]
+func (h *OIDCHandler) Login(c *gin.Context) {
+	url, err := h.oidcSvc.AuthURL(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+		return
+	}
+
+	if c.Query("format") == "json" {
+		c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"authorizationUrl": url}})
+		return
+	}
+	c.Redirect(http.StatusFound, url)
+}
+
+// Callback godoc
+// @Summary SSO callback (OpenID Connect)
+// @Description Redirect URI yang didaftarkan di IdP. Tukar authorization code dengan token aplikasi
+// @Tags Auth - SSO
+// @Produce json
+// @Param state query string true "State from /auth/oidc/login"
+// @Param code query string true "Authorization code"
+// @Success 200 {object} map[string]interface{} "Login success"
+// @Failure 400 {object} map[string]string "Invalid state or IdP error"
+// @Failure 401 {object} map[string]string "Token invalid or account not linked"
+// @Failure 429 {object} map[string]string "Account / IP temporarily locked (see Retry-After)"
+// @Failure 503 {object} map[string]string "Identity provider unavailable"
+// @Router /auth/oidc/callback [get]
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	// user batal / ditolak di halaman IdP
+	if idpErr := c.Query("error"); idpErr != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": idpErr, "description": c.Query("error_description")})
+		return
+	}
+
+	res, err := h.oidcSvc.Callback(c.Request.Context(), service.OIDCCallbackInput{
+		State:     c.Query("state"),
+		Code:      c.Query("code"),
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	switch {
+	case errors.Is(err, service.ErrOIDCStateInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	case errors.Is(err, service.ErrOIDCProviderFailed), errors.Is(err, service.ErrOIDCDisabled):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+		return
+	}
+	writeLoginResult(c, res, err)
+}
@@ -0,0 +1,138 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/service"
+	"github.com/nerhays/prestasi_uas/middleware"
+)
+
+type PasswordHandler struct {
+	passwordSvc *service.PasswordService
+}
+
+func NewPasswordHandler(passwordSvc *service.PasswordService) *PasswordHandler {
+	return &PasswordHandler{passwordSvc}
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// ChangePassword godoc
+// @Summary Change own password
+// @Description Ganti password user login. Token baru dikembalikan (akun dengan wajib ganti password bisa akses API lagi)
+// @Tags Auth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} map[string]interface{} "Password changed"
+// @Failure 400 {object} map[string]string "Password does not meet the policy"
+// @Failure 401 {object} map[string]string "Current password incorrect"
+// @Router /auth/password [post]
+func (h *PasswordHandler) ChangePassword(c *gin.Context) {
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	userID := c.GetString(middleware.ContextUserIDKey)
+	token, err := h.passwordSvc.ChangePassword(userID, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		if errors.Is(err, service.ErrCurrentPasswordInvalid) {
+			c.JSON(http.StatusUnauthorized, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(passwordErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   gin.H{"token": token},
+	})
+}
+
+// ForgotPassword godoc
+// @Summary Request password reset
+// @Description Kirim token reset password ke email. Respons selalu sama walaupun email tidak terdaftar
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body ForgotPasswordRequest true "Account email"
+// @Success 200 {object} map[string]string "Reset mail sent if the account exists"
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Router /auth/password/forgot [post]
+func (h *PasswordHandler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	if err := h.passwordSvc.RequestReset(c.Request.Context(), req.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "if the email is registered, a reset link has been sent",
+	})
+}
+
+// ResetPassword godoc
+// @Summary Reset password with token
+// @Description Set password baru memakai token dari email (sekali pakai, ada masa berlaku)
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} map[string]string "Password reset"
+// @Failure 400 {object} map[string]string "Invalid token or password does not meet the policy"
+// @Router /auth/password/reset [post]
+func (h *PasswordHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	if err := h.passwordSvc.ResetPassword(req.Token, req.NewPassword); err != nil {
+		c.JSON(passwordErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "password has been reset",
+	})
+}
+
+func passwordErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrPasswordTooShort),
+		errors.Is(err, service.ErrPasswordTooLong),
+		errors.Is(err, service.ErrPasswordBreached),
+		errors.Is(err, service.ErrPasswordContainsIdentity),
+		errors.Is(err, service.ErrPasswordUnchanged),
+		errors.Is(err, service.ErrResetTokenInvalid):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -1,13 +1,17 @@
 package route
 
 import (
+	"context"
+	"errors"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 
-	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/app/container"
 	"github.com/nerhays/prestasi_uas/app/service"
 	"github.com/nerhays/prestasi_uas/middleware"
 )
@@ -32,9 +36,10 @@ type loginRequest struct {
 // @Accept json
 // @Produce json
 // @Param body body loginRequest true "Login payload"
-// @Success 200 {object} map[string]interface{} "Login success"
+// @Success 200 {object} map[string]interface{} "Login success, or status 2fa_required with challengeToken"
 // @Failure 400 {object} map[string]string "Invalid input"
 // @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 429 {object} map[string]string "Account / IP temporarily locked (see Retry-After)"
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req loginRequest
@@ -44,13 +49,83 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	res, err := h.authService.Login(service.LoginInput{
-		Username: req.Username,
-		Password: req.Password,
+		Username:  req.Username,
+		Password:  req.Password,
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
 	})
-	if err != nil {
-		// simple error handling, nanti bisa dibagusin
+	writeLoginResult(c, res, err)
+}
+
+type twoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	// kode TOTP 6 digit atau kode cadangan
+	Code string `json:"code" binding:"required"`
+}
+
+// VerifyTwoFactor godoc
+// @Summary Login step 2: verify 2FA code
+// @Description Tukar challengeToken dari /auth/login dengan kode TOTP atau kode cadangan
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body twoFactorLoginRequest true "Challenge token and code"
+// @Success 200 {object} map[string]interface{} "Login success"
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 401 {object} map[string]string "Invalid code or challenge"
+// @Failure 429 {object} map[string]string "Account / IP temporarily locked (see Retry-After)"
+// @Router /auth/2fa/verify [post]
+func (h *AuthHandler) VerifyTwoFactor(c *gin.Context) {
+	var req twoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	res, err := h.authService.VerifyTwoFactor(service.TwoFactorLoginInput{
+		ChallengeToken: req.ChallengeToken,
+		Code:           req.Code,
+		IP:             c.ClientIP(),
+		UserAgent:      c.Request.UserAgent(),
+	})
+	writeLoginResult(c, res, err)
+}
+
+func writeLoginResult(c *gin.Context, res *service.LoginOutput, err error) {
+	var blocked *service.LoginBlockedError
+	if errors.As(err, &blocked) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(blocked.RetryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{"message": err.Error()})
+		return
+	}
+	switch {
+	case err == nil:
+	case errors.Is(err, service.ErrAuthBackendUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+		return
+	// langkah 2FA: password sudah terbukti benar
+	case errors.Is(err, service.ErrInvalidTwoFactorCode), errors.Is(err, service.ErrInvalidChallenge):
 		c.JSON(http.StatusUnauthorized, gin.H{"message": err.Error()})
 		return
+	default:
+		// alasan lain (akun nonaktif, error internal) tidak dibocorkan ke client
+		if !errors.Is(err, service.ErrInvalidCredentials) {
+			log.Printf("[AUTH] login failed: %v", err)
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"message": service.ErrInvalidCredentials.Error()})
+		return
+	}
+
+	// password benar, tunggu kode 2FA
+	if res.TwoFactorRequired {
+		c.JSON(http.StatusOK, gin.H{
+			"status": "2fa_required",
+			"data": gin.H{
+				"challengeToken": res.ChallengeToken,
+				"expiresIn":      int(res.ChallengeTTL.Seconds()),
+			},
+		})
+		return
 	}
 
 	// mapping permission ke string
@@ -70,6 +145,8 @@ func (h *AuthHandler) Login(c *gin.Context) {
 				"role":     res.User.Role.Name,
 			},
 			"permissions": perms,
+			// true → semua endpoint ditolak sampai POST /auth/password
+			"mustChangePassword": res.User.MustChangePassword,
 		},
 	})
 }
@@ -153,19 +230,41 @@ func (h *AuthHandler) Profile(c *gin.Context) {
 }
 
 
-func SetupAuthRoutes(rg *gin.RouterGroup, db *gorm.DB) {
-	userRepo := repository.NewUserRepository(db)
-	authSvc := service.NewAuthService(userRepo)
-	handler := NewAuthHandler(authSvc)
+func SetupAuthRoutes(rg *gin.RouterGroup, c *container.Container, authn *middleware.Auth, audit *middleware.Audit) {
+	handler := NewAuthHandler(c.Auth)
+	passwordHandler := NewPasswordHandler(c.Password)
+	twoFactorHandler := NewTwoFactorHandler(c.TwoFactor)
+	tokenHandler := NewAPITokenHandler(c.APITokens)
 
 	auth := rg.Group("/auth")
 
 	// public
 	auth.POST("/login", handler.Login)
 	auth.POST("/refresh", handler.Refresh)
+	auth.POST("/2fa/verify", handler.VerifyTwoFactor)
+	auth.POST("/password/forgot", passwordHandler.ForgotPassword)
+	auth.POST("/password/reset", passwordHandler.ResetPassword)
+
+	if c.Config.OIDCIssuerURL != "" {
+		oidcHandler := NewOIDCHandler(c.OIDC)
+		auth.GET("/oidc/login", oidcHandler.Login)
+		auth.GET("/oidc/callback", oidcHandler.Callback)
+	}
 
 	// protected
-	auth.Use(middleware.AuthMiddleware())
+	auth.Use(authn.Middleware())
 	auth.POST("/logout", handler.Logout)
 	auth.GET("/profile", handler.Profile)
+	auth.POST("/password", passwordHandler.ChangePassword)
+	auth.GET("/2fa", twoFactorHandler.Status)
+	auth.POST("/2fa/setup", twoFactorHandler.Setup)
+	auth.POST("/2fa/enable", twoFactorHandler.Enable)
+	auth.POST("/2fa/disable", twoFactorHandler.Disable)
+	auth.POST("/2fa/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes)
+	auth.POST("/tokens", tokenHandler.CreatePersonalToken)
+	auth.GET("/tokens", tokenHandler.ListPersonalTokens)
+	auth.DELETE("/tokens/:id", tokenHandler.RevokePersonalToken)
+	audit.Target(auth.BasePath()+"/tokens/:id", "api_token", func(_ context.Context, id string) (interface{}, error) {
+		return c.Repos.APITokens.FindByID(id)
+	})
 }
@@ -0,0 +1,167 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/app/service"
+	"github.com/nerhays/prestasi_uas/middleware"
+)
+
+type TwoFactorHandler struct {
+	twoFactorSvc *service.TwoFactorService
+}
+
+func NewTwoFactorHandler(twoFactorSvc *service.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactorSvc}
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+	// kode TOTP atau kode cadangan
+	Code string `json:"code" binding:"required"`
+}
+
+// Status godoc
+// @Summary Two-factor status
+// @Description Status 2FA user login (aktif, wajib dari role, sisa kode cadangan)
+// @Tags Auth - 2FA
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /auth/2fa [get]
+func (h *TwoFactorHandler) Status(c *gin.Context) {
+	status, err := h.twoFactorSvc.Status(c.GetString(middleware.ContextUserIDKey))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": status})
+}
+
+// Setup godoc
+// @Summary Start two-factor enrollment
+// @Description Buat secret TOTP baru + otpauth URI (untuk QR code). 2FA baru aktif setelah /auth/2fa/enable
+// @Tags Auth - 2FA
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 409 {object} map[string]string "Already enabled"
+// @Router /auth/2fa/setup [post]
+func (h *TwoFactorHandler) Setup(c *gin.Context) {
+	setup, err := h.twoFactorSvc.Setup(c.GetString(middleware.ContextUserIDKey))
+	if err != nil {
+		c.JSON(twoFactorErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": setup})
+}
+
+// Enable godoc
+// @Summary Confirm two-factor enrollment
+// @Description Aktifkan 2FA dengan kode TOTP pertama. Kode cadangan hanya ditampilkan sekali; token baru dikembalikan
+// @Tags Auth - 2FA
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body TwoFactorCodeRequest true "TOTP code"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Setup not started"
+// @Failure 401 {object} map[string]string "Invalid code"
+// @Failure 409 {object} map[string]string "Already enabled"
+// @Router /auth/2fa/enable [post]
+func (h *TwoFactorHandler) Enable(c *gin.Context) {
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	codes, token, err := h.twoFactorSvc.Enable(c.GetString(middleware.ContextUserIDKey), req.Code)
+	if err != nil {
+		c.JSON(twoFactorErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"token":         token,
+			"recoveryCodes": codes,
+		},
+	})
+}
+
+// Disable godoc
+// @Summary Disable two-factor authentication
+// @Description Nonaktifkan 2FA dengan password + kode. Ditolak kalau role mewajibkan 2FA
+// @Tags Auth - 2FA
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body DisableTwoFactorRequest true "Password and code"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string "Invalid password or code"
+// @Failure 403 {object} map[string]string "Required by role"
+// @Router /auth/2fa/disable [post]
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	var req DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	if err := h.twoFactorSvc.Disable(c.GetString(middleware.ContextUserIDKey), req.Password, req.Code); err != nil {
+		c.JSON(twoFactorErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "two-factor authentication disabled"})
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerate recovery codes
+// @Description Buat kode cadangan baru (kode lama hangus). Butuh kode TOTP
+// @Tags Auth - 2FA
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body TwoFactorCodeRequest true "TOTP code"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "2FA not enabled"
+// @Failure 401 {object} map[string]string "Invalid code"
+// @Router /auth/2fa/recovery-codes [post]
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	codes, err := h.twoFactorSvc.RegenerateRecoveryCodes(c.GetString(middleware.ContextUserIDKey), req.Code)
+	if err != nil {
+		c.JSON(twoFactorErrorStatus(err), gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"recoveryCodes": codes}})
+}
+
+func twoFactorErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidTwoFactorCode),
+		errors.Is(err, service.ErrCurrentPasswordInvalid):
+		return http.StatusUnauthorized
+	case errors.Is(err, service.ErrTwoFactorRequiredByRole):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrTwoFactorAlreadyEnabled):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrTwoFactorNotEnabled),
+		errors.Is(err, service.ErrTwoFactorSetupMissing):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,64 @@
+package route
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/nerhays/prestasi_uas/app/container"
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+// StartBackgroundJobs: job berkala yang jalan selama server hidup; berhenti saat ctx dibatalkan,
+// Wait pada WaitGroup yang dikembalikan menunggu semua job selesai
+func StartBackgroundJobs(ctx context.Context, c *container.Container) *sync.WaitGroup {
+	cfg := c.Config
+	var wg sync.WaitGroup
+	spawn := func(job func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job()
+		}()
+	}
+
+	// rotasi kunci JWT: dicek tiap jam, kunci diganti kalau sudah lewat JWT_KEY_ROTATION_HOURS
+	spawn(func() { runKeyRotation(ctx, c.Keys, time.Hour) })
+	log.Printf("[JOB] JWT key rotation every %dh", cfg.JWTKeyRotationHours)
+
+	if cfg.ReminderIntervalMinutes > 0 {
+		interval := time.Duration(cfg.ReminderIntervalMinutes) * time.Minute
+		spawn(func() { c.AdvisorDashboard.RunReminderLoop(ctx, interval) })
+		log.Printf("[JOB] SLA reminder every %s (SLA %dh)", interval, cfg.VerificationSLAHours)
+	}
+
+	// import yang terputus saat server mati ditandai failed (dicek saat start lalu tiap 10 menit)
+	spawn(func() { c.Imports.RunStaleJobLoop(ctx, 10*time.Minute) })
+	// import yang diunggah lewat API ikut ditunggu saat shutdown
+	spawn(func() { c.Imports.RunJobs(ctx) })
+
+	if cfg.TrashRetentionDays > 0 {
+		spawn(func() { c.Trash.RunPurgeLoop(ctx, time.Hour) })
+		log.Printf("[JOB] trash purge every 1h (retention %d days)", cfg.TrashRetentionDays)
+	}
+	return &wg
+}
+
+func runKeyRotation(ctx context.Context, keys *utils.KeyManager, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			rotated, err := keys.RotateIfDue(now)
+			if err != nil {
+				log.Printf("[JOB] jwt key rotation failed: %v", err)
+			} else if rotated {
+				log.Printf("[JOB] jwt signing key rotated")
+			}
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+type JWKSHandler struct {
+	keys *utils.KeyManager
+}
+
+func NewJWKSHandler(keys *utils.KeyManager) *JWKSHandler {
+	return &JWKSHandler{keys}
+}
+
+// JWKS godoc
+// @Summary JSON Web Key Set
+// @Description Kunci publik untuk memverifikasi access token (header kid). Dipakai layanan kampus lain
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} utils.JWKSet
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) JWKS(c *gin.Context) {
+	// kunci baru sudah ikut JWKS sejak dibuat, cache singkat cukup aman
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.keys.JWKS())
+}
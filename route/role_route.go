@@ -4,9 +4,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 
-	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/app/container"
 	"github.com/nerhays/prestasi_uas/app/service"
 )
 
@@ -40,10 +39,40 @@ func (h *RoleHandler) GetAll(c *gin.Context) {
 	})
 }
 
-func SetupRoleRoutes(rg *gin.RouterGroup, db *gorm.DB) {
-	roleRepo := repository.NewRoleRepository(db)
-	roleSvc := service.NewRoleService(roleRepo)
-	handler := NewRoleHandler(roleSvc)
+type TwoFactorRequirementRequest struct {
+	Required bool `json:"required"`
+}
+
+// SetTwoFactorRequired godoc
+// @Summary Require 2FA for a role
+// @Description Wajibkan / bebaskan 2FA untuk semua user dengan role ini. User yang belum daftar 2FA hanya bisa akses endpoint setup 2FA.
+// @Tags Admin - Roles
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Role ID"
+// @Param body body TwoFactorRequirementRequest true "Required flag"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 404 {object} map[string]string "Role not found"
+// @Router /admin/roles/{id}/two-factor [put]
+func (h *RoleHandler) SetTwoFactorRequired(c *gin.Context) {
+	var req TwoFactorRequirementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid input"})
+		return
+	}
+
+	role, err := h.roleService.SetTwoFactorRequired(c.Param("id"), req.Required)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success", "data": role})
+}
+
+func SetupRoleRoutes(rg *gin.RouterGroup, c *container.Container) {
+	handler := NewRoleHandler(c.Roles)
 
 	rg.GET("/roles", handler.GetAll)
 }
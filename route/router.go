@@ -4,15 +4,23 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/mongo"
-	"gorm.io/gorm"
 
+	"github.com/nerhays/prestasi_uas/app/container"
 	"github.com/nerhays/prestasi_uas/middleware"
 )
 
-func SetupRouter(db *gorm.DB, mongoDB *mongo.Database) *gin.Engine {
+// SetupRouter: semua route di atas service dari container; c.Keys harus sudah dimuat (LoadKeys)
+func SetupRouter(c *container.Container) *gin.Engine {
 	r := gin.Default()
 
+	// Authorization: Bearer prs_... diverifikasi ke tabel api_tokens;
+	// JWT user nonaktif / yang sesinya dicabut ditolak (cache status dibagi dengan endpoint nonaktifkan user)
+	authn := middleware.NewAuth(c.Keys, c.UserLifecycle, c.APITokens)
+	audit := middleware.NewAudit(c.Audit)
+
+	// request ID + audit log semua request yang mengubah data (aktor diisi middleware auth)
+	r.Use(middleware.RequestID(), audit.Trail())
+
 	// health check (public)
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -21,19 +29,23 @@ func SetupRouter(db *gorm.DB, mongoDB *mongo.Database) *gin.Engine {
 		})
 	})
 
+	// kunci publik JWT (di luar /api/v1, lokasi standar)
+	r.GET("/.well-known/jwks.json", NewJWKSHandler(c.Keys).JWKS)
+
 	api := r.Group("/api/v1")
 
 	// PUBLIC ROUTES
-	SetupAuthRoutes(api, db) // /auth/login
+	SetupAuthRoutes(api, c, authn, audit) // /auth/login
 
-	// PROTECTED ROUTES (JWT)
+	// PROTECTED ROUTES (JWT / API token)
 	protected := api.Group("")
-	protected.Use(middleware.AuthMiddleware())
+	protected.Use(authn.Middleware())
 
-	SetupRoleRoutes(protected, db)
-	SetupStudentRoutes(protected, db)
-	SetupAchievementRoutes(protected, db, mongoDB)
-	SetupAdminRoutes(api, db, mongoDB)
+	SetupRoleRoutes(protected, c)
+	SetupStudentRoutes(protected, c, authn)
+	SetupAcademicPeriodRoutes(protected, c)
+	SetupAchievementRoutes(protected, c, authn, audit)
+	SetupAdminRoutes(api, c, authn, audit)
 
 	// SetupAchievementRoutes(protected, db, mongo)
 
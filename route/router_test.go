@@ -0,0 +1,370 @@
+package route
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nerhays/prestasi_uas/app/container"
+	"github.com/nerhays/prestasi_uas/app/model"
+	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/app/repository/memory"
+	"github.com/nerhays/prestasi_uas/config"
+	"github.com/nerhays/prestasi_uas/mailer"
+	"github.com/nerhays/prestasi_uas/utils"
+)
+
+const testPassword = "rahasia-123"
+
+var testKeys *utils.KeyManager
+
+// TestMain: JWT ditandatangani kunci di memori, sama seperti test di package service
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	keys, err := utils.NewKeyManager(utils.NewMemoryKeyStore(), utils.KeyManagerConfig{
+		Algorithm: utils.AlgEdDSA,
+		Issuer:    "prestasi-test",
+		Audience:  "prestasi",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	testKeys = keys
+	os.Exit(m.Run())
+}
+
+// testServer: SetupRouter lengkap di atas container dengan repository + storage in-memory, tanpa Postgres / Mongo
+type testServer struct {
+	t       *testing.T
+	router  *gin.Engine
+	repos   *repository.Repositories
+	storage *utils.MemoryStorage
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	store := memory.NewStore()
+	store.SeedRolesAndPermissions()
+	repos := store.Repositories()
+
+	cfg := &config.Config{
+		JWTKeyStore:               "memory",
+		LoginAttemptStore:         "memory",
+		LoginMaxFailures:          5,
+		LoginIPMaxFailures:        50,
+		LoginFailureWindowMinutes: 15,
+		LoginLockoutMinutes:       15,
+		PasswordMinLength:         8,
+		PasswordResetTTLMinutes:   30,
+		TeamPointSplit:            string(model.TeamPointSplitEqual),
+		TrashRetentionDays:        30,
+		VerificationSLAHours:      72,
+		AuthBackend:               "local",
+	}
+	storage := utils.NewMemoryStorage()
+	c := container.Build(cfg, repos, container.Options{
+		Mailer:  &mailer.LogMailer{},
+		Storage: storage,
+		Keys:    testKeys,
+	})
+	return &testServer{t: t, router: SetupRouter(c), repos: repos, storage: storage}
+}
+
+func (s *testServer) newUser(roleName, username string) *model.User {
+	s.t.Helper()
+	role, err := s.repos.Roles.FindByName(roleName)
+	require.NoError(s.t, err)
+	hash, err := utils.HashPassword(testPassword)
+	require.NoError(s.t, err)
+	return &model.User{
+		Username: username, Email: username + "@test.local", PasswordHash: hash,
+		FullName: username, RoleID: role.ID, IsActive: true,
+	}
+}
+
+func (s *testServer) newLecturer(username string) *model.Lecturer {
+	s.t.Helper()
+	lecturer := &model.Lecturer{LecturerID: "D-" + username, Department: "Teknik"}
+	require.NoError(s.t, s.repos.Profiles.SaveLecturerAccount(s.newUser("Dosen Wali", username), lecturer))
+	return lecturer
+}
+
+func (s *testServer) newStudent(username, advisorID string) *model.Student {
+	s.t.Helper()
+	student := &model.Student{StudentID: "M-" + username, ProgramStudy: "Informatika", AcademicYear: "2023", AdvisorID: advisorID}
+	require.NoError(s.t, s.repos.Profiles.SaveStudentAccount(s.newUser("Mahasiswa", username), student))
+	return student
+}
+
+// openPeriod: periode akademik berjalan, syarat submit prestasi
+func (s *testServer) openPeriod() {
+	s.t.Helper()
+	now := time.Now()
+	require.NoError(s.t, s.repos.AcademicPeriods.Create(&model.AcademicPeriod{
+		AcademicYear: "2024/2025", Semester: model.SemesterGanjil,
+		StartDate: now.AddDate(0, -1, 0), EndDate: now.AddDate(0, 5, 0), IsActive: true,
+	}))
+}
+
+func (s *testServer) do(method, path, token string, body any) (int, map[string]any) {
+	s.t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(s.t, json.NewEncoder(&buf).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	out := map[string]any{}
+	if w.Body.Len() > 0 {
+		require.NoError(s.t, json.Unmarshal(w.Body.Bytes(), &out), w.Body.String())
+	}
+	return w.Code, out
+}
+
+func (s *testServer) upload(path, token, filename string, content []byte) (int, map[string]any) {
+	s.t.Helper()
+	var buf bytes.Buffer
+	form := multipart.NewWriter(&buf)
+	part, err := form.CreateFormFile("file", filename)
+	require.NoError(s.t, err)
+	_, err = part.Write(content)
+	require.NoError(s.t, err)
+	require.NoError(s.t, form.Close())
+
+	req := httptest.NewRequest(http.MethodPost, path, &buf)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	out := map[string]any{}
+	require.NoError(s.t, json.Unmarshal(w.Body.Bytes(), &out), w.Body.String())
+	return w.Code, out
+}
+
+func (s *testServer) login(username string) string {
+	s.t.Helper()
+	code, body := s.do(http.MethodPost, "/api/v1/auth/login", "", gin.H{"username": username, "password": testPassword})
+	require.Equal(s.t, http.StatusOK, code, body)
+	return body["data"].(map[string]any)["token"].(string)
+}
+
+// tokenIssuedAt: access token user yang terbit pada at (iat JWT hanya presisi detik,
+// jadi token "lama" dibuat mundur supaya tidak jatuh di detik yang sama dengan pencabutan)
+func (s *testServer) tokenIssuedAt(user *model.User, at time.Time) string {
+	s.t.Helper()
+	token, err := testKeys.Sign(&utils.JWTCustomClaims{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     "Admin",
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(at),
+			ExpiresAt: jwt.NewNumericDate(at.Add(utils.AccessTokenTTL)),
+		},
+	})
+	require.NoError(s.t, err)
+	return token
+}
+
+func TestRouter_Health(t *testing.T) {
+	s := newTestServer(t)
+
+	code, body := s.do(http.MethodGet, "/health", "", nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", body["status"])
+}
+
+func TestRouter_Login(t *testing.T) {
+	s := newTestServer(t)
+	s.newStudent("budi", "")
+
+	code, body := s.do(http.MethodPost, "/api/v1/auth/login", "", gin.H{"username": "budi", "password": "salah-password"})
+	assert.Equal(t, http.StatusUnauthorized, code, body)
+
+	code, body = s.do(http.MethodPost, "/api/v1/auth/login", "", gin.H{"username": "budi", "password": testPassword})
+	require.Equal(t, http.StatusOK, code, body)
+	data := body["data"].(map[string]any)
+	assert.NotEmpty(t, data["token"])
+	assert.Equal(t, "Mahasiswa", data["user"].(map[string]any)["role"])
+
+	// akun nonaktif dengan password benar: jawaban sama dengan password salah
+	user, err := s.repos.Users.FindByUsernameOrEmail("budi")
+	require.NoError(t, err)
+	require.NoError(t, s.repos.Users.SetActive(user.ID, false, time.Now()))
+	code, body = s.do(http.MethodPost, "/api/v1/auth/login", "", gin.H{"username": "budi", "password": testPassword})
+	assert.Equal(t, http.StatusUnauthorized, code, body)
+	assert.Equal(t, "invalid_credentials", body["message"])
+}
+
+func TestRouter_ProtectedRequiresToken(t *testing.T) {
+	s := newTestServer(t)
+
+	code, _ := s.do(http.MethodGet, "/api/v1/achievements/me", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, _ = s.do(http.MethodGet, "/api/v1/achievements/me", "bukan-token", nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestRouter_AchievementFlow(t *testing.T) {
+	s := newTestServer(t)
+	lecturer := s.newLecturer("pakdosen")
+	s.newStudent("budi", lecturer.ID)
+	s.newStudent("ani", "")
+	s.openPeriod()
+
+	studentToken := s.login("budi")
+	code, body := s.do(http.MethodPost, "/api/v1/achievements/", studentToken, gin.H{
+		"achievementType": "competition",
+		"title":           "Juara 1 Hackathon",
+		"description":     "Tingkat nasional",
+		"details":         gin.H{"competitionLevel": "national", "rank": 1},
+	})
+	require.Equal(t, http.StatusCreated, code, body)
+	ref := body["data"].(map[string]any)["reference"].(map[string]any)
+	refID := ref["id"].(string)
+	assert.Equal(t, string(model.AchievementStatusDraft), ref["status"])
+
+	code, body = s.do(http.MethodGet, "/api/v1/achievements/me", studentToken, nil)
+	require.Equal(t, http.StatusOK, code, body)
+	assert.Len(t, body["data"], 1)
+
+	// mahasiswa lain bukan pemilik
+	code, body = s.do(http.MethodPost, "/api/v1/achievements/"+refID+"/submit", s.login("ani"), nil)
+	assert.Equal(t, http.StatusBadRequest, code, body)
+
+	code, body = s.do(http.MethodPost, "/api/v1/achievements/"+refID+"/submit", studentToken, nil)
+	require.Equal(t, http.StatusOK, code, body)
+
+	// mahasiswa tidak boleh memverifikasi
+	code, _ = s.do(http.MethodPost, "/api/v1/achievements/"+refID+"/verify", studentToken, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code, body = s.do(http.MethodPost, "/api/v1/achievements/"+refID+"/verify", s.login("pakdosen"), nil)
+	require.Equal(t, http.StatusOK, code, body)
+	assert.Equal(t, string(model.AchievementStatusVerified), body["data"].(map[string]any)["status"])
+
+	stored, err := s.repos.AchievementReferences.GetByID(refID)
+	require.NoError(t, err)
+	assert.Equal(t, model.AchievementStatusVerified, stored.Status)
+}
+
+func TestRouter_UploadAttachmentUsesStorage(t *testing.T) {
+	s := newTestServer(t)
+	s.newStudent("budi", "")
+	token := s.login("budi")
+
+	code, body := s.do(http.MethodPost, "/api/v1/achievements/", token, gin.H{
+		"achievementType": "certification",
+		"title":           "Sertifikasi Cloud",
+	})
+	require.Equal(t, http.StatusCreated, code, body)
+	refID := body["data"].(map[string]any)["reference"].(map[string]any)["id"].(string)
+
+	code, _ = s.upload("/api/v1/achievements/"+refID+"/attachments", token, "bukti.exe", []byte("x"))
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	content := []byte("%PDF-1.4 sertifikat")
+	code, body = s.upload("/api/v1/achievements/"+refID+"/attachments", token, "bukti.pdf", content)
+	require.Equal(t, http.StatusOK, code, body)
+
+	att := body["data"].(map[string]any)
+	assert.Equal(t, utils.SHA256Hex(string(content)), att["hash"])
+	stored, ok := s.storage.File(att["fileUrl"].(string))
+	require.True(t, ok)
+	assert.Equal(t, content, stored)
+}
+
+func TestRouter_AnonymizeLeavesNoPersonalDataInAuditLog(t *testing.T) {
+	s := newTestServer(t)
+	require.NoError(t, s.repos.Users.Create(s.newUser("Admin", "admin")))
+	token := s.login("admin")
+
+	code, body := s.do(http.MethodPost, "/api/v1/admin/students", token, gin.H{
+		"username": "sitiaminah", "email": "siti@kampus.test", "password": "Rahasia-12345",
+		"full_name": "Siti Aminah", "student_id": "2101990001", "program_study": "Informatika", "academic_year": "2023",
+	})
+	require.Equal(t, http.StatusCreated, code, body)
+	student := body["data"].(map[string]any)
+	studentID, userID := student["id"].(string), student["user_id"].(string)
+
+	code, body = s.do(http.MethodPut, "/api/v1/admin/students/"+studentID, token, gin.H{
+		"username": "sitiaminah", "email": "aminah@kampus.test",
+		"full_name": "Siti Aminah Putri", "student_id": "2101990002", "program_study": "Informatika", "academic_year": "2023",
+	})
+	require.Equal(t, http.StatusOK, code, body)
+	code, body = s.do(http.MethodPost, "/api/v1/admin/users/"+userID+"/deactivate", token, nil)
+	require.Equal(t, http.StatusOK, code, body)
+
+	code, body = s.do(http.MethodPost, "/api/v1/admin/users/"+userID+"/anonymize", token, nil)
+	require.Equal(t, http.StatusOK, code, body)
+
+	logs, total, err := s.repos.AuditLogs.FindAll(model.AuditLogFilter{}, 0, 100)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, total, int64(4))
+	raw, err := json.Marshal(logs)
+	require.NoError(t, err)
+	for _, pii := range []string{"sitiaminah", "siti@kampus.test", "aminah@kampus.test", "Siti Aminah", "2101990001", "2101990002"} {
+		assert.NotContains(t, string(raw), pii)
+	}
+
+	code, body = s.do(http.MethodGet, "/api/v1/admin/audit-logs/verify", token, nil)
+	require.Equal(t, http.StatusOK, code, body)
+	assert.Equal(t, true, body["data"].(map[string]any)["valid"], body)
+}
+
+func TestRouter_ScopeAndRoleChangeRevokeOldTokens(t *testing.T) {
+	s := newTestServer(t)
+	require.NoError(t, s.repos.Users.Create(s.newUser("Admin", "admin")))
+	token := s.login("admin")
+
+	scoped := s.newUser("Admin", "adminft")
+	require.NoError(t, s.repos.Users.Create(scoped))
+	demoted := s.newUser("Admin", "adminlama")
+	require.NoError(t, s.repos.Users.Create(demoted))
+	scopedToken := s.tokenIssuedAt(scoped, time.Now().Add(-time.Minute))
+	demotedToken := s.tokenIssuedAt(demoted, time.Now().Add(-time.Minute))
+
+	code, body := s.do(http.MethodGet, "/api/v1/admin/users", scopedToken, nil)
+	require.Equal(t, http.StatusOK, code, body)
+	code, body = s.do(http.MethodGet, "/api/v1/admin/users", demotedToken, nil)
+	require.Equal(t, http.StatusOK, code, body)
+
+	// admin global dipersempit ke satu fakultas: token global lamanya tidak berlaku lagi
+	code, body = s.do(http.MethodPost, "/api/v1/admin/faculties", token, gin.H{"code": "FT", "name": "Fakultas Teknik"})
+	require.Equal(t, http.StatusCreated, code, body)
+	facultyID := body["data"].(map[string]any)["id"].(string)
+	code, body = s.do(http.MethodPut, "/api/v1/admin/users/"+scoped.ID+"/scope", token, gin.H{"faculty_id": facultyID})
+	require.Equal(t, http.StatusOK, code, body)
+	code, body = s.do(http.MethodGet, "/api/v1/admin/users", scopedToken, nil)
+	assert.Equal(t, http.StatusUnauthorized, code, body)
+
+	// admin diturunkan jadi mahasiswa: token Admin lamanya tidak berlaku lagi
+	role, err := s.repos.Roles.FindByName("Mahasiswa")
+	require.NoError(t, err)
+	code, body = s.do(http.MethodPut, "/api/v1/admin/users/"+demoted.ID+"/role", token, gin.H{"role_id": role.ID})
+	require.Equal(t, http.StatusOK, code, body)
+	code, body = s.do(http.MethodGet, "/api/v1/admin/users", demotedToken, nil)
+	assert.Equal(t, http.StatusUnauthorized, code, body)
+
+	// admin yang mengubah tidak ikut kehilangan sesi
+	code, body = s.do(http.MethodGet, "/api/v1/admin/users", token, nil)
+	assert.Equal(t, http.StatusOK, code, body)
+}
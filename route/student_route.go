@@ -4,10 +4,9 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/nerhays/prestasi_uas/app/repository"
+	"github.com/nerhays/prestasi_uas/app/container"
 	"github.com/nerhays/prestasi_uas/app/service"
 	"github.com/nerhays/prestasi_uas/middleware"
-	"gorm.io/gorm"
 )
 
 type StudentHandler struct {
@@ -43,17 +42,9 @@ func (h *StudentHandler) GetMyProfile(c *gin.Context) {
 	})
 }
 
-func SetupStudentRoutes(rg *gin.RouterGroup, db *gorm.DB) {
-	studentRepo := repository.NewStudentRepository(db)
-	lecturerRepo := repository.NewLecturerRepository(db)
+func SetupStudentRoutes(rg *gin.RouterGroup, c *container.Container, authn *middleware.Auth) {
+	handler := NewStudentHandler(c.Students)
 
-	studentSvc := service.NewStudentService(
-		studentRepo,
-		lecturerRepo,
-	)
-
-	handler := NewStudentHandler(studentSvc)
-
-	authRequired := rg.Group("/students", middleware.AuthMiddleware())
+	authRequired := rg.Group("/students", authn.Middleware())
 	authRequired.GET("/me", handler.GetMyProfile)
 }
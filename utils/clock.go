@@ -0,0 +1,11 @@
+package utils
+
+import "time"
+
+// Clock: sumber waktu "sekarang" untuk service yang menghitung TTL / SLA; time.Now di produksi
+type Clock func() time.Time
+
+// FixedClock: jam yang selalu menunjuk t, dipakai di test
+func FixedClock(t time.Time) Clock {
+	return func() time.Time { return t }
+}
@@ -1,7 +1,7 @@
 package utils
 
 import (
-	"os"
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -9,54 +9,105 @@ import (
 )
 
 type JWTCustomClaims struct {
-	UserID      string             `json:"sub"`
-	FullName    string             `json:"fullName"`
-	Username    string             `json:"username"`
-	Role        string             `json:"role"`
-	Permissions []string           `json:"perms"`
+	UserID      string   `json:"sub"`
+	FullName    string   `json:"fullName"`
+	Username    string   `json:"username"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"perms"`
+	// scope admin fakultas/jurusan (kosong = global)
+	FacultyID    string `json:"facultyId,omitempty"`
+	DepartmentID string `json:"departmentId,omitempty"`
+	// true = hanya boleh ganti password (akun seed / password dari admin)
+	PasswordChangeRequired bool `json:"pwdChange,omitempty"`
+	// true = role wajib 2FA tapi user belum mendaftar; hanya boleh endpoint /auth/2fa
+	TwoFactorSetupRequired bool `json:"mfaSetup,omitempty"`
+	// kosong = access token; "2fa" = token tantangan login langkah kedua
+	Purpose string `json:"purpose,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken utk login
-func GenerateToken(user *model.User, permissions []model.Permission) (string, error) {
-	secret := []byte(os.Getenv("JWT_SECRET"))
+const (
+	PurposeTwoFactorChallenge = "2fa"
+
+	AccessTokenTTL = 24 * time.Hour
+)
 
+var (
+	ErrTokenPurpose = errors.New("token cannot be used for this purpose")
+	ErrNoSigningKey = errors.New("jwt signing key is not configured")
+)
+
+// GenerateToken utk login
+func (m *KeyManager) GenerateToken(user *model.User, permissions []model.Permission) (string, error) {
 	perms := make([]string, 0, len(permissions))
 	for _, p := range permissions {
 		perms = append(perms, p.Name)
 	}
 
+	scope := user.Scope()
+
 	claims := JWTCustomClaims{
-		UserID:      user.ID,
-		FullName:    user.FullName,
-		Username:    user.Username,
-		Role:        user.Role.Name,
-		Permissions: perms,
+		UserID:                 user.ID,
+		FullName:               user.FullName,
+		Username:               user.Username,
+		Role:                   user.Role.Name,
+		Permissions:            perms,
+		FacultyID:              scope.FacultyID,
+		DepartmentID:           scope.DepartmentID,
+		PasswordChangeRequired: user.MustChangePassword,
+		TwoFactorSetupRequired: user.Role.RequireTwoFactor && !user.TwoFactorEnabled,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(secret)
+	return m.Sign(&claims)
 }
 
-// ParseToken utk middleware
-func ParseToken(tokenStr string) (*JWTCustomClaims, error) {
-	secret := []byte(os.Getenv("JWT_SECRET"))
+// GenerateChallengeToken: token singkat setelah password benar, ditukar dengan kode 2FA
+func (m *KeyManager) GenerateChallengeToken(userID string, ttl time.Duration) (string, error) {
+	claims := JWTCustomClaims{
+		UserID:  userID,
+		Purpose: PurposeTwoFactorChallenge,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return m.Sign(&claims)
+}
 
-	token, err := jwt.ParseWithClaims(tokenStr, &JWTCustomClaims{}, func(t *jwt.Token) (interface{}, error) {
-		return secret, nil
-	})
+// ParseChallengeToken: hanya menerima token tantangan 2FA
+func (m *KeyManager) ParseChallengeToken(tokenStr string) (*JWTCustomClaims, error) {
+	claims, err := m.parseClaims(tokenStr)
 	if err != nil {
 		return nil, err
 	}
+	if claims.Purpose != PurposeTwoFactorChallenge {
+		return nil, ErrTokenPurpose
+	}
+	return claims, nil
+}
 
-	claims, ok := token.Claims.(*JWTCustomClaims)
-	if !ok || !token.Valid {
-		return nil, jwt.ErrTokenInvalidClaims
+// ParseToken utk middleware; token tantangan 2FA ditolak
+func (m *KeyManager) ParseToken(tokenStr string) (*JWTCustomClaims, error) {
+	claims, err := m.parseClaims(tokenStr)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != "" {
+		return nil, ErrTokenPurpose
 	}
+	return claims, nil
+}
 
+// parseClaims: tanda tangan (kid), exp, iss dan aud diverifikasi key manager
+func (m *KeyManager) parseClaims(tokenStr string) (*JWTCustomClaims, error) {
+	claims := &JWTCustomClaims{}
+	if err := m.Parse(tokenStr, claims); err != nil {
+		return nil, err
+	}
 	return claims, nil
 }
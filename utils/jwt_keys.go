@@ -0,0 +1,457 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nerhays/prestasi_uas/app/model"
+)
+
+const (
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+
+	// kid tidak dikenal → muat ulang kunci dari store (instance lain mungkin baru rotasi),
+	// paling sering sekali per interval ini supaya kid asal-asalan tidak membanjiri DB
+	keyReloadMinInterval = time.Second
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported jwt algorithm, use RS256 or EdDSA")
+	ErrUnknownKeyID         = errors.New("unknown jwt key id")
+)
+
+// KeyStore: penyimpanan kunci bersama antar instance
+type KeyStore interface {
+	LoadKeys() ([]model.SigningKey, error)
+	// SaveKey: insert / update
+	SaveKey(key *model.SigningKey) error
+	DeleteKey(kid string) error
+	// WithLock: fn dijalankan sambil memegang kunci rotasi bersama, jadi hanya satu instance
+	// yang membuat / mempensiunkan kunci pada satu waktu
+	WithLock(fn func() error) error
+}
+
+type KeyManagerConfig struct {
+	Algorithm string
+	Issuer    string
+	Audience  string
+	// umur kunci sebelum diganti kunci baru
+	RotationInterval time.Duration
+	// umur access token; kunci lama tetap dipakai verifikasi selama ini setelah pensiun
+	TokenTTL time.Duration
+}
+
+type signingKey struct {
+	kid       string
+	alg       string
+	private   crypto.Signer
+	createdAt time.Time
+	retired   bool
+}
+
+// KeyManager: kunci RS256 / EdDSA dengan kid, rotasi terjadwal dan JWKS
+type KeyManager struct {
+	cfg   KeyManagerConfig
+	store KeyStore
+
+	mu         sync.RWMutex
+	keys       map[string]*signingKey
+	current    *signingKey
+	lastReload time.Time
+}
+
+// NewKeyManager: kunci langsung dimuat dari store (lihat Load)
+func NewKeyManager(store KeyStore, cfg KeyManagerConfig) (*KeyManager, error) {
+	m := NewDeferredKeyManager(store, cfg)
+	if err := m.Load(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NewDeferredKeyManager: kunci belum dimuat sampai Load dipanggil; sebelum itu token tidak bisa
+// dibuat (ErrNoSigningKey) maupun diverifikasi. Dipakai container supaya service bisa dirakit
+// tanpa membaca / membuat kunci (subcommand CLI selain serve)
+func NewDeferredKeyManager(store KeyStore, cfg KeyManagerConfig) *KeyManager {
+	if cfg.Algorithm == "" {
+		cfg.Algorithm = AlgRS256
+	}
+	if cfg.RotationInterval <= 0 {
+		cfg.RotationInterval = 30 * 24 * time.Hour
+	}
+	if cfg.TokenTTL <= 0 {
+		cfg.TokenTTL = AccessTokenTTL
+	}
+	return &KeyManager{cfg: cfg, store: store}
+}
+
+// Load: baca kunci dari store; store kosong → kunci pertama dibuat
+func (m *KeyManager) Load() error {
+	if m.cfg.Algorithm != AlgRS256 && m.cfg.Algorithm != AlgEdDSA {
+		return ErrUnsupportedAlgorithm
+	}
+	if err := m.Reload(); err != nil {
+		return err
+	}
+	if m.currentKey() != nil {
+		return nil
+	}
+
+	// store kosong: instance yang start bersamaan cukup membuat satu kunci, sisanya memakai kunci itu
+	err := m.store.WithLock(func() error {
+		if err := m.Reload(); err != nil {
+			return err
+		}
+		if m.currentKey() != nil {
+			return nil
+		}
+		return m.rotateLocked(time.Now())
+	})
+	if err != nil {
+		return err
+	}
+	return m.Reload()
+}
+
+// Reload: baca ulang semua kunci yang belum kadaluarsa dari store
+func (m *KeyManager) Reload() error {
+	stored, err := m.store.LoadKeys()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	keys := make(map[string]*signingKey, len(stored))
+	var current *signingKey
+	for _, sk := range stored {
+		if sk.ExpiresAt != nil && !sk.ExpiresAt.After(now) {
+			continue
+		}
+		priv, err := parsePrivateKeyPEM(sk.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("jwt key %s: %w", sk.KID, err)
+		}
+		k := &signingKey{kid: sk.KID, alg: sk.Algorithm, private: priv, createdAt: sk.CreatedAt, retired: sk.RetiredAt != nil}
+		keys[k.kid] = k
+		// kunci aktif terbaru dengan algoritma yang dikonfigurasi dipakai tanda tangan
+		if !k.retired && k.alg == m.cfg.Algorithm && (current == nil || k.createdAt.After(current.createdAt)) {
+			current = k
+		}
+	}
+
+	m.mu.Lock()
+	m.keys, m.current, m.lastReload = keys, current, now
+	m.mu.Unlock()
+	return nil
+}
+
+// Rotate: buat kunci baru, kunci aktif lain dipensiunkan (masih diverifikasi sampai TokenTTL lewat)
+func (m *KeyManager) Rotate(now time.Time) error {
+	if err := m.store.WithLock(func() error { return m.rotateLocked(now) }); err != nil {
+		return err
+	}
+	return m.Reload()
+}
+
+// rotateLocked: generate + pensiunkan kunci lama; dipanggil di dalam store.WithLock
+func (m *KeyManager) rotateLocked(now time.Time) error {
+	priv, err := generatePrivateKey(m.cfg.Algorithm)
+	if err != nil {
+		return err
+	}
+	pemStr, kid, err := encodePrivateKey(priv)
+	if err != nil {
+		return err
+	}
+	if err := m.store.SaveKey(&model.SigningKey{
+		KID:        kid,
+		Algorithm:  m.cfg.Algorithm,
+		PrivateKey: pemStr,
+		CreatedAt:  now,
+	}); err != nil {
+		return err
+	}
+
+	stored, err := m.store.LoadKeys()
+	if err != nil {
+		return err
+	}
+	for i := range stored {
+		sk := &stored[i]
+		switch {
+		case sk.KID == kid:
+		case sk.ExpiresAt != nil && !sk.ExpiresAt.After(now):
+			if err := m.store.DeleteKey(sk.KID); err != nil {
+				return err
+			}
+		case sk.RetiredAt == nil:
+			expires := now.Add(m.cfg.TokenTTL)
+			sk.RetiredAt, sk.ExpiresAt = &now, &expires
+			if err := m.store.SaveKey(sk); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RotateIfDue: dipanggil job berkala; true kalau kunci baru dibuat.
+// Jatuh tempo dicek ulang di dalam lock supaya instance lain yang baru rotasi tidak diulang.
+func (m *KeyManager) RotateIfDue(now time.Time) (bool, error) {
+	if err := m.Reload(); err != nil {
+		return false, err
+	}
+	if !m.rotationDue(now) {
+		return false, nil
+	}
+
+	rotated := false
+	err := m.store.WithLock(func() error {
+		if err := m.Reload(); err != nil {
+			return err
+		}
+		if !m.rotationDue(now) {
+			return nil
+		}
+		rotated = true
+		return m.rotateLocked(now)
+	})
+	if err != nil {
+		return false, err
+	}
+	return rotated, m.Reload()
+}
+
+func (m *KeyManager) rotationDue(now time.Time) bool {
+	cur := m.currentKey()
+	return cur == nil || now.Sub(cur.createdAt) >= m.cfg.RotationInterval
+}
+
+func (m *KeyManager) currentKey() *signingKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.current
+}
+
+func (m *KeyManager) lookup(kid string) *signingKey {
+	m.mu.RLock()
+	k := m.keys[kid]
+	stale := time.Since(m.lastReload) > keyReloadMinInterval
+	m.mu.RUnlock()
+
+	if k == nil && stale {
+		if err := m.Reload(); err == nil {
+			m.mu.RLock()
+			k = m.keys[kid]
+			m.mu.RUnlock()
+		}
+	}
+	return k
+}
+
+// Sign: tanda tangani claims dengan kunci aktif; iss / aud diisi dari konfigurasi
+func (m *KeyManager) Sign(claims *JWTCustomClaims) (string, error) {
+	k := m.currentKey()
+	if k == nil {
+		return "", ErrNoSigningKey
+	}
+	if m.cfg.Issuer != "" {
+		claims.Issuer = m.cfg.Issuer
+	}
+	if m.cfg.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{m.cfg.Audience}
+	}
+
+	token := jwt.NewWithClaims(signingMethod(k.alg), claims)
+	token.Header["kid"] = k.kid
+	return token.SignedString(k.private)
+}
+
+// Parse: verifikasi tanda tangan (berdasarkan kid), exp, iss dan aud
+func (m *KeyManager) Parse(tokenStr string, claims *JWTCustomClaims) error {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{AlgRS256, AlgEdDSA}),
+		jwt.WithExpirationRequired(),
+	}
+	if m.cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(m.cfg.Issuer))
+	}
+	if m.cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(m.cfg.Audience))
+	}
+
+	token, err := jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		k := m.lookup(kid)
+		if k == nil {
+			return nil, ErrUnknownKeyID
+		}
+		// alg di header harus sama dengan algoritma kunci
+		if t.Method.Alg() != k.alg {
+			return nil, jwt.ErrTokenSignatureInvalid
+		}
+		return k.private.Public(), nil
+	}, opts...)
+	if err != nil {
+		return err
+	}
+	if !token.Valid {
+		return jwt.ErrTokenInvalidClaims
+	}
+	return nil
+}
+
+// JWK: satu kunci publik di JWKS (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS: semua kunci publik yang masih berlaku (aktif + pensiun yang belum kadaluarsa)
+func (m *KeyManager) JWKS() JWKSet {
+	m.mu.RLock()
+	keys := make([]*signingKey, 0, len(m.keys))
+	for _, k := range m.keys {
+		keys = append(keys, k)
+	}
+	m.mu.RUnlock()
+
+	// terbaru dulu
+	sort.Slice(keys, func(i, j int) bool { return keys[i].createdAt.After(keys[j].createdAt) })
+
+	set := JWKSet{Keys: make([]JWK, 0, len(keys))}
+	for _, k := range keys {
+		jwk := JWK{KeyID: k.kid, Use: "sig", Algorithm: k.alg}
+		switch pub := k.private.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+func signingMethod(alg string) jwt.SigningMethod {
+	if alg == AlgEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+func generatePrivateKey(alg string) (crypto.Signer, error) {
+	switch alg {
+	case AlgRS256:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case AlgEdDSA:
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		return priv, err
+	}
+	return nil, ErrUnsupportedAlgorithm
+}
+
+// encodePrivateKey: PEM PKCS#8 + kid (hash kunci publik)
+func encodePrivateKey(priv crypto.Signer) (string, string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return "", "", err
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(priv.Public())
+	if err != nil {
+		return "", "", err
+	}
+	pemStr := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	return pemStr, SHA256Hex(string(pubDER))[:16], nil
+}
+
+func parsePrivateKeyPEM(s string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, errors.New("invalid PEM")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	switch signer.(type) {
+	case *rsa.PrivateKey, ed25519.PrivateKey:
+		return signer, nil
+	}
+	return nil, ErrUnsupportedAlgorithm
+}
+
+// MemoryKeyStore: kunci hanya di memori (test / satu instance, token hangus saat restart)
+type MemoryKeyStore struct {
+	mu   sync.Mutex
+	keys map[string]model.SigningKey
+	// rotation: pengganti advisory lock, terpisah dari mu karena fn memanggil LoadKeys / SaveKey
+	rotation sync.Mutex
+}
+
+func NewMemoryKeyStore() *MemoryKeyStore {
+	return &MemoryKeyStore{keys: map[string]model.SigningKey{}}
+}
+
+func (s *MemoryKeyStore) LoadKeys() ([]model.SigningKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]model.SigningKey, 0, len(s.keys))
+	for _, k := range s.keys {
+		out = append(out, k)
+	}
+	return out, nil
+}
+
+func (s *MemoryKeyStore) SaveKey(key *model.SigningKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key.KID] = *key
+	return nil
+}
+
+func (s *MemoryKeyStore) DeleteKey(kid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, kid)
+	return nil
+}
+
+func (s *MemoryKeyStore) WithLock(fn func() error) error {
+	s.rotation.Lock()
+	defer s.rotation.Unlock()
+	return fn()
+}
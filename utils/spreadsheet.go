@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var ErrUnsupportedSpreadsheet = errors.New("unsupported file type, use .csv or .xlsx")
+
+// ReadSpreadsheet: baca semua baris dari file CSV / XLSX (sheet pertama)
+func ReadSpreadsheet(filename string, r io.Reader) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+		cr.TrimLeadingSpace = true
+		return cr.ReadAll()
+
+	case ".xlsx":
+		f, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		return f.GetRows(f.GetSheetName(0))
+
+	default:
+		return nil, ErrUnsupportedSpreadsheet
+	}
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// UploadDir: direktori lampiran yang disajikan sebagai /uploads/...
+const UploadDir = "uploads"
+
+// Storage: penyimpanan file lampiran; URL hasil Save disimpan di dokumen prestasi
+type Storage interface {
+	// Save: simpan isi src sebagai <dir>/<name>, return URL-nya ("/uploads/<dir>/<name>")
+	Save(dir, name string, src io.Reader) (string, error)
+	// Remove: file yang sudah tidak ada dianggap terhapus
+	Remove(fileURL string) error
+}
+
+// LocalStorage: file lampiran di disk, di bawah UploadDir
+type LocalStorage struct{}
+
+func NewLocalStorage() *LocalStorage {
+	return &LocalStorage{}
+}
+
+func (s *LocalStorage) Save(dir, name string, src io.Reader) (string, error) {
+	target := filepath.Join(UploadDir, dir)
+	if err := os.MkdirAll(target, 0755); err != nil {
+		return "", err
+	}
+	filePath := filepath.Join(target, name)
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(f, src); err != nil {
+		f.Close()
+		_ = os.Remove(filePath)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(filePath)
+		return "", err
+	}
+	return "/" + filepath.ToSlash(filePath), nil
+}
+
+func (s *LocalStorage) Remove(fileURL string) error {
+	return RemoveUpload(fileURL)
+}
+
+// RemoveUpload: hapus file lampiran dari URL-nya ("/uploads/achievements/x.pdf").
+// URL di luar UploadDir ditolak; file yang sudah tidak ada dianggap terhapus.
+func RemoveUpload(fileURL string) error {
+	rel := filepath.Clean(strings.TrimPrefix(fileURL, "/"))
+	if !strings.HasPrefix(rel, UploadDir+string(filepath.Separator)) {
+		return fmt.Errorf("not an uploaded file: %s", fileURL)
+	}
+	if err := os.Remove(rel); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// MemoryStorage: lampiran di memori, untuk test / CLI tanpa disk
+type MemoryStorage struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{files: map[string][]byte{}}
+}
+
+func (s *MemoryStorage) Save(dir, name string, src io.Reader) (string, error) {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return "", err
+	}
+	url := "/" + path.Join(UploadDir, dir, name)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[url] = data
+	return url, nil
+}
+
+func (s *MemoryStorage) Remove(fileURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.files, fileURL)
+	return nil
+}
+
+// File: isi file yang tersimpan di URL tersebut
+func (s *MemoryStorage) File(fileURL string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.files[fileURL]
+	return data, ok
+}